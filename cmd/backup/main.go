@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"vault0/internal/logger"
+	"vault0/internal/services/backup"
+	"vault0/internal/wire"
+)
+
+func main() {
+	// Define command line flags
+	action := flag.String("action", "", "Action to perform (create, list, verify, restore)")
+	dir := flag.String("dir", "", "Backup directory to verify or restore")
+	flag.Parse()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	switch *action {
+	case "create":
+		createBackup(ctx)
+	case "list":
+		listBackups(ctx)
+	case "verify", "restore":
+		if *dir == "" {
+			fmt.Printf("The -dir flag is required to %s a backup.\n", *action)
+			os.Exit(1)
+		}
+		if *action == "verify" {
+			verifyBackup(ctx, *dir)
+		} else {
+			restoreBackup(ctx, *dir)
+		}
+	default:
+		fmt.Printf("Invalid action: %q. Must be 'create', 'list', 'verify' or 'restore'.\n", *action)
+		os.Exit(1)
+	}
+}
+
+// createBackup takes an online backup of the configured database
+func createBackup(ctx context.Context) {
+	// Initialize container with all dependencies
+	container, err := wire.BuildContainer()
+	if err != nil {
+		log.Fatalf("Failed to build container: %v", err)
+	}
+	defer container.Core.DB.Close()
+
+	created, err := container.Services.BackupService.CreateBackup(ctx)
+	if err != nil {
+		log.Fatalf("Failed to create backup: %v", err)
+	}
+	fmt.Printf("Created backup %s\n", created.Path)
+	printManifest(created.Manifest)
+}
+
+// listBackups prints the backups stored under the configured backup path
+func listBackups(ctx context.Context) {
+	container, err := wire.BuildContainer()
+	if err != nil {
+		log.Fatalf("Failed to build container: %v", err)
+	}
+	defer container.Core.DB.Close()
+
+	backups, err := container.Services.BackupService.ListBackups(ctx)
+	if err != nil {
+		log.Fatalf("Failed to list backups: %v", err)
+	}
+	if len(backups) == 0 {
+		fmt.Println("No backups found")
+		return
+	}
+	for _, b := range backups {
+		encrypted := ""
+		if b.Manifest.Encrypted {
+			encrypted = ", encrypted"
+		}
+		fmt.Printf("%s (schema version %d, %d bytes%s)\n", b.Name, b.Manifest.SchemaVersion, b.Manifest.DatabaseSize, encrypted)
+	}
+}
+
+// verifyBackup validates a backup without restoring it
+func verifyBackup(ctx context.Context, dir string) {
+	cfg, err := wire.NewConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	appLog, err := logger.NewLogger(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create logger: %v", err)
+	}
+
+	manifest, err := backup.Verify(ctx, cfg, dir, appLog)
+	if err != nil {
+		log.Fatalf("Invalid backup: %v", err)
+	}
+	fmt.Printf("Backup %s is valid\n", dir)
+	printManifest(manifest)
+}
+
+// restoreBackup validates a backup and replaces the configured database with it.
+// The database is not opened through the container, as the server must be stopped
+// while it is replaced.
+func restoreBackup(ctx context.Context, dir string) {
+	cfg, err := wire.NewConfig()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+	appLog, err := logger.NewLogger(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create logger: %v", err)
+	}
+
+	manifest, err := backup.Restore(ctx, cfg, dir, appLog)
+	if err != nil {
+		log.Fatalf("Failed to restore backup: %v", err)
+	}
+	fmt.Printf("Restored backup %s into %s\n", dir, cfg.DBPath)
+	printManifest(manifest)
+}
+
+func printManifest(manifest *backup.Manifest) {
+	fmt.Printf("  Created at:     %s\n", manifest.CreatedAt.Format(time.RFC3339))
+	fmt.Printf("  Schema version: %d\n", manifest.SchemaVersion)
+	fmt.Printf("  Encrypted:      %t\n", manifest.Encrypted)
+	fmt.Printf("  Database:       %d bytes, sha256 %s\n", manifest.DatabaseSize, manifest.DatabaseSHA256)
+}
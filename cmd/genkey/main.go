@@ -1,10 +1,14 @@
 package main
 
 import (
+	"bufio"
+	"encoding/base64"
 	"encoding/hex"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"vault0/internal/core/crypto"
 	"vault0/internal/core/keygen"
@@ -13,9 +17,17 @@ import (
 
 func main() {
 	// Define command line flags
-	keyType := flag.String("type", "encryption", "Type of key to generate (encryption, keypair)")
+	keyType := flag.String("type", "encryption", "Type of key to generate (encryption, keypair, keystore-export, keystore-import, split, combine)")
 	keySize := flag.Int("size", 32, "Encryption key size in bytes (16, 24, or 32)")
 	outputFormat := flag.String("format", "text", "Output format (text, env)")
+	keyFile := flag.String("key-file", "", "File containing the hex encoded secp256k1 private key (keystore-export)")
+	keystoreFile := flag.String("keystore", "", "Path to the V3 keystore JSON file (keystore-import)")
+	passphraseFile := flag.String("passphrase-file", "", "File containing the keystore passphrase (read from stdin if empty)")
+	kdf := flag.String("kdf", "scrypt", "Key derivation function for keystore-export (scrypt, pbkdf2)")
+	outputFile := flag.String("out", "", "Output file for keystore-export or output directory for split (stdout if empty)")
+	shareCount := flag.Int("shares", 5, "Number of shares to split the encryption key into (split)")
+	threshold := flag.Int("threshold", 3, "Number of shares required to reconstruct the encryption key (split)")
+	shareFiles := flag.String("share-files", "", "Comma-separated share files (combine, read from stdin if empty)")
 	flag.Parse()
 
 	switch *keyType {
@@ -23,8 +35,16 @@ func main() {
 		generateEncryptionKey(*keySize, *outputFormat)
 	case "keypair":
 		generateKeypair(*outputFormat)
+	case "keystore-export":
+		exportKeystore(*keyFile, *passphraseFile, *kdf, *outputFile)
+	case "keystore-import":
+		importKeystore(*keystoreFile, *passphraseFile, *outputFormat)
+	case "split":
+		splitEncryptionKey(*keySize, *shareCount, *threshold, *outputFile)
+	case "combine":
+		combineEncryptionKey(*shareFiles, *outputFormat)
 	default:
-		fmt.Printf("Invalid key type: %s. Must be 'encryption' or 'keypair'.\n", *keyType)
+		fmt.Printf("Invalid key type: %s. Must be 'encryption', 'keypair', 'keystore-export', 'keystore-import', 'split' or 'combine'.\n", *keyType)
 		os.Exit(1)
 	}
 }
@@ -72,3 +92,223 @@ func generateKeypair(format string) {
 		fmt.Printf("Public key (hex):\n%s\n", hex.EncodeToString(pubKey))
 	}
 }
+
+// exportKeystore encrypts a secp256k1 private key into a V3 keystore JSON document.
+// The key file may contain either the DER encoded key printed by -type keypair or
+// a raw 32-byte scalar, both hex encoded.
+func exportKeystore(keyFile, passphraseFile, kdf, outputFile string) {
+	if keyFile == "" {
+		fmt.Println("The -key-file flag is required for keystore-export.")
+		os.Exit(1)
+	}
+
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		fmt.Printf("Error reading key file: %v\n", err)
+		os.Exit(1)
+	}
+
+	keyBytes, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+	if err != nil {
+		fmt.Printf("Error decoding private key: %v\n", err)
+		os.Exit(1)
+	}
+
+	scalar := keyBytes
+	if len(keyBytes) != 32 {
+		privKey, err := crypto.UnmarshalPrivateKey(keyBytes)
+		if err != nil {
+			fmt.Printf("Error parsing private key: %v\n", err)
+			os.Exit(1)
+		}
+		scalar = make([]byte, 32)
+		privKey.D.FillBytes(scalar)
+	}
+
+	passphrase := readPassphrase(passphraseFile)
+
+	keystoreJSON, err := crypto.EncryptKeystoreV3(scalar, passphrase, crypto.KDF(kdf))
+	if err != nil {
+		fmt.Printf("Error creating keystore: %v\n", err)
+		os.Exit(1)
+	}
+
+	if outputFile == "" {
+		fmt.Println(string(keystoreJSON))
+		return
+	}
+
+	if err := os.WriteFile(outputFile, keystoreJSON, 0600); err != nil {
+		fmt.Printf("Error writing keystore file: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Keystore written to %s\n", outputFile)
+}
+
+// importKeystore decrypts a V3 keystore JSON file and prints the keypair in the
+// same encoding as -type keypair
+func importKeystore(keystoreFile, passphraseFile, format string) {
+	if keystoreFile == "" {
+		fmt.Println("The -keystore flag is required for keystore-import.")
+		os.Exit(1)
+	}
+
+	data, err := os.ReadFile(keystoreFile)
+	if err != nil {
+		fmt.Printf("Error reading keystore file: %v\n", err)
+		os.Exit(1)
+	}
+
+	passphrase := readPassphrase(passphraseFile)
+
+	scalar, err := crypto.DecryptKeystoreV3(data, passphrase)
+	if err != nil {
+		fmt.Printf("Error decrypting keystore: %v\n", err)
+		os.Exit(1)
+	}
+
+	privKey, err := crypto.PrivateKeyFromBytes(scalar)
+	if err != nil {
+		fmt.Printf("Error parsing private key: %v\n", err)
+		os.Exit(1)
+	}
+
+	privDER, err := crypto.MarshalPrivateKey(privKey)
+	if err != nil {
+		fmt.Printf("Error encoding private key: %v\n", err)
+		os.Exit(1)
+	}
+
+	pubKey, err := crypto.MarshalPublicKey(&privKey.PublicKey)
+	if err != nil {
+		fmt.Printf("Error encoding public key: %v\n", err)
+		os.Exit(1)
+	}
+
+	if format == "env" {
+		fmt.Printf("PRIVATE_KEY='%s'\n", hex.EncodeToString(privDER))
+		fmt.Printf("PUBLIC_KEY='%s'\n", hex.EncodeToString(pubKey))
+	} else {
+		fmt.Printf("Imported ECDSA keypair:\n\n")
+		fmt.Printf("Private key (hex):\n%s\n\n", hex.EncodeToString(privDER))
+		fmt.Printf("Public key (hex):\n%s\n", hex.EncodeToString(pubKey))
+	}
+}
+
+// readPassphrase reads the keystore passphrase from a file or the first line of stdin
+func readPassphrase(passphraseFile string) string {
+	if passphraseFile != "" {
+		data, err := os.ReadFile(passphraseFile)
+		if err != nil {
+			fmt.Printf("Error reading passphrase file: %v\n", err)
+			os.Exit(1)
+		}
+		return strings.TrimRight(string(data), "\r\n")
+	}
+
+	fmt.Fprint(os.Stderr, "Keystore passphrase: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		fmt.Printf("Error reading passphrase: %v\n", err)
+		os.Exit(1)
+	}
+
+	passphrase := strings.TrimRight(line, "\r\n")
+	if passphrase == "" {
+		fmt.Println("Passphrase must not be empty.")
+		os.Exit(1)
+	}
+	return passphrase
+}
+
+// splitEncryptionKey splits the encryption key from DB_ENCRYPTION_KEY into Shamir
+// shares. When the variable is not set, a new key is generated and only its
+// shares are output, so the full key is never displayed.
+func splitEncryptionKey(keySize, shareCount, threshold int, outputDir string) {
+	var key []byte
+	if encoded := os.Getenv("DB_ENCRYPTION_KEY"); encoded != "" {
+		var err error
+		key, err = base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			fmt.Printf("Error decoding DB_ENCRYPTION_KEY: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		var err error
+		key, err = crypto.GenerateEncryptionKey(keySize)
+		if err != nil {
+			fmt.Printf("Error generating encryption key: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	shares, err := crypto.SplitSecret(key, shareCount, threshold)
+	if err != nil {
+		fmt.Printf("Error splitting encryption key: %v\n", err)
+		os.Exit(1)
+	}
+
+	if outputDir == "" {
+		fmt.Printf("Split encryption key into %d shares (%d required to reconstruct):\n\n", shareCount, threshold)
+		for _, share := range shares {
+			fmt.Println(share.String())
+		}
+		return
+	}
+
+	if err := os.MkdirAll(outputDir, 0700); err != nil {
+		fmt.Printf("Error creating output directory: %v\n", err)
+		os.Exit(1)
+	}
+	for _, share := range shares {
+		path := filepath.Join(outputDir, fmt.Sprintf("share-%d.txt", share.X))
+		if err := os.WriteFile(path, []byte(share.String()+"\n"), 0600); err != nil {
+			fmt.Printf("Error writing share file: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Share %d written to %s\n", share.X, path)
+	}
+	fmt.Printf("\n%d of %d shares are required to reconstruct the encryption key.\n", threshold, shareCount)
+}
+
+// combineEncryptionKey reconstructs the encryption key from Shamir shares read
+// from files or stdin
+func combineEncryptionKey(shareFiles, format string) {
+	var shares []crypto.Share
+	if shareFiles != "" {
+		for _, path := range strings.Split(shareFiles, ",") {
+			data, err := os.ReadFile(strings.TrimSpace(path))
+			if err != nil {
+				fmt.Printf("Error reading share file: %v\n", err)
+				os.Exit(1)
+			}
+			share, err := crypto.ParseShare(string(data))
+			if err != nil {
+				fmt.Printf("Error parsing share file %s: %v\n", path, err)
+				os.Exit(1)
+			}
+			shares = append(shares, share)
+		}
+	} else {
+		fmt.Fprintln(os.Stderr, "Enter shares, one per line:")
+		var err error
+		shares, err = crypto.ReadShares(os.Stdin, nil)
+		if err != nil {
+			fmt.Printf("Error reading shares: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	key, err := crypto.CombineShares(shares)
+	if err != nil {
+		fmt.Printf("Error combining shares: %v\n", err)
+		os.Exit(1)
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(key)
+	if format == "env" {
+		fmt.Printf("DB_ENCRYPTION_KEY='%s'\n", encoded)
+	} else {
+		fmt.Printf("Reconstructed %d-byte encryption key (base64 encoded):\n\n%s\n", len(key), encoded)
+	}
+}
@@ -67,6 +67,93 @@ func main() {
 		log.Error("Failed to register wallet transformer", logger.Error(err))
 	}
 
+	// Register risk transformer to flag spam and scam transactions, after the wallet ID is set
+	if err := container.Services.Transaction.TransformerService.RegisterTransformer("3_risk_flags", container.Services.Transaction.RiskTransformer); err != nil {
+		log.Error("Failed to register risk transformer", logger.Error(err))
+	}
+
+	// Setup routes
+	container.Server.SetupRoutes()
+
+	// Fan out live events to the stream clients of this replica
+	container.Services.StreamService.StartStreaming(ctx)
+
+	// Setup graceful shutdown
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	// Run the server in a goroutine
+	go func() {
+		if err := container.Server.Run(); err != nil {
+			log.Fatal("Failed to start server", logger.Error(err))
+		}
+	}()
+
+	log.Info("Server is running",
+		logger.String("port", container.Core.Config.Port),
+		logger.String("message", "Press Ctrl+C to shutdown"),
+	)
+
+	// Run the background workers once this replica is elected, while all replicas serve the API
+	elector := container.Core.LeaderElector
+	workersStarted := false
+	campaignDone := make(chan struct{})
+	go func() {
+		defer close(campaignDone)
+		if err := elector.Campaign(ctx); err != nil {
+			return
+		}
+		startWorkers(ctx, container)
+		workersStarted = true
+	}()
+
+	// Wait for interrupt signal, or for another replica to take the workers over. The
+	// workers can't be restarted within the process, so a replica that lost its lease
+	// exits and campaigns again once its supervisor restarts it.
+	exitCode := 0
+	select {
+	case <-quit:
+		log.Info("Received shutdown signal")
+	case <-elector.Lost():
+		log.Error("Lost the background workers lease, shutting down")
+		exitCode = 1
+	}
+
+	// Cancel the root context
+	cancel()
+
+	// Stop the background workers and hand the lease over to another replica
+	<-campaignDone
+	if workersStarted {
+		stopWorkers(ctx, container)
+	}
+	if err := elector.Resign(context.Background()); err != nil {
+		log.Error("Failed to resign the background workers lease", logger.Error(err))
+	}
+
+	// End the live event streams
+	container.Services.StreamService.StopStreaming()
+
+	// Perform cleanup
+	container.Server.Shutdown()
+
+	// Close the database connection
+	if container.Core.DB != nil {
+		if err := container.Core.DB.Close(); err != nil {
+			log.Error("Failed to close database connection", logger.Error(err))
+		}
+	}
+
+	log.Info("Server gracefully stopped")
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+}
+
+// startWorkers starts the background pollers and monitors
+func startWorkers(ctx context.Context, container *wire.Container) {
+	log := container.Core.Logger
+
 	// Start pending transaction polling
 	container.Services.Transaction.PoolingService.StartPendingTransactionPolling(ctx)
 
@@ -93,37 +180,22 @@ func main() {
 	// Start token price update job
 	container.Services.TokenPricePollingService.StartPricePolling(ctx)
 
+	// Start exchange rate update job
+	container.Services.FXRatePollingService.StartRatePolling(ctx)
+
 	// Start vault recovery polling
 	container.Services.VaultService.StartRecoveryPolling(ctx)
 
 	// Start vault deployment monitoring
 	container.Services.VaultService.StartDeploymentMonitoring(ctx)
 
-	// Setup routes
-	container.Server.SetupRoutes()
-
-	// Setup graceful shutdown
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-
-	// Run the server in a goroutine
-	go func() {
-		if err := container.Server.Run(); err != nil {
-			log.Fatal("Failed to start server", logger.Error(err))
-		}
-	}()
-
-	log.Info("Server is running",
-		logger.String("port", container.Core.Config.Port),
-		logger.String("message", "Press Ctrl+C to shutdown"),
-	)
-
-	// Wait for interrupt signal
-	<-quit
-	log.Info("Received shutdown signal")
+	// Start webhook delivery
+	container.Services.WebhookDeliveryService.StartDelivery(ctx)
+}
 
-	// Cancel the root context
-	cancel()
+// stopWorkers stops the background pollers and monitors
+func stopWorkers(ctx context.Context, container *wire.Container) {
+	log := container.Core.Logger
 
 	// Stop wallet monitoring
 	if err := container.Services.WalletMonitorService.StopWalletMonitoring(ctx); err != nil {
@@ -154,15 +226,9 @@ func main() {
 	// Stop token price update job
 	container.Services.TokenPricePollingService.StopPricePolling()
 
-	// Perform cleanup
-	container.Server.Shutdown()
-
-	// Close the database connection
-	if container.Core.DB != nil {
-		if err := container.Core.DB.Close(); err != nil {
-			log.Error("Failed to close database connection", logger.Error(err))
-		}
-	}
+	// Stop exchange rate update job
+	container.Services.FXRatePollingService.StopRatePolling()
 
-	log.Info("Server gracefully stopped")
+	// Stop webhook delivery
+	container.Services.WebhookDeliveryService.StopDelivery()
 }
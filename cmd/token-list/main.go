@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"vault0/internal/core/tokenstore"
+	"vault0/internal/types"
+	"vault0/internal/wire"
+)
+
+func main() {
+	// Define command line flags
+	action := flag.String("action", "", "Action to perform (import, export)")
+	file := flag.String("file", "", "Token list file to import or export to (stdin/stdout if empty)")
+	name := flag.String("name", "Vault0", "Name of the exported token list")
+	dryRun := flag.Bool("dry-run", false, "Report the changes of an import without applying them")
+	prune := flag.Bool("prune", false, "Delete the verified ERC20 tokens missing from the imported list")
+	flag.Parse()
+
+	if *action != "import" && *action != "export" {
+		fmt.Printf("Invalid action: %q. Must be 'import' or 'export'.\n", *action)
+		os.Exit(1)
+	}
+
+	// Initialize container with all dependencies
+	container, err := wire.BuildContainer()
+	if err != nil {
+		log.Fatalf("Failed to build container: %v", err)
+	}
+	defer container.Core.DB.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	switch *action {
+	case "import":
+		var reader io.Reader = os.Stdin
+		if *file != "" {
+			f, err := os.Open(*file)
+			if err != nil {
+				log.Fatalf("Failed to open token list: %v", err)
+			}
+			defer f.Close()
+			reader = f
+		}
+		if err := importTokenList(ctx, container.Core.TokenStore, reader, os.Stdout, tokenstore.ImportOptions{DryRun: *dryRun, Prune: *prune}); err != nil {
+			log.Fatal(err)
+		}
+	case "export":
+		var writer io.Writer = os.Stdout
+		if *file != "" {
+			f, err := os.Create(*file)
+			if err != nil {
+				log.Fatalf("Failed to create token list: %v", err)
+			}
+			defer f.Close()
+			writer = f
+		}
+		count, err := exportTokenList(ctx, container.Core.TokenStore, writer, *name)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if *file != "" {
+			fmt.Printf("Exported %d tokens to %s\n", count, *file)
+		}
+	}
+}
+
+// importTokenList imports a token list read from r and prints the resulting changes to w
+func importTokenList(ctx context.Context, store tokenstore.TokenStore, r io.Reader, w io.Writer, opts tokenstore.ImportOptions) error {
+	var list tokenstore.TokenList
+	if err := json.NewDecoder(r).Decode(&list); err != nil {
+		return fmt.Errorf("failed to parse token list: %w", err)
+	}
+
+	diff, err := store.ImportTokenList(ctx, &list, opts)
+	if err != nil {
+		return fmt.Errorf("failed to import token list: %w", err)
+	}
+
+	if opts.DryRun {
+		fmt.Fprintf(w, "Dry run of %s v%d.%d.%d, no changes applied\n", list.Name, list.Version.Major, list.Version.Minor, list.Version.Patch)
+	} else {
+		fmt.Fprintf(w, "Imported %s v%d.%d.%d\n", list.Name, list.Version.Major, list.Version.Minor, list.Version.Patch)
+	}
+
+	printTokens(w, "Added", "➕", diff.Added)
+	printTokens(w, "Changed", "✏️ ", diff.Changed)
+	if opts.Prune {
+		printTokens(w, "Removed", "➖", diff.Removed)
+	} else {
+		printTokens(w, "Missing from list (use -prune to remove)", "⚠️ ", diff.Removed)
+	}
+
+	if len(diff.Skipped) > 0 {
+		fmt.Fprintf(w, "\nSkipped (%d)\n", len(diff.Skipped))
+		for _, skipped := range diff.Skipped {
+			fmt.Fprintf(w, "❌ %s (%s) on chain %d: %s\n", skipped.Token.Symbol, skipped.Token.Address, skipped.Token.ChainID, skipped.Reason)
+		}
+	}
+	return nil
+}
+
+// exportTokenList writes the verified ERC20 tokens to w as a token list, returning
+// the number of tokens exported
+func exportTokenList(ctx context.Context, store tokenstore.TokenStore, w io.Writer, name string) (int, error) {
+	list, err := store.ExportTokenList(ctx, name)
+	if err != nil {
+		return 0, fmt.Errorf("failed to export token list: %w", err)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(list); err != nil {
+		return 0, fmt.Errorf("failed to write token list: %w", err)
+	}
+	return len(list.Tokens), nil
+}
+
+func printTokens(w io.Writer, title, marker string, tokens []types.Token) {
+	if len(tokens) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n%s (%d)\n", title, len(tokens))
+	for _, token := range tokens {
+		fmt.Fprintf(w, "%s %s (%s) on %s\n", marker, token.Symbol, token.Address, token.ChainType)
+	}
+}
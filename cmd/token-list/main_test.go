@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/core/tokenstore"
+	"vault0/internal/types"
+)
+
+// testTokenStore records the imported list and exports a fixed one
+type testTokenStore struct {
+	tokenstore.TokenStore
+	imported *tokenstore.TokenList
+	opts     tokenstore.ImportOptions
+	diff     *tokenstore.TokenListDiff
+	exported *tokenstore.TokenList
+}
+
+func (s *testTokenStore) ImportTokenList(ctx context.Context, list *tokenstore.TokenList, opts tokenstore.ImportOptions) (*tokenstore.TokenListDiff, error) {
+	s.imported = list
+	s.opts = opts
+	return s.diff, nil
+}
+
+func (s *testTokenStore) ExportTokenList(ctx context.Context, name string) (*tokenstore.TokenList, error) {
+	s.exported.Name = name
+	return s.exported, nil
+}
+
+func TestImportTokenList(t *testing.T) {
+	usdc := types.Token{Address: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", ChainType: types.ChainTypeEthereum, Symbol: "USDC"}
+	wbtc := types.Token{Address: "0x2260FAC5E5542a773Aa44fBCfeDf7C193bc2C599", ChainType: types.ChainTypeEthereum, Symbol: "WBTC"}
+	store := &testTokenStore{diff: &tokenstore.TokenListDiff{
+		Added:   []types.Token{usdc},
+		Removed: []types.Token{wbtc},
+		Skipped: []tokenstore.SkippedToken{{Token: tokenstore.TokenListToken{ChainID: 999, Symbol: "UNK"}, Reason: "unsupported chain"}},
+	}}
+
+	input := `{"name":"Test","version":{"major":1,"minor":2,"patch":3},"tokens":[{"chainId":1,"address":"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48","symbol":"USDC","decimals":6}]}`
+	var output bytes.Buffer
+	err := importTokenList(context.Background(), store, strings.NewReader(input), &output, tokenstore.ImportOptions{DryRun: true})
+	require.NoError(t, err)
+
+	require.NotNil(t, store.imported)
+	assert.Equal(t, "Test", store.imported.Name)
+	require.Len(t, store.imported.Tokens, 1)
+	assert.Equal(t, uint8(6), store.imported.Tokens[0].Decimals)
+	assert.True(t, store.opts.DryRun)
+
+	assert.Contains(t, output.String(), "Dry run of Test v1.2.3, no changes applied")
+	assert.Contains(t, output.String(), "Added (1)")
+	assert.Contains(t, output.String(), "Missing from list (use -prune to remove) (1)")
+	assert.Contains(t, output.String(), "Skipped (1)")
+	assert.NotContains(t, output.String(), "Changed")
+
+	err = importTokenList(context.Background(), store, strings.NewReader("not json"), &output, tokenstore.ImportOptions{})
+	assert.ErrorContains(t, err, "failed to parse token list")
+}
+
+func TestExportTokenList(t *testing.T) {
+	store := &testTokenStore{exported: &tokenstore.TokenList{
+		Version: tokenstore.TokenListVersion{Major: 1},
+		Tokens: []tokenstore.TokenListToken{
+			{ChainID: 1, Address: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Name: "USD Coin", Symbol: "USDC", Decimals: 6},
+		},
+	}}
+
+	var output bytes.Buffer
+	count, err := exportTokenList(context.Background(), store, &output, "Vault0")
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// The exported list can be imported back
+	imported := &testTokenStore{diff: &tokenstore.TokenListDiff{}}
+	require.NoError(t, importTokenList(context.Background(), imported, &output, &bytes.Buffer{}, tokenstore.ImportOptions{}))
+	assert.Equal(t, store.exported, imported.imported)
+}
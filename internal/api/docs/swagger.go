@@ -80,6 +80,17 @@ type TokenPricePagedResponse struct {
 	Limit int `json:"limit" example:"10"`
 }
 
+// FXRatePagedResponse is a non-generic version of PagedResponse[FXRateResponse]
+// swagger:model FXRatePagedResponse
+type FXRatePagedResponse struct {
+	// The list of exchange rates
+	Items []FXRateResponse `json:"items"`
+	// Token for the next page
+	NextToken string `json:"next_token,omitempty" example:"eyJjIjoiaWQiLCJ2IjoxMDAwfQ=="`
+	// The limit used for the page
+	Limit int `json:"limit" example:"10"`
+}
+
 // SignerPagedResponse is a non-generic version of PagedResponse[SignerResponse]
 // swagger:model SignerPagedResponse
 type SignerPagedResponse struct {
@@ -91,6 +102,72 @@ type SignerPagedResponse struct {
 	Limit int `json:"limit" example:"10"`
 }
 
+// SigningAuditPagedResponse is a non-generic version of PagedResponse[SigningAuditEntryResponse]
+// swagger:model SigningAuditPagedResponse
+type SigningAuditPagedResponse struct {
+	// The list of signing audit entries
+	Items []SigningAuditEntryResponse `json:"items"`
+	// Token for the next page
+	NextToken string `json:"next_token,omitempty" example:"eyJjIjoiaWQiLCJ2IjoxMDAwfQ=="`
+	// The limit used for the page
+	Limit int `json:"limit" example:"10"`
+}
+
+// ContractABIPagedResponse is a non-generic version of PagedResponse[ContractABIResponse]
+// swagger:model ContractABIPagedResponse
+type ContractABIPagedResponse struct {
+	// The list of contract ABIs
+	Items []ContractABIResponse `json:"items"`
+	// Token for the next page
+	NextToken string `json:"next_token,omitempty" example:"eyJjIjoiaWQiLCJ2IjoxMDAwfQ=="`
+	// The limit used for the page
+	Limit int `json:"limit" example:"10"`
+}
+
+// NFTHoldingPagedResponse is a non-generic version of PagedResponse[NFTHoldingResponse]
+// swagger:model NFTHoldingPagedResponse
+type NFTHoldingPagedResponse struct {
+	// The list of NFT holdings
+	Items []NFTHoldingResponse `json:"items"`
+	// Token for the next page
+	NextToken string `json:"next_token,omitempty" example:"eyJjIjoiaWQiLCJ2IjoxMDAwfQ=="`
+	// The limit used for the page
+	Limit int `json:"limit" example:"10"`
+}
+
+// TokenAllowancePagedResponse is a non-generic version of PagedResponse[TokenAllowanceResponse]
+// swagger:model TokenAllowancePagedResponse
+type TokenAllowancePagedResponse struct {
+	// The list of token allowances
+	Items []TokenAllowanceResponse `json:"items"`
+	// Token for the next page
+	NextToken string `json:"next_token,omitempty" example:"eyJjIjoiaWQiLCJ2IjoxMDAwfQ=="`
+	// The limit used for the page
+	Limit int `json:"limit" example:"10"`
+}
+
+// WebhookPagedResponse is a non-generic version of PagedResponse[WebhookResponse]
+// swagger:model WebhookPagedResponse
+type WebhookPagedResponse struct {
+	// The list of webhooks
+	Items []WebhookResponse `json:"items"`
+	// Token for the next page
+	NextToken string `json:"next_token,omitempty" example:"eyJjIjoiaWQiLCJ2IjoxMDAwfQ=="`
+	// The limit used for the page
+	Limit int `json:"limit" example:"10"`
+}
+
+// WebhookDeliveryPagedResponse is a non-generic version of PagedResponse[WebhookDeliveryResponse]
+// swagger:model WebhookDeliveryPagedResponse
+type WebhookDeliveryPagedResponse struct {
+	// The list of webhook deliveries
+	Items []WebhookDeliveryResponse `json:"items"`
+	// Token for the next page
+	NextToken string `json:"next_token,omitempty" example:"eyJjIjoiaWQiLCJ2IjoxMDAwfQ=="`
+	// The limit used for the page
+	Limit int `json:"limit" example:"10"`
+}
+
 // These are placeholders to make the file compile
 // The actual implementations are in their respective handler packages
 
@@ -101,4 +178,11 @@ type UserResponse struct{}
 type WalletResponse struct{}
 type VaultResponse struct{}
 type TokenPriceResponse struct{}
+type FXRateResponse struct{}
 type SignerResponse struct{}
+type SigningAuditEntryResponse struct{}
+type ContractABIResponse struct{}
+type NFTHoldingResponse struct{}
+type TokenAllowanceResponse struct{}
+type WebhookResponse struct{}
+type WebhookDeliveryResponse struct{}
@@ -0,0 +1,52 @@
+package abi
+
+import (
+	"encoding/json"
+	"time"
+
+	"vault0/internal/core/abi"
+	"vault0/internal/types"
+)
+
+// UploadABIRequest is the request body for registering a contract ABI.
+// Exactly one of ABI or Artifact must be provided.
+type UploadABIRequest struct {
+	ChainType types.ChainType `json:"chain_type" binding:"required"`
+	Address   string          `json:"address" binding:"required"`
+	Name      string          `json:"name"`
+	// ABI is a plain JSON ABI array
+	ABI json.RawMessage `json:"abi,omitempty" swaggertype:"array,object"`
+	// Artifact is a Hardhat or Truffle build artifact containing an "abi" field
+	Artifact json.RawMessage `json:"artifact,omitempty" swaggertype:"object"`
+}
+
+// ListABIsRequest defines the query parameters for listing contract ABIs
+type ListABIsRequest struct {
+	ChainType string `form:"chain_type"`
+	NextToken string `form:"next_token"`
+	Limit     *int   `form:"limit" binding:"omitempty,min=1"`
+}
+
+// ContractABIResponse is the contract ABI data returned in responses
+type ContractABIResponse struct {
+	ChainType types.ChainType `json:"chain_type"`
+	Address   string          `json:"address"`
+	Name      string          `json:"name,omitempty"`
+	ABI       json.RawMessage `json:"abi" swaggertype:"array,object"`
+	Source    abi.ABISource   `json:"source"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// ContractABIToResponse converts a registered contract ABI to a response
+func ContractABIToResponse(contractABI *abi.ContractABI) ContractABIResponse {
+	return ContractABIResponse{
+		ChainType: contractABI.ChainType,
+		Address:   contractABI.Address,
+		Name:      contractABI.Name,
+		ABI:       json.RawMessage(contractABI.ABI),
+		Source:    contractABI.Source,
+		CreatedAt: contractABI.CreatedAt,
+		UpdatedAt: contractABI.UpdatedAt,
+	}
+}
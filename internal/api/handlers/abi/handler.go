@@ -0,0 +1,163 @@
+package abi
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	_ "vault0/internal/api/docs" // Required for Swagger documentation
+	"vault0/internal/api/middleares"
+	"vault0/internal/api/utils"
+	"vault0/internal/errors"
+	"vault0/internal/services/abi"
+	"vault0/internal/types"
+)
+
+// Handler manages contract ABI registry API endpoints
+type Handler struct {
+	service abi.Service
+}
+
+// NewHandler creates a new contract ABI handler
+func NewHandler(service abi.Service) *Handler {
+	return &Handler{service: service}
+}
+
+// SetupRoutes configures the contract ABI API routes
+func (h *Handler) SetupRoutes(router *gin.RouterGroup) {
+	errorHandler := middleares.NewErrorHandler(nil)
+
+	abiRoutes := router.Group("/abis")
+	abiRoutes.Use(errorHandler.Middleware())
+	abiRoutes.GET("", h.listABIs)
+	abiRoutes.POST("", h.uploadABI)
+	abiRoutes.GET("/:chain_type/:address", h.getABI)
+	abiRoutes.DELETE("/:chain_type/:address", h.deleteABI)
+}
+
+// uploadABI handles POST /abis
+// @Summary Upload contract ABI
+// @Description Register an ABI for a contract, replacing any existing one. Provide either a plain JSON ABI array or a Hardhat/Truffle artifact.
+// @Description Uploaded ABIs take precedence over ABIs fetched from block explorers.
+// @Tags abis
+// @Accept json
+// @Produce json
+// @Param request body UploadABIRequest true "Contract ABI"
+// @Success 201 {object} ContractABIResponse
+// @Failure 400 {object} errors.Vault0Error "Invalid request or ABI"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /abis [post]
+func (h *Handler) uploadABI(c *gin.Context) {
+	var req UploadABIRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	hasABI := len(req.ABI) > 0
+	hasArtifact := len(req.Artifact) > 0
+	if hasABI == hasArtifact {
+		c.Error(errors.NewInvalidParameterError("abi", "exactly one of abi or artifact must be provided"))
+		return
+	}
+
+	data := req.ABI
+	if hasArtifact {
+		data = req.Artifact
+	}
+
+	contractABI, err := h.service.UploadABI(c.Request.Context(), req.ChainType, req.Address, req.Name, data, hasArtifact)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ContractABIToResponse(contractABI))
+}
+
+// listABIs handles GET /abis
+// @Summary List contract ABIs
+// @Description Get a paginated list of registered contract ABIs
+// @Tags abis
+// @Produce json
+// @Param chain_type query string false "Filter by chain type (ethereum, polygon, etc.)"
+// @Param next_token query string false "Token for pagination (empty for first page)"
+// @Param limit query int false "Number of items to return (default: 10)" default(10)
+// @Success 200 {object} docs.ContractABIPagedResponse
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /abis [get]
+func (h *Handler) listABIs(c *gin.Context) {
+	var req ListABIsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.Error(errors.NewInvalidParameterError("query", "invalid query parameters format or value"))
+		return
+	}
+
+	limit := 10
+	if req.Limit != nil {
+		limit = *req.Limit
+	}
+
+	var chainType *types.ChainType
+	if req.ChainType != "" {
+		ct := types.ChainType(req.ChainType)
+		chainType = &ct
+	}
+
+	abis, err := h.service.ListABIs(c.Request.Context(), chainType, limit, req.NextToken)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.NewPagedResponse(abis, ContractABIToResponse))
+}
+
+// getABI handles GET /abis/:chain_type/:address
+// @Summary Get contract ABI
+// @Description Get the ABI registered for a contract
+// @Tags abis
+// @Produce json
+// @Param chain_type path string true "Chain type (ethereum, polygon, base)"
+// @Param address path string true "Contract address"
+// @Success 200 {object} ContractABIResponse
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 404 {object} errors.Vault0Error "ABI not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /abis/{chain_type}/{address} [get]
+func (h *Handler) getABI(c *gin.Context) {
+	chainType := types.ChainType(c.Param("chain_type"))
+	address := c.Param("address")
+
+	contractABI, err := h.service.GetABI(c.Request.Context(), chainType, address)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ContractABIToResponse(contractABI))
+}
+
+// deleteABI handles DELETE /abis/:chain_type/:address
+// @Summary Delete contract ABI
+// @Description Remove the ABI registered for a contract
+// @Tags abis
+// @Param chain_type path string true "Chain type (ethereum, polygon, base)"
+// @Param address path string true "Contract address"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 404 {object} errors.Vault0Error "ABI not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /abis/{chain_type}/{address} [delete]
+func (h *Handler) deleteABI(c *gin.Context) {
+	chainType := types.ChainType(c.Param("chain_type"))
+	address := c.Param("address")
+
+	if err := h.service.DeleteABI(c.Request.Context(), chainType, address); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
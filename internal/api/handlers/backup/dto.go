@@ -0,0 +1,37 @@
+package backup
+
+import (
+	"time"
+
+	backupsvc "vault0/internal/services/backup"
+)
+
+// BackupResponse represents a database backup in API responses.
+type BackupResponse struct {
+	Name           string    `json:"name" example:"vault0-20240503T120000.000Z"`
+	CreatedAt      time.Time `json:"created_at" example:"2024-05-03T12:00:00Z"`
+	Driver         string    `json:"driver" example:"sqlite"`
+	SchemaVersion  uint      `json:"schema_version" example:"24"`
+	Encrypted      bool      `json:"encrypted" example:"true"`
+	File           string    `json:"file" example:"vault0.db.enc"`
+	FileSize       int64     `json:"file_size" example:"1048604"`
+	FileSHA256     string    `json:"file_sha256" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	DatabaseSize   int64     `json:"database_size" example:"1048576"`
+	DatabaseSHA256 string    `json:"database_sha256" example:"60303ae22b998861bce3b28f33eec1be758a213c86c93c076dbe9f558c11c752"`
+}
+
+// mapModelToResponse converts a backup to its API response
+func mapModelToResponse(backup *backupsvc.Backup) BackupResponse {
+	return BackupResponse{
+		Name:           backup.Name,
+		CreatedAt:      backup.Manifest.CreatedAt,
+		Driver:         backup.Manifest.Driver,
+		SchemaVersion:  backup.Manifest.SchemaVersion,
+		Encrypted:      backup.Manifest.Encrypted,
+		File:           backup.Manifest.File,
+		FileSize:       backup.Manifest.FileSize,
+		FileSHA256:     backup.Manifest.FileSHA256,
+		DatabaseSize:   backup.Manifest.DatabaseSize,
+		DatabaseSHA256: backup.Manifest.DatabaseSHA256,
+	}
+}
@@ -0,0 +1,137 @@
+package backup
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	_ "vault0/internal/api/docs" // Required for Swagger documentation
+	"vault0/internal/api/middleares"
+	"vault0/internal/config"
+	"vault0/internal/logger"
+	backupsvc "vault0/internal/services/backup"
+)
+
+// Handler holds the dependencies for the database backup API handlers.
+type Handler struct {
+	service   backupsvc.Service
+	adminAuth *middleares.AdminAuth
+	logger    logger.Logger
+}
+
+// NewHandler creates a new backup handler instance.
+func NewHandler(svc backupsvc.Service, cfg *config.Config, log logger.Logger) *Handler {
+	return &Handler{
+		service:   svc,
+		adminAuth: middleares.NewAdminAuth(cfg.AdminAPIKey),
+		logger:    log.With(logger.String("handler", "backup")),
+	}
+}
+
+// SetupRoutes registers the backup API routes with the Gin engine. All of them
+// require the admin API key.
+func (h *Handler) SetupRoutes(router *gin.RouterGroup) {
+	errorHandler := middleares.NewErrorHandler(nil)
+
+	group := router.Group("/backups")
+	group.Use(errorHandler.Middleware(), h.adminAuth.Middleware())
+	{
+		group.POST("", h.CreateBackup)
+		group.GET("", h.ListBackups)
+		group.GET("/:name", h.GetBackup)
+		group.POST("/:name/verify", h.VerifyBackup)
+	}
+}
+
+// CreateBackup godoc
+// @Summary Create a database backup
+// @Description Take a consistent online backup of the database, encrypted with the DB encryption key when backup encryption is enabled. Requires the admin API key.
+// @Tags Backups
+// @Produce json
+// @Param X-Admin-Key header string true "Admin API key"
+// @Success 201 {object} BackupResponse "Created backup"
+// @Failure 401 {object} errors.Vault0Error "Missing admin API key"
+// @Failure 403 {object} errors.Vault0Error "Invalid admin API key"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /backups [post]
+func (h *Handler) CreateBackup(c *gin.Context) {
+	backup, err := h.service.CreateBackup(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, mapModelToResponse(backup))
+}
+
+// ListBackups godoc
+// @Summary List database backups
+// @Description Get the stored database backups, newest first. Requires the admin API key.
+// @Tags Backups
+// @Produce json
+// @Param X-Admin-Key header string true "Admin API key"
+// @Success 200 {array} BackupResponse "Backups"
+// @Failure 401 {object} errors.Vault0Error "Missing admin API key"
+// @Failure 403 {object} errors.Vault0Error "Invalid admin API key"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /backups [get]
+func (h *Handler) ListBackups(c *gin.Context) {
+	backups, err := h.service.ListBackups(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := make([]BackupResponse, len(backups))
+	for i, backup := range backups {
+		response[i] = mapModelToResponse(backup)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetBackup godoc
+// @Summary Get a database backup
+// @Description Get the manifest of a stored database backup. Requires the admin API key.
+// @Tags Backups
+// @Produce json
+// @Param X-Admin-Key header string true "Admin API key"
+// @Param name path string true "Backup name"
+// @Success 200 {object} BackupResponse "Backup"
+// @Failure 401 {object} errors.Vault0Error "Missing admin API key"
+// @Failure 403 {object} errors.Vault0Error "Invalid admin API key"
+// @Failure 404 {object} errors.Vault0Error "Backup not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /backups/{name} [get]
+func (h *Handler) GetBackup(c *gin.Context) {
+	backup, err := h.service.GetBackup(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapModelToResponse(backup))
+}
+
+// VerifyBackup godoc
+// @Summary Verify a database backup
+// @Description Validate the checksums, decryption, integrity and schema version of a stored backup without restoring it. Requires the admin API key.
+// @Tags Backups
+// @Produce json
+// @Param X-Admin-Key header string true "Admin API key"
+// @Param name path string true "Backup name"
+// @Success 200 {object} BackupResponse "Valid backup"
+// @Failure 400 {object} errors.Vault0Error "Invalid backup"
+// @Failure 401 {object} errors.Vault0Error "Missing admin API key"
+// @Failure 403 {object} errors.Vault0Error "Invalid admin API key"
+// @Failure 404 {object} errors.Vault0Error "Backup not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /backups/{name}/verify [post]
+func (h *Handler) VerifyBackup(c *gin.Context) {
+	backup, err := h.service.VerifyBackup(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapModelToResponse(backup))
+}
@@ -0,0 +1,25 @@
+package fxrate
+
+import "time"
+
+// FXRateResponse represents the USD exchange rate of a currency in API responses.
+type FXRateResponse struct {
+	Currency string    `json:"currency" example:"EUR"`
+	Rate     float64   `json:"rate" example:"0.9305"`
+	AsOf     time.Time `json:"as_of" example:"2024-05-03T00:00:00Z"`
+	Source   string    `json:"source" example:"frankfurter"`
+}
+
+// GetFXRateRequest defines the query parameters for getting the rate of a currency.
+type GetFXRateRequest struct {
+	// At selects the rate in effect at a point in time instead of the latest one
+	At *time.Time `form:"at" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// ListFXRateHistoryRequest defines the query parameters for the rate history endpoint.
+type ListFXRateHistoryRequest struct {
+	Limit     *int       `form:"limit" binding:"omitempty,min=1,max=100"`
+	NextToken string     `form:"next_token"`
+	From      *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To        *time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
@@ -0,0 +1,141 @@
+package fxrate
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	_ "vault0/internal/api/docs" // Required for Swagger documentation
+	"vault0/internal/api/utils"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	fxsvc "vault0/internal/services/fxrate"
+)
+
+// Handler holds the dependencies for the exchange rate API handlers.
+type Handler struct {
+	service fxsvc.Service
+	logger  logger.Logger
+}
+
+// NewHandler creates a new exchange rate handler instance.
+func NewHandler(svc fxsvc.Service, log logger.Logger) *Handler {
+	return &Handler{
+		service: svc,
+		logger:  log.With(logger.String("handler", "fxrate")),
+	}
+}
+
+// SetupRoutes registers the exchange rate API routes with the Gin engine.
+func (h *Handler) SetupRoutes(router *gin.RouterGroup) {
+	group := router.Group("/fx-rates")
+	{
+		group.GET("", h.ListLatestRates)
+		group.GET("/:currency", h.GetRate)
+		group.GET("/:currency/history", h.ListRateHistory)
+	}
+}
+
+// ListLatestRates godoc
+// @Summary List exchange rates
+// @Description Get the latest USD exchange rate of every known fiat currency.
+// @Tags FXRates
+// @Produce json
+// @Success 200 {array} FXRateResponse "Latest exchange rates"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /fx-rates [get]
+func (h *Handler) ListLatestRates(c *gin.Context) {
+	rates, err := h.service.ListLatestRates(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := make([]FXRateResponse, len(rates))
+	for i, rate := range rates {
+		response[i] = mapModelToResponse(rate)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetRate godoc
+// @Summary Get exchange rate
+// @Description Get the latest USD exchange rate of a fiat currency, or the rate in effect at a point in time.
+// @Tags FXRates
+// @Produce json
+// @Param currency path string true "ISO 4217 currency code (e.g., EUR)"
+// @Param at query string false "RFC 3339 time to get the rate in effect at"
+// @Success 200 {object} FXRateResponse "Exchange rate"
+// @Failure 400 {object} errors.Vault0Error "Invalid currency or time"
+// @Failure 404 {object} errors.Vault0Error "Exchange rate not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /fx-rates/{currency} [get]
+func (h *Handler) GetRate(c *gin.Context) {
+	var req GetFXRateRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.Error(errors.NewInvalidParameterError("at", "must be an RFC 3339 time"))
+		return
+	}
+
+	var (
+		rate *fxsvc.FXRate
+		err  error
+	)
+	if req.At != nil {
+		rate, err = h.service.GetRateAt(c.Request.Context(), c.Param("currency"), *req.At)
+	} else {
+		rate, err = h.service.GetRate(c.Request.Context(), c.Param("currency"))
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapModelToResponse(rate))
+}
+
+// ListRateHistory godoc
+// @Summary List exchange rate history
+// @Description Get a paginated list of the historical USD exchange rates of a fiat currency, most recent first.
+// @Tags FXRates
+// @Produce json
+// @Param currency path string true "ISO 4217 currency code (e.g., EUR)"
+// @Param from query string false "RFC 3339 time of the oldest rate to return"
+// @Param to query string false "RFC 3339 time of the most recent rate to return"
+// @Param limit query int false "Maximum number of items to return" default(10) minimum(1) maximum(100)
+// @Param next_token query string false "Token for fetching the next page"
+// @Success 200 {object} docs.FXRatePagedResponse "Paginated list of exchange rates"
+// @Failure 400 {object} errors.Vault0Error "Invalid query parameters"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /fx-rates/{currency}/history [get]
+func (h *Handler) ListRateHistory(c *gin.Context) {
+	var req ListFXRateHistoryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.Error(errors.NewInvalidParameterError("query", "invalid query parameters format or value"))
+		return
+	}
+
+	limit := 0
+	if req.Limit != nil {
+		limit = *req.Limit
+	}
+
+	filter := &fxsvc.FXRateHistoryFilter{From: req.From, To: req.To}
+	page, err := h.service.ListRateHistory(c.Request.Context(), c.Param("currency"), filter, limit, req.NextToken)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.NewPagedResponse(page, mapModelToResponse))
+}
+
+// mapModelToResponse converts a service layer FXRate model to an API response DTO.
+func mapModelToResponse(model *fxsvc.FXRate) FXRateResponse {
+	return FXRateResponse{
+		Currency: model.Currency,
+		Rate:     model.Rate,
+		AsOf:     model.AsOf,
+		Source:   model.Source,
+	}
+}
@@ -1,7 +1,9 @@
 package keystore
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
 	"vault0/internal/core/keystore"
@@ -37,6 +39,25 @@ type ImportKeyRequest struct {
 	Tags       map[string]string `json:"tags"`
 }
 
+// ImportKeystoreRequest represents a request to import a key from a V3 keystore JSON document
+type ImportKeystoreRequest struct {
+	Name       string            `json:"name" binding:"required"`
+	Keystore   json.RawMessage   `json:"keystore" binding:"required" swaggertype:"object"`
+	Passphrase string            `json:"passphrase" binding:"required"`
+	Tags       map[string]string `json:"tags"`
+}
+
+// ExportKeystoreRequest represents a request to export a key as a V3 keystore JSON document
+type ExportKeystoreRequest struct {
+	Passphrase string `json:"passphrase" binding:"required"`
+	KDF        string `json:"kdf,omitempty" binding:"omitempty,oneof=scrypt pbkdf2"`
+}
+
+// ExportKeystoreResponse represents the response to an export keystore request
+type ExportKeystoreResponse struct {
+	Keystore json.RawMessage `json:"keystore" swaggertype:"object"`
+}
+
 // SignDataRequest represents a request to sign data with a key
 type SignDataRequest struct {
 	Data    string `json:"data" binding:"required"`
@@ -48,6 +69,41 @@ type SignDataResponse struct {
 	Signature string `json:"signature"`
 }
 
+// VerifySignatureRequest represents a request to verify a signature with a stored key
+type VerifySignatureRequest struct {
+	Data      string `json:"data" binding:"required"`
+	Signature string `json:"signature" binding:"required"`
+	RawData   *bool  `json:"raw_data,omitempty"`
+}
+
+// VerifyPublicKeySignatureRequest represents a request to verify a signature with a supplied public key
+type VerifyPublicKeySignatureRequest struct {
+	PublicKey string `json:"public_key" binding:"required"`
+	Data      string `json:"data" binding:"required"`
+	Signature string `json:"signature" binding:"required"`
+	RawData   *bool  `json:"raw_data,omitempty"`
+}
+
+// VerifySignatureResponse represents the result of a signature verification
+type VerifySignatureResponse struct {
+	Valid bool `json:"valid"`
+}
+
+// RecoverAddressRequest represents a request to recover the Ethereum address behind a signature.
+// Data is base64 encoded and used for the raw, digest and personal_sign formats; TypedData is
+// used for the typed_data format. Signature is a 65-byte r||s||v value, 0x-prefixed hex or base64.
+type RecoverAddressRequest struct {
+	Format    string          `json:"format" binding:"required,oneof=raw digest personal_sign typed_data"`
+	Data      string          `json:"data,omitempty"`
+	TypedData json.RawMessage `json:"typed_data,omitempty" swaggertype:"object"`
+	Signature string          `json:"signature" binding:"required"`
+}
+
+// RecoverAddressResponse represents the recovered signer of a signature
+type RecoverAddressResponse struct {
+	Address string `json:"address"`
+}
+
 // UpdateKeyRequest represents a request to update a key's metadata
 type UpdateKeyRequest struct {
 	Name string            `json:"name" binding:"required"`
@@ -62,6 +118,113 @@ type ListKeysRequest struct {
 	NextToken string   `form:"next_token" binding:"omitempty"`
 }
 
+// KeyPolicyRequest represents a request to replace a key's usage policy
+type KeyPolicyRequest struct {
+	Disabled                    bool     `json:"disabled"`
+	AllowedOperations           []string `json:"allowed_operations,omitempty" binding:"omitempty,dive,oneof=sign sign_transaction sign_message export"`
+	AllowedDataTypes            []string `json:"allowed_data_types,omitempty" binding:"omitempty,dive,oneof=raw digest"`
+	RateLimit                   int      `json:"rate_limit" binding:"min=0"`
+	RateLimitWindow             int64    `json:"rate_limit_window_seconds" binding:"min=0"`
+	AllowedDestinations         []string `json:"allowed_destinations,omitempty"`
+	AllowUnverifiedDestinations bool     `json:"allow_unverified_destinations"`
+}
+
+// KeyPolicyResponse represents a key's usage policy in API responses
+type KeyPolicyResponse struct {
+	KeyID                       string    `json:"key_id"`
+	Disabled                    bool      `json:"disabled"`
+	AllowedOperations           []string  `json:"allowed_operations"`
+	AllowedDataTypes            []string  `json:"allowed_data_types"`
+	RateLimit                   int       `json:"rate_limit"`
+	RateLimitWindow             int64     `json:"rate_limit_window_seconds"`
+	AllowedDestinations         []string  `json:"allowed_destinations"`
+	AllowUnverifiedDestinations bool      `json:"allow_unverified_destinations"`
+	UpdatedAt                   time.Time `json:"updated_at,omitempty"`
+}
+
+// SigningAuditEntryResponse represents a signing audit log entry in API responses
+type SigningAuditEntryResponse struct {
+	ID              string    `json:"id"`
+	KeyID           string    `json:"key_id"`
+	Operation       string    `json:"operation"`
+	Actor           string    `json:"actor"`
+	DataType        string    `json:"data_type"`
+	Digest          string    `json:"digest"`
+	Destination     string    `json:"destination,omitempty"`
+	TransactionHash string    `json:"transaction_hash,omitempty"`
+	Status          string    `json:"status"`
+	Reason          string    `json:"reason,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// ListSigningAuditRequest defines the query parameters for listing signing audit entries
+type ListSigningAuditRequest struct {
+	KeyID           string `form:"key_id" binding:"omitempty"`
+	Actor           string `form:"actor" binding:"omitempty"`
+	TransactionHash string `form:"transaction_hash" binding:"omitempty"`
+	Limit           *int   `form:"limit" binding:"omitempty,min=1"`
+	NextToken       string `form:"next_token" binding:"omitempty"`
+}
+
+// toPolicy converts a KeyPolicyRequest to a keystore.KeyPolicy for the given key
+func (r *KeyPolicyRequest) toPolicy(keyID string) *keystore.KeyPolicy {
+	policy := &keystore.KeyPolicy{
+		KeyID:                       keyID,
+		Disabled:                    r.Disabled,
+		RateLimit:                   r.RateLimit,
+		RateLimitWindow:             time.Duration(r.RateLimitWindow) * time.Second,
+		AllowedDestinations:         r.AllowedDestinations,
+		AllowUnverifiedDestinations: r.AllowUnverifiedDestinations,
+	}
+	for _, operation := range r.AllowedOperations {
+		policy.AllowedOperations = append(policy.AllowedOperations, keystore.Operation(operation))
+	}
+	for _, dataType := range r.AllowedDataTypes {
+		policy.AllowedDataTypes = append(policy.AllowedDataTypes, keystore.DataType(dataType))
+	}
+	return policy
+}
+
+// toPolicyResponse converts a keystore.KeyPolicy to a KeyPolicyResponse
+func toPolicyResponse(policy *keystore.KeyPolicy) KeyPolicyResponse {
+	response := KeyPolicyResponse{
+		KeyID:                       policy.KeyID,
+		Disabled:                    policy.Disabled,
+		AllowedOperations:           []string{},
+		AllowedDataTypes:            []string{},
+		RateLimit:                   policy.RateLimit,
+		RateLimitWindow:             int64(policy.RateLimitWindow / time.Second),
+		AllowedDestinations:         []string{},
+		AllowUnverifiedDestinations: policy.AllowUnverifiedDestinations,
+		UpdatedAt:                   policy.UpdatedAt,
+	}
+	for _, operation := range policy.AllowedOperations {
+		response.AllowedOperations = append(response.AllowedOperations, string(operation))
+	}
+	for _, dataType := range policy.AllowedDataTypes {
+		response.AllowedDataTypes = append(response.AllowedDataTypes, string(dataType))
+	}
+	response.AllowedDestinations = append(response.AllowedDestinations, policy.AllowedDestinations...)
+	return response
+}
+
+// toAuditEntryResponse converts a keystore.SigningAuditEntry to a SigningAuditEntryResponse
+func toAuditEntryResponse(entry *keystore.SigningAuditEntry) SigningAuditEntryResponse {
+	return SigningAuditEntryResponse{
+		ID:              strconv.FormatInt(entry.ID, 10),
+		KeyID:           entry.KeyID,
+		Operation:       string(entry.Operation),
+		Actor:           entry.Actor,
+		DataType:        string(entry.DataType),
+		Digest:          entry.Digest,
+		Destination:     entry.Destination,
+		TransactionHash: entry.TransactionHash,
+		Status:          string(entry.Status),
+		Reason:          entry.Reason,
+		CreatedAt:       entry.CreatedAt,
+	}
+}
+
 // Convert a keystore.Key to a KeyResponse
 func toResponse(key *keystore.Key) KeyResponse {
 	var publicKeyStr *string
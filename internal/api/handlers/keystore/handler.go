@@ -1,6 +1,7 @@
 package keystore
 
 import (
+	"encoding/hex"
 	"net/http"
 	"strings"
 
@@ -9,6 +10,9 @@ import (
 	_ "vault0/internal/api/docs" // Required for Swagger documentation
 	"vault0/internal/api/middleares"
 	"vault0/internal/api/utils"
+	"vault0/internal/config"
+	"vault0/internal/core/crypto"
+	"vault0/internal/core/keystore"
 	"vault0/internal/errors"
 	keystoreSvc "vault0/internal/services/keystore"
 	"vault0/internal/types"
@@ -16,12 +20,16 @@ import (
 
 // Handler manages keystore-related API endpoints
 type Handler struct {
-	service keystoreSvc.Service
+	service   keystoreSvc.Service
+	adminAuth *middleares.AdminAuth
 }
 
 // NewHandler creates a new keystore handler
-func NewHandler(service keystoreSvc.Service) *Handler {
-	return &Handler{service: service}
+func NewHandler(service keystoreSvc.Service, cfg *config.Config) *Handler {
+	return &Handler{
+		service:   service,
+		adminAuth: middleares.NewAdminAuth(cfg.AdminAPIKey),
+	}
 }
 
 // SetupRoutes configures the keystore API routes
@@ -33,10 +41,19 @@ func (h *Handler) SetupRoutes(router *gin.RouterGroup) {
 	keystoreRoutes.GET("", h.listKeys)
 	keystoreRoutes.POST("", h.createKey)
 	keystoreRoutes.POST("/import", h.importKey)
+	keystoreRoutes.POST("/import/keystore", h.adminAuth.Middleware(), h.importKeystore)
+	keystoreRoutes.POST("/verify", h.verifyWithPublicKey)
+	keystoreRoutes.POST("/recover", h.recoverAddress)
+	keystoreRoutes.GET("/audit", h.listSigningAudit)
 	keystoreRoutes.GET("/:id", h.getKey)
 	keystoreRoutes.PUT("/:id", h.updateKey)
 	keystoreRoutes.DELETE("/:id", h.deleteKey)
 	keystoreRoutes.POST("/:id/sign", h.signData)
+	keystoreRoutes.POST("/:id/verify", h.verifySignature)
+	keystoreRoutes.POST("/:id/export", h.adminAuth.Middleware(), h.exportKeystore)
+	keystoreRoutes.GET("/:id/policy", h.getKeyPolicy)
+	keystoreRoutes.PUT("/:id/policy", h.adminAuth.Middleware(), h.updateKeyPolicy)
+	keystoreRoutes.GET("/:id/audit", h.listKeySigningAudit)
 }
 
 // listKeys handles GET /keys
@@ -330,3 +347,370 @@ func (h *Handler) signData(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// importKeystore handles POST /keys/import/keystore
+// @Summary Import a V3 keystore
+// @Description Import a secp256k1 key from a Web3 Secret Storage (V3) JSON keystore, as produced by geth or MetaMask. Requires the admin API key in the X-Admin-Key header.
+// @Tags keys
+// @Accept json
+// @Produce json
+// @Param X-Admin-Key header string true "Admin API key"
+// @Param key body ImportKeystoreRequest true "Keystore details"
+// @Success 201 {object} KeyResponse
+// @Failure 400 {object} errors.Vault0Error "Invalid request, keystore or passphrase"
+// @Failure 401 {object} errors.Vault0Error "Missing admin API key"
+// @Failure 403 {object} errors.Vault0Error "Invalid admin API key"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /keys/import/keystore [post]
+func (h *Handler) importKeystore(c *gin.Context) {
+	var req ImportKeystoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	key, err := h.service.ImportKeyV3(c.Request.Context(), req.Name, req.Keystore, req.Passphrase, req.Tags)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// Build response
+	response := toResponse(key)
+	c.JSON(http.StatusCreated, response)
+}
+
+// exportKeystore handles POST /keys/:id/export
+// @Summary Export a key as a V3 keystore
+// @Description Export a secp256k1 key as a passphrase-protected Web3 Secret Storage (V3) JSON keystore. Requires the admin API key in the X-Admin-Key header.
+// @Tags keys
+// @Accept json
+// @Produce json
+// @Param id path string true "Key ID"
+// @Param X-Admin-Key header string true "Admin API key"
+// @Param export body ExportKeystoreRequest true "Export options"
+// @Success 200 {object} ExportKeystoreResponse
+// @Failure 400 {object} errors.Vault0Error "Invalid request or key not exportable"
+// @Failure 401 {object} errors.Vault0Error "Missing admin API key"
+// @Failure 403 {object} errors.Vault0Error "Invalid admin API key"
+// @Failure 404 {object} errors.Vault0Error "Key not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /keys/{id}/export [post]
+func (h *Handler) exportKeystore(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.Error(errors.NewMissingParameterError("id"))
+		return
+	}
+
+	var req ExportKeystoreRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	kdf := crypto.KDFScrypt
+	if req.KDF != "" {
+		kdf = crypto.KDF(req.KDF)
+	}
+
+	keystoreJSON, err := h.service.ExportKeyV3(c.Request.Context(), id, req.Passphrase, kdf)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ExportKeystoreResponse{Keystore: keystoreJSON})
+}
+
+// verifySignature handles POST /keys/:id/verify
+// @Summary Verify a signature with a key
+// @Description Verify a signature over data using the public key of a stored key
+// @Tags keys
+// @Accept json
+// @Produce json
+// @Param id path string true "Key ID"
+// @Param request body VerifySignatureRequest true "Data and signature to verify"
+// @Success 200 {object} VerifySignatureResponse
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 404 {object} errors.Vault0Error "Key not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /keys/{id}/verify [post]
+func (h *Handler) verifySignature(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.Error(errors.NewMissingParameterError("id"))
+		return
+	}
+
+	var req VerifySignatureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	data, err := utils.DecodeBytes(req.Data)
+	if err != nil {
+		c.Error(errors.NewInvalidParameterError("data", "must be valid base64 encoded data"))
+		return
+	}
+
+	signature, err := utils.DecodeBytes(req.Signature)
+	if err != nil {
+		c.Error(errors.NewInvalidParameterError("signature", "must be valid base64 encoded data"))
+		return
+	}
+
+	rawData := false
+	if req.RawData != nil {
+		rawData = *req.RawData
+	}
+
+	valid, err := h.service.VerifySignature(c.Request.Context(), id, data, signature, rawData)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, VerifySignatureResponse{Valid: valid})
+}
+
+// verifyWithPublicKey handles POST /keys/verify
+// @Summary Verify a signature with a public key
+// @Description Verify a signature over data using a base64 encoded public key (PKIX DER, 65-byte secp256k1 or 32-byte Ed25519)
+// @Tags keys
+// @Accept json
+// @Produce json
+// @Param request body VerifyPublicKeySignatureRequest true "Public key, data and signature to verify"
+// @Success 200 {object} VerifySignatureResponse
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /keys/verify [post]
+func (h *Handler) verifyWithPublicKey(c *gin.Context) {
+	var req VerifyPublicKeySignatureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	publicKey, err := utils.DecodeBytes(req.PublicKey)
+	if err != nil {
+		c.Error(errors.NewInvalidParameterError("public_key", "must be valid base64 encoded data"))
+		return
+	}
+
+	data, err := utils.DecodeBytes(req.Data)
+	if err != nil {
+		c.Error(errors.NewInvalidParameterError("data", "must be valid base64 encoded data"))
+		return
+	}
+
+	signature, err := utils.DecodeBytes(req.Signature)
+	if err != nil {
+		c.Error(errors.NewInvalidParameterError("signature", "must be valid base64 encoded data"))
+		return
+	}
+
+	rawData := false
+	if req.RawData != nil {
+		rawData = *req.RawData
+	}
+
+	valid, err := h.service.VerifyWithPublicKey(c.Request.Context(), publicKey, data, signature, rawData)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, VerifySignatureResponse{Valid: valid})
+}
+
+// recoverAddress handles POST /keys/recover
+// @Summary Recover an Ethereum signer
+// @Description Recover the Ethereum address that produced a 65-byte signature over raw data, a digest, an EIP-191 message or EIP-712 typed data
+// @Tags keys
+// @Accept json
+// @Produce json
+// @Param request body RecoverAddressRequest true "Payload and signature"
+// @Success 200 {object} RecoverAddressResponse
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /keys/recover [post]
+func (h *Handler) recoverAddress(c *gin.Context) {
+	var req RecoverAddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	format := crypto.EthMessageFormat(req.Format)
+
+	var payload []byte
+	if format == crypto.EthMessageFormatTypedData {
+		if len(req.TypedData) == 0 {
+			c.Error(errors.NewMissingParameterError("typed_data"))
+			return
+		}
+		payload = req.TypedData
+	} else {
+		data, err := utils.DecodeBytes(req.Data)
+		if err != nil {
+			c.Error(errors.NewInvalidParameterError("data", "must be valid base64 encoded data"))
+			return
+		}
+		payload = data
+	}
+
+	signature, err := decodeEthSignature(req.Signature)
+	if err != nil {
+		c.Error(errors.NewInvalidParameterError("signature", "must be 0x-prefixed hex or base64 encoded data"))
+		return
+	}
+
+	address, err := h.service.RecoverAddress(c.Request.Context(), format, payload, signature)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, RecoverAddressResponse{Address: address})
+}
+
+// decodeEthSignature decodes a signature given either as 0x-prefixed hex or base64
+func decodeEthSignature(signature string) ([]byte, error) {
+	if strings.HasPrefix(signature, "0x") {
+		return hex.DecodeString(signature[2:])
+	}
+	return utils.DecodeBytes(signature)
+}
+
+// getKeyPolicy handles GET /keys/:id/policy
+// @Summary Get a key's usage policy
+// @Description Get the policy evaluated before every signing operation with the key
+// @Tags keys
+// @Produce json
+// @Param id path string true "Key ID"
+// @Success 200 {object} KeyPolicyResponse
+// @Failure 404 {object} errors.Vault0Error "Key not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /keys/{id}/policy [get]
+func (h *Handler) getKeyPolicy(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.Error(errors.NewMissingParameterError("id"))
+		return
+	}
+
+	policy, err := h.service.GetKeyPolicy(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toPolicyResponse(policy))
+}
+
+// updateKeyPolicy handles PUT /keys/:id/policy
+// @Summary Update a key's usage policy
+// @Description Replace the allowed operations, data types, destinations, rate limit and disabled flag of a key. Requires the admin API key.
+// @Tags keys
+// @Accept json
+// @Produce json
+// @Param id path string true "Key ID"
+// @Param X-Admin-Key header string true "Admin API key"
+// @Param policy body KeyPolicyRequest true "Key policy"
+// @Success 200 {object} KeyPolicyResponse
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 401 {object} errors.Vault0Error "Missing admin API key"
+// @Failure 403 {object} errors.Vault0Error "Invalid admin API key"
+// @Failure 404 {object} errors.Vault0Error "Key not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /keys/{id}/policy [put]
+func (h *Handler) updateKeyPolicy(c *gin.Context) {
+	id := c.Param("id")
+	if id == "" {
+		c.Error(errors.NewMissingParameterError("id"))
+		return
+	}
+
+	var req KeyPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	policy, err := h.service.UpdateKeyPolicy(c.Request.Context(), req.toPolicy(id))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toPolicyResponse(policy))
+}
+
+// listSigningAudit handles GET /keys/audit
+// @Summary List signing audit entries
+// @Description Query the append-only log of signing attempts across all keys, newest first
+// @Tags keys
+// @Produce json
+// @Param key_id query string false "Filter by key ID"
+// @Param actor query string false "Filter by actor"
+// @Param transaction_hash query string false "Filter by transaction hash"
+// @Param limit query int false "Maximum number of entries to return (default 10)"
+// @Param next_token query string false "Token for pagination (empty for first page)"
+// @Success 200 {object} docs.SigningAuditPagedResponse
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /keys/audit [get]
+func (h *Handler) listSigningAudit(c *gin.Context) {
+	h.listAudit(c, "")
+}
+
+// listKeySigningAudit handles GET /keys/:id/audit
+// @Summary List signing audit entries of a key
+// @Description Query the append-only log of signing attempts with a key, newest first
+// @Tags keys
+// @Produce json
+// @Param id path string true "Key ID"
+// @Param actor query string false "Filter by actor"
+// @Param transaction_hash query string false "Filter by transaction hash"
+// @Param limit query int false "Maximum number of entries to return (default 10)"
+// @Param next_token query string false "Token for pagination (empty for first page)"
+// @Success 200 {object} docs.SigningAuditPagedResponse
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /keys/{id}/audit [get]
+func (h *Handler) listKeySigningAudit(c *gin.Context) {
+	h.listAudit(c, c.Param("id"))
+}
+
+// listAudit lists signing audit entries, restricted to keyID when set
+func (h *Handler) listAudit(c *gin.Context, keyID string) {
+	var req ListSigningAuditRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.Error(errors.NewInvalidParameterError("query", "invalid query parameters format or value"))
+		return
+	}
+
+	limit := 10 // Default limit
+	if req.Limit != nil {
+		limit = *req.Limit
+	}
+
+	filter := keystore.SigningAuditFilter{
+		KeyID:           req.KeyID,
+		Actor:           req.Actor,
+		TransactionHash: req.TransactionHash,
+	}
+	if keyID != "" {
+		filter.KeyID = keyID
+	}
+
+	page, err := h.service.ListSigningAudit(c.Request.Context(), filter, limit, req.NextToken)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.NewPagedResponse(page, toAuditEntryResponse))
+}
@@ -0,0 +1,122 @@
+package stream
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"vault0/internal/errors"
+	"vault0/internal/services/stream"
+	"vault0/internal/types"
+)
+
+// StreamTokenResponse represents a token authenticating a stream
+type StreamTokenResponse struct {
+	Token     string    `json:"token" example:"1714737660.9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	ExpiresAt time.Time `json:"expires_at" example:"2024-05-03T12:01:00Z"`
+}
+
+// EventResponse represents a live event, sent as the data of SSE events and as
+// websocket messages
+type EventResponse struct {
+	ID        string          `json:"id,omitempty" example:"1234567890"`
+	Type      string          `json:"type" example:"transaction.deposit"`
+	ChainType string          `json:"chain_type,omitempty" example:"ethereum"`
+	WalletID  string          `json:"wallet_id,omitempty" example:"1234567890"`
+	VaultID   string          `json:"vault_id,omitempty" example:"1234567890"`
+	Data      json.RawMessage `json:"data,omitempty" swaggertype:"object"`
+	CreatedAt *time.Time      `json:"created_at,omitempty"`
+}
+
+// ToEventResponse converts a live event to its API response
+func ToEventResponse(event *stream.Event) *EventResponse {
+	response := &EventResponse{
+		ID:        strconv.FormatInt(event.ID, 10),
+		Type:      string(event.Type),
+		ChainType: string(event.ChainType),
+		Data:      json.RawMessage(event.Data),
+		CreatedAt: &event.CreatedAt,
+	}
+	if event.WalletID != nil {
+		response.WalletID = strconv.FormatInt(*event.WalletID, 10)
+	}
+	if event.VaultID != nil {
+		response.VaultID = strconv.FormatInt(*event.VaultID, 10)
+	}
+	return response
+}
+
+// newResetResponse returns the message telling a resuming client to reload its state.
+// It carries the ID to resume from, zero meaning no replay, so that the client stops
+// sending the ID of the events that can't be replayed.
+func newResetResponse(resumeID int64) *EventResponse {
+	return &EventResponse{
+		ID:   strconv.FormatInt(resumeID, 10),
+		Type: string(stream.EventTypeReset),
+	}
+}
+
+// parseFilter reads the filter and resume position of a stream from the request. List
+// parameters accept comma-separated and repeated values. The resume position is read
+// from the Last-Event-ID header sent by reconnecting EventSources, or the last_event_id
+// query parameter.
+func parseFilter(c *gin.Context) (stream.Filter, int64, error) {
+	var filter stream.Filter
+	for _, eventType := range queryList(c, "types") {
+		filter.EventTypes = append(filter.EventTypes, stream.EventType(eventType))
+	}
+	for _, chainType := range queryList(c, "chain_types") {
+		filter.ChainTypes = append(filter.ChainTypes, types.ChainType(chainType))
+	}
+
+	var err error
+	if filter.WalletIDs, err = queryIDs(c, "wallet_ids"); err != nil {
+		return filter, 0, err
+	}
+	if filter.VaultIDs, err = queryIDs(c, "vault_ids"); err != nil {
+		return filter, 0, err
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	if lastEventID == "" {
+		return filter, 0, nil
+	}
+
+	id, err := strconv.ParseInt(lastEventID, 10, 64)
+	if err != nil {
+		return filter, 0, errors.NewInvalidInputError("Invalid last event ID format", "last_event_id", lastEventID)
+	}
+	return filter, id, nil
+}
+
+// queryList returns the values of a comma-separated, possibly repeated, query parameter
+func queryList(c *gin.Context, name string) []string {
+	var values []string
+	for _, param := range c.QueryArray(name) {
+		for _, value := range strings.Split(param, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// queryIDs returns the IDs of a comma-separated, possibly repeated, query parameter
+func queryIDs(c *gin.Context, name string) ([]int64, error) {
+	var ids []int64
+	for _, value := range queryList(c, name) {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, errors.NewInvalidInputError("Invalid ID format", name, value)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
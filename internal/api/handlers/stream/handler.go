@@ -0,0 +1,296 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	_ "vault0/internal/api/docs" // Required for Swagger documentation
+	"vault0/internal/api/middleares"
+	"vault0/internal/config"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/services/stream"
+)
+
+// writeTimeout bounds the time spent writing a websocket message to a client
+const writeTimeout = 10 * time.Second
+
+// Handler holds the dependencies for the live event stream API handlers.
+type Handler struct {
+	service   stream.Service
+	adminAuth *middleares.AdminAuth
+	tokens    *tokenSigner
+	config    *config.Config
+	logger    logger.Logger
+	upgrader  websocket.Upgrader
+}
+
+// NewHandler creates a new live event stream handler instance.
+func NewHandler(svc stream.Service, cfg *config.Config, log logger.Logger) *Handler {
+	return &Handler{
+		service:   svc,
+		adminAuth: middleares.NewAdminAuth(cfg.AdminAPIKey),
+		tokens:    newTokenSigner(cfg.AdminAPIKey),
+		config:    cfg,
+		logger:    log.With(logger.String("handler", "stream")),
+		upgrader: websocket.Upgrader{
+			// Clients are authenticated by the admin API key or a stream token, not cookies
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// SetupRoutes registers the live event stream API routes with the Gin engine. The
+// streams accept the admin API key or a stream token, as browsers can't set headers
+// on EventSource and WebSocket connections.
+func (h *Handler) SetupRoutes(router *gin.RouterGroup) {
+	errorHandler := middleares.NewErrorHandler(nil)
+
+	group := router.Group("/events")
+	group.Use(errorHandler.Middleware())
+	{
+		group.POST("/tokens", h.adminAuth.Middleware(), h.CreateStreamToken)
+		group.GET("/stream", h.authenticate(), h.StreamEvents)
+		group.GET("/ws", h.authenticate(), h.StreamEventsWebSocket)
+	}
+}
+
+// authenticate returns a middleware accepting either the admin API key or a stream token
+func (h *Handler) authenticate() gin.HandlerFunc {
+	adminAuth := h.adminAuth.Middleware()
+	return func(c *gin.Context) {
+		token := c.Query("token")
+		if token == "" {
+			adminAuth(c)
+			return
+		}
+
+		if h.config.AdminAPIKey == "" || !h.tokens.Verify(token) {
+			c.Error(errors.NewForbiddenError())
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// CreateStreamToken godoc
+// @Summary Create a stream token
+// @Description Issue a short-lived token authenticating a live event stream, for browsers that can't send the admin API key header with EventSource and WebSocket. Requires the admin API key.
+// @Tags Events
+// @Produce json
+// @Param X-Admin-Key header string true "Admin API key"
+// @Success 201 {object} StreamTokenResponse "Stream token"
+// @Failure 401 {object} errors.Vault0Error "Missing admin API key"
+// @Failure 403 {object} errors.Vault0Error "Invalid admin API key"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /events/tokens [post]
+func (h *Handler) CreateStreamToken(c *gin.Context) {
+	ttl := 60
+	if h.config.Stream.TokenTTL > 0 {
+		ttl = h.config.Stream.TokenTTL
+	}
+
+	expiresAt := time.Now().Add(time.Duration(ttl) * time.Second).UTC().Truncate(time.Second)
+	c.JSON(http.StatusCreated, StreamTokenResponse{
+		Token:     h.tokens.Issue(expiresAt),
+		ExpiresAt: expiresAt,
+	})
+}
+
+// StreamEvents godoc
+// @Summary Stream live events
+// @Description Stream transaction, balance, vault status and price events as server-sent events. Each event carries its ID, which reconnecting clients send back in the Last-Event-ID header to receive the events they missed. When they can't be replayed, a stream.reset event tells the client to reload its state. Requires the admin API key or a stream token.
+// @Tags Events
+// @Produce text/event-stream
+// @Param X-Admin-Key header string false "Admin API key"
+// @Param token query string false "Stream token"
+// @Param types query string false "Comma-separated event types" example(transaction.deposit,balance.updated)
+// @Param chain_types query string false "Comma-separated chain types" example(ethereum,base)
+// @Param wallet_ids query string false "Comma-separated wallet IDs"
+// @Param vault_ids query string false "Comma-separated vault IDs"
+// @Param last_event_id query string false "ID of the last event received"
+// @Param Last-Event-ID header string false "ID of the last event received"
+// @Success 200 {object} EventResponse "Stream of events"
+// @Failure 400 {object} errors.Vault0Error "Invalid filter"
+// @Failure 401 {object} errors.Vault0Error "Missing admin API key"
+// @Failure 403 {object} errors.Vault0Error "Invalid admin API key or stream token"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /events/stream [get]
+func (h *Handler) StreamEvents(c *gin.Context) {
+	subscription, err := h.subscribe(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer subscription.Close()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Disable proxy buffering
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	write := func(event *EventResponse) bool {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			h.logger.Error("Failed to encode stream event", logger.Error(err))
+			return false
+		}
+		if event.ID != "" {
+			fmt.Fprintf(c.Writer, "id: %s\n", event.ID)
+		}
+		if _, err := fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event.Type, payload); err != nil {
+			return false
+		}
+		c.Writer.Flush()
+		return true
+	}
+
+	if !h.sendBacklog(subscription, write) {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeatInterval())
+	defer heartbeat.Stop()
+
+	ctx := c.Request.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return
+			}
+			if !write(ToEventResponse(event)) {
+				return
+			}
+		}
+	}
+}
+
+// StreamEventsWebSocket godoc
+// @Summary Stream live events over a websocket
+// @Description Stream transaction, balance, vault status and price events as JSON websocket messages. Reconnecting clients pass the ID of the last event received in last_event_id to receive the events they missed. When they can't be replayed, a stream.reset message tells the client to reload its state. Requires the admin API key or a stream token.
+// @Tags Events
+// @Param X-Admin-Key header string false "Admin API key"
+// @Param token query string false "Stream token"
+// @Param types query string false "Comma-separated event types" example(transaction.deposit,balance.updated)
+// @Param chain_types query string false "Comma-separated chain types" example(ethereum,base)
+// @Param wallet_ids query string false "Comma-separated wallet IDs"
+// @Param vault_ids query string false "Comma-separated vault IDs"
+// @Param last_event_id query string false "ID of the last event received"
+// @Success 101 {object} EventResponse "Stream of events"
+// @Failure 400 {object} errors.Vault0Error "Invalid filter"
+// @Failure 401 {object} errors.Vault0Error "Missing admin API key"
+// @Failure 403 {object} errors.Vault0Error "Invalid admin API key or stream token"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /events/ws [get]
+func (h *Handler) StreamEventsWebSocket(c *gin.Context) {
+	// Subscribe before upgrading, so that invalid filters are reported as HTTP errors
+	subscription, err := h.subscribe(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer subscription.Close()
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already replied to the client
+		h.logger.Debug("Failed to upgrade stream connection", logger.Error(err))
+		return
+	}
+	defer conn.Close()
+
+	// Read the client messages, which are ignored, to process control frames and
+	// detect when the connection is closed
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	write := func(event *EventResponse) bool {
+		conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		return conn.WriteJSON(event) == nil
+	}
+
+	if !h.sendBacklog(subscription, write) {
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeatInterval())
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				return
+			}
+		case event, ok := <-subscription.Events:
+			if !ok {
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, "stream ended"),
+					time.Now().Add(writeTimeout))
+				return
+			}
+			if !write(ToEventResponse(event)) {
+				return
+			}
+		}
+	}
+}
+
+// subscribe subscribes to the events matching the filter of the request
+func (h *Handler) subscribe(c *gin.Context) (*stream.Subscription, error) {
+	filter, lastEventID, err := parseFilter(c)
+	if err != nil {
+		return nil, err
+	}
+	return h.service.Subscribe(c.Request.Context(), filter, lastEventID)
+}
+
+// sendBacklog sends the reset message or the missed events of a resuming client, and
+// returns whether the stream can go on
+func (h *Handler) sendBacklog(subscription *stream.Subscription, write func(*EventResponse) bool) bool {
+	if subscription.Reset {
+		return write(newResetResponse(subscription.ResumeID))
+	}
+	for _, event := range subscription.Backlog {
+		if !write(ToEventResponse(event)) {
+			return false
+		}
+	}
+	return true
+}
+
+// heartbeatInterval returns the interval between keep-alive messages
+func (h *Handler) heartbeatInterval() time.Duration {
+	interval := 15
+	if h.config.Stream.HeartbeatInterval > 0 {
+		interval = h.config.Stream.HeartbeatInterval
+	}
+	return time.Duration(interval) * time.Second
+}
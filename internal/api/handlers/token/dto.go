@@ -1,25 +1,82 @@
 package token
 
 import (
+	"vault0/internal/core/tokendiscovery"
+	"vault0/internal/core/tokenstore"
 	"vault0/internal/types"
 )
 
 // TokenResponse is the token data returned in responses
 type TokenResponse struct {
-	Address   string          `json:"address"`
-	ChainType types.ChainType `json:"chain_type"`
-	Symbol    string          `json:"symbol"`
-	Decimals  uint8           `json:"decimals"`
-	Type      types.TokenType `json:"type"`
+	Address       string           `json:"address"`
+	ChainType     types.ChainType  `json:"chain_type"`
+	Symbol        string           `json:"symbol"`
+	Name          string           `json:"name,omitempty"`
+	Decimals      uint8            `json:"decimals"`
+	Type          types.TokenType  `json:"type"`
+	Verified      bool             `json:"verified"`
+	LogoURI       string           `json:"logo_uri,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+	RiskFlags     []types.RiskFlag `json:"risk_flags,omitempty"`
+	FeeOnTransfer bool             `json:"fee_on_transfer"`
+	Rebasing      bool             `json:"rebasing"`
+}
+
+// DiscoveredTokenResponse is the token metadata read from a token contract
+type DiscoveredTokenResponse struct {
+	TokenResponse
+	TotalSupply string `json:"total_supply,omitempty"`
 }
 
-// AddTokenRequest is the request body for adding a token
+// AddTokenRequest is the request body for adding a token.
+// Only the address and chain type are required; the other details are
+// discovered from the token contract when the symbol or type is omitted.
 type AddTokenRequest struct {
 	Address   string          `json:"address" binding:"required"`
 	ChainType types.ChainType `json:"chain_type" binding:"required"`
-	Symbol    string          `json:"symbol" binding:"required"`
-	Decimals  uint8           `json:"decimals" binding:"required"`
-	Type      types.TokenType `json:"type" binding:"required"`
+	Symbol    string          `json:"symbol"`
+	Name      string          `json:"name"`
+	Decimals  uint8           `json:"decimals"`
+	Type      types.TokenType `json:"type"`
+	// FeeOnTransfer and Rebasing describe ERC20 tokens whose balances don't
+	// change by the transferred amounts
+	FeeOnTransfer bool `json:"fee_on_transfer"`
+	Rebasing      bool `json:"rebasing"`
+}
+
+// TokenBehaviorRequest is the request body for setting the transfer behavior of a token
+type TokenBehaviorRequest struct {
+	FeeOnTransfer bool `json:"fee_on_transfer"`
+	Rebasing      bool `json:"rebasing"`
+}
+
+// ImportTokenListRequest defines the query parameters for importing a token list
+type ImportTokenListRequest struct {
+	DryRun bool `form:"dry_run"`
+	Prune  bool `form:"prune"`
+}
+
+// ExportTokenListRequest defines the query parameters for exporting a token list
+type ExportTokenListRequest struct {
+	Name string `form:"name"`
+}
+
+// SkippedTokenResponse is a token list entry that couldn't be imported
+type SkippedTokenResponse struct {
+	ChainID int64  `json:"chain_id"`
+	Address string `json:"address"`
+	Symbol  string `json:"symbol"`
+	Reason  string `json:"reason"`
+}
+
+// TokenListDiffResponse reports the changes made by a token list import
+type TokenListDiffResponse struct {
+	Added   []TokenResponse        `json:"added"`
+	Changed []TokenResponse        `json:"changed"`
+	Removed []TokenResponse        `json:"removed"`
+	Skipped []SkippedTokenResponse `json:"skipped"`
+	DryRun  bool                   `json:"dry_run"`
+	Pruned  bool                   `json:"pruned"`
 }
 
 // ListTokensRequest defines the query parameters for listing tokens
@@ -33,10 +90,57 @@ type ListTokensRequest struct {
 // TokenToResponse converts a token to a token response
 func TokenToResponse(token types.Token) TokenResponse {
 	return TokenResponse{
-		Address:   token.Address,
-		ChainType: token.ChainType,
-		Symbol:    token.Symbol,
-		Decimals:  token.Decimals,
-		Type:      token.Type,
+		Address:       token.Address,
+		ChainType:     token.ChainType,
+		Symbol:        token.Symbol,
+		Name:          token.Name,
+		Decimals:      token.Decimals,
+		Type:          token.Type,
+		Verified:      token.Verified,
+		LogoURI:       token.LogoURI,
+		Tags:          token.Tags,
+		RiskFlags:     token.RiskFlags,
+		FeeOnTransfer: token.FeeOnTransfer,
+		Rebasing:      token.Rebasing,
+	}
+}
+
+// ToDiscoveredTokenResponse converts discovered token metadata to a response
+func ToDiscoveredTokenResponse(metadata *tokendiscovery.Metadata) DiscoveredTokenResponse {
+	response := DiscoveredTokenResponse{
+		TokenResponse: TokenToResponse(*metadata.Token),
+	}
+	if metadata.TotalSupply != nil {
+		response.TotalSupply = metadata.TotalSupply.String()
+	}
+	return response
+}
+
+// ToTokenListDiffResponse converts a token list diff to a response
+func ToTokenListDiffResponse(diff *tokenstore.TokenListDiff, req ImportTokenListRequest) TokenListDiffResponse {
+	response := TokenListDiffResponse{
+		Added:   tokensToResponses(diff.Added),
+		Changed: tokensToResponses(diff.Changed),
+		Removed: tokensToResponses(diff.Removed),
+		Skipped: make([]SkippedTokenResponse, 0, len(diff.Skipped)),
+		DryRun:  req.DryRun,
+		Pruned:  req.Prune && !req.DryRun,
+	}
+	for _, skipped := range diff.Skipped {
+		response.Skipped = append(response.Skipped, SkippedTokenResponse{
+			ChainID: skipped.Token.ChainID,
+			Address: skipped.Token.Address,
+			Symbol:  skipped.Token.Symbol,
+			Reason:  skipped.Reason,
+		})
+	}
+	return response
+}
+
+func tokensToResponses(tokens []types.Token) []TokenResponse {
+	responses := make([]TokenResponse, 0, len(tokens))
+	for _, token := range tokens {
+		responses = append(responses, TokenToResponse(token))
 	}
+	return responses
 }
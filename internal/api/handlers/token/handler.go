@@ -29,7 +29,7 @@ func NewHandler(service token.Service, logger logger.Logger) *Handler {
 // UpdateTokenRequest defines the request body for updating a token
 type UpdateTokenRequest struct {
 	Symbol   string          `json:"symbol" binding:"required"`
-	Decimals uint8           `json:"decimals" binding:"required"`
+	Decimals uint8           `json:"decimals"`
 	Type     types.TokenType `json:"type" binding:"required"`
 }
 
@@ -41,10 +41,14 @@ func (h *Handler) SetupRoutes(router *gin.RouterGroup) {
 	tokenRoutes.Use(errorHandler.Middleware())
 	tokenRoutes.GET("", h.listTokens)
 	tokenRoutes.POST("", h.addToken)
+	tokenRoutes.POST("/import", h.importTokenList)
+	tokenRoutes.GET("/export", h.exportTokenList)
 	tokenRoutes.GET("/verify/:address", h.verifyToken)
+	tokenRoutes.GET("/discover/:chain_type/:address", h.discoverToken)
 	tokenRoutes.GET("/:address", h.getToken)
 	tokenRoutes.DELETE("/:address", h.deleteToken)
 	tokenRoutes.PUT("/:address", h.updateToken)
+	tokenRoutes.PUT("/:address/behavior", h.setTokenBehavior)
 }
 
 // listTokens handles GET /tokens
@@ -101,7 +105,8 @@ func (h *Handler) listTokens(c *gin.Context) {
 
 // addToken handles POST /tokens
 // @Summary Add a new token
-// @Description Add a new token to the system
+// @Description Add a new token to the system. Only the address and chain type are required;
+// @Description when the symbol or type is omitted the token details are read from the contract.
 // @Tags tokens
 // @Accept json
 // @Produce json
@@ -124,8 +129,12 @@ func (h *Handler) addToken(c *gin.Context) {
 		Address:   req.Address,
 		ChainType: req.ChainType,
 		Symbol:    req.Symbol,
+		Name:      req.Name,
 		Decimals:  req.Decimals,
 		Type:      req.Type,
+
+		FeeOnTransfer: req.FeeOnTransfer,
+		Rebasing:      req.Rebasing,
 	}
 
 	// Add token
@@ -135,20 +144,14 @@ func (h *Handler) addToken(c *gin.Context) {
 	}
 
 	// Build response
-	response := TokenResponse{
-		Address:   token.Address,
-		ChainType: token.ChainType,
-		Symbol:    token.Symbol,
-		Decimals:  token.Decimals,
-		Type:      token.Type,
-	}
+	response := TokenToResponse(*token)
 
 	c.JSON(http.StatusCreated, response)
 }
 
 // verifyToken handles GET /tokens/:address
 // @Summary Verify token
-// @Description Verify a token by its address and return its details
+// @Description Verify a token against its contract and mark it as verified
 // @Tags tokens
 // @Produce json
 // @Param address path string true "Token address"
@@ -156,7 +159,7 @@ func (h *Handler) addToken(c *gin.Context) {
 // @Failure 400 {object} errors.Vault0Error "Invalid request"
 // @Failure 404 {object} errors.Vault0Error "Token not found"
 // @Failure 500 {object} errors.Vault0Error "Internal server error"
-// @Router /tokens/{address} [get]
+// @Router /tokens/verify/{address} [get]
 func (h *Handler) verifyToken(c *gin.Context) {
 	address := c.Param("address")
 	if address == "" {
@@ -171,17 +174,107 @@ func (h *Handler) verifyToken(c *gin.Context) {
 	}
 
 	// Build response
-	response := TokenResponse{
-		Address:   token.Address,
-		ChainType: token.ChainType,
-		Symbol:    token.Symbol,
-		Decimals:  token.Decimals,
-		Type:      token.Type,
-	}
+	response := TokenToResponse(*token)
 
 	c.JSON(http.StatusOK, response)
 }
 
+// importTokenList handles POST /tokens/import
+// @Summary Import token list
+// @Description Import a token list in the Uniswap token list format. New tokens are added and changed tokens updated.
+// @Description Verified ERC20 tokens missing from the list on the chains it covers are reported as removed and deleted only when prune is set.
+// @Tags tokens
+// @Accept json
+// @Produce json
+// @Param list body tokenstore.TokenList true "Token list"
+// @Param dry_run query bool false "Report the changes without applying them"
+// @Param prune query bool false "Delete the tokens missing from the list"
+// @Success 200 {object} TokenListDiffResponse
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /tokens/import [post]
+func (h *Handler) importTokenList(c *gin.Context) {
+	var req ImportTokenListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.Error(errors.NewInvalidParameterError("query", "invalid query parameters format or value"))
+		return
+	}
+
+	var list tokenstore.TokenList
+	if err := c.ShouldBindJSON(&list); err != nil {
+		c.Error(err)
+		return
+	}
+
+	diff, err := h.service.ImportTokenList(c.Request.Context(), &list, tokenstore.ImportOptions{
+		DryRun: req.DryRun,
+		Prune:  req.Prune,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToTokenListDiffResponse(diff, req))
+}
+
+// exportTokenList handles GET /tokens/export
+// @Summary Export token list
+// @Description Export the verified ERC20 tokens in the Uniswap token list format
+// @Tags tokens
+// @Produce json
+// @Param name query string false "Token list name (default: Vault0)"
+// @Success 200 {object} tokenstore.TokenList
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /tokens/export [get]
+func (h *Handler) exportTokenList(c *gin.Context) {
+	var req ExportTokenListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.Error(errors.NewInvalidParameterError("query", "invalid query parameters format or value"))
+		return
+	}
+	if req.Name == "" {
+		req.Name = "Vault0"
+	}
+
+	list, err := h.service.ExportTokenList(c.Request.Context(), req.Name)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// discoverToken handles GET /tokens/discover/:chain_type/:address
+// @Summary Discover token
+// @Description Read a token's name, symbol, decimals, total supply and type from its contract without registering it
+// @Tags tokens
+// @Produce json
+// @Param chain_type path string true "Chain type (ethereum, polygon, base)"
+// @Param address path string true "Token contract address"
+// @Success 200 {object} DiscoveredTokenResponse
+// @Failure 400 {object} errors.Vault0Error "Invalid request or not a token contract"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /tokens/discover/{chain_type}/{address} [get]
+func (h *Handler) discoverToken(c *gin.Context) {
+	chainType := types.ChainType(c.Param("chain_type"))
+	address := c.Param("address")
+	if address == "" {
+		c.Error(errors.NewInvalidParameterError("address", "cannot be empty"))
+		return
+	}
+
+	metadata, err := h.service.DiscoverToken(c.Request.Context(), chainType, address)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToDiscoveredTokenResponse(metadata))
+}
+
 // getToken handles GET /tokens/:chainType/:address
 // @Summary Get token details
 // @Description Get a token by its chain type and address
@@ -208,13 +301,7 @@ func (h *Handler) getToken(c *gin.Context) {
 	}
 
 	// Build response
-	response := TokenResponse{
-		Address:   token.Address,
-		ChainType: token.ChainType,
-		Symbol:    token.Symbol,
-		Decimals:  token.Decimals,
-		Type:      token.Type,
-	}
+	response := TokenToResponse(*token)
 
 	c.JSON(http.StatusOK, response)
 }
@@ -285,13 +372,42 @@ func (h *Handler) updateToken(c *gin.Context) {
 	}
 
 	// Build response
-	response := TokenResponse{
-		Address:   token.Address,
-		ChainType: token.ChainType,
-		Symbol:    token.Symbol,
-		Decimals:  token.Decimals,
-		Type:      token.Type,
-	}
+	response := TokenToResponse(*token)
 
 	c.JSON(http.StatusOK, response)
 }
+
+// setTokenBehavior handles PUT /tokens/:address/behavior
+// @Summary Set token transfer behavior
+// @Description Flag an ERC20 token as fee-on-transfer or rebasing, so that wallet balances are derived from receipt logs and balanceOf reads
+// @Tags tokens
+// @Accept json
+// @Produce json
+// @Param address path string true "Token address"
+// @Param behavior body TokenBehaviorRequest true "Token transfer behavior"
+// @Success 200 {object} TokenResponse
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 404 {object} errors.Vault0Error "Token not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /tokens/{address}/behavior [put]
+func (h *Handler) setTokenBehavior(c *gin.Context) {
+	address := c.Param("address")
+	if address == "" {
+		c.Error(errors.NewInvalidParameterError("address", "cannot be empty"))
+		return
+	}
+
+	var req TokenBehaviorRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	token, err := h.service.SetTokenBehavior(c.Request.Context(), address, req.FeeOnTransfer, req.Rebasing)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, TokenToResponse(*token))
+}
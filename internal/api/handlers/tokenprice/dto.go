@@ -1,16 +1,28 @@
 package tokenprice
 
-import "time"
+import (
+	"time"
+
+	"vault0/internal/types"
+)
 
 // TokenPriceResponse represents a single token price entry in API responses.
 type TokenPriceResponse struct {
-	Symbol       string    `json:"symbol"`
-	Rank         int       `json:"rank"`
-	PriceUSD     float64   `json:"price_usd"`
-	Supply       float64   `json:"supply"`
-	MarketCapUSD float64   `json:"market_cap_usd"`
-	VolumeUSD24h float64   `json:"volume_usd_24h"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	AssetID         string    `json:"asset_id"`
+	Symbol          string    `json:"symbol"`
+	ChainType       string    `json:"chain_type,omitempty"`
+	ContractAddress string    `json:"contract_address,omitempty"`
+	Rank            int       `json:"rank"`
+	PriceUSD        float64   `json:"price_usd"`
+	Supply          float64   `json:"supply"`
+	MarketCapUSD    float64   `json:"market_cap_usd"`
+	VolumeUSD24h    float64   `json:"volume_usd_24h"`
+	Currency        string    `json:"currency"`
+	Price           float64   `json:"price"`
+	MarketCap       float64   `json:"market_cap"`
+	Volume24h       float64   `json:"volume_24h"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	Sources         []string  `json:"sources,omitempty"`
 }
 
 // ListTokenPricesRequest defines query parameters for the list endpoint.
@@ -19,9 +31,30 @@ type ListTokenPricesRequest struct {
 	Limit     *int     `form:"limit" binding:"omitempty,min=1,max=100"`
 	NextToken string   `form:"next_token"`
 	Symbol    []string `form:"symbol" binding:"omitempty"`
+	AssetID   []string `form:"asset_id" binding:"omitempty"`
+	Currency  string   `form:"currency"`
 }
 
 // GetTokenPriceRequest defines path parameters for getting a single token.
 type GetTokenPriceRequest struct {
 	Symbol string `uri:"symbol" binding:"required"`
 }
+
+// GetTokenPriceQuery defines query parameters for getting a single token.
+type GetTokenPriceQuery struct {
+	Currency string `form:"currency"`
+}
+
+// TokenPriceMappingRequest defines the body for mapping a token to a price feed asset.
+type TokenPriceMappingRequest struct {
+	AssetID string `json:"asset_id" binding:"required" example:"usdc-usd-coin"`
+}
+
+// TokenPriceMappingResponse represents a token price mapping in API responses.
+type TokenPriceMappingResponse struct {
+	ChainType    types.ChainType `json:"chain_type" example:"base"`
+	TokenAddress string          `json:"token_address" example:"0x833589fcd6edb6e08f4c7c32d4f71b54bda02913"`
+	AssetID      string          `json:"asset_id" example:"usdc-usd-coin"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
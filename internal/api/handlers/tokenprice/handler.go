@@ -8,22 +8,26 @@ import (
 	"vault0/internal/api/utils"
 	"vault0/internal/errors"
 	"vault0/internal/logger"
+	fxsvc "vault0/internal/services/fxrate"
 	tokensvc "vault0/internal/services/tokenprice"
+	"vault0/internal/types"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Handler holds the dependencies for the token price API handlers.
 type Handler struct {
-	service tokensvc.Service
-	logger  logger.Logger
+	service   tokensvc.Service
+	fxService fxsvc.Service
+	logger    logger.Logger
 }
 
 // NewHandler creates a new token price handler instance.
-func NewHandler(svc tokensvc.Service, log logger.Logger) *Handler {
+func NewHandler(svc tokensvc.Service, fxSvc fxsvc.Service, log logger.Logger) *Handler {
 	return &Handler{
-		service: svc,
-		logger:  log.With(logger.String("handler", "tokenprice")),
+		service:   svc,
+		fxService: fxSvc,
+		logger:    log.With(logger.String("handler", "tokenprice")),
 	}
 }
 
@@ -34,6 +38,13 @@ func (h *Handler) SetupRoutes(router *gin.RouterGroup) {
 		group.GET("", h.ListTokenPrices)
 		group.GET("/:symbol", h.GetTokenPriceBySymbol)
 	}
+
+	mappings := router.Group("/token-price-mappings")
+	{
+		mappings.GET("", h.ListTokenPriceMappings)
+		mappings.PUT("/:chain_type/:address", h.SetTokenPriceMapping)
+		mappings.DELETE("/:chain_type/:address", h.DeleteTokenPriceMapping)
+	}
 }
 
 // ListTokenPrices godoc
@@ -45,6 +56,8 @@ func (h *Handler) SetupRoutes(router *gin.RouterGroup) {
 // @Param limit query int false "Maximum number of items to return (0 for all)" default(50) minimum(1) maximum(100)
 // @Param next_token query string false "Token for fetching the next page"
 // @Param symbol query string false "Token symbol to filter by (can be used multiple times, e.g., ?symbol=BTC&symbol=ETH)"
+// @Param asset_id query string false "Price feed asset ID to filter by (can be used multiple times)"
+// @Param currency query string false "ISO 4217 currency to convert prices to" default(USD)
 // @Success 200 {object} docs.TokenPricePagedResponse "Paginated list of token prices"
 // @Failure 400 {object} errors.Vault0Error "Invalid query parameters"
 // @Failure 500 {object} errors.Vault0Error "Internal server error"
@@ -62,15 +75,26 @@ func (h *Handler) ListTokenPrices(c *gin.Context) {
 		limit = *req.Limit
 	}
 
+	rate, err := h.fxService.GetRate(c.Request.Context(), req.Currency)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
 	// Normalize symbols (convert to uppercase)
 	var filter *tokensvc.TokenPriceFilter
-	if len(req.Symbol) > 0 {
+	if len(req.Symbol) > 0 || len(req.AssetID) > 0 {
 		symbols := make([]string, len(req.Symbol))
 		for i, symbol := range req.Symbol {
 			symbols[i] = strings.ToUpper(strings.TrimSpace(symbol))
 		}
+		assetIDs := make([]string, len(req.AssetID))
+		for i, assetID := range req.AssetID {
+			assetIDs[i] = strings.ToLower(strings.TrimSpace(assetID))
+		}
 		filter = &tokensvc.TokenPriceFilter{
-			Symbols: symbols,
+			Symbols:  symbols,
+			AssetIDs: assetIDs,
 		}
 	}
 
@@ -82,16 +106,19 @@ func (h *Handler) ListTokenPrices(c *gin.Context) {
 	}
 
 	// Use the generic paged response directly from utils
-	c.JSON(http.StatusOK, utils.NewPagedResponse(page, mapModelToResponse))
+	c.JSON(http.StatusOK, utils.NewPagedResponse(page, func(model *tokensvc.TokenPrice) TokenPriceResponse {
+		return mapModelToResponse(model, rate)
+	}))
 }
 
 // GetTokenPriceBySymbol godoc
 // @Summary Get token price by symbol
-// @Description Get the stored price data for a specific token symbol.
+// @Description Get the stored price data for a specific token symbol. When several assets share the symbol, the best ranked one is returned.
 // @Tags TokenPrices
 // @Accept json
 // @Produce json
 // @Param symbol path string true "Token Symbol (e.g., BTC)"
+// @Param currency query string false "ISO 4217 currency to convert the price to" default(USD)
 // @Success 200 {object} TokenPriceResponse "Token price data"
 // @Failure 400 {object} errors.Vault0Error "Invalid symbol format"
 // @Failure 404 {object} errors.Vault0Error "Token price not found"
@@ -104,6 +131,18 @@ func (h *Handler) GetTokenPriceBySymbol(c *gin.Context) {
 		return
 	}
 
+	var query GetTokenPriceQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(errors.NewInvalidParameterError("query", "invalid query parameters format or value"))
+		return
+	}
+
+	rate, err := h.fxService.GetRate(c.Request.Context(), query.Currency)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
 	symbol := strings.ToUpper(strings.TrimSpace(req.Symbol))
 
 	// Call the service
@@ -114,19 +153,121 @@ func (h *Handler) GetTokenPriceBySymbol(c *gin.Context) {
 	}
 
 	// Map to response DTO
-	response := mapModelToResponse(price)
+	response := mapModelToResponse(price, rate)
 	c.JSON(http.StatusOK, response)
 }
 
-// mapModelToResponse converts a service layer TokenPrice model to an API response DTO.
-func mapModelToResponse(model *tokensvc.TokenPrice) TokenPriceResponse {
+// mapModelToResponse converts a service layer TokenPrice model to an API response DTO,
+// converting the USD amounts with the given exchange rate.
+func mapModelToResponse(model *tokensvc.TokenPrice, rate *fxsvc.FXRate) TokenPriceResponse {
 	return TokenPriceResponse{
-		Symbol:       model.Symbol,
-		Rank:         model.Rank,
-		PriceUSD:     model.PriceUSD,
-		Supply:       model.Supply,
-		MarketCapUSD: model.MarketCapUSD,
-		VolumeUSD24h: model.VolumeUSD24h,
+		AssetID:         model.AssetID,
+		Symbol:          model.Symbol,
+		ChainType:       model.ChainType,
+		ContractAddress: model.ContractAddress,
+		Rank:            model.Rank,
+		PriceUSD:        model.PriceUSD,
+		Supply:          model.Supply,
+		MarketCapUSD:    model.MarketCapUSD,
+		VolumeUSD24h:    model.VolumeUSD24h,
+		Currency:        rate.Currency,
+		Price:           rate.Convert(model.PriceUSD),
+		MarketCap:       rate.Convert(model.MarketCapUSD),
+		Volume24h:       rate.Convert(model.VolumeUSD24h),
+		UpdatedAt:       model.UpdatedAt,
+		Sources:         splitSources(model.Sources),
+	}
+}
+
+// ListTokenPriceMappings godoc
+// @Summary List token price mappings
+// @Description Get the price feed assets tokens are explicitly mapped to.
+// @Tags TokenPrices
+// @Produce json
+// @Success 200 {array} TokenPriceMappingResponse "Token price mappings"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /token-price-mappings [get]
+func (h *Handler) ListTokenPriceMappings(c *gin.Context) {
+	mappings, err := h.service.ListTokenPriceMappings(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := make([]TokenPriceMappingResponse, len(mappings))
+	for i, mapping := range mappings {
+		response[i] = mapMappingToResponse(mapping)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// SetTokenPriceMapping godoc
+// @Summary Map a token to a price feed asset
+// @Description Set the price feed asset the value of a token is derived from, replacing any existing mapping.
+// @Tags TokenPrices
+// @Accept json
+// @Produce json
+// @Param chain_type path string true "Chain type of the token (e.g., base)"
+// @Param address path string true "Token contract address"
+// @Param request body TokenPriceMappingRequest true "Price feed asset"
+// @Success 200 {object} TokenPriceMappingResponse "Token price mapping"
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 404 {object} errors.Vault0Error "Token or token price not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /token-price-mappings/{chain_type}/{address} [put]
+func (h *Handler) SetTokenPriceMapping(c *gin.Context) {
+	var req TokenPriceMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Let middleware handle binding errors
+		c.Error(err)
+		return
+	}
+
+	chainType := types.ChainType(c.Param("chain_type"))
+	mapping, err := h.service.SetTokenPriceMapping(c.Request.Context(), chainType, c.Param("address"), req.AssetID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, mapMappingToResponse(mapping))
+}
+
+// DeleteTokenPriceMapping godoc
+// @Summary Remove a token price mapping
+// @Description Remove the price feed asset a token is mapped to, falling back to contract and symbol matching.
+// @Tags TokenPrices
+// @Param chain_type path string true "Chain type of the token (e.g., base)"
+// @Param address path string true "Token contract address"
+// @Success 204 "No Content"
+// @Failure 404 {object} errors.Vault0Error "Token price mapping not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /token-price-mappings/{chain_type}/{address} [delete]
+func (h *Handler) DeleteTokenPriceMapping(c *gin.Context) {
+	chainType := types.ChainType(c.Param("chain_type"))
+	if err := h.service.DeleteTokenPriceMapping(c.Request.Context(), chainType, c.Param("address")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// mapMappingToResponse converts a service layer TokenPriceMapping to an API response DTO.
+func mapMappingToResponse(model *tokensvc.TokenPriceMapping) TokenPriceMappingResponse {
+	return TokenPriceMappingResponse{
+		ChainType:    model.ChainType,
+		TokenAddress: model.TokenAddress,
+		AssetID:      model.AssetID,
+		CreatedAt:    model.CreatedAt,
 		UpdatedAt:    model.UpdatedAt,
 	}
 }
+
+// splitSources splits the comma-separated price feed sources of a stored price
+func splitSources(sources string) []string {
+	if sources == "" {
+		return nil
+	}
+	return strings.Split(sources, ",")
+}
@@ -30,6 +30,13 @@ type TransactionResponse struct {
 	ProposalID         string  `json:"proposal_id,omitempty"`
 	TargetTokenAddress string  `json:"target_token_address,omitempty"`
 	NewRecoveryAddress string  `json:"new_recovery_address,omitempty"`
+
+	// Generic decoding using the contract ABI
+	DecodedCall *types.DecodedCall   `json:"decoded_call,omitempty"`
+	Events      []types.DecodedEvent `json:"events,omitempty"`
+
+	// Spam and scam detection
+	RiskFlags []types.RiskFlag `json:"risk_flags,omitempty"`
 }
 
 // ListTransactionsRequest defines the query parameters for listing transactions
@@ -41,6 +48,7 @@ type ListTransactionsRequest struct {
 	TokenAddress string `form:"token_address"`
 	Status       string `form:"status"`
 	Type         string `form:"type"`
+	HideFlagged  bool   `form:"hide_flagged"`
 }
 
 // ListTransactionsByAddressRequest defines query parameters for listing transactions by address
@@ -49,6 +57,7 @@ type ListTransactionsByAddressRequest struct {
 	Limit        *int   `form:"limit" binding:"omitempty,min=1"`
 	TokenAddress string `form:"token_address"`
 	Type         string `form:"type"`
+	HideFlagged  bool   `form:"hide_flagged"`
 }
 
 // SyncTransactionsResponse represents the response for a transaction sync operation
@@ -104,6 +113,17 @@ func ToResponse(tx types.CoreTransaction) TransactionResponse {
 		response.Timestamp = concreteTx.Timestamp
 	}
 
+	// Attach the generically decoded contract call and events, if available
+	if decodedCall, ok := tx.GetMetadata().GetDecodedCall(); ok {
+		response.DecodedCall = decodedCall
+	}
+	if events, ok := tx.GetMetadata().GetDecodedEvents(); ok {
+		response.Events = events
+	}
+	if flags, ok := tx.GetMetadata().GetRiskFlags(); ok {
+		response.RiskFlags = flags
+	}
+
 	// Handle specific transaction types
 	switch typedTx := tx.(type) {
 	case *types.ERC20Transfer:
@@ -82,6 +82,7 @@ func (h *Handler) GetTransactionByHash(c *gin.Context) {
 // @Param limit query int false "Number of items to return (default: 10)" default(10)
 // @Param next_token query string false "Token for fetching the next page"
 // @Param token_address query string false "Filter transactions by token address"
+// @Param hide_flagged query bool false "Hide transactions flagged as spam or scams"
 // @Success 200 {object} docs.TransactionPagedResponse
 // @Failure 400 {object} errors.Vault0Error "Invalid request"
 // @Failure 404 {object} errors.Vault0Error "Wallet not found"
@@ -109,8 +110,9 @@ func (h *Handler) ListTransactionsByWalletAddress(c *gin.Context) {
 	addressVal := address
 
 	filter := &transaction.Filter{
-		ChainType: &chainTypeVal,
-		Address:   &addressVal,
+		ChainType:   &chainTypeVal,
+		Address:     &addressVal,
+		HideFlagged: req.HideFlagged,
 	}
 
 	// Add token address filter if provided
@@ -152,6 +154,7 @@ func (h *Handler) ListTransactionsByWalletAddress(c *gin.Context) {
 // @Param address query string false "Filter by wallet address (from or to)"
 // @Param token_address query string false "Filter by token address"
 // @Param status query string false "Filter by transaction status"
+// @Param hide_flagged query bool false "Hide transactions flagged as spam or scams"
 // @Param limit query int false "Number of items to return (default: 10)" default(10)
 // @Param next_token query string false "Token for fetching the next page"
 // @Success 200 {object} docs.TransactionPagedResponse
@@ -173,7 +176,9 @@ func (h *Handler) ListTransactions(c *gin.Context) {
 	}
 
 	// Create a filter with the provided parameters
-	filter := &transaction.Filter{}
+	filter := &transaction.Filter{
+		HideFlagged: req.HideFlagged,
+	}
 	// Apply chain type filter if provided
 	if req.ChainType != "" {
 		chainType := types.ChainType(req.ChainType)
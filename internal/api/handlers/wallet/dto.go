@@ -1,9 +1,15 @@
 package wallet
 
 import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
 	"strconv"
+	"strings"
 	"time"
 
+	"vault0/internal/services/fxrate"
+	"vault0/internal/services/tokenprice"
 	"vault0/internal/services/wallet"
 	"vault0/internal/types"
 )
@@ -21,12 +27,123 @@ type UpdateWalletRequest struct {
 	Tags map[string]string `json:"tags,omitempty" example:"{\"purpose\":\"defi\",\"environment\":\"production\"}"`
 }
 
+// @Description Request model for signing an EIP-191 personal message
+type SignMessageRequest struct {
+	// Message is signed as UTF-8 text unless it is 0x-prefixed hex, in which case the decoded bytes are signed
+	Message string `json:"message" binding:"required" example:"Sign in to example.com"`
+}
+
+// @Description Request model for signing EIP-712 typed data
+type SignTypedDataRequest struct {
+	TypedData json.RawMessage `json:"typed_data" binding:"required" swaggertype:"object"`
+}
+
+// @Description Response model containing an Ethereum-style signature
+type SignatureResponse struct {
+	Signature string `json:"signature" example:"0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b915621c"`
+}
+
+// @Description Request model for recovering the signer of an EIP-191 or EIP-712 signature
+type VerifySignatureRequest struct {
+	Format    wallet.SignatureFormat `json:"format" binding:"required,oneof=personal_sign typed_data" example:"personal_sign"`
+	Message   string                 `json:"message,omitempty" example:"Sign in to example.com"`
+	TypedData json.RawMessage        `json:"typed_data,omitempty" swaggertype:"object"`
+	Signature string                 `json:"signature" binding:"required" example:"0x4355c47d...1c"`
+	// Address optionally sets the expected signer; when present the response reports whether it matches
+	Address string `json:"address,omitempty" example:"0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"`
+}
+
+// @Description Response model containing the recovered signer address
+type VerifySignatureResponse struct {
+	Address string `json:"address" example:"0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"`
+	Valid   *bool  `json:"valid,omitempty" example:"true"`
+}
+
+// decodeMessage returns the bytes of a personal_sign message, decoding 0x-prefixed hex like wallets do
+func decodeMessage(message string) []byte {
+	if strings.HasPrefix(message, "0x") {
+		if data, err := hex.DecodeString(message[2:]); err == nil {
+			return data
+		}
+	}
+	return []byte(message)
+}
+
+// decodeSignature decodes a 0x-prefixed hex signature
+func decodeSignature(signature string) ([]byte, bool) {
+	data, err := hex.DecodeString(strings.TrimPrefix(signature, "0x"))
+	return data, err == nil
+}
+
 // ListWalletsRequest defines the query parameters for listing wallets
 type ListWalletsRequest struct {
 	NextToken string `form:"next_token"`
 	Limit     *int   `form:"limit" binding:"omitempty,min=0"`
 }
 
+// GetWalletBalanceRequest defines the query parameters for retrieving a wallet's balances
+type GetWalletBalanceRequest struct {
+	HideFlagged bool   `form:"hide_flagged"`
+	Currency    string `form:"currency"`
+}
+
+// ListNFTsRequest defines the query parameters for listing a wallet's NFTs
+type ListNFTsRequest struct {
+	NextToken string `form:"next_token"`
+	Limit     *int   `form:"limit" binding:"omitempty,min=0"`
+}
+
+// @Description Request model for sending an ERC721 token or an ERC1155 balance
+type SendNFTRequest struct {
+	TokenAddress string `json:"token_address" binding:"required" example:"0xBC4CA0EdA7647A8aB7C2061c2E118A18a936f13D"`
+	To           string `json:"to" binding:"required" example:"0x742d35Cc6634C0532925a3b844Bc454e4438f44e"`
+	TokenID      string `json:"token_id" binding:"required" example:"1234"`
+	// Amount defaults to 1 and must be 1 for ERC721 tokens
+	Amount string `json:"amount,omitempty" example:"1"`
+	// Standard is required only when the token is not registered in the token store
+	Standard types.TokenType `json:"standard,omitempty" binding:"omitempty,oneof=erc721 erc1155" example:"erc721"`
+}
+
+// @Description Response model containing the hash of a submitted transaction
+type SendNFTResponse struct {
+	TxHash string `json:"tx_hash" example:"0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b"`
+}
+
+// @Description Response model containing an NFT held by a wallet
+type NFTHoldingResponse struct {
+	TokenAddress string          `json:"token_address" example:"0xBC4CA0EdA7647A8aB7C2061c2E118A18a936f13D"`
+	TokenID      string          `json:"token_id" example:"1234"`
+	Standard     types.TokenType `json:"standard" example:"erc721"`
+	Amount       string          `json:"amount" example:"1"`
+	UpdatedAt    time.Time       `json:"updated_at" example:"2023-01-02T12:00:00Z"`
+}
+
+// ListAllowancesRequest defines the query parameters for listing a wallet's token allowances
+type ListAllowancesRequest struct {
+	NextToken string `form:"next_token"`
+	Limit     *int   `form:"limit" binding:"omitempty,min=0"`
+}
+
+// @Description Request model for revoking an ERC20 allowance
+type RevokeAllowanceRequest struct {
+	TokenAddress string `json:"token_address" binding:"required" example:"0xdAC17F958D2ee523a2206206994597C13D831ec7"`
+	Spender      string `json:"spender" binding:"required" example:"0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45"`
+}
+
+// @Description Response model containing the hash of the revocation transaction
+type RevokeAllowanceResponse struct {
+	TxHash string `json:"tx_hash" example:"0x88df016429689c079f3b2f6ad39fa052532c56795b733da78a91ebe6a713944b"`
+}
+
+// @Description Response model containing an ERC20 allowance granted by a wallet
+type TokenAllowanceResponse struct {
+	TokenAddress string    `json:"token_address" example:"0xdAC17F958D2ee523a2206206994597C13D831ec7"`
+	Spender      string    `json:"spender" example:"0x68b3465833fb72A70ecDF485E0e4C7bD8665Fc45"`
+	Amount       string    `json:"amount" example:"115792089237316195423570985008687907853269984665640564039457584007913129639935"`
+	Unlimited    bool      `json:"unlimited" example:"true"`
+	UpdatedAt    time.Time `json:"updated_at" example:"2023-01-02T12:00:00Z"`
+}
+
 // @Description Response model containing wallet details
 type WalletResponse struct {
 	ID              string            `json:"id" example:"1"`
@@ -45,16 +162,22 @@ type WalletResponse struct {
 type TokenBalanceResponse struct {
 	Token     TokenResponse `json:"token"`
 	Balance   string        `json:"balance" example:"100.000000"`
+	PriceUSD  *float64      `json:"price_usd,omitempty" example:"1.0002"`
+	ValueUSD  *float64      `json:"value_usd,omitempty" example:"100.02"`
+	Currency  string        `json:"currency,omitempty" example:"EUR"`
+	Price     *float64      `json:"price,omitempty" example:"0.9202"`
+	Value     *float64      `json:"value,omitempty" example:"92.02"`
 	UpdatedAt time.Time     `json:"updated_at" example:"2023-01-02T12:00:00Z"`
 }
 
 // @Description Response model containing token details
 type TokenResponse struct {
-	Address   string          `json:"address" example:"0xdAC17F958D2ee523a2206206994597C13D831ec7"`
-	ChainType types.ChainType `json:"chain_type" example:"ethereum"`
-	Symbol    string          `json:"symbol" example:"USDT"`
-	Decimals  uint8           `json:"decimals" example:"6"`
-	Type      string          `json:"type" example:"erc20"`
+	Address   string           `json:"address" example:"0xdAC17F958D2ee523a2206206994597C13D831ec7"`
+	ChainType types.ChainType  `json:"chain_type" example:"ethereum"`
+	Symbol    string           `json:"symbol" example:"USDT"`
+	Decimals  uint8            `json:"decimals" example:"6"`
+	Type      string           `json:"type" example:"erc20"`
+	RiskFlags []types.RiskFlag `json:"risk_flags,omitempty" example:"lookalike_symbol"`
 }
 
 func ToResponse(wallet *wallet.Wallet) *WalletResponse {
@@ -86,23 +209,69 @@ func ToTokenResponse(token *types.Token) TokenResponse {
 		Symbol:    token.Symbol,
 		Decimals:  token.Decimals,
 		Type:      string(token.Type),
+		RiskFlags: token.RiskFlags,
 	}
 }
 
-func ToTokenBalanceResponse(tokenBalance *wallet.TokenBalanceData) *TokenBalanceResponse {
+// ToTokenBalanceResponse converts a token balance to its response, valuing it in USD
+// and in the currency of the rate when the price of the token is known
+func ToTokenBalanceResponse(tokenBalance *wallet.TokenBalanceData, price *tokenprice.TokenPrice, rate *fxrate.FXRate) *TokenBalanceResponse {
 	balanceFloat := tokenBalance.Token.ToBigFloat(tokenBalance.Balance)
 
-	return &TokenBalanceResponse{
+	response := &TokenBalanceResponse{
 		Token:     ToTokenResponse(tokenBalance.Token),
 		Balance:   balanceFloat.Text('f', int(tokenBalance.Token.Decimals)),
 		UpdatedAt: tokenBalance.UpdatedAt,
 	}
+	if price != nil {
+		value, _ := new(big.Float).Mul(balanceFloat, big.NewFloat(price.PriceUSD)).Float64()
+		response.PriceUSD = &price.PriceUSD
+		response.ValueUSD = &value
+
+		convertedPrice := rate.Convert(price.PriceUSD)
+		convertedValue := rate.Convert(value)
+		response.Currency = rate.Currency
+		response.Price = &convertedPrice
+		response.Value = &convertedValue
+	}
+	return response
 }
 
-func ToTokenBalanceResponseList(tokenBalances []*wallet.TokenBalanceData) []*TokenBalanceResponse {
+// ToTokenBalanceResponseList converts token balances to responses, prices holding
+// the price of each balance's token or nil when unknown
+func ToTokenBalanceResponseList(tokenBalances []*wallet.TokenBalanceData, prices []*tokenprice.TokenPrice, rate *fxrate.FXRate) []*TokenBalanceResponse {
 	responses := make([]*TokenBalanceResponse, len(tokenBalances))
 	for i, tb := range tokenBalances {
-		responses[i] = ToTokenBalanceResponse(tb)
+		var price *tokenprice.TokenPrice
+		if i < len(prices) {
+			price = prices[i]
+		}
+		responses[i] = ToTokenBalanceResponse(tb, price, rate)
 	}
 	return responses
 }
+
+func ToNFTHoldingResponse(holding *wallet.NFTHolding) *NFTHoldingResponse {
+	return &NFTHoldingResponse{
+		TokenAddress: holding.TokenAddress,
+		TokenID:      holding.TokenID.String(),
+		Standard:     holding.Standard,
+		Amount:       holding.Amount.String(),
+		UpdatedAt:    holding.UpdatedAt,
+	}
+}
+
+// unlimitedAllowanceThreshold treats allowances of at least 2^255 as unlimited,
+// covering max uint256 approvals that some tokens decrement on spending
+var unlimitedAllowanceThreshold = new(big.Int).Lsh(big.NewInt(1), 255)
+
+func ToTokenAllowanceResponse(allowance *wallet.TokenAllowance) *TokenAllowanceResponse {
+	amount := allowance.Amount.ToBigInt()
+	return &TokenAllowanceResponse{
+		TokenAddress: allowance.TokenAddress,
+		Spender:      allowance.Spender,
+		Amount:       allowance.Amount.String(),
+		Unlimited:    amount != nil && amount.Cmp(unlimitedAllowanceThreshold) >= 0,
+		UpdatedAt:    allowance.UpdatedAt,
+	}
+}
@@ -1,32 +1,43 @@
 package wallet
 
 import (
+	"encoding/hex"
+	"math/big"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
 	_ "vault0/internal/api/docs" // Required for Swagger documentation
 	"vault0/internal/api/middleares"
 	"vault0/internal/api/utils"
-	_ "vault0/internal/errors" // Required for Swagger documentation
+	"vault0/internal/errors"
+	"vault0/internal/services/fxrate"
 	"vault0/internal/services/token"
+	"vault0/internal/services/tokenprice"
 	walletService "vault0/internal/services/wallet"
 	"vault0/internal/types"
 )
 
 // Handler handles wallet API requests
 type Handler struct {
-	walletService  walletService.Service
-	balanceService walletService.BalanceService
-	tokenService   token.Service
+	walletService     walletService.Service
+	balanceService    walletService.BalanceService
+	allowanceService  walletService.AllowanceService
+	tokenService      token.Service
+	tokenPriceService tokenprice.Service
+	fxRateService     fxrate.Service
 }
 
 // NewHandler creates a new wallet handler
-func NewHandler(walletService walletService.Service, balanceService walletService.BalanceService, tokenService token.Service) *Handler {
+func NewHandler(walletService walletService.Service, balanceService walletService.BalanceService, allowanceService walletService.AllowanceService, tokenService token.Service, tokenPriceService tokenprice.Service, fxRateService fxrate.Service) *Handler {
 	return &Handler{
-		walletService:  walletService,
-		balanceService: balanceService,
-		tokenService:   tokenService,
+		walletService:     walletService,
+		balanceService:    balanceService,
+		allowanceService:  allowanceService,
+		tokenService:      tokenService,
+		tokenPriceService: tokenPriceService,
+		fxRateService:     fxRateService,
 	}
 }
 
@@ -52,6 +63,13 @@ func (h *Handler) SetupRoutes(router *gin.RouterGroup) {
 	walletRoutes.DELETE("/:chain_type/:address", h.DeleteWallet)
 	walletRoutes.GET("/:chain_type/:address/balance", h.GetWalletBalance)
 	walletRoutes.POST("/:chain_type/:address/activate-token", h.ActivateToken)
+	walletRoutes.GET("/:chain_type/:address/nfts", h.ListNFTs)
+	walletRoutes.POST("/:chain_type/:address/nfts/send", h.SendNFT)
+	walletRoutes.GET("/:chain_type/:address/allowances", h.ListAllowances)
+	walletRoutes.POST("/:chain_type/:address/allowances/revoke", h.RevokeAllowance)
+	walletRoutes.POST("/:chain_type/:address/sign-message", h.SignMessage)
+	walletRoutes.POST("/:chain_type/:address/sign-typed-data", h.SignTypedData)
+	walletRoutes.POST("/:chain_type/verify-signature", h.VerifySignature)
 }
 
 // CreateWallet handles wallet creation
@@ -215,11 +233,13 @@ func (h *Handler) ListWallets(c *gin.Context) {
 
 // GetWalletBalance handles retrieving a wallet's balances by chain type and address
 // @Summary Get a wallet's balances
-// @Description Get a wallet's native token and other token balances by chain type and address
+// @Description Get a wallet's native token and other token balances by chain type and address, valued in USD and in the requested currency when a price is known for the token
 // @Tags wallets
 // @Produce json
 // @Param chain_type path string true "Blockchain network type (e.g., ethereum, bitcoin)"
 // @Param address path string true "Wallet address on the blockchain"
+// @Param hide_flagged query bool false "Hide balances of tokens flagged as spam or scams"
+// @Param currency query string false "ISO 4217 currency to value the balances in" default(USD)
 // @Success 200 {object} []TokenBalanceResponse "Array of token balances including native currency"
 // @Failure 404 {object} errors.Vault0Error "Wallet not found"
 // @Failure 500 {object} errors.Vault0Error "Internal server error"
@@ -228,6 +248,18 @@ func (h *Handler) GetWalletBalance(c *gin.Context) {
 	chainType := types.ChainType(c.Param("chain_type"))
 	address := c.Param("address")
 
+	var req GetWalletBalanceRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	rate, err := h.fxRateService.GetRate(c.Request.Context(), req.Currency)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
 	// Get the wallet balances
 	balances, err := h.balanceService.GetWalletBalancesByAddress(c.Request.Context(), chainType, address)
 	if err != nil {
@@ -235,8 +267,29 @@ func (h *Handler) GetWalletBalance(c *gin.Context) {
 		return
 	}
 
+	if req.HideFlagged {
+		visible := balances[:0]
+		for _, balance := range balances {
+			if !balance.Token.HasRiskFlags() {
+				visible = append(visible, balance)
+			}
+		}
+		balances = visible
+	}
+
+	// Value the balances with the prices mapped to their tokens
+	tokens := make([]*types.Token, len(balances))
+	for i, balance := range balances {
+		tokens[i] = balance.Token
+	}
+	prices, err := h.tokenPriceService.GetTokenPricesForTokens(c.Request.Context(), tokens)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
 	// Convert to response
-	response := ToTokenBalanceResponseList(balances)
+	response := ToTokenBalanceResponseList(balances, prices, rate)
 
 	// Write response
 	c.JSON(http.StatusOK, response)
@@ -274,3 +327,285 @@ func (h *Handler) ActivateToken(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+// ListNFTs handles listing the NFTs held by a wallet
+// @Summary List a wallet's NFTs
+// @Description Get a paginated list of the ERC721 tokens and ERC1155 balances held by a wallet
+// @Tags wallets
+// @Produce json
+// @Param chain_type path string true "Blockchain network type (e.g., ethereum)"
+// @Param address path string true "Wallet address on the blockchain"
+// @Param limit query int false "Maximum number of NFTs to return (default: 10, 0 for all)" default(10)
+// @Param next_token query string false "Token for retrieving the next page of results"
+// @Success 200 {object} docs.NFTHoldingPagedResponse "Paginated list of NFT holdings"
+// @Failure 400 {object} errors.Vault0Error "Invalid pagination token"
+// @Failure 404 {object} errors.Vault0Error "Wallet not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /wallets/{chain_type}/{address}/nfts [get]
+func (h *Handler) ListNFTs(c *gin.Context) {
+	chainType := types.ChainType(c.Param("chain_type"))
+	address := c.Param("address")
+
+	var req ListNFTsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	limit := 10
+	if req.Limit != nil {
+		limit = *req.Limit
+	}
+
+	holdings, err := h.balanceService.ListNFTHoldings(c.Request.Context(), chainType, address, limit, req.NextToken)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.NewPagedResponse(holdings, ToNFTHoldingResponse))
+}
+
+// SendNFT handles transferring an NFT from a wallet
+// @Summary Send an NFT
+// @Description Transfer an ERC721 token or an ERC1155 balance from the wallet using safeTransferFrom
+// @Tags wallets
+// @Accept json
+// @Produce json
+// @Param chain_type path string true "Blockchain network type (e.g., ethereum)"
+// @Param address path string true "Wallet address on the blockchain"
+// @Param request body SendNFTRequest true "NFT transfer details"
+// @Success 202 {object} SendNFTResponse "Transaction submitted"
+// @Failure 400 {object} errors.Vault0Error "Invalid request data"
+// @Failure 404 {object} errors.Vault0Error "Wallet not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /wallets/{chain_type}/{address}/nfts/send [post]
+func (h *Handler) SendNFT(c *gin.Context) {
+	chainType := types.ChainType(c.Param("chain_type"))
+	address := c.Param("address")
+
+	var req SendNFTRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	tokenID, ok := new(big.Int).SetString(req.TokenID, 10)
+	if !ok || tokenID.Sign() < 0 {
+		c.Error(errors.NewInvalidInputError("Token ID must be a non-negative integer", "token_id", req.TokenID))
+		return
+	}
+
+	amount := big.NewInt(1)
+	if req.Amount != "" {
+		amount, ok = new(big.Int).SetString(req.Amount, 10)
+		if !ok || amount.Sign() <= 0 {
+			c.Error(errors.NewInvalidInputError("Amount must be a positive integer", "amount", req.Amount))
+			return
+		}
+	}
+
+	txHash, err := h.walletService.SendNFT(c.Request.Context(), chainType, address, req.Standard, req.TokenAddress, req.To, tokenID, amount)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, SendNFTResponse{TxHash: txHash})
+}
+
+// ListAllowances handles listing the ERC20 allowances granted by a wallet
+// @Summary List a wallet's token allowances
+// @Description Get a paginated list of the outstanding ERC20 allowances granted by a wallet
+// @Tags wallets
+// @Produce json
+// @Param chain_type path string true "Blockchain network type (e.g., ethereum)"
+// @Param address path string true "Wallet address on the blockchain"
+// @Param limit query int false "Maximum number of allowances to return (default: 10, 0 for all)" default(10)
+// @Param next_token query string false "Token for retrieving the next page of results"
+// @Success 200 {object} docs.TokenAllowancePagedResponse "Paginated list of token allowances"
+// @Failure 400 {object} errors.Vault0Error "Invalid pagination token"
+// @Failure 404 {object} errors.Vault0Error "Wallet not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /wallets/{chain_type}/{address}/allowances [get]
+func (h *Handler) ListAllowances(c *gin.Context) {
+	chainType := types.ChainType(c.Param("chain_type"))
+	address := c.Param("address")
+
+	var req ListAllowancesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	limit := 10
+	if req.Limit != nil {
+		limit = *req.Limit
+	}
+
+	allowances, err := h.allowanceService.ListAllowances(c.Request.Context(), chainType, address, limit, req.NextToken)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.NewPagedResponse(allowances, ToTokenAllowanceResponse))
+}
+
+// RevokeAllowance handles revoking an ERC20 allowance granted by a wallet
+// @Summary Revoke a token allowance
+// @Description Submit an approve(spender, 0) transaction from the wallet. The allowance is removed once the transaction is mined.
+// @Tags wallets
+// @Accept json
+// @Produce json
+// @Param chain_type path string true "Blockchain network type (e.g., ethereum)"
+// @Param address path string true "Wallet address on the blockchain"
+// @Param request body RevokeAllowanceRequest true "Allowance to revoke"
+// @Success 202 {object} RevokeAllowanceResponse "Transaction submitted"
+// @Failure 400 {object} errors.Vault0Error "Invalid request data"
+// @Failure 404 {object} errors.Vault0Error "Wallet not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /wallets/{chain_type}/{address}/allowances/revoke [post]
+func (h *Handler) RevokeAllowance(c *gin.Context) {
+	chainType := types.ChainType(c.Param("chain_type"))
+	address := c.Param("address")
+
+	var req RevokeAllowanceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	txHash, err := h.allowanceService.RevokeAllowance(c.Request.Context(), chainType, address, req.TokenAddress, req.Spender)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, RevokeAllowanceResponse{TxHash: txHash})
+}
+
+// SignMessage handles EIP-191 personal message signing
+// @Summary Sign a personal message
+// @Description Sign a message with the wallet following EIP-191 (personal_sign) and return an r||s||v signature
+// @Tags wallets
+// @Accept json
+// @Produce json
+// @Param chain_type path string true "Blockchain network type (e.g., ethereum)"
+// @Param address path string true "Wallet address on the blockchain"
+// @Param request body SignMessageRequest true "Message to sign"
+// @Success 200 {object} SignatureResponse "Signature"
+// @Failure 400 {object} errors.Vault0Error "Invalid request data"
+// @Failure 404 {object} errors.Vault0Error "Wallet not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /wallets/{chain_type}/{address}/sign-message [post]
+func (h *Handler) SignMessage(c *gin.Context) {
+	chainType := types.ChainType(c.Param("chain_type"))
+	address := c.Param("address")
+
+	var req SignMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	signature, err := h.walletService.SignMessage(c.Request.Context(), chainType, address, decodeMessage(req.Message))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SignatureResponse{Signature: "0x" + hex.EncodeToString(signature)})
+}
+
+// SignTypedData handles EIP-712 typed data signing
+// @Summary Sign EIP-712 typed data
+// @Description Sign an EIP-712 typed data document (types, primaryType, domain, message) with the wallet and return an r||s||v signature
+// @Tags wallets
+// @Accept json
+// @Produce json
+// @Param chain_type path string true "Blockchain network type (e.g., ethereum)"
+// @Param address path string true "Wallet address on the blockchain"
+// @Param request body SignTypedDataRequest true "Typed data to sign"
+// @Success 200 {object} SignatureResponse "Signature"
+// @Failure 400 {object} errors.Vault0Error "Invalid request data or typed data"
+// @Failure 404 {object} errors.Vault0Error "Wallet not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /wallets/{chain_type}/{address}/sign-typed-data [post]
+func (h *Handler) SignTypedData(c *gin.Context) {
+	chainType := types.ChainType(c.Param("chain_type"))
+	address := c.Param("address")
+
+	var req SignTypedDataRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	signature, err := h.walletService.SignTypedData(c.Request.Context(), chainType, address, req.TypedData)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, SignatureResponse{Signature: "0x" + hex.EncodeToString(signature)})
+}
+
+// VerifySignature handles recovering the signer of an EIP-191 or EIP-712 signature
+// @Summary Verify a signature
+// @Description Recover the address that signed an EIP-191 personal message or EIP-712 typed data, optionally checking it against an expected address
+// @Tags wallets
+// @Accept json
+// @Produce json
+// @Param chain_type path string true "Blockchain network type (e.g., ethereum)"
+// @Param request body VerifySignatureRequest true "Signed payload and signature"
+// @Success 200 {object} VerifySignatureResponse "Recovered signer"
+// @Failure 400 {object} errors.Vault0Error "Invalid request data or signature"
+// @Failure 412 {object} errors.Vault0Error "Signature recovery failed"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /wallets/{chain_type}/verify-signature [post]
+func (h *Handler) VerifySignature(c *gin.Context) {
+	chainType := types.ChainType(c.Param("chain_type"))
+
+	var req VerifySignatureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	signature, ok := decodeSignature(req.Signature)
+	if !ok {
+		c.Error(errors.NewInvalidParameterError("signature", "must be hex encoded"))
+		return
+	}
+
+	var payload []byte
+	switch req.Format {
+	case walletService.SignatureFormatTypedData:
+		if len(req.TypedData) == 0 {
+			c.Error(errors.NewMissingParameterError("typed_data"))
+			return
+		}
+		payload = req.TypedData
+	default:
+		if req.Message == "" {
+			c.Error(errors.NewMissingParameterError("message"))
+			return
+		}
+		payload = decodeMessage(req.Message)
+	}
+
+	signer, err := h.walletService.RecoverSigner(c.Request.Context(), chainType, req.Format, payload, signature)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := VerifySignatureResponse{Address: signer}
+	if req.Address != "" {
+		valid := strings.EqualFold(signer, req.Address)
+		response.Valid = &valid
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"vault0/internal/services/webhook"
+	"vault0/internal/types"
+)
+
+// WebhookRequest defines the body for creating and updating webhooks
+type WebhookRequest struct {
+	URL         string   `json:"url" binding:"required" example:"https://example.com/hooks/vault0"`
+	Description string   `json:"description" example:"Treasury notifications"`
+	EventTypes  []string `json:"event_types" example:"transaction.deposit,vault.status_changed"` // All event types when empty
+	ChainTypes  []string `json:"chain_types" example:"ethereum"`                                 // All chains when empty
+	Addresses   []string `json:"addresses" example:"0x71C7656EC7ab88b098defB751B7401B5f6d8976F"` // All addresses when empty
+	Enabled     *bool    `json:"enabled,omitempty" example:"true"`                               // Only used on update, defaults to true
+}
+
+// ToFilter converts the request to a webhook filter
+func (r *WebhookRequest) ToFilter() webhook.Filter {
+	filter := webhook.Filter{Addresses: r.Addresses}
+	for _, eventType := range r.EventTypes {
+		filter.EventTypes = append(filter.EventTypes, webhook.EventType(eventType))
+	}
+	for _, chainType := range r.ChainTypes {
+		filter.ChainTypes = append(filter.ChainTypes, types.ChainType(chainType))
+	}
+	return filter
+}
+
+// ListWebhooksRequest defines the query parameters for listing webhooks
+type ListWebhooksRequest struct {
+	NextToken string `form:"next_token"`
+	Limit     *int   `form:"limit" binding:"omitempty,min=1"`
+}
+
+// ListDeliveriesRequest defines the query parameters for listing webhook deliveries
+type ListDeliveriesRequest struct {
+	Status    string `form:"status" binding:"omitempty,oneof=pending succeeded dead"`
+	NextToken string `form:"next_token"`
+	Limit     *int   `form:"limit" binding:"omitempty,min=1"`
+}
+
+// WebhookResponse represents a webhook in API responses
+type WebhookResponse struct {
+	ID          string    `json:"id" example:"1234567890"`
+	URL         string    `json:"url" example:"https://example.com/hooks/vault0"`
+	Description string    `json:"description" example:"Treasury notifications"`
+	EventTypes  []string  `json:"event_types" example:"transaction.deposit,vault.status_changed"`
+	ChainTypes  []string  `json:"chain_types" example:"ethereum"`
+	Addresses   []string  `json:"addresses" example:"0x71C7656EC7ab88b098defB751B7401B5f6d8976F"`
+	Enabled     bool      `json:"enabled" example:"true"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// WebhookSecretResponse represents a webhook with its signing secret, only returned
+// when the secret is generated
+type WebhookSecretResponse struct {
+	*WebhookResponse
+	Secret string `json:"secret" example:"5f4dcc3b5aa765d61d8327deb882cf995f4dcc3b5aa765d61d8327deb882cf99"`
+}
+
+// WebhookDeliveryResponse represents a webhook delivery in API responses
+type WebhookDeliveryResponse struct {
+	ID             string          `json:"id" example:"1234567890"`
+	WebhookID      string          `json:"webhook_id" example:"1234567890"`
+	EventID        string          `json:"event_id" example:"1234567890"`
+	EventType      string          `json:"event_type" example:"transaction.deposit"`
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"`
+	Status         string          `json:"status" example:"pending"`
+	Attempts       int             `json:"attempts" example:"1"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty"`
+	ResponseStatus *int            `json:"response_status,omitempty" example:"503"`
+	LastError      *string         `json:"last_error,omitempty" example:"unexpected response status 503: Service Unavailable"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// ToWebhookResponse converts a webhook model to its API response
+func ToWebhookResponse(webhook *webhook.Webhook) *WebhookResponse {
+	return &WebhookResponse{
+		ID:          strconv.FormatInt(webhook.ID, 10),
+		URL:         webhook.URL,
+		Description: webhook.Description,
+		EventTypes:  nonNil(webhook.EventTypes),
+		ChainTypes:  nonNil(webhook.ChainTypes),
+		Addresses:   nonNil(webhook.Addresses),
+		Enabled:     webhook.Enabled,
+		CreatedAt:   webhook.CreatedAt,
+		UpdatedAt:   webhook.UpdatedAt,
+	}
+}
+
+// ToWebhookDeliveryResponse converts a webhook delivery model to its API response
+func ToWebhookDeliveryResponse(delivery *webhook.Delivery) *WebhookDeliveryResponse {
+	return &WebhookDeliveryResponse{
+		ID:             strconv.FormatInt(delivery.ID, 10),
+		WebhookID:      strconv.FormatInt(delivery.WebhookID, 10),
+		EventID:        strconv.FormatInt(delivery.EventID, 10),
+		EventType:      string(delivery.EventType),
+		Payload:        json.RawMessage(delivery.Payload),
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastAttemptAt:  delivery.LastAttemptAt,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+}
+
+// nonNil returns an empty list instead of nil, so that lists are never encoded as null
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,350 @@
+package webhook
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	_ "vault0/internal/api/docs" // Required for Swagger documentation
+	"vault0/internal/api/middleares"
+	"vault0/internal/api/utils"
+	"vault0/internal/config"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/services/webhook"
+)
+
+// Handler holds the dependencies for the webhook API handlers.
+type Handler struct {
+	service   webhook.Service
+	adminAuth *middleares.AdminAuth
+	logger    logger.Logger
+}
+
+// NewHandler creates a new webhook handler instance.
+func NewHandler(svc webhook.Service, cfg *config.Config, log logger.Logger) *Handler {
+	return &Handler{
+		service:   svc,
+		adminAuth: middleares.NewAdminAuth(cfg.AdminAPIKey),
+		logger:    log.With(logger.String("handler", "webhook")),
+	}
+}
+
+// SetupRoutes registers the webhook API routes with the Gin engine. All of them
+// require the admin API key.
+func (h *Handler) SetupRoutes(router *gin.RouterGroup) {
+	errorHandler := middleares.NewErrorHandler(nil)
+
+	group := router.Group("/webhooks")
+	group.Use(errorHandler.Middleware(), h.adminAuth.Middleware())
+	{
+		group.POST("", h.CreateWebhook)
+		group.GET("", h.ListWebhooks)
+		group.GET("/:id", h.GetWebhook)
+		group.PUT("/:id", h.UpdateWebhook)
+		group.DELETE("/:id", h.DeleteWebhook)
+		group.POST("/:id/secret", h.RotateSecret)
+		group.GET("/:id/deliveries", h.ListDeliveries)
+		group.POST("/:id/deliveries/:deliveryId/retry", h.RetryDelivery)
+	}
+}
+
+// CreateWebhook godoc
+// @Summary Create a webhook
+// @Description Subscribe a URL to wallet, transaction and vault events, optionally filtered by event type, chain and address. The signing secret is only returned on creation. Requires the admin API key.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param X-Admin-Key header string true "Admin API key"
+// @Param webhook body WebhookRequest true "Webhook data"
+// @Success 201 {object} WebhookSecretResponse "Created webhook"
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 401 {object} errors.Vault0Error "Missing admin API key"
+// @Failure 403 {object} errors.Vault0Error "Invalid admin API key"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /webhooks [post]
+func (h *Handler) CreateWebhook(c *gin.Context) {
+	var req WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	created, secret, err := h.service.CreateWebhook(c.Request.Context(), req.URL, req.Description, req.ToFilter())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, WebhookSecretResponse{WebhookResponse: ToWebhookResponse(created), Secret: secret})
+}
+
+// ListWebhooks godoc
+// @Summary List webhooks
+// @Description Get a paginated list of webhooks. Requires the admin API key.
+// @Tags Webhooks
+// @Produce json
+// @Param X-Admin-Key header string true "Admin API key"
+// @Param limit query int false "Number of items to return (default: 10)" default(10)
+// @Param next_token query string false "Token for fetching the next page"
+// @Success 200 {object} docs.WebhookPagedResponse "Webhooks"
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 401 {object} errors.Vault0Error "Missing admin API key"
+// @Failure 403 {object} errors.Vault0Error "Invalid admin API key"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /webhooks [get]
+func (h *Handler) ListWebhooks(c *gin.Context) {
+	var req ListWebhooksRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.Error(errors.NewInvalidParameterError("query", "invalid query parameters format or value"))
+		return
+	}
+
+	limit := 10
+	if req.Limit != nil {
+		limit = *req.Limit
+	}
+
+	page, err := h.service.ListWebhooks(c.Request.Context(), limit, req.NextToken)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.NewPagedResponse(page, ToWebhookResponse))
+}
+
+// GetWebhook godoc
+// @Summary Get a webhook
+// @Description Get a webhook by ID. Requires the admin API key.
+// @Tags Webhooks
+// @Produce json
+// @Param X-Admin-Key header string true "Admin API key"
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} WebhookResponse "Webhook"
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 401 {object} errors.Vault0Error "Missing admin API key"
+// @Failure 403 {object} errors.Vault0Error "Invalid admin API key"
+// @Failure 404 {object} errors.Vault0Error "Webhook not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /webhooks/{id} [get]
+func (h *Handler) GetWebhook(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	found, err := h.service.GetWebhook(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToWebhookResponse(found))
+}
+
+// UpdateWebhook godoc
+// @Summary Update a webhook
+// @Description Replace the URL, description, filters and enabled state of a webhook. Requires the admin API key.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param X-Admin-Key header string true "Admin API key"
+// @Param id path int true "Webhook ID"
+// @Param webhook body WebhookRequest true "Webhook data"
+// @Success 200 {object} WebhookResponse "Updated webhook"
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 401 {object} errors.Vault0Error "Missing admin API key"
+// @Failure 403 {object} errors.Vault0Error "Invalid admin API key"
+// @Failure 404 {object} errors.Vault0Error "Webhook not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /webhooks/{id} [put]
+func (h *Handler) UpdateWebhook(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req WebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.Error(err)
+		return
+	}
+
+	enabled := true
+	if req.Enabled != nil {
+		enabled = *req.Enabled
+	}
+
+	updated, err := h.service.UpdateWebhook(c.Request.Context(), id, req.URL, req.Description, req.ToFilter(), enabled)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToWebhookResponse(updated))
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook
+// @Description Delete a webhook and its delivery log. Requires the admin API key.
+// @Tags Webhooks
+// @Param X-Admin-Key header string true "Admin API key"
+// @Param id path int true "Webhook ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 401 {object} errors.Vault0Error "Missing admin API key"
+// @Failure 403 {object} errors.Vault0Error "Invalid admin API key"
+// @Failure 404 {object} errors.Vault0Error "Webhook not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /webhooks/{id} [delete]
+func (h *Handler) DeleteWebhook(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := h.service.DeleteWebhook(c.Request.Context(), id); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RotateSecret godoc
+// @Summary Rotate a webhook secret
+// @Description Replace the signing secret of a webhook. Deliveries are signed with the new secret from their next attempt. Requires the admin API key.
+// @Tags Webhooks
+// @Produce json
+// @Param X-Admin-Key header string true "Admin API key"
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} WebhookSecretResponse "Webhook with its new secret"
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 401 {object} errors.Vault0Error "Missing admin API key"
+// @Failure 403 {object} errors.Vault0Error "Invalid admin API key"
+// @Failure 404 {object} errors.Vault0Error "Webhook not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /webhooks/{id}/secret [post]
+func (h *Handler) RotateSecret(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	secret, err := h.service.RotateSecret(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	found, err := h.service.GetWebhook(c.Request.Context(), id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, WebhookSecretResponse{WebhookResponse: ToWebhookResponse(found), Secret: secret})
+}
+
+// ListDeliveries godoc
+// @Summary List webhook deliveries
+// @Description Get the delivery log of a webhook, newest first, optionally filtered by status. Dead deliveries failed every attempt and are only retried on request. Requires the admin API key.
+// @Tags Webhooks
+// @Produce json
+// @Param X-Admin-Key header string true "Admin API key"
+// @Param id path int true "Webhook ID"
+// @Param status query string false "Delivery status" Enums(pending, succeeded, dead)
+// @Param limit query int false "Number of items to return (default: 10)" default(10)
+// @Param next_token query string false "Token for fetching the next page"
+// @Success 200 {object} docs.WebhookDeliveryPagedResponse "Deliveries"
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 401 {object} errors.Vault0Error "Missing admin API key"
+// @Failure 403 {object} errors.Vault0Error "Invalid admin API key"
+// @Failure 404 {object} errors.Vault0Error "Webhook not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /webhooks/{id}/deliveries [get]
+func (h *Handler) ListDeliveries(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var req ListDeliveriesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.Error(errors.NewInvalidParameterError("query", "invalid query parameters format or value"))
+		return
+	}
+
+	limit := 10
+	if req.Limit != nil {
+		limit = *req.Limit
+	}
+
+	var status *webhook.DeliveryStatus
+	if req.Status != "" {
+		s := webhook.DeliveryStatus(req.Status)
+		status = &s
+	}
+
+	page, err := h.service.ListDeliveries(c.Request.Context(), id, status, limit, req.NextToken)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.NewPagedResponse(page, ToWebhookDeliveryResponse))
+}
+
+// RetryDelivery godoc
+// @Summary Retry a webhook delivery
+// @Description Schedule a delivery, typically a dead one, for immediate redelivery with a new series of attempts. Requires the admin API key.
+// @Tags Webhooks
+// @Produce json
+// @Param X-Admin-Key header string true "Admin API key"
+// @Param id path int true "Webhook ID"
+// @Param deliveryId path int true "Delivery ID"
+// @Success 200 {object} WebhookDeliveryResponse "Rescheduled delivery"
+// @Failure 400 {object} errors.Vault0Error "Invalid request"
+// @Failure 401 {object} errors.Vault0Error "Missing admin API key"
+// @Failure 403 {object} errors.Vault0Error "Invalid admin API key"
+// @Failure 404 {object} errors.Vault0Error "Delivery not found"
+// @Failure 500 {object} errors.Vault0Error "Internal server error"
+// @Router /webhooks/{id}/deliveries/{deliveryId}/retry [post]
+func (h *Handler) RetryDelivery(c *gin.Context) {
+	id, err := parseID(c, "id")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	deliveryID, err := parseID(c, "deliveryId")
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	delivery, err := h.service.RetryDelivery(c.Request.Context(), id, deliveryID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ToWebhookDeliveryResponse(delivery))
+}
+
+// parseID parses an ID path parameter
+func parseID(c *gin.Context, param string) (int64, error) {
+	value := c.Param(param)
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, errors.NewInvalidInputError("Invalid ID format", param, value)
+	}
+	return id, nil
+}
@@ -0,0 +1,25 @@
+package middleares
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"vault0/internal/core/keystore"
+)
+
+// Prefixes of the audit actor, naming the principal the client IP authenticated as
+const (
+	AnonymousActorPrefix = "ip:"
+	AdminActorPrefix     = "admin:"
+)
+
+// Actor returns a Gin middleware that identifies the caller of each request in
+// the request context, so key operations can be attributed in the signing
+// audit log. Without user authentication the client IP identifies the caller:
+// the connection address, or the address forwarded by a trusted proxy.
+func Actor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := keystore.WithActor(c.Request.Context(), AnonymousActorPrefix+c.ClientIP())
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,54 @@
+package middleares
+
+import (
+	"crypto/subtle"
+
+	"github.com/gin-gonic/gin"
+
+	"vault0/internal/core/keystore"
+	"vault0/internal/errors"
+)
+
+// AdminKeyHeader is the request header carrying the admin API key
+const AdminKeyHeader = "X-Admin-Key"
+
+// AdminAuth guards endpoints that require elevated permissions, such as
+// exporting private key material. Requests must present the configured
+// admin API key; when no key is configured the endpoints are disabled.
+type AdminAuth struct {
+	apiKey string
+}
+
+// NewAdminAuth creates a new AdminAuth for the given admin API key
+func NewAdminAuth(apiKey string) *AdminAuth {
+	return &AdminAuth{apiKey: apiKey}
+}
+
+// Middleware returns a Gin middleware function that enforces the admin API key
+func (a *AdminAuth) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if a.apiKey == "" {
+			c.Error(errors.NewForbiddenError())
+			c.Abort()
+			return
+		}
+
+		provided := c.GetHeader(AdminKeyHeader)
+		if provided == "" {
+			c.Error(errors.NewUnauthorizedError())
+			c.Abort()
+			return
+		}
+
+		if subtle.ConstantTimeCompare([]byte(provided), []byte(a.apiKey)) != 1 {
+			c.Error(errors.NewForbiddenError())
+			c.Abort()
+			return
+		}
+
+		// Attribute key operations to the admin key holder
+		ctx := keystore.WithActor(c.Request.Context(), AdminActorPrefix+c.ClientIP())
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -33,6 +33,11 @@ func DefaultErrorMapper(err error) (int, any) {
 			errors.ErrCodeInvalidAPIKey,
 			errors.ErrCodeInvalidExplorerResponse,
 			errors.ErrCodeInvalidEncryptionKey,
+			errors.ErrCodeInvalidKeystoreFile,
+			errors.ErrCodeInvalidPassphrase,
+			errors.ErrCodeInvalidSecretShare,
+			errors.ErrCodeInvalidTypedData,
+			errors.ErrCodeKeyNotExportable,
 			errors.ErrCodeInvalidKeystore,
 			errors.ErrCodeInvalidToken,
 			errors.ErrCodeMissingKeyID,
@@ -45,7 +50,9 @@ func DefaultErrorMapper(err error) (int, any) {
 			errors.ErrCodeInvalidBlockIdentifier,
 			errors.ErrCodeInvalidEventSignature,
 			errors.ErrCodeInvalidEventArgs,
-			errors.ErrCodeUnsupportedEventArgType:
+			errors.ErrCodeUnsupportedEventArgType,
+			errors.ErrCodeABIParseFailed,
+			errors.ErrCodeInvalidBackup:
 			return http.StatusBadRequest, appErr
 
 		// Authentication errors - 401 Unauthorized
@@ -59,7 +66,8 @@ func DefaultErrorMapper(err error) (int, any) {
 			return http.StatusUnauthorized, appErr
 
 		// Permission errors - 403 Forbidden
-		case errors.ErrCodeForbidden:
+		case errors.ErrCodeForbidden,
+			errors.ErrCodeKeyPolicyDenied:
 			return http.StatusForbidden, appErr
 
 		// Resource not found errors - 404 Not Found
@@ -75,6 +83,10 @@ func DefaultErrorMapper(err error) (int, any) {
 			errors.ErrCodeSignerAddressNotFound,
 			// New Not Found Errors
 			errors.ErrCodeTokenPriceNotFound,
+			errors.ErrCodeFXRateNotFound,
+			errors.ErrCodeBackupNotFound,
+			errors.ErrCodeWebhookNotFound,
+			errors.ErrCodeWebhookDeliveryNotFound,
 			errors.ErrCodeBlockNotFound:
 			return http.StatusNotFound, appErr
 
@@ -96,7 +108,8 @@ func DefaultErrorMapper(err error) (int, any) {
 			return http.StatusPreconditionFailed, appErr
 
 		// Rate limit errors - 429 Too Many Requests
-		case errors.ErrCodeRateLimitExceeded:
+		case errors.ErrCodeRateLimitExceeded,
+			errors.ErrCodeKeyRateLimited:
 			return http.StatusTooManyRequests, appErr
 
 		// Timeout errors - 408 Request Timeout
@@ -132,6 +145,11 @@ func DefaultErrorMapper(err error) (int, any) {
 			errors.ErrCodePriceFeedRequestFailed,
 			errors.ErrCodeInvalidPriceFeedResponse,
 			errors.ErrCodePriceFeedProviderNotSupported,
+			errors.ErrCodeFXRateUpdateFailed,
+			errors.ErrCodeFXFeedRequestFailed,
+			errors.ErrCodeInvalidFXFeedResponse,
+			errors.ErrCodeFXFeedProviderNotSupported,
+			errors.ErrCodeBackupFailed,
 			errors.ErrCodeLogTopicIndexOutOfBounds,
 			errors.ErrCodeLogTopicInvalidFormat:
 			return http.StatusInternalServerError, appErr
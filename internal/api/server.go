@@ -1,6 +1,7 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 	"path/filepath"
 
@@ -11,15 +12,21 @@ import (
 
 	// Import generated docs
 	_ "vault0/internal/api/docs"
+	"vault0/internal/api/handlers/abi"
+	"vault0/internal/api/handlers/backup"
+	"vault0/internal/api/handlers/fxrate"
 	"vault0/internal/api/handlers/keystore"
 	"vault0/internal/api/handlers/reference"
 	"vault0/internal/api/handlers/signer"
+	"vault0/internal/api/handlers/stream"
 	"vault0/internal/api/handlers/token"
 	"vault0/internal/api/handlers/tokenprice"
 	"vault0/internal/api/handlers/transaction"
 	"vault0/internal/api/handlers/user"
 	"vault0/internal/api/handlers/vault"
 	"vault0/internal/api/handlers/wallet"
+	"vault0/internal/api/handlers/webhook"
+	"vault0/internal/api/middleares"
 	"vault0/internal/config"
 	"vault0/internal/logger"
 )
@@ -35,9 +42,14 @@ type Server struct {
 	tokenHandler       *token.Handler
 	signerHandler      *signer.Handler
 	tokenPriceHandler  *tokenprice.Handler
+	fxRateHandler      *fxrate.Handler
 	referenceHandler   *reference.Handler
 	keystoreHandler    *keystore.Handler
 	vaultHandler       *vault.Handler
+	abiHandler         *abi.Handler
+	backupHandler      *backup.Handler
+	webhookHandler     *webhook.Handler
+	streamHandler      *stream.Handler
 }
 
 // NewServer creates a new API server
@@ -50,12 +62,21 @@ func NewServer(
 	tokenHandler *token.Handler,
 	signerHandler *signer.Handler,
 	tokenPriceHandler *tokenprice.Handler,
+	fxRateHandler *fxrate.Handler,
 	referenceHandler *reference.Handler,
 	keystoreHandler *keystore.Handler,
 	vaultHandler *vault.Handler,
+	abiHandler *abi.Handler,
+	backupHandler *backup.Handler,
+	webhookHandler *webhook.Handler,
+	streamHandler *stream.Handler,
 ) *Server {
 	router := gin.Default()
+	// Trust no proxy until Run applies the configured ones, as the client IP
+	// identifies the caller in the signing audit log
+	_ = router.SetTrustedProxies(nil)
 	router.Use(cors.Default())
+	router.Use(middleares.Actor())
 
 	return &Server{
 		router:             router,
@@ -67,9 +88,14 @@ func NewServer(
 		tokenHandler:       tokenHandler,
 		signerHandler:      signerHandler,
 		tokenPriceHandler:  tokenPriceHandler,
+		fxRateHandler:      fxRateHandler,
 		referenceHandler:   referenceHandler,
 		keystoreHandler:    keystoreHandler,
 		vaultHandler:       vaultHandler,
+		abiHandler:         abiHandler,
+		backupHandler:      backupHandler,
+		webhookHandler:     webhookHandler,
+		streamHandler:      streamHandler,
 	}
 }
 
@@ -85,9 +111,14 @@ func (s *Server) SetupRoutes() {
 	s.tokenHandler.SetupRoutes(api)
 	s.signerHandler.SetupRoutes(api)
 	s.tokenPriceHandler.SetupRoutes(api)
+	s.fxRateHandler.SetupRoutes(api)
 	s.referenceHandler.SetupRoutes(api)
 	s.keystoreHandler.SetupRoutes(api)
 	s.vaultHandler.SetupRoutes(api)
+	s.abiHandler.SetupRoutes(api)
+	s.backupHandler.SetupRoutes(api)
+	s.webhookHandler.SetupRoutes(api)
+	s.streamHandler.SetupRoutes(api)
 
 	// Health check endpoint
 	api.GET("/health", s.healthHandler)
@@ -113,6 +144,10 @@ func (s *Server) healthHandler(c *gin.Context) {
 
 // Run starts the server
 func (s *Server) Run() error {
+	// Only the configured proxies may forward the client IP
+	if err := s.router.SetTrustedProxies(s.config.TrustedProxies); err != nil {
+		return fmt.Errorf("invalid trusted proxies: %w", err)
+	}
 	return s.router.Run(":" + s.config.Port)
 }
 
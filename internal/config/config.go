@@ -115,6 +115,99 @@ type PriceFeedConfig struct {
 	APIKey          string `yaml:"api_key"`
 	Limit           int    `yaml:"limit"`
 	RefreshInterval int    `yaml:"refresh_interval"` // Interval in seconds
+	// Sources lists the providers queried concurrently and aggregated into a single
+	// price per token. When empty, only the provider configured above is used.
+	Sources []PriceFeedSourceConfig `yaml:"sources"`
+	// MaxDeviation is the relative distance from the median beyond which a source
+	// price is rejected as an outlier (e.g., 0.05 for 5%)
+	MaxDeviation float64 `yaml:"max_deviation"`
+	// MaxAge is the age in seconds beyond which a source price is considered stale
+	MaxAge int `yaml:"max_age"`
+	// MinSources is the number of agreeing sources required to publish a price
+	MinSources int `yaml:"min_sources"`
+	// Tokens lists the tokens priced from on-chain oracles and pools by the "onchain" provider
+	Tokens []OnChainPriceConfig `yaml:"tokens"`
+}
+
+// FXConfig holds configuration for the fiat exchange rates provider
+type FXConfig struct {
+	Provider        string `yaml:"provider"` // e.g., "frankfurter"
+	APIURL          string `yaml:"api_url"`
+	APIKey          string `yaml:"api_key"`
+	RefreshInterval int    `yaml:"refresh_interval"` // Interval in seconds
+	// Currencies lists the currencies to fetch USD exchange rates for (e.g., EUR, GBP).
+	// When empty, all currencies supported by the provider are fetched.
+	Currencies []string `yaml:"currencies"`
+}
+
+// BackupConfig holds configuration for database backups
+type BackupConfig struct {
+	// Path is the directory backups are written to, one subdirectory per backup
+	Path string `yaml:"path"`
+	// Encrypt encrypts backups with the DB encryption key
+	Encrypt bool `yaml:"encrypt"`
+}
+
+// WebhooksConfig holds configuration for the delivery of outbound webhooks
+type WebhooksConfig struct {
+	PollInterval int `yaml:"poll_interval"` // Interval in seconds between checks for due deliveries (default: 5)
+	Timeout      int `yaml:"timeout"`       // Timeout in seconds of a delivery request (default: 10)
+	// MaxAttempts is the number of failed attempts after which a delivery is dead-lettered (default: 10)
+	MaxAttempts int `yaml:"max_attempts"`
+	// RetryBaseDelay is the delay in seconds before the first retry, doubled on each attempt (default: 30)
+	RetryBaseDelay int `yaml:"retry_base_delay"`
+	// RetryMaxDelay caps the delay in seconds between retries (default: 21600)
+	RetryMaxDelay int `yaml:"retry_max_delay"`
+}
+
+// StreamConfig holds configuration for the live event stream served to the UI
+type StreamConfig struct {
+	PollInterval int `yaml:"poll_interval"` // Interval in seconds between checks for events published by other replicas (default: 1)
+	Retention    int `yaml:"retention"`     // Time in hours events are kept for resuming streams (default: 24)
+	// HeartbeatInterval is the interval in seconds of the keep-alive messages of idle streams (default: 15)
+	HeartbeatInterval int `yaml:"heartbeat_interval"`
+	// TokenTTL is the validity in seconds of the tokens authenticating browser streams (default: 60)
+	TokenTTL int `yaml:"token_ttl"`
+}
+
+// LeaderElectionConfig holds configuration for electing the replica that runs the
+// background workers when several replicas share a database
+type LeaderElectionConfig struct {
+	// Enabled runs the background workers only in the replica holding a lease stored in
+	// the database. All replicas serve the API.
+	Enabled bool `yaml:"enabled"`
+	// Identity identifies this replica as lease holder (default: hostname and process ID)
+	Identity string `yaml:"identity"`
+	// LeaseDuration is the time in seconds after which a lease that is not renewed expires (default: 15)
+	LeaseDuration int `yaml:"lease_duration"`
+	// RenewInterval is the interval in seconds at which the leader renews the lease and
+	// the other replicas try to acquire it (default: 5)
+	RenewInterval int `yaml:"renew_interval"`
+}
+
+// PriceFeedSourceConfig holds configuration for one of the aggregated price feed providers
+type PriceFeedSourceConfig struct {
+	Provider string `yaml:"provider"` // e.g., "coincap"
+	APIURL   string `yaml:"api_url"`
+	APIKey   string `yaml:"api_key"`
+	Limit    int    `yaml:"limit"`
+	// Tokens lists the tokens priced from on-chain oracles and pools by the "onchain" provider
+	Tokens []OnChainPriceConfig `yaml:"tokens"`
+}
+
+// OnChainPriceConfig holds the on-chain price source of a single token
+type OnChainPriceConfig struct {
+	Chain   string `yaml:"chain"`   // e.g., "ethereum"
+	Address string `yaml:"address"` // Token contract address
+	Symbol  string `yaml:"symbol"`
+	Name    string `yaml:"name"`
+	// Oracle is the kind of contract the price is read from:
+	// "chainlink", "uniswap_v2" or "uniswap_v3"
+	Oracle string `yaml:"oracle"`
+	// Feed is the address of the Chainlink USD aggregator or of the Uniswap pool.
+	// The other token of a pool must be priced by an earlier entry, or is assumed
+	// to be a USD stablecoin.
+	Feed string `yaml:"feed"`
 }
 
 // TransactionConfig holds configuration for transaction processing
@@ -133,26 +226,60 @@ type VaultConfig struct {
 	RecoveryUpdateInterval int `yaml:"recovery_update_interval"`
 }
 
+// WalletConfig holds configuration for wallet balance tracking
+type WalletConfig struct {
+	// RebasingBalanceSynchInterval is the time interval in seconds between balanceOf reads
+	// refreshing the balances of rebasing tokens, which change without transfers
+	RebasingBalanceSynchInterval int `yaml:"rebasing_balance_synch_interval"`
+}
+
+// KeySharesConfig holds configuration for reconstructing the master encryption key
+// from Shamir secret shares at startup
+type KeySharesConfig struct {
+	// Files lists paths to files each containing one share
+	Files []string `yaml:"files"`
+	// Stdin reads shares from standard input at startup, one per line
+	Stdin bool `yaml:"stdin"`
+}
+
+// Enabled reports whether the encryption key should be reconstructed from shares
+func (c KeySharesConfig) Enabled() bool {
+	return len(c.Files) > 0 || c.Stdin
+}
+
 // Config holds the application configuration
 type Config struct {
+	// DBDriver selects the database backend, either sqlite (default) or postgres
+	DBDriver string `yaml:"db_driver"`
 	// DBPath is the path to the SQLite database file
 	DBPath string `yaml:"db_path"`
+	// DBURL is the PostgreSQL connection URL, used when DBDriver is postgres
+	DBURL string `yaml:"db_url"`
 	// Port is the server port to listen on
 	Port string `yaml:"port"`
 	// UIPath is the path to the static React UI files
 	UIPath string `yaml:"ui_path"`
+	// TrustedProxies lists the proxy addresses or CIDRs allowed to set the client IP
+	// through X-Forwarded-For (empty trusts none, using the connection address)
+	TrustedProxies []string `yaml:"trusted_proxies"`
 	// MigrationsPath is the path to the migration files
 	MigrationsPath string `yaml:"migrations_path"`
 	// DBEncryptionKey is the base64-encoded key used for encrypting sensitive data in the database
 	DBEncryptionKey string `yaml:"db_encryption_key"`
+	// DBEncryptionKeyShares configures reconstruction of DBEncryptionKey from Shamir shares
+	DBEncryptionKeyShares KeySharesConfig `yaml:"db_encryption_key_shares"`
 	// SmartContractsPath is the path to the compiled smart contract artifacts
 	SmartContractsPath string `yaml:"smart_contracts_path"`
 	// KeyStoreType specifies the type of key store to use (db or kms)
 	KeyStoreType string `yaml:"key_store_type"`
+	// AdminAPIKey is the key required by endpoints with elevated permissions (empty disables them)
+	AdminAPIKey string `yaml:"admin_api_key"`
 	// Transaction holds configuration for transaction processing
 	Transaction TransactionConfig `yaml:"transaction"`
 	// Vault holds configuration for vault management
 	Vault VaultConfig `yaml:"vault"`
+	// Wallet holds configuration for wallet balance tracking
+	Wallet WalletConfig `yaml:"wallet"`
 	// Snowflake holds configuration for ID generation
 	Snowflake SnowflakeConfig `yaml:"snowflake"`
 	// Log holds the logging configuration
@@ -161,8 +288,20 @@ type Config struct {
 	Blockchains BlockchainsConfig `yaml:"blockchains"`
 	// PriceFeed holds configuration for the price feed service
 	PriceFeed PriceFeedConfig `yaml:"price_feed"`
+	// FX holds configuration for the fiat exchange rates service
+	FX FXConfig `yaml:"fx"`
+	// Backup holds configuration for database backups
+	Backup BackupConfig `yaml:"backup"`
+	// Webhooks holds configuration for the delivery of outbound webhooks
+	Webhooks WebhooksConfig `yaml:"webhooks"`
+	// Stream holds configuration for the live event stream
+	Stream StreamConfig `yaml:"stream"`
+	// LeaderElection holds configuration for electing the replica that runs the background workers
+	LeaderElection LeaderElectionConfig `yaml:"leader_election"`
 	// ABIMapping maps supported ABI types (e.g., "erc20") to their contract artifact names
 	ABIMapping map[string]string `yaml:"abi_mapping"`
+	// SignaturesPath is an optional 4byte-format signature file imported on top of the embedded signature database
+	SignaturesPath string `yaml:"signatures_path"`
 }
 
 // LoadConfig loads the application configuration from YAML file and environment variables
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"vault0/internal/config"
 	"vault0/internal/core/blockchain"
@@ -16,11 +17,13 @@ import (
 )
 
 // SupportedABIType defines the types of ABIs that can be explicitly loaded by name.
-// Currently limited to ERC20 and MultiSig.
+// Currently limited to the ERC20, ERC721 and ERC1155 token standards and MultiSig.
 type SupportedABIType string
 
 const (
 	ABITypeERC20    SupportedABIType = "erc20"
+	ABITypeERC721   SupportedABIType = "erc721"
+	ABITypeERC1155  SupportedABIType = "erc1155"
 	ABITypeMultiSig SupportedABIType = "multisig"
 )
 
@@ -29,11 +32,23 @@ type ABILoader interface {
 	// LoadABIByType loads a known ABI type using the configured mapping to find the artifact file.
 	LoadABIByType(ctx context.Context, abiType SupportedABIType) (string, error)
 
-	// LoadABIByAddress loads ABI from the configured block explorer or local cache.
-	// It attempts to resolve proxy contracts by looking for an 'implementation()' method.
+	// LoadABIByAddress loads ABI from the ABI registry, local cache or the configured block explorer.
+	// Uploaded ABIs in the registry take precedence over everything else. Otherwise it
+	// resolves proxy contracts using the EIP-1967, EIP-1822 and EIP-1167 standards,
+	// falling back to an 'implementation()' method exposed by the proxy.
 	LoadABIByAddress(ctx context.Context, contractAddress types.Address) (string, error)
 }
 
+// unresolvedABITTL is how long the explorer is not queried again for a contract
+// without a verified ABI, as the logs of such contracts are decoded repeatedly
+const unresolvedABITTL = time.Hour
+
+// unresolvedABI records a failed explorer lookup
+type unresolvedABI struct {
+	err      error
+	failedAt time.Time
+}
+
 // abiLoader is responsible for loading ABI from files and addresses.
 type abiLoader struct {
 	config           config.ABIConfigProvider
@@ -43,6 +58,8 @@ type abiLoader struct {
 	chainType        types.ChainType
 	abiCache         *sync.Map // Cache: string (address or name) -> string (ABI JSON)
 	abiUtils         ABIUtils
+	registry         ABIRegistry // Persistent ABIs; optional
+	unresolved       sync.Map    // Cache: string (address) -> unresolvedABI
 }
 
 // NewABILoader creates a new ABILoader instance.
@@ -52,6 +69,7 @@ func NewABILoader(
 	explorer blockexplorer.BlockExplorer,
 	blockchainClient blockchain.BlockchainClient,
 	abiUtils ABIUtils,
+	registry ABIRegistry,
 	log logger.Logger,
 ) ABILoader {
 	return &abiLoader{
@@ -62,6 +80,7 @@ func NewABILoader(
 		chainType:        chainType,
 		abiCache:         &sync.Map{},
 		abiUtils:         abiUtils,
+		registry:         registry,
 	}
 }
 
@@ -120,10 +139,16 @@ func (l *abiLoader) LoadABIByType(ctx context.Context, abiType SupportedABIType)
 }
 
 // LoadABIByAddress loads ABI from the configured block explorer or local cache.
-// It attempts to resolve proxy contracts by looking for an 'implementation()' method.
+// It resolves proxy contracts through the standard proxy storage slots and bytecode
+// patterns, falling back to an 'implementation()' method.
 func (l *abiLoader) LoadABIByAddress(ctx context.Context, contractAddress types.Address) (string, error) {
 	cacheKey := contractAddress.String()
 
+	// 0. ABIs supplied by users are authoritative and never resolved as proxies
+	if registered := l.lookupRegistry(ctx, contractAddress); registered != nil && registered.Source != ABISourceExplorer {
+		return registered.ABI, nil
+	}
+
 	// 1. Check cache first
 	if cached, found := l.abiCache.Load(cacheKey); found {
 		if abiStr, ok := cached.(string); ok {
@@ -134,11 +159,15 @@ func (l *abiLoader) LoadABIByAddress(ctx context.Context, contractAddress types.
 	// 2. Fetch the ABI for the given contractAddress
 	proxyOrDirectABIString, err := l.fetchAndCacheABIForAddress(ctx, contractAddress)
 	if err != nil {
+		// An unverified proxy can still be resolved through its standard storage slots
+		if implementationABIString, ok := l.loadImplementationABIFromStorage(ctx, contractAddress); ok {
+			return implementationABIString, nil
+		}
 		return "", err
 	}
 
 	// 3. Try to get the implementation address from the potentially proxy contract
-	implementationAddress, proxyErr := l.getImplementationAddressFromProxy(ctx, contractAddress, proxyOrDirectABIString)
+	implementationAddress, proxyErr := l.resolveImplementationAddress(ctx, contractAddress, proxyOrDirectABIString)
 
 	// 4. Handle outcomes of proxy resolution
 	if proxyErr != nil {
@@ -202,11 +231,25 @@ func (l *abiLoader) fetchAndCacheABIForAddress(ctx context.Context, addressToFet
 		}
 	}
 
-	// 2. Fetch from block explorer
+	// 2. Check the registry for an ABI persisted by an earlier fetch or upload
+	if registered := l.lookupRegistry(ctx, addressToFetch); registered != nil {
+		l.abiCache.Store(cacheKey, registered.ABI)
+		return registered.ABI, nil
+	}
+
+	// 3. Skip contracts the explorer recently had no ABI for
+	if cached, found := l.unresolved.Load(cacheKey); found {
+		if unresolved := cached.(unresolvedABI); time.Since(unresolved.failedAt) < unresolvedABITTL {
+			return "", unresolved.err
+		}
+		l.unresolved.Delete(cacheKey)
+	}
+
+	// 4. Fetch from block explorer
 	contractInfo, err := l.explorer.GetContract(ctx, addressToFetch.String())
 	if err != nil {
 		if errors.IsError(err, errors.ErrCodeContractNotFound) {
-			return "", errors.NewContractNotFoundError(addressToFetch.String(), string(l.chainType))
+			return "", l.markUnresolved(cacheKey, errors.NewContractNotFoundError(addressToFetch.String(), string(l.chainType)))
 		}
 		return "", errors.NewExplorerRequestFailedError(
 			fmt.Errorf("failed to get contract info for ABI fetch for %s on chain %s: %w",
@@ -214,16 +257,112 @@ func (l *abiLoader) fetchAndCacheABIForAddress(ctx context.Context, addressToFet
 		)
 	}
 
-	// 3. Check if ABI is available
+	// 5. Check if ABI is available
 	if contractInfo.ABI == "" {
-		return "", errors.NewABIUnavailableOrUnverifiedError(addressToFetch.String(), string(l.chainType))
+		return "", l.markUnresolved(cacheKey, errors.NewABIUnavailableOrUnverifiedError(addressToFetch.String(), string(l.chainType)))
+	}
+
+	// 6. Persist the ABI so it survives restarts
+	if l.registry != nil {
+		err := l.registry.SaveABI(ctx, &ContractABI{
+			ChainType: l.chainType,
+			Address:   addressToFetch.String(),
+			Name:      contractInfo.ContractName,
+			ABI:       contractInfo.ABI,
+			Source:    ABISourceExplorer,
+		})
+		if err != nil {
+			l.log.Warn("Failed to persist explorer ABI",
+				logger.String("contract_address", addressToFetch.String()),
+				logger.String("chain_type", string(l.chainType)),
+				logger.Error(err),
+			)
+		}
 	}
 
-	// 4. Store ABI in cache and return
+	// 7. Store ABI in cache and return
 	l.abiCache.Store(cacheKey, contractInfo.ABI)
 	return contractInfo.ABI, nil
 }
 
+// markUnresolved remembers that the explorer has no ABI for an address and returns the error
+func (l *abiLoader) markUnresolved(cacheKey string, err error) error {
+	l.unresolved.Store(cacheKey, unresolvedABI{err: err, failedAt: time.Now()})
+	return err
+}
+
+// lookupRegistry returns the ABI registered for an address, or nil if there is
+// none or the registry is unavailable
+func (l *abiLoader) lookupRegistry(ctx context.Context, address types.Address) *ContractABI {
+	if l.registry == nil {
+		return nil
+	}
+
+	registered, err := l.registry.GetABI(ctx, l.chainType, address.String())
+	if err != nil {
+		if !errors.IsError(err, errors.ErrCodeResourceNotFound) {
+			l.log.Warn("Failed to read ABI registry",
+				logger.String("contract_address", address.String()),
+				logger.String("chain_type", string(l.chainType)),
+				logger.Error(err),
+			)
+		}
+		return nil
+	}
+
+	return registered
+}
+
+// resolveImplementationAddress resolves the implementation of a proxy contract. The
+// standard proxy storage slots and bytecode patterns are checked first, falling back
+// to calling an 'implementation()' method exposed by the proxy ABI.
+func (l *abiLoader) resolveImplementationAddress(
+	ctx context.Context,
+	proxyAddress types.Address,
+	proxyABIString string,
+) (*types.Address, error) {
+	if implementationAddress, standard := l.getImplementationAddressFromStorage(ctx, proxyAddress); implementationAddress != nil {
+		l.log.Debug("Resolved proxy implementation from storage",
+			logger.String("proxy_address", proxyAddress.String()),
+			logger.String("implementation_address", implementationAddress.String()),
+			logger.String("proxy_standard", string(standard)),
+		)
+		return implementationAddress, nil
+	}
+
+	return l.getImplementationAddressFromProxy(ctx, proxyAddress, proxyABIString)
+}
+
+// loadImplementationABIFromStorage resolves a proxy through its storage slots and
+// loads the implementation ABI, caching it under the proxy's address. It reports
+// false if the contract is not a recognized proxy or the implementation ABI is unavailable.
+func (l *abiLoader) loadImplementationABIFromStorage(ctx context.Context, proxyAddress types.Address) (string, bool) {
+	implementationAddress, standard := l.getImplementationAddressFromStorage(ctx, proxyAddress)
+	if implementationAddress == nil {
+		return "", false
+	}
+
+	implementationABIString, err := l.fetchAndCacheABIForAddress(ctx, *implementationAddress)
+	if err != nil {
+		l.log.Warn("Error fetching implementation ABI of unverified proxy",
+			logger.String("proxy_address", proxyAddress.String()),
+			logger.String("implementation_address", implementationAddress.String()),
+			logger.String("proxy_standard", string(standard)),
+			logger.Error(err),
+		)
+		return "", false
+	}
+
+	l.log.Info("Resolved implementation ABI of unverified proxy",
+		logger.String("proxy_address", proxyAddress.String()),
+		logger.String("implementation_address", implementationAddress.String()),
+		logger.String("proxy_standard", string(standard)),
+	)
+
+	l.abiCache.Store(proxyAddress.String(), implementationABIString)
+	return implementationABIString, true
+}
+
 // getImplementationAddressFromProxy attempts to read the implementation address
 // from a proxy contract that follows the "implementation() view returns (address)" pattern
 func (l *abiLoader) getImplementationAddressFromProxy(
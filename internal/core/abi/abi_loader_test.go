@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
@@ -25,7 +26,7 @@ import (
 func setupTestABILoader() (*abiLoader, *mocks.MockConfig, *mocks.MockBlockExplorer, *mocks.MockBlockchainClient, *mocks.MockABIUtils) {
 	mockConfig := mocks.NewMockConfig()
 	mockExplorer := mocks.NewMockBlockExplorer()
-	mockBlockchainClient := mocks.NewMockBlockchainClient()
+	mockBlockchainClient := mocks.NewMockBlockchainClient().WithNoProxyStorage()
 	mockABIUtils := mocks.NewMockABIUtils()
 	mockLogger := mocks.NewNopLogger()
 
@@ -484,3 +485,29 @@ func TestLoadABIByAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadABIByAddress_UnresolvedCache(t *testing.T) {
+	loader, _, mockExplorer, _, _ := setupTestABILoader()
+	addr, err := types.NewAddress(types.ChainTypeEthereum, "0xABCDEF0123456789ABCDEF0123456789ABCDEF01")
+	require.NoError(t, err)
+
+	mockExplorer.On("GetContract", mock.Anything, matchers.AddressMatcher(addr.Address)).Return(&blockexplorer.ContractInfo{ABI: ""}, nil).Once()
+
+	// Contracts without an ABI are not looked up again
+	for i := 0; i < 2; i++ {
+		_, err = loader.LoadABIByAddress(context.Background(), *addr)
+		assert.True(t, coreerrors.IsError(err, coreerrors.ErrCodeABIUnavailableOrUnverified))
+	}
+	mockExplorer.AssertNumberOfCalls(t, "GetContract", 1)
+
+	// Until the failed lookup expires
+	loader.unresolved.Store(addr.Address, unresolvedABI{
+		err:      coreerrors.NewABIUnavailableOrUnverifiedError(addr.Address, "ethereum"),
+		failedAt: time.Now().Add(-unresolvedABITTL),
+	})
+	mockExplorer.On("GetContract", mock.Anything, matchers.AddressMatcher(addr.Address)).Return(&blockexplorer.ContractInfo{ABI: `[{"name": "getValue", "type": "function"}]`}, nil).Once()
+
+	_, err = loader.fetchAndCacheABIForAddress(context.Background(), *addr)
+	require.NoError(t, err)
+	mockExplorer.AssertNumberOfCalls(t, "GetContract", 2)
+}
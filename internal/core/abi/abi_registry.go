@@ -0,0 +1,90 @@
+package abi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"vault0/internal/db"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/types"
+)
+
+// ABISource describes where a registered ABI came from.
+type ABISource string
+
+const (
+	// ABISourceExplorer marks ABIs fetched from a block explorer
+	ABISourceExplorer ABISource = "explorer"
+	// ABISourceUpload marks ABIs uploaded as a plain JSON ABI array
+	ABISourceUpload ABISource = "upload"
+	// ABISourceArtifact marks ABIs extracted from an uploaded Hardhat or Truffle artifact
+	ABISourceArtifact ABISource = "artifact"
+)
+
+// ContractABI is an ABI registered for a contract address on a chain.
+type ContractABI struct {
+	// ID is the unique identifier of the registry entry
+	ID int64
+	// ChainType is the chain the contract is deployed on
+	ChainType types.ChainType
+	// Address is the normalized contract address
+	Address string
+	// Name is an optional human-readable contract name
+	Name string
+	// ABI is the compact JSON ABI array
+	ABI string
+	// Source describes where the ABI came from
+	Source ABISource
+	// CreatedAt is when the ABI was first registered
+	CreatedAt time.Time
+	// UpdatedAt is when the ABI was last replaced
+	UpdatedAt time.Time
+}
+
+// ABIRegistry persists contract ABIs per chain and address so they survive
+// restarts and can be supplied manually for unverified contracts.
+type ABIRegistry interface {
+	// GetABI retrieves the ABI registered for a contract.
+	// Returns ErrCodeResourceNotFound if no ABI is registered.
+	GetABI(ctx context.Context, chainType types.ChainType, address string) (*ContractABI, error)
+
+	// SaveABI registers an ABI for a contract, replacing any existing one.
+	// The ABI is validated and the address normalized before storing.
+	SaveABI(ctx context.Context, contractABI *ContractABI) error
+
+	// ListABIs retrieves registered ABIs with pagination, optionally filtered by chain.
+	// If limit is 0, all ABIs are returned without pagination.
+	ListABIs(ctx context.Context, chainType *types.ChainType, limit int, nextToken string) (*types.Page[*ContractABI], error)
+
+	// DeleteABI removes the ABI registered for a contract.
+	// Returns ErrCodeResourceNotFound if no ABI is registered.
+	DeleteABI(ctx context.Context, chainType types.ChainType, address string) error
+}
+
+// NewABIRegistry creates a new database-backed ABIRegistry. Lookups are cached
+// and invalidated when the ABI of the contract is saved or deleted.
+func NewABIRegistry(db *db.DB, log logger.Logger) ABIRegistry {
+	return &dbABIRegistry{
+		db:  db,
+		log: log,
+	}
+}
+
+// ParseArtifactABI extracts the ABI from a compiled contract artifact. Both
+// Hardhat and Truffle artifacts keep the ABI under the top-level "abi" field.
+func ParseArtifactABI(artifact []byte) (string, error) {
+	var artifactData map[string]json.RawMessage
+	if err := json.Unmarshal(artifact, &artifactData); err != nil {
+		return "", errors.NewABIParseError(fmt.Errorf("failed to parse artifact JSON: %w", err))
+	}
+
+	abiData, ok := artifactData["abi"]
+	if !ok {
+		return "", errors.NewABIParseError(fmt.Errorf("abi not found in artifact"))
+	}
+
+	return string(abiData), nil
+}
@@ -0,0 +1,247 @@
+package abi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/db"
+	coreerrors "vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/testing/dbtest"
+	"vault0/internal/types"
+)
+
+const testRegistryABI = `[{"type":"function","name":"getValue","inputs":[],"outputs":[{"name":"","type":"uint256"}],"stateMutability":"view"}]`
+
+// newTestRegistry creates an ABIRegistry backed by a test database
+func newTestRegistry(database *db.DB) ABIRegistry {
+	return NewABIRegistry(database, logger.NewNopLogger())
+}
+
+// expireRegistryCache ages the cached lookups of a registry past their TTL
+func expireRegistryCache(registry ABIRegistry) {
+	cache := &registry.(*dbABIRegistry).cache
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	for key, entry := range cache.entries {
+		entry.cachedAt = entry.cachedAt.Add(-registryCacheTTL)
+		cache.entries[key] = entry
+	}
+}
+
+func TestABIRegistry(t *testing.T) {
+	ctx := context.Background()
+	address := "0x1234567890123456789012345678901234567890"
+
+	t.Run("Save and get", func(t *testing.T) {
+		dbtest.Run(t, func(t *testing.T, database *db.DB) {
+			registry := newTestRegistry(database)
+
+			contractABI := &ContractABI{
+				ChainType: types.ChainTypeEthereum,
+				Address:   address,
+				Name:      "Counter",
+				ABI:       "[\n  " + testRegistryABI[1:],
+				Source:    ABISourceUpload,
+			}
+			require.NoError(t, registry.SaveABI(ctx, contractABI))
+			assert.NotZero(t, contractABI.ID)
+			assert.Equal(t, testRegistryABI, contractABI.ABI, "ABI should be stored compacted")
+
+			stored, err := registry.GetABI(ctx, types.ChainTypeEthereum, address)
+			require.NoError(t, err)
+			assert.Equal(t, contractABI.ID, stored.ID)
+			assert.Equal(t, "Counter", stored.Name)
+			assert.Equal(t, ABISourceUpload, stored.Source)
+		})
+	})
+
+	t.Run("Save replaces existing entry", func(t *testing.T) {
+		dbtest.Run(t, func(t *testing.T, database *db.DB) {
+			registry := newTestRegistry(database)
+
+			first := &ContractABI{ChainType: types.ChainTypeEthereum, Address: address, ABI: testRegistryABI, Source: ABISourceExplorer}
+			require.NoError(t, registry.SaveABI(ctx, first))
+
+			second := &ContractABI{ChainType: types.ChainTypeEthereum, Address: address, ABI: testRegistryABI, Source: ABISourceUpload}
+			require.NoError(t, registry.SaveABI(ctx, second))
+			assert.Equal(t, first.ID, second.ID)
+			assert.Equal(t, ABISourceUpload, second.Source)
+		})
+	})
+
+	t.Run("Rejects invalid ABI", func(t *testing.T) {
+		dbtest.Run(t, func(t *testing.T, database *db.DB) {
+			registry := newTestRegistry(database)
+
+			err := registry.SaveABI(ctx, &ContractABI{ChainType: types.ChainTypeEthereum, Address: address, ABI: `{"abi": []}`, Source: ABISourceUpload})
+			require.Error(t, err)
+			assert.True(t, coreerrors.IsError(err, coreerrors.ErrCodeABIParseFailed))
+
+			err = registry.SaveABI(ctx, &ContractABI{ChainType: types.ChainTypeEthereum, Address: address, ABI: testRegistryABI, Source: "unknown"})
+			require.Error(t, err)
+			assert.True(t, coreerrors.IsError(err, coreerrors.ErrCodeInvalidParameter))
+		})
+	})
+
+	t.Run("List with pagination and chain filter", func(t *testing.T) {
+		dbtest.Run(t, func(t *testing.T, database *db.DB) {
+			registry := newTestRegistry(database)
+
+			addresses := []string{
+				"0x1111111111111111111111111111111111111111",
+				"0x2222222222222222222222222222222222222222",
+				"0x3333333333333333333333333333333333333333",
+			}
+			for _, addr := range addresses {
+				require.NoError(t, registry.SaveABI(ctx, &ContractABI{ChainType: types.ChainTypeEthereum, Address: addr, ABI: testRegistryABI, Source: ABISourceUpload}))
+			}
+			require.NoError(t, registry.SaveABI(ctx, &ContractABI{ChainType: types.ChainTypePolygon, Address: addresses[0], ABI: testRegistryABI, Source: ABISourceUpload}))
+
+			chainType := types.ChainTypeEthereum
+			page, err := registry.ListABIs(ctx, &chainType, 2, "")
+			require.NoError(t, err)
+			assert.Len(t, page.Items, 2)
+			require.NotEmpty(t, page.NextToken)
+
+			page, err = registry.ListABIs(ctx, &chainType, 2, page.NextToken)
+			require.NoError(t, err)
+			assert.Len(t, page.Items, 1)
+			assert.Empty(t, page.NextToken)
+
+			all, err := registry.ListABIs(ctx, nil, 0, "")
+			require.NoError(t, err)
+			assert.Len(t, all.Items, 4)
+		})
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		dbtest.Run(t, func(t *testing.T, database *db.DB) {
+			registry := newTestRegistry(database)
+
+			require.NoError(t, registry.SaveABI(ctx, &ContractABI{ChainType: types.ChainTypeEthereum, Address: address, ABI: testRegistryABI, Source: ABISourceUpload}))
+			require.NoError(t, registry.DeleteABI(ctx, types.ChainTypeEthereum, address))
+
+			_, err := registry.GetABI(ctx, types.ChainTypeEthereum, address)
+			assert.True(t, coreerrors.IsError(err, coreerrors.ErrCodeResourceNotFound))
+
+			err = registry.DeleteABI(ctx, types.ChainTypeEthereum, address)
+			assert.True(t, coreerrors.IsError(err, coreerrors.ErrCodeResourceNotFound))
+		})
+	})
+
+	t.Run("Caches lookups until changed", func(t *testing.T) {
+		dbtest.Run(t, func(t *testing.T, database *db.DB) {
+			registry := newTestRegistry(database)
+
+			_, err := registry.GetABI(ctx, types.ChainTypeEthereum, address)
+			assert.True(t, coreerrors.IsError(err, coreerrors.ErrCodeResourceNotFound))
+
+			// Saving invalidates the cached miss
+			require.NoError(t, registry.SaveABI(ctx, &ContractABI{ChainType: types.ChainTypeEthereum, Address: address, Name: "Counter", ABI: testRegistryABI, Source: ABISourceUpload}))
+			stored, err := registry.GetABI(ctx, types.ChainTypeEthereum, address)
+			require.NoError(t, err)
+			assert.Equal(t, "Counter", stored.Name)
+
+			// Hits are served from the cache, as copies
+			stored.Name = "Modified"
+			_, err = database.ExecuteStatementContext(ctx, "UPDATE contract_abis SET name = 'Renamed'")
+			require.NoError(t, err)
+			stored, err = registry.GetABI(ctx, types.ChainTypeEthereum, address)
+			require.NoError(t, err)
+			assert.Equal(t, "Counter", stored.Name)
+
+			// Changes made through other replicas are read once the cached entries expire
+			expireRegistryCache(registry)
+			stored, err = registry.GetABI(ctx, types.ChainTypeEthereum, address)
+			require.NoError(t, err)
+			assert.Equal(t, "Renamed", stored.Name)
+
+			// Deleting invalidates the cached hit
+			require.NoError(t, registry.DeleteABI(ctx, types.ChainTypeEthereum, address))
+			_, err = registry.GetABI(ctx, types.ChainTypeEthereum, address)
+			assert.True(t, coreerrors.IsError(err, coreerrors.ErrCodeResourceNotFound))
+
+			// Misses expire as well, so that ABIs uploaded through other replicas are found
+			replica := newTestRegistry(database)
+			require.NoError(t, replica.SaveABI(ctx, &ContractABI{ChainType: types.ChainTypeEthereum, Address: address, Name: "Counter", ABI: testRegistryABI, Source: ABISourceUpload}))
+			_, err = registry.GetABI(ctx, types.ChainTypeEthereum, address)
+			assert.True(t, coreerrors.IsError(err, coreerrors.ErrCodeResourceNotFound))
+			expireRegistryCache(registry)
+			_, err = registry.GetABI(ctx, types.ChainTypeEthereum, address)
+			require.NoError(t, err)
+		})
+	})
+}
+
+func TestParseArtifactABI(t *testing.T) {
+	t.Run("Hardhat artifact", func(t *testing.T) {
+		artifact := `{"_format":"hh-sol-artifact-1","contractName":"Counter","abi":` + testRegistryABI + `,"bytecode":"0x"}`
+
+		abiJSON, err := ParseArtifactABI([]byte(artifact))
+		require.NoError(t, err)
+		assert.Equal(t, testRegistryABI, abiJSON)
+	})
+
+	t.Run("Missing abi field", func(t *testing.T) {
+		_, err := ParseArtifactABI([]byte(`{"contractName":"Counter"}`))
+		require.Error(t, err)
+		assert.True(t, coreerrors.IsError(err, coreerrors.ErrCodeABIParseFailed))
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		_, err := ParseArtifactABI([]byte(`not json`))
+		require.Error(t, err)
+		assert.True(t, coreerrors.IsError(err, coreerrors.ErrCodeABIParseFailed))
+	})
+}
+
+func TestLoadABIByAddress_Registry(t *testing.T) {
+	ctx := context.Background()
+	address := "0x1234567890123456789012345678901234567890"
+	contractAddress, err := types.NewAddress(types.ChainTypeEthereum, address)
+	require.NoError(t, err)
+
+	t.Run("Uploaded ABI takes precedence over explorer", func(t *testing.T) {
+		dbtest.Run(t, func(t *testing.T, database *db.DB) {
+			loader, _, mockExplorer, _, _ := setupTestABILoader()
+			loader.registry = newTestRegistry(database)
+
+			require.NoError(t, loader.registry.SaveABI(ctx, &ContractABI{
+				ChainType: types.ChainTypeEthereum,
+				Address:   address,
+				ABI:       testRegistryABI,
+				Source:    ABISourceUpload,
+			}))
+
+			result, err := loader.LoadABIByAddress(ctx, *contractAddress)
+			require.NoError(t, err)
+			assert.Equal(t, testRegistryABI, result)
+			mockExplorer.AssertNotCalled(t, "GetContract")
+		})
+	})
+
+	t.Run("Explorer ABI is persisted", func(t *testing.T) {
+		dbtest.Run(t, func(t *testing.T, database *db.DB) {
+			loader, _, mockExplorer, _, mockABIUtils := setupTestABILoader()
+			loader.registry = newTestRegistry(database)
+
+			mockExplorer.WithSuccessfulContractFetch(contractAddress.Address, testRegistryABI)
+			mockABIUtils.On("Pack", testRegistryABI, "implementation", mock.Anything).
+				Return(nil, coreerrors.NewABIProxyMethodNotFoundError(address, "implementation"))
+
+			result, err := loader.LoadABIByAddress(ctx, *contractAddress)
+			require.NoError(t, err)
+			assert.Equal(t, testRegistryABI, result)
+
+			stored, err := loader.registry.GetABI(ctx, types.ChainTypeEthereum, address)
+			require.NoError(t, err)
+			assert.Equal(t, ABISourceExplorer, stored.Source)
+		})
+	})
+}
@@ -14,7 +14,8 @@ type ABIUtils interface {
 	// If includesSelector is true, the first 4 bytes of inputData are treated as the method selector.
 	Unpack(contractABI string, methodName string, inputData []byte) (map[string]any, error)
 
-	// Pack packs the arguments according to the ABI specification for the given method name.
+	// Pack packs the arguments according to the ABI specification for the given method.
+	// The method may be a plain name or a full signature, which selects among overloads.
 	Pack(contractABI string, methodName string, args ...any) ([]byte, error)
 
 	// ExtractMethodID extracts the 4-byte method selector from transaction data.
@@ -32,12 +33,30 @@ type ABIUtils interface {
 
 	// Helper function to get uint64 from parsed ABI arguments.
 	GetUint64FromArgs(args map[string]any, key string) (uint64, error)
+
+	// DecodeCall decodes contract call input data into the called method and its
+	// named, typed arguments. The method is identified by the 4-byte selector.
+	DecodeCall(contractABI string, inputData []byte) (*types.DecodedCall, error)
+
+	// DecodeLog decodes a log emitted by a contract into the event and its named,
+	// typed arguments. Returns ErrCodeABIEventNotFound if the ABI has no matching event.
+	DecodeLog(contractABI string, log types.Log) (*types.DecodedEvent, error)
+
+	// DecodeCallHeuristic decodes contract call input data without an ABI, using the
+	// offline signature database to identify the method by its selector.
+	// The result is marked as heuristic. Returns ErrCodeABIMethodNotFound if the selector is unknown.
+	DecodeCallHeuristic(inputData []byte) (*types.DecodedCall, error)
+
+	// DecodeLogHeuristic decodes a log without an ABI, using the offline signature
+	// database to identify the event by its topic.
+	// The result is marked as heuristic. Returns ErrCodeABIEventNotFound if the topic is unknown.
+	DecodeLogHeuristic(log types.Log) (*types.DecodedEvent, error)
 }
 
-func NewABIUtils(chainType types.ChainType, log logger.Logger) (ABIUtils, error) {
+func NewABIUtils(chainType types.ChainType, signatures SignatureDB, log logger.Logger) (ABIUtils, error) {
 	switch chainType {
 	case types.ChainTypeEthereum, types.ChainTypePolygon, types.ChainTypeBase:
-		return NewEvmAbiUtils(chainType, log)
+		return NewEvmAbiUtils(chainType, signatures, log)
 	default:
 		return nil, errors.NewChainNotSupportedError(string(chainType))
 	}
@@ -16,15 +16,18 @@ import (
 
 // EVMABIUtils implements the ABIUtils interface for EVM chains.
 type EVMABIUtils struct {
-	log       logger.Logger
-	chainType types.ChainType // Store chain type for context
+	log        logger.Logger
+	chainType  types.ChainType // Store chain type for context
+	signatures SignatureDB     // Offline signatures for heuristic decoding; optional
 }
 
 // NewEvmAbiUtils creates a new EVM ABI utility instance for a specific chain.
-func NewEvmAbiUtils(chainType types.ChainType, log logger.Logger) (ABIUtils, error) {
+// The signature database is optional; without it heuristic decoding finds nothing.
+func NewEvmAbiUtils(chainType types.ChainType, signatures SignatureDB, log logger.Logger) (ABIUtils, error) {
 	return &EVMABIUtils{
-		log:       log,
-		chainType: chainType, // Store the chain type
+		log:        log,
+		chainType:  chainType, // Store the chain type
+		signatures: signatures,
 	}, nil
 }
 
@@ -110,7 +113,8 @@ func (u EVMABIUtils) ExtractMethodID(data []byte) []byte {
 	return data[:4]
 }
 
-// Pack packs arguments for a method call.
+// Pack packs arguments for a method call. The method may be given by name or
+// by its full signature.
 func (u *EVMABIUtils) Pack(contractABI string, methodName string, args ...interface{}) ([]byte, error) {
 	if contractABI == "" {
 		return nil, errors.NewInvalidParameterError("contract ABI is empty", "contractABI")
@@ -122,7 +126,19 @@ func (u *EVMABIUtils) Pack(contractABI string, methodName string, args ...interf
 		return nil, errors.NewABIParseError(err)
 	}
 
-	data, err := parsedABI.Pack(methodName, args...)
+	// Full signatures such as "safeTransferFrom(address,address,uint256)" are
+	// resolved to the go-ethereum method name, which disambiguates overloads
+	packName := methodName
+	if strings.Contains(methodName, "(") {
+		for name, method := range parsedABI.Methods {
+			if method.Sig == methodName {
+				packName = name
+				break
+			}
+		}
+	}
+
+	data, err := parsedABI.Pack(packName, args...)
 	if err != nil {
 		if strings.Contains(err.Error(), "no method with id") || strings.Contains(err.Error(), "method '"+methodName+"' not found") {
 			return nil, errors.NewABIMethodNotFoundError(methodName, true)
@@ -0,0 +1,204 @@
+package abi
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"vault0/internal/errors"
+	"vault0/internal/types"
+)
+
+// DecodeCall decodes contract call input data into the called method and its
+// named, typed arguments. The method is identified by the 4-byte selector.
+func (u *EVMABIUtils) DecodeCall(contractABI string, inputData []byte) (*types.DecodedCall, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(contractABI))
+	if err != nil {
+		return nil, errors.NewABIParseError(err)
+	}
+
+	if len(inputData) < 4 {
+		return nil, errors.NewABIInputDataTooShortError(len(inputData), 4)
+	}
+
+	method, err := parsedABI.MethodById(inputData[:4])
+	if err != nil {
+		return nil, errors.NewABIMethodNotFoundError(hexutil.Encode(inputData[:4]), false)
+	}
+
+	values, err := method.Inputs.Unpack(inputData[4:])
+	if err != nil {
+		return nil, errors.NewABIUnpackFailedError(err, method.Name)
+	}
+
+	arguments := make([]types.DecodedArgument, 0, len(method.Inputs))
+	for i, input := range method.Inputs {
+		arguments = append(arguments, types.DecodedArgument{
+			Name:  input.Name,
+			Type:  input.Type.String(),
+			Value: normalizeABIValue(values[i]),
+		})
+	}
+
+	return &types.DecodedCall{
+		Method:    method.RawName,
+		Signature: method.Sig,
+		Selector:  hexutil.Encode(method.ID),
+		Arguments: arguments,
+	}, nil
+}
+
+// DecodeLog decodes a log emitted by a contract into the event and its named,
+// typed arguments. The event is identified by the first topic, so anonymous
+// events cannot be decoded.
+func (u *EVMABIUtils) DecodeLog(contractABI string, log types.Log) (*types.DecodedEvent, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(contractABI))
+	if err != nil {
+		return nil, errors.NewABIParseError(err)
+	}
+
+	if len(log.Topics) == 0 {
+		return nil, errors.NewABIEventNotFoundError("")
+	}
+
+	eventID := common.HexToHash(log.Topics[0])
+	event, err := parsedABI.EventByID(eventID)
+	if err != nil {
+		return nil, errors.NewABIEventNotFoundError(eventID.Hex())
+	}
+
+	arguments, err := decodeLogArguments(event.Inputs, log, event.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.DecodedEvent{
+		Address:   log.Address,
+		LogIndex:  log.LogIndex,
+		Name:      event.RawName,
+		Signature: event.Sig,
+		Arguments: arguments,
+	}, nil
+}
+
+// decodeLogArguments decodes the arguments of an event from the log topics
+// (indexed arguments) and data (non-indexed arguments)
+func decodeLogArguments(inputs abi.Arguments, log types.Log, eventName string) ([]types.DecodedArgument, error) {
+	nonIndexedValues, err := inputs.NonIndexed().Unpack(log.Data)
+	if err != nil {
+		return nil, errors.NewABIUnpackFailedError(err, eventName)
+	}
+
+	arguments := make([]types.DecodedArgument, 0, len(inputs))
+	topicIndex, dataIndex := 1, 0
+	for _, input := range inputs {
+		argument := types.DecodedArgument{
+			Name:    input.Name,
+			Type:    input.Type.String(),
+			Indexed: input.Indexed,
+		}
+
+		if input.Indexed {
+			if topicIndex >= len(log.Topics) {
+				return nil, errors.NewABIUnpackFailedError(fmt.Errorf("missing topic for indexed argument %q", input.Name), eventName)
+			}
+			value, err := decodeTopic(input.Type, common.HexToHash(log.Topics[topicIndex]))
+			if err != nil {
+				return nil, errors.NewABIUnpackFailedError(err, eventName)
+			}
+			argument.Value = value
+			topicIndex++
+		} else {
+			argument.Value = normalizeABIValue(nonIndexedValues[dataIndex])
+			dataIndex++
+		}
+
+		arguments = append(arguments, argument)
+	}
+
+	return arguments, nil
+}
+
+// decodeTopic decodes an indexed event argument. Dynamic types are stored in
+// topics as their keccak256 hash, which is returned as is.
+func decodeTopic(argType abi.Type, topic common.Hash) (any, error) {
+	switch argType.T {
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		return topic.Hex(), nil
+	}
+
+	values, err := abi.Arguments{{Type: argType}}.Unpack(topic.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return normalizeABIValue(values[0]), nil
+}
+
+// normalizeABIValue converts a value unpacked by go-ethereum into a JSON-friendly
+// form: integers and addresses become strings, byte values become hex, arrays
+// become lists and tuples become objects keyed by their ABI field names.
+func normalizeABIValue(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case bool, string:
+		return v
+	case *big.Int:
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case common.Hash:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	case int8, int16, int32, int64, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v)
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return nil
+		}
+		return normalizeABIValue(rv.Elem().Interface())
+	case reflect.Array:
+		// Fixed-size byte arrays (bytes1..bytes32)
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return hexutil.Encode(b)
+		}
+		return normalizeABIList(rv)
+	case reflect.Slice:
+		return normalizeABIList(rv)
+	case reflect.Struct:
+		fields := make(map[string]any, rv.NumField())
+		for i := 0; i < rv.NumField(); i++ {
+			field := rv.Type().Field(i)
+			name := field.Name
+			if tag := field.Tag.Get("json"); tag != "" {
+				name = tag
+			}
+			fields[name] = normalizeABIValue(rv.Field(i).Interface())
+		}
+		return fields
+	}
+
+	return fmt.Sprint(value)
+}
+
+// normalizeABIList normalizes every element of an array or slice value
+func normalizeABIList(rv reflect.Value) []any {
+	list := make([]any, rv.Len())
+	for i := range list {
+		list[i] = normalizeABIValue(rv.Index(i).Interface())
+	}
+	return list
+}
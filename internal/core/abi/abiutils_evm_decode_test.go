@@ -0,0 +1,147 @@
+package abi
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/errors"
+	"vault0/internal/testing/mocks"
+	"vault0/internal/types"
+)
+
+const (
+	testDecodeERC20ABI = `[
+		{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}],"stateMutability":"nonpayable"},
+		{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]}
+	]`
+	testDecodeTupleABI = `[
+		{"type":"function","name":"submit","inputs":[{"name":"order","type":"tuple","components":[{"name":"maker","type":"address"},{"name":"amount","type":"uint256"}]},{"name":"salt","type":"bytes32"},{"name":"ids","type":"uint64[]"}],"outputs":[],"stateMutability":"nonpayable"},
+		{"type":"event","name":"Memo","anonymous":false,"inputs":[{"name":"note","type":"string","indexed":true},{"name":"text","type":"string","indexed":false}]}
+	]`
+	testDecodeFrom = "0x1111111111111111111111111111111111111111"
+	testDecodeTo   = "0x2222222222222222222222222222222222222222"
+)
+
+func TestEVMABIUtils_DecodeCall(t *testing.T) {
+	utils, err := NewEvmAbiUtils(types.ChainTypeEthereum, nil, mocks.NewNopLogger())
+	require.NoError(t, err)
+
+	t.Run("ERC20 transfer", func(t *testing.T) {
+		parsed, err := abi.JSON(strings.NewReader(testDecodeERC20ABI))
+		require.NoError(t, err)
+		data, err := parsed.Pack("transfer", common.HexToAddress(testDecodeTo), big.NewInt(1000))
+		require.NoError(t, err)
+
+		call, err := utils.DecodeCall(testDecodeERC20ABI, data)
+		require.NoError(t, err)
+		assert.Equal(t, "transfer", call.Method)
+		assert.Equal(t, "transfer(address,uint256)", call.Signature)
+		assert.Equal(t, "0xa9059cbb", call.Selector)
+		require.Len(t, call.Arguments, 2)
+		assert.Equal(t, types.DecodedArgument{Name: "to", Type: "address", Value: common.HexToAddress(testDecodeTo).Hex()}, call.Arguments[0])
+		assert.Equal(t, types.DecodedArgument{Name: "amount", Type: "uint256", Value: "1000"}, call.Arguments[1])
+	})
+
+	t.Run("Tuple, fixed bytes and array arguments", func(t *testing.T) {
+		parsed, err := abi.JSON(strings.NewReader(testDecodeTupleABI))
+		require.NoError(t, err)
+		order := struct {
+			Maker  common.Address
+			Amount *big.Int
+		}{common.HexToAddress(testDecodeFrom), big.NewInt(42)}
+		salt := [32]byte{0x01}
+		data, err := parsed.Pack("submit", order, salt, []uint64{1, 2})
+		require.NoError(t, err)
+
+		call, err := utils.DecodeCall(testDecodeTupleABI, data)
+		require.NoError(t, err)
+		assert.Equal(t, "submit", call.Method)
+		require.Len(t, call.Arguments, 3)
+		assert.Equal(t, map[string]any{
+			"maker":  common.HexToAddress(testDecodeFrom).Hex(),
+			"amount": "42",
+		}, call.Arguments[0].Value)
+		assert.Equal(t, "0x01"+strings.Repeat("00", 31), call.Arguments[1].Value)
+		assert.Equal(t, []any{"1", "2"}, call.Arguments[2].Value)
+	})
+
+	t.Run("Unknown selector", func(t *testing.T) {
+		_, err := utils.DecodeCall(testDecodeERC20ABI, common.FromHex("0xdeadbeef"))
+		require.Error(t, err)
+		assert.True(t, errors.IsError(err, errors.ErrCodeABIMethodNotFound))
+	})
+
+	t.Run("Input data too short", func(t *testing.T) {
+		_, err := utils.DecodeCall(testDecodeERC20ABI, []byte{0xa9})
+		require.Error(t, err)
+		assert.True(t, errors.IsError(err, errors.ErrCodeABIInputDataTooShort))
+	})
+}
+
+func TestEVMABIUtils_DecodeLog(t *testing.T) {
+	utils, err := NewEvmAbiUtils(types.ChainTypeEthereum, nil, mocks.NewNopLogger())
+	require.NoError(t, err)
+
+	t.Run("ERC20 Transfer event", func(t *testing.T) {
+		log := types.Log{
+			Address: "0x3333333333333333333333333333333333333333",
+			Topics: []string{
+				crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")).Hex(),
+				common.BytesToHash(common.HexToAddress(testDecodeFrom).Bytes()).Hex(),
+				common.BytesToHash(common.HexToAddress(testDecodeTo).Bytes()).Hex(),
+			},
+			Data:     common.LeftPadBytes(big.NewInt(500).Bytes(), 32),
+			LogIndex: 7,
+		}
+
+		event, err := utils.DecodeLog(testDecodeERC20ABI, log)
+		require.NoError(t, err)
+		assert.Equal(t, "Transfer", event.Name)
+		assert.Equal(t, "Transfer(address,address,uint256)", event.Signature)
+		assert.Equal(t, log.Address, event.Address)
+		assert.Equal(t, uint(7), event.LogIndex)
+		require.Len(t, event.Arguments, 3)
+		assert.Equal(t, types.DecodedArgument{Name: "from", Type: "address", Value: common.HexToAddress(testDecodeFrom).Hex(), Indexed: true}, event.Arguments[0])
+		assert.Equal(t, types.DecodedArgument{Name: "to", Type: "address", Value: common.HexToAddress(testDecodeTo).Hex(), Indexed: true}, event.Arguments[1])
+		assert.Equal(t, types.DecodedArgument{Name: "value", Type: "uint256", Value: "500"}, event.Arguments[2])
+	})
+
+	t.Run("Indexed dynamic argument keeps its hash", func(t *testing.T) {
+		parsed, err := abi.JSON(strings.NewReader(testDecodeTupleABI))
+		require.NoError(t, err)
+		data, err := parsed.Events["Memo"].Inputs.NonIndexed().Pack("hello")
+		require.NoError(t, err)
+		noteHash := crypto.Keccak256Hash([]byte("note"))
+
+		event, err := utils.DecodeLog(testDecodeTupleABI, types.Log{
+			Topics: []string{parsed.Events["Memo"].ID.Hex(), noteHash.Hex()},
+			Data:   data,
+		})
+		require.NoError(t, err)
+		require.Len(t, event.Arguments, 2)
+		assert.Equal(t, noteHash.Hex(), event.Arguments[0].Value)
+		assert.True(t, event.Arguments[0].Indexed)
+		assert.Equal(t, "hello", event.Arguments[1].Value)
+	})
+
+	t.Run("Event not found", func(t *testing.T) {
+		_, err := utils.DecodeLog(testDecodeERC20ABI, types.Log{
+			Topics: []string{crypto.Keccak256Hash([]byte("Unknown()")).Hex()},
+		})
+		require.Error(t, err)
+		assert.True(t, errors.IsError(err, errors.ErrCodeABIEventNotFound))
+	})
+
+	t.Run("Anonymous log", func(t *testing.T) {
+		_, err := utils.DecodeLog(testDecodeERC20ABI, types.Log{})
+		require.Error(t, err)
+		assert.True(t, errors.IsError(err, errors.ErrCodeABIEventNotFound))
+	})
+}
@@ -0,0 +1,234 @@
+package abi
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"vault0/internal/errors"
+	"vault0/internal/types"
+)
+
+// DecodeCallHeuristic decodes contract call input data without an ABI. Candidate
+// signatures are looked up by selector in the signature database; a candidate whose
+// arguments re-encode to the exact input data is preferred over one that merely
+// unpacks. The result is marked as heuristic.
+func (u *EVMABIUtils) DecodeCallHeuristic(inputData []byte) (*types.DecodedCall, error) {
+	if len(inputData) < 4 {
+		return nil, errors.NewABIInputDataTooShortError(len(inputData), 4)
+	}
+
+	selector := hexutil.Encode(inputData[:4])
+	if u.signatures == nil {
+		return nil, errors.NewABIMethodNotFoundError(selector, false)
+	}
+
+	var fallback *types.DecodedCall
+	for _, signature := range u.signatures.LookupFunction(selector) {
+		name, inputs, err := parseSignature(signature)
+		if err != nil {
+			continue
+		}
+
+		values, err := inputs.Unpack(inputData[4:])
+		if err != nil {
+			continue
+		}
+
+		call := &types.DecodedCall{
+			Method:    name,
+			Signature: signature,
+			Selector:  selector,
+			Arguments: make([]types.DecodedArgument, 0, len(inputs)),
+			Heuristic: true,
+		}
+		for i, input := range inputs {
+			call.Arguments = append(call.Arguments, types.DecodedArgument{
+				Type:  input.Type.String(),
+				Value: normalizeABIValue(values[i]),
+			})
+		}
+
+		if packed, err := inputs.Pack(values...); err == nil && bytes.Equal(packed, inputData[4:]) {
+			return call, nil
+		}
+		if fallback == nil {
+			fallback = call
+		}
+	}
+
+	if fallback == nil {
+		return nil, errors.NewABIMethodNotFoundError(selector, false)
+	}
+
+	return fallback, nil
+}
+
+// DecodeLogHeuristic decodes a log without an ABI. Candidate signatures are
+// looked up by the first topic in the signature database. As signatures don't
+// record which arguments are indexed, the leading arguments are assumed to be
+// the indexed ones, one per remaining topic. The result is marked as heuristic.
+func (u *EVMABIUtils) DecodeLogHeuristic(log types.Log) (*types.DecodedEvent, error) {
+	if len(log.Topics) == 0 {
+		return nil, errors.NewABIEventNotFoundError("")
+	}
+
+	topic := topicHex(log.Topics[0])
+	if u.signatures == nil {
+		return nil, errors.NewABIEventNotFoundError(topic)
+	}
+
+	indexedCount := len(log.Topics) - 1
+	for _, signature := range u.signatures.LookupEvent(topic) {
+		name, inputs, err := parseSignature(signature)
+		if err != nil || indexedCount > len(inputs) {
+			continue
+		}
+
+		for i := range inputs {
+			inputs[i].Indexed = i < indexedCount
+		}
+
+		// Require the data to match the non-indexed arguments exactly
+		values, err := inputs.NonIndexed().Unpack(log.Data)
+		if err != nil {
+			continue
+		}
+		if packed, err := inputs.NonIndexed().Pack(values...); err != nil || !bytes.Equal(packed, log.Data) {
+			continue
+		}
+
+		arguments, err := decodeLogArguments(inputs, log, name)
+		if err != nil {
+			continue
+		}
+
+		return &types.DecodedEvent{
+			Address:   log.Address,
+			LogIndex:  log.LogIndex,
+			Name:      name,
+			Signature: signature,
+			Arguments: arguments,
+			Heuristic: true,
+		}, nil
+	}
+
+	return nil, errors.NewABIEventNotFoundError(topic)
+}
+
+// parseSignature parses a text signature such as "swap((address,uint256)[],bytes)"
+// into its name and unnamed arguments
+func parseSignature(signature string) (string, abi.Arguments, error) {
+	open := strings.Index(signature, "(")
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return "", nil, fmt.Errorf("invalid signature %q", signature)
+	}
+
+	typeNames, err := splitSignatureTypes(signature[open+1 : len(signature)-1])
+	if err != nil {
+		return "", nil, err
+	}
+
+	arguments := make(abi.Arguments, 0, len(typeNames))
+	for _, typeName := range typeNames {
+		marshaling, err := signatureTypeMarshaling(typeName, "")
+		if err != nil {
+			return "", nil, err
+		}
+
+		argType, err := abi.NewType(marshaling.Type, "", marshaling.Components)
+		if err != nil {
+			return "", nil, err
+		}
+
+		arguments = append(arguments, abi.Argument{Type: argType})
+	}
+
+	return signature[:open], arguments, nil
+}
+
+// signatureTypeMarshaling converts a signature type into its ABI JSON form,
+// expanding tuples. Tuple components are named field0, field1, ... as
+// go-ethereum requires named tuple fields.
+func signatureTypeMarshaling(typeName, name string) (abi.ArgumentMarshaling, error) {
+	if !strings.HasPrefix(typeName, "(") {
+		return abi.ArgumentMarshaling{Name: name, Type: typeName}, nil
+	}
+
+	depth, end := 0, -1
+	for i, c := range typeName {
+		if c == '(' {
+			depth++
+		} else if c == ')' {
+			depth--
+			if depth == 0 {
+				end = i
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return abi.ArgumentMarshaling{}, fmt.Errorf("unbalanced tuple type %q", typeName)
+	}
+
+	componentTypes, err := splitSignatureTypes(typeName[1:end])
+	if err != nil {
+		return abi.ArgumentMarshaling{}, err
+	}
+
+	components := make([]abi.ArgumentMarshaling, 0, len(componentTypes))
+	for i, componentType := range componentTypes {
+		component, err := signatureTypeMarshaling(componentType, fmt.Sprintf("field%d", i))
+		if err != nil {
+			return abi.ArgumentMarshaling{}, err
+		}
+		components = append(components, component)
+	}
+
+	return abi.ArgumentMarshaling{
+		Name:       name,
+		Type:       "tuple" + typeName[end+1:],
+		Components: components,
+	}, nil
+}
+
+// splitSignatureTypes splits a comma separated type list, keeping tuples intact
+func splitSignatureTypes(list string) ([]string, error) {
+	if list == "" {
+		return nil, nil
+	}
+
+	var typeNames []string
+	depth, start := 0, 0
+	for i, c := range list {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced type list %q", list)
+			}
+		case ',':
+			if depth == 0 {
+				typeNames = append(typeNames, list[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced type list %q", list)
+	}
+	typeNames = append(typeNames, list[start:])
+
+	for _, typeName := range typeNames {
+		if typeName == "" {
+			return nil, fmt.Errorf("empty type in list %q", list)
+		}
+	}
+
+	return typeNames, nil
+}
@@ -0,0 +1,137 @@
+package abi
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/config"
+	"vault0/internal/errors"
+	"vault0/internal/testing/mocks"
+	"vault0/internal/types"
+)
+
+func setupHeuristicABIUtils(t *testing.T) ABIUtils {
+	signatures, err := NewSignatureDB(&config.Config{}, mocks.NewNopLogger())
+	require.NoError(t, err)
+
+	utils, err := NewEvmAbiUtils(types.ChainTypeEthereum, signatures, mocks.NewNopLogger())
+	require.NoError(t, err)
+
+	return utils
+}
+
+func TestEVMABIUtils_DecodeCallHeuristic(t *testing.T) {
+	utils := setupHeuristicABIUtils(t)
+
+	t.Run("Known selector", func(t *testing.T) {
+		parsed, err := abi.JSON(strings.NewReader(testDecodeERC20ABI))
+		require.NoError(t, err)
+		data, err := parsed.Pack("transfer", common.HexToAddress(testDecodeTo), big.NewInt(1000))
+		require.NoError(t, err)
+
+		call, err := utils.DecodeCallHeuristic(data)
+		require.NoError(t, err)
+		assert.True(t, call.Heuristic)
+		assert.Equal(t, "transfer", call.Method)
+		assert.Equal(t, "transfer(address,uint256)", call.Signature)
+		assert.Equal(t, "0xa9059cbb", call.Selector)
+		assert.Equal(t, []types.DecodedArgument{
+			{Type: "address", Value: common.HexToAddress(testDecodeTo).Hex()},
+			{Type: "uint256", Value: "1000"},
+		}, call.Arguments)
+	})
+
+	t.Run("Tuple array arguments", func(t *testing.T) {
+		parsed, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"aggregate","inputs":[{"name":"calls","type":"tuple[]","components":[{"name":"target","type":"address"},{"name":"callData","type":"bytes"}]}],"outputs":[],"stateMutability":"nonpayable"}]`))
+		require.NoError(t, err)
+		calls := []struct {
+			Target   common.Address
+			CallData []byte
+		}{{common.HexToAddress(testDecodeTo), []byte{0x01, 0x02}}}
+		data, err := parsed.Pack("aggregate", calls)
+		require.NoError(t, err)
+
+		call, err := utils.DecodeCallHeuristic(data)
+		require.NoError(t, err)
+		assert.Equal(t, "aggregate", call.Method)
+		require.Len(t, call.Arguments, 1)
+		assert.Equal(t, []any{map[string]any{
+			"field0": common.HexToAddress(testDecodeTo).Hex(),
+			"field1": "0x0102",
+		}}, call.Arguments[0].Value)
+	})
+
+	t.Run("Unknown selector", func(t *testing.T) {
+		_, err := utils.DecodeCallHeuristic(common.FromHex("0xdeadbeef"))
+		require.Error(t, err)
+		assert.True(t, errors.IsError(err, errors.ErrCodeABIMethodNotFound))
+	})
+
+	t.Run("Malformed arguments", func(t *testing.T) {
+		_, err := utils.DecodeCallHeuristic(common.FromHex("0xa9059cbb0102"))
+		require.Error(t, err)
+		assert.True(t, errors.IsError(err, errors.ErrCodeABIMethodNotFound))
+	})
+
+	t.Run("Without signature database", func(t *testing.T) {
+		plainUtils, err := NewEvmAbiUtils(types.ChainTypeEthereum, nil, mocks.NewNopLogger())
+		require.NoError(t, err)
+
+		_, err = plainUtils.DecodeCallHeuristic(common.FromHex("0xa9059cbb"))
+		require.Error(t, err)
+		assert.True(t, errors.IsError(err, errors.ErrCodeABIMethodNotFound))
+	})
+}
+
+func TestEVMABIUtils_DecodeLogHeuristic(t *testing.T) {
+	utils := setupHeuristicABIUtils(t)
+
+	fromTopic := common.BytesToHash(common.HexToAddress(testDecodeFrom).Bytes()).Hex()
+	toTopic := common.BytesToHash(common.HexToAddress(testDecodeTo).Bytes()).Hex()
+
+	t.Run("ERC20 Transfer", func(t *testing.T) {
+		event, err := utils.DecodeLogHeuristic(types.Log{
+			Topics: []string{transferTopic, fromTopic, toTopic},
+			Data:   common.LeftPadBytes(big.NewInt(500).Bytes(), 32),
+		})
+		require.NoError(t, err)
+		assert.True(t, event.Heuristic)
+		assert.Equal(t, "Transfer", event.Name)
+		assert.Equal(t, []types.DecodedArgument{
+			{Type: "address", Value: common.HexToAddress(testDecodeFrom).Hex(), Indexed: true},
+			{Type: "address", Value: common.HexToAddress(testDecodeTo).Hex(), Indexed: true},
+			{Type: "uint256", Value: "500"},
+		}, event.Arguments)
+	})
+
+	t.Run("ERC721 Transfer with indexed token ID", func(t *testing.T) {
+		event, err := utils.DecodeLogHeuristic(types.Log{
+			Topics: []string{transferTopic, fromTopic, toTopic, common.BigToHash(big.NewInt(42)).Hex()},
+		})
+		require.NoError(t, err)
+		require.Len(t, event.Arguments, 3)
+		assert.Equal(t, "42", event.Arguments[2].Value)
+		assert.True(t, event.Arguments[2].Indexed)
+	})
+
+	t.Run("Data not matching the signature", func(t *testing.T) {
+		_, err := utils.DecodeLogHeuristic(types.Log{
+			Topics: []string{transferTopic, fromTopic},
+			Data:   common.LeftPadBytes(big.NewInt(500).Bytes(), 32),
+		})
+		require.Error(t, err)
+		assert.True(t, errors.IsError(err, errors.ErrCodeABIEventNotFound))
+	})
+
+	t.Run("Unknown topic", func(t *testing.T) {
+		_, err := utils.DecodeLogHeuristic(types.Log{Topics: []string{common.BigToHash(big.NewInt(1)).Hex()}})
+		require.Error(t, err)
+		assert.True(t, errors.IsError(err, errors.ErrCodeABIEventNotFound))
+	})
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -17,7 +18,7 @@ import (
 
 func TestNewEvmAbiUtils(t *testing.T) {
 	log := mocks.NewNopLogger()
-	utils, err := NewEvmAbiUtils(types.ChainTypeEthereum, log)
+	utils, err := NewEvmAbiUtils(types.ChainTypeEthereum, nil, log)
 
 	require.NoError(t, err)
 	require.NotNil(t, utils)
@@ -26,7 +27,7 @@ func TestNewEvmAbiUtils(t *testing.T) {
 
 func TestEVMABIUtils_Pack(t *testing.T) {
 	log := mocks.NewNopLogger()
-	utils, err := NewEvmAbiUtils(types.ChainTypeEthereum, log)
+	utils, err := NewEvmAbiUtils(types.ChainTypeEthereum, nil, log)
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -73,6 +74,29 @@ func TestEVMABIUtils_Pack(t *testing.T) {
 			want:        nil, // We won't check the exact value, just that it's not nil and no error
 			wantErr:     false,
 		},
+		{
+			name:        "overloaded method by signature",
+			contractABI: `[{"inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"},{"name":"data","type":"bytes"}],"name":"safeTransferFrom","outputs":[],"stateMutability":"nonpayable","type":"function"},{"inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"tokenId","type":"uint256"}],"name":"safeTransferFrom","outputs":[],"stateMutability":"nonpayable","type":"function"}]`,
+			methodName:  "safeTransferFrom(address,address,uint256)",
+			args:        []interface{}{common.HexToAddress("0x1234567890123456789012345678901234567890"), common.HexToAddress("0x0987654321098765432109876543210987654321"), big.NewInt(7)},
+			want: append(
+				crypto.Keccak256([]byte("safeTransferFrom(address,address,uint256)"))[:4],
+				append(append(
+					common.LeftPadBytes(common.HexToAddress("0x1234567890123456789012345678901234567890").Bytes(), 32),
+					common.LeftPadBytes(common.HexToAddress("0x0987654321098765432109876543210987654321").Bytes(), 32)...),
+					common.LeftPadBytes(big.NewInt(7).Bytes(), 32)...)...,
+			),
+			wantErr: false,
+		},
+		{
+			name:        "unknown signature",
+			contractABI: `[{"constant":false,"inputs":[{"name":"_to","type":"address"},{"name":"_value","type":"uint256"}],"name":"transfer","outputs":[{"name":"success","type":"bool"}],"payable":false,"stateMutability":"nonpayable","type":"function"}]`,
+			methodName:  "transfer(address)",
+			args:        []interface{}{},
+			want:        nil,
+			wantErr:     true,
+			errCode:     errors.ErrCodeABIMethodNotFound,
+		},
 		{
 			name:        "invalid args",
 			contractABI: `[{"constant":false,"inputs":[{"name":"_to","type":"address"},{"name":"_value","type":"uint256"}],"name":"transfer","outputs":[{"name":"success","type":"bool"}],"payable":false,"stateMutability":"nonpayable","type":"function"}]`,
@@ -109,7 +133,7 @@ func TestEVMABIUtils_Pack(t *testing.T) {
 
 func TestEVMABIUtils_Unpack(t *testing.T) {
 	log := mocks.NewNopLogger()
-	utils, err := NewEvmAbiUtils(types.ChainTypeEthereum, log)
+	utils, err := NewEvmAbiUtils(types.ChainTypeEthereum, nil, log)
 	require.NoError(t, err)
 
 	// Simple ABI for testing
@@ -225,7 +249,7 @@ func TestEVMABIUtils_Unpack(t *testing.T) {
 
 func TestEVMABIUtils_ExtractMethodID(t *testing.T) {
 	log := mocks.NewNopLogger()
-	utils, err := NewEvmAbiUtils(types.ChainTypeEthereum, log)
+	utils, err := NewEvmAbiUtils(types.ChainTypeEthereum, nil, log)
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -260,7 +284,7 @@ func TestEVMABIUtils_ExtractMethodID(t *testing.T) {
 
 func TestEVMABIUtils_GetAddressFromArgs(t *testing.T) {
 	log := mocks.NewNopLogger()
-	utils, err := NewEvmAbiUtils(types.ChainTypeEthereum, log)
+	utils, err := NewEvmAbiUtils(types.ChainTypeEthereum, nil, log)
 	require.NoError(t, err)
 
 	ethAddr := common.HexToAddress("0x1234567890123456789012345678901234567890")
@@ -334,7 +358,7 @@ func TestEVMABIUtils_GetAddressFromArgs(t *testing.T) {
 
 func TestEVMABIUtils_GetBytes32FromArgs(t *testing.T) {
 	log := mocks.NewNopLogger()
-	utils, err := NewEvmAbiUtils(types.ChainTypeEthereum, log)
+	utils, err := NewEvmAbiUtils(types.ChainTypeEthereum, nil, log)
 	require.NoError(t, err)
 
 	// Create a sample [32]byte and byte slice
@@ -416,7 +440,7 @@ func TestEVMABIUtils_GetBytes32FromArgs(t *testing.T) {
 
 func TestEVMABIUtils_GetBigIntFromArgs(t *testing.T) {
 	log := mocks.NewNopLogger()
-	utils, err := NewEvmAbiUtils(types.ChainTypeEthereum, log)
+	utils, err := NewEvmAbiUtils(types.ChainTypeEthereum, nil, log)
 	require.NoError(t, err)
 
 	// Create sample big.Int values
@@ -512,7 +536,7 @@ func TestEVMABIUtils_GetBigIntFromArgs(t *testing.T) {
 
 func TestEVMABIUtils_GetUint64FromArgs(t *testing.T) {
 	log := mocks.NewNopLogger()
-	utils, err := NewEvmAbiUtils(types.ChainTypeEthereum, log)
+	utils, err := NewEvmAbiUtils(types.ChainTypeEthereum, nil, log)
 	require.NoError(t, err)
 
 	uint64Value := uint64(1000)
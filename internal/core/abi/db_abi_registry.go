@@ -0,0 +1,354 @@
+package abi
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"vault0/internal/db"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/types"
+)
+
+// dbABIRegistry implements the ABIRegistry interface using an SQL database
+type dbABIRegistry struct {
+	db    *db.DB
+	log   logger.Logger
+	cache registryCache
+}
+
+// registryCacheTTL is how long registry lookups are cached. Other replicas change the
+// registry without invalidating the cache of this one, so their changes are only seen
+// once the entries expire.
+const registryCacheTTL = 30 * time.Second
+
+// registryCache caches the registry lookups, including the addresses without an
+// ABI, as the ABI loaders look up the registry for every contract they decode
+type registryCache struct {
+	mu      sync.Mutex
+	entries map[string]registryCacheEntry
+	// generation is incremented on every change, so that a lookup racing with a
+	// change doesn't cache the value it replaced
+	generation uint64
+}
+
+// registryCacheEntry is a cached registry lookup
+type registryCacheEntry struct {
+	abi      *ContractABI // nil marks an address without an ABI
+	cachedAt time.Time
+}
+
+// get returns a copy of the cached entry of a key and whether the key is cached
+func (c *registryCache) get(key string) (*ContractABI, bool, uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, found := c.entries[key]
+	if !found || time.Since(entry.cachedAt) >= registryCacheTTL {
+		return nil, false, c.generation
+	}
+	if entry.abi == nil {
+		return nil, true, c.generation
+	}
+	copied := *entry.abi
+	return &copied, true, c.generation
+}
+
+// put caches the entry of a key read at a generation, unless the registry changed since
+func (c *registryCache) put(key string, contractABI *ContractABI, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	if c.entries == nil {
+		c.entries = make(map[string]registryCacheEntry)
+	}
+	if contractABI != nil {
+		copied := *contractABI
+		contractABI = &copied
+	}
+	c.entries[key] = registryCacheEntry{abi: contractABI, cachedAt: time.Now()}
+}
+
+// invalidate drops the cached entry of a key
+func (c *registryCache) invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	delete(c.entries, key)
+}
+
+// registryCacheKey returns the cache key of a normalized contract address
+func registryCacheKey(chainType types.ChainType, address string) string {
+	return string(chainType) + ":" + address
+}
+
+// GetABI retrieves the ABI registered for a contract
+func (r *dbABIRegistry) GetABI(ctx context.Context, chainType types.ChainType, address string) (*ContractABI, error) {
+	addr, err := types.NewAddress(chainType, address)
+	if err != nil {
+		return nil, err
+	}
+
+	// Entries read within a transaction may be rolled back, so they aren't cached
+	key := registryCacheKey(chainType, addr.Address)
+	cacheable := !db.InTransaction(ctx)
+	cached, found, generation := r.cache.get(key)
+	if cacheable && found {
+		if cached == nil {
+			return nil, errors.NewResourceNotFoundError("contract ABI", addr.Address)
+		}
+		return cached, nil
+	}
+
+	contractABI, err := r.getABI(ctx, chainType, addr.Address)
+	if cacheable {
+		if err == nil {
+			r.cache.put(key, contractABI, generation)
+		} else if errors.IsError(err, errors.ErrCodeResourceNotFound) {
+			r.cache.put(key, nil, generation)
+		}
+	}
+	return contractABI, err
+}
+
+// getABI reads the ABI registered for a normalized contract address from the database
+func (r *dbABIRegistry) getABI(ctx context.Context, chainType types.ChainType, address string) (*ContractABI, error) {
+	rows, err := r.db.ExecuteQueryContext(
+		ctx,
+		`SELECT id, chain_type, address, name, abi, source, created_at, updated_at
+		FROM contract_abis
+		WHERE chain_type = ? AND address = ?`,
+		string(chainType),
+		address,
+	)
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, errors.NewResourceNotFoundError("contract ABI", address)
+	}
+
+	return scanContractABI(rows)
+}
+
+// SaveABI registers an ABI for a contract, replacing any existing one
+func (r *dbABIRegistry) SaveABI(ctx context.Context, contractABI *ContractABI) error {
+	if contractABI == nil {
+		return errors.NewMissingParameterError("abi")
+	}
+
+	switch contractABI.Source {
+	case ABISourceExplorer, ABISourceUpload, ABISourceArtifact:
+	default:
+		return errors.NewInvalidParameterError("source", "must be one of: explorer, upload, artifact")
+	}
+
+	addr, err := types.NewAddress(contractABI.ChainType, contractABI.Address)
+	if err != nil {
+		return err
+	}
+
+	compactABI, err := validateABI(contractABI.ABI)
+	if err != nil {
+		return err
+	}
+
+	id, err := r.db.GenerateID()
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+
+	now := time.Now().UTC()
+	_, err = r.db.ExecuteStatementContext(
+		ctx,
+		`INSERT INTO contract_abis (id, chain_type, address, name, abi, source, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(chain_type, address) DO UPDATE SET
+			name = excluded.name,
+			abi = excluded.abi,
+			source = excluded.source,
+			updated_at = excluded.updated_at`,
+		id,
+		string(contractABI.ChainType),
+		addr.Address,
+		contractABI.Name,
+		compactABI,
+		string(contractABI.Source),
+		now,
+		now,
+	)
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	r.cache.invalidate(registryCacheKey(contractABI.ChainType, addr.Address))
+
+	// Reload to pick up the ID and creation time of a replaced entry
+	stored, err := r.getABI(ctx, contractABI.ChainType, addr.Address)
+	if err != nil {
+		return err
+	}
+	*contractABI = *stored
+
+	r.log.Info("Contract ABI registered",
+		logger.String("chain_type", string(contractABI.ChainType)),
+		logger.String("address", addr.Address),
+		logger.String("source", string(contractABI.Source)))
+
+	return nil
+}
+
+// ListABIs retrieves registered ABIs with pagination, optionally filtered by chain
+func (r *dbABIRegistry) ListABIs(ctx context.Context, chainType *types.ChainType, limit int, nextToken string) (*types.Page[*ContractABI], error) {
+	query := `SELECT id, chain_type, address, name, abi, source, created_at, updated_at
+		FROM contract_abis
+		WHERE 1=1` // Base condition to make adding filters easier
+
+	args := []any{}
+
+	if chainType != nil {
+		query += " AND chain_type = ?"
+		args = append(args, string(*chainType))
+	}
+
+	paginationColumn := "id"
+
+	token, err := types.DecodeNextPageToken(nextToken, paginationColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	if token != nil {
+		value, ok := token.GetValueInt64()
+		if !ok {
+			return nil, errors.NewInvalidPaginationTokenError(nextToken, nil)
+		}
+		query += " AND id > ?"
+		args = append(args, value)
+	}
+
+	query += " ORDER BY id ASC"
+
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit+1) // Fetch one extra item to determine if there are more results
+	}
+
+	rows, err := r.db.ExecuteQueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+	defer rows.Close()
+
+	var abis []*ContractABI
+	for rows.Next() {
+		contractABI, err := scanContractABI(rows)
+		if err != nil {
+			return nil, err
+		}
+		abis = append(abis, contractABI)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	generateToken := func(contractABI *ContractABI) *types.NextPageToken {
+		return &types.NextPageToken{
+			Column: paginationColumn,
+			Value:  strconv.FormatInt(contractABI.ID, 10), // Snowflake IDs exceed float64 precision
+		}
+	}
+
+	return types.NewPage(abis, limit, generateToken), nil
+}
+
+// DeleteABI removes the ABI registered for a contract
+func (r *dbABIRegistry) DeleteABI(ctx context.Context, chainType types.ChainType, address string) error {
+	addr, err := types.NewAddress(chainType, address)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecuteStatementContext(
+		ctx,
+		"DELETE FROM contract_abis WHERE chain_type = ? AND address = ?",
+		string(chainType),
+		addr.Address,
+	)
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	r.cache.invalidate(registryCacheKey(chainType, addr.Address))
+	if rowsAffected == 0 {
+		return errors.NewResourceNotFoundError("contract ABI", addr.Address)
+	}
+
+	r.log.Info("Contract ABI deleted",
+		logger.String("chain_type", string(chainType)),
+		logger.String("address", addr.Address))
+
+	return nil
+}
+
+// scanContractABI scans a single contract_abis row
+func scanContractABI(rows *sql.Rows) (*ContractABI, error) {
+	var (
+		contractABI ContractABI
+		name        sql.NullString
+	)
+	err := rows.Scan(
+		&contractABI.ID,
+		&contractABI.ChainType,
+		&contractABI.Address,
+		&name,
+		&contractABI.ABI,
+		&contractABI.Source,
+		&contractABI.CreatedAt,
+		&contractABI.UpdatedAt,
+	)
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+	contractABI.Name = name.String
+
+	return &contractABI, nil
+}
+
+// validateABI checks that the ABI is a well-formed JSON ABI array and returns it compacted
+func validateABI(abiJSON string) (string, error) {
+	trimmed := strings.TrimSpace(abiJSON)
+	if !strings.HasPrefix(trimmed, "[") {
+		return "", errors.NewABIParseError(fmt.Errorf("ABI must be a JSON array"))
+	}
+
+	if _, err := abi.JSON(strings.NewReader(trimmed)); err != nil {
+		return "", errors.NewABIParseError(err)
+	}
+
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, []byte(trimmed)); err != nil {
+		return "", errors.NewABIParseError(err)
+	}
+
+	return compact.String(), nil
+}
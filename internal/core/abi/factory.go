@@ -26,6 +26,8 @@ type factory struct {
 	log               logger.Logger
 	blockchainFactory blockchain.Factory
 	explorerFactory   blockexplorer.Factory
+	registry          ABIRegistry
+	signatures        SignatureDB
 	abiUtils          map[types.ChainType]ABIUtils
 	abiLoaders        map[types.ChainType]ABILoader
 	abiUtilsMux       sync.RWMutex
@@ -38,12 +40,16 @@ func NewFactory(
 	log logger.Logger,
 	blockchainFactory blockchain.Factory,
 	explorerFactory blockexplorer.Factory,
+	registry ABIRegistry,
+	signatures SignatureDB,
 ) Factory {
 	return &factory{
 		cfg:               cfg,
 		log:               log,
 		blockchainFactory: blockchainFactory,
 		explorerFactory:   explorerFactory,
+		registry:          registry,
+		signatures:        signatures,
 		abiUtils:          make(map[types.ChainType]ABIUtils),
 		abiLoaders:        make(map[types.ChainType]ABILoader),
 	}
@@ -69,7 +75,7 @@ func (f *factory) NewABIUtils(chainType types.ChainType) (ABIUtils, error) {
 	}
 
 	// Create and cache new instance
-	utils, err := NewABIUtils(chainType, f.log)
+	utils, err := NewABIUtils(chainType, f.signatures, f.log)
 	if err != nil {
 		return nil, err
 	}
@@ -122,6 +128,7 @@ func (f *factory) NewABILoader(chainType types.ChainType) (ABILoader, error) {
 		explorer,
 		blockchainClient,
 		abiUtils,
+		f.registry,
 		f.log,
 	)
 
@@ -0,0 +1,157 @@
+package abi
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"vault0/internal/logger"
+	"vault0/internal/types"
+)
+
+// ProxyStandard identifies how a proxy contract's implementation was resolved.
+type ProxyStandard string
+
+const (
+	// ProxyStandardEIP1967 is a transparent or UUPS proxy storing its implementation in the EIP-1967 slot
+	ProxyStandardEIP1967 ProxyStandard = "eip1967"
+	// ProxyStandardEIP1967Beacon is a beacon proxy storing its beacon in the EIP-1967 beacon slot
+	ProxyStandardEIP1967Beacon ProxyStandard = "eip1967_beacon"
+	// ProxyStandardEIP1822 is a UUPS proxy storing its implementation in the EIP-1822 PROXIABLE slot
+	ProxyStandardEIP1822 ProxyStandard = "eip1822"
+	// ProxyStandardEIP1167 is a minimal proxy (clone) with the implementation embedded in its bytecode
+	ProxyStandardEIP1167 ProxyStandard = "eip1167"
+	// ProxyStandardImplementationMethod is a proxy exposing an implementation() view method
+	ProxyStandardImplementationMethod ProxyStandard = "implementation_method"
+)
+
+const (
+	// eip1967ImplementationSlot is bytes32(uint256(keccak256('eip1967.proxy.implementation')) - 1)
+	eip1967ImplementationSlot = "0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc"
+	// eip1967BeaconSlot is bytes32(uint256(keccak256('eip1967.proxy.beacon')) - 1)
+	eip1967BeaconSlot = "0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50"
+	// eip1822ProxiableSlot is keccak256('PROXIABLE')
+	eip1822ProxiableSlot = "0xc5f16f0fcc639fa48a6947836d9850f504798523bf8c9a3a87d5876cf622bcf7"
+)
+
+var (
+	// implementationSelector is the selector of implementation(), used to query beacons
+	implementationSelector = common.FromHex("0x5c60da1b")
+
+	// eip1167Prefix and eip1167Suffix surround the 20-byte implementation address
+	// in the runtime bytecode of an EIP-1167 minimal proxy
+	eip1167Prefix = common.FromHex("0x363d3d373d3d3d363d73")
+	eip1167Suffix = common.FromHex("0x5af43d82803e903d91602b57fd5bf3")
+)
+
+// getImplementationAddressFromStorage resolves the implementation of a proxy
+// using the standard proxy storage slots and the EIP-1167 bytecode pattern. It
+// returns a nil address if the contract doesn't follow any of these standards.
+// Errors reading individual slots are logged and the next standard is tried.
+func (l *abiLoader) getImplementationAddressFromStorage(
+	ctx context.Context,
+	proxyAddress types.Address,
+) (*types.Address, ProxyStandard) {
+	if l.blockchainClient == nil {
+		return nil, ""
+	}
+
+	// 1. EIP-1967 implementation slot (transparent and UUPS proxies)
+	if impl := l.readAddressSlot(ctx, proxyAddress, eip1967ImplementationSlot); impl != nil {
+		return impl, ProxyStandardEIP1967
+	}
+
+	// 2. EIP-1967 beacon slot; the implementation is provided by the beacon
+	if beacon := l.readAddressSlot(ctx, proxyAddress, eip1967BeaconSlot); beacon != nil {
+		if impl := l.getBeaconImplementation(ctx, *beacon); impl != nil {
+			return impl, ProxyStandardEIP1967Beacon
+		}
+	}
+
+	// 3. EIP-1822 PROXIABLE slot (legacy UUPS proxies)
+	if impl := l.readAddressSlot(ctx, proxyAddress, eip1822ProxiableSlot); impl != nil {
+		return impl, ProxyStandardEIP1822
+	}
+
+	// 4. EIP-1167 minimal proxy bytecode
+	if impl := l.getMinimalProxyImplementation(ctx, proxyAddress); impl != nil {
+		return impl, ProxyStandardEIP1167
+	}
+
+	return nil, ""
+}
+
+// readAddressSlot reads a storage slot holding an address, returning nil if the
+// slot is empty or cannot be read
+func (l *abiLoader) readAddressSlot(ctx context.Context, contractAddress types.Address, slot string) *types.Address {
+	value, err := l.blockchainClient.GetStorageAt(ctx, contractAddress.String(), slot)
+	if err != nil {
+		l.log.Debug("Failed to read proxy storage slot",
+			logger.String("contract_address", contractAddress.String()),
+			logger.String("slot", slot),
+			logger.Error(err),
+		)
+		return nil
+	}
+
+	return l.addressFromWord(value)
+}
+
+// getBeaconImplementation calls implementation() on a beacon contract
+func (l *abiLoader) getBeaconImplementation(ctx context.Context, beaconAddress types.Address) *types.Address {
+	result, err := l.blockchainClient.CallContract(ctx, "", beaconAddress.String(), implementationSelector)
+	if err != nil {
+		l.log.Debug("Failed to query beacon implementation",
+			logger.String("beacon_address", beaconAddress.String()),
+			logger.Error(err),
+		)
+		return nil
+	}
+	if len(result) < common.HashLength {
+		return nil
+	}
+
+	return l.addressFromWord(result[:common.HashLength])
+}
+
+// getMinimalProxyImplementation extracts the implementation address from the
+// bytecode of an EIP-1167 minimal proxy
+func (l *abiLoader) getMinimalProxyImplementation(ctx context.Context, contractAddress types.Address) *types.Address {
+	code, err := l.blockchainClient.GetCode(ctx, contractAddress.String())
+	if err != nil {
+		l.log.Debug("Failed to read contract code",
+			logger.String("contract_address", contractAddress.String()),
+			logger.Error(err),
+		)
+		return nil
+	}
+
+	if len(code) != len(eip1167Prefix)+common.AddressLength+len(eip1167Suffix) ||
+		!bytes.HasPrefix(code, eip1167Prefix) ||
+		!bytes.HasSuffix(code, eip1167Suffix) {
+		return nil
+	}
+
+	return l.addressFromWord(code[len(eip1167Prefix) : len(eip1167Prefix)+common.AddressLength])
+}
+
+// addressFromWord converts a right-aligned address word into an address,
+// returning nil for empty or zero values
+func (l *abiLoader) addressFromWord(word []byte) *types.Address {
+	if len(word) == 0 || len(word) > common.HashLength {
+		return nil
+	}
+
+	addr := common.BytesToAddress(word)
+	if addr == (common.Address{}) {
+		return nil
+	}
+
+	address, err := types.NewAddress(l.chainType, addr.Hex())
+	if err != nil {
+		return nil
+	}
+
+	return address
+}
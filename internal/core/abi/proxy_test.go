@@ -0,0 +1,153 @@
+package abi
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/core/blockexplorer"
+	coreerrors "vault0/internal/errors"
+	"vault0/internal/testing/matchers"
+	"vault0/internal/testing/mocks"
+	"vault0/internal/types"
+)
+
+const (
+	testProxyAddress          = "0xA00000000000000000000000000000000000000A"
+	testImplementationAddress = "0xB00000000000000000000000000000000000000B"
+	testBeaconAddress         = "0xC00000000000000000000000000000000000000C"
+	testProxyABI              = `[{"type":"fallback","stateMutability":"payable"}]`
+	testImplementationABI     = `[{"type":"function","name":"actualFunction","inputs":[],"outputs":[],"stateMutability":"nonpayable"}]`
+)
+
+// addressWord returns an address left-padded to a 32-byte storage word
+func addressWord(address string) []byte {
+	return common.LeftPadBytes(common.HexToAddress(address).Bytes(), 32)
+}
+
+// setupProxyTestLoader creates a loader whose blockchain client expectations are
+// configured by setup before the non-proxy defaults are applied
+func setupProxyTestLoader(setup func(*mocks.MockBlockchainClient)) (*abiLoader, *mocks.MockBlockExplorer, *mocks.MockBlockchainClient) {
+	mockExplorer := mocks.NewMockBlockExplorer()
+	mockBlockchainClient := mocks.NewMockBlockchainClient()
+	mockABIUtils := mocks.NewMockABIUtils()
+
+	setup(mockBlockchainClient)
+	mockBlockchainClient.WithNoProxyStorage()
+
+	// Proxies in these tests don't expose implementation()
+	mockABIUtils.On("Pack", mock.Anything, "implementation", mock.Anything).
+		Return(nil, coreerrors.NewABIProxyMethodNotFoundError(testProxyAddress, "implementation")).Maybe()
+
+	loader := &abiLoader{
+		config:           mocks.NewMockConfig(),
+		explorer:         mockExplorer,
+		blockchainClient: mockBlockchainClient,
+		log:              mocks.NewNopLogger(),
+		chainType:        types.ChainTypeEthereum,
+		abiCache:         &sync.Map{},
+		abiUtils:         mockABIUtils,
+	}
+
+	return loader, mockExplorer, mockBlockchainClient
+}
+
+func TestLoadABIByAddress_StorageProxies(t *testing.T) {
+	ctx := context.Background()
+	proxyAddress, err := types.NewAddress(types.ChainTypeEthereum, testProxyAddress)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		setup func(*mocks.MockBlockchainClient)
+	}{
+		{
+			name: "EIP-1967 implementation slot",
+			setup: func(mbc *mocks.MockBlockchainClient) {
+				mbc.On("GetStorageAt", mock.Anything, proxyAddress.String(), eip1967ImplementationSlot).
+					Return(addressWord(testImplementationAddress), nil)
+			},
+		},
+		{
+			name: "EIP-1967 beacon slot",
+			setup: func(mbc *mocks.MockBlockchainClient) {
+				mbc.On("GetStorageAt", mock.Anything, proxyAddress.String(), eip1967BeaconSlot).
+					Return(addressWord(testBeaconAddress), nil)
+				mbc.On("CallContract", mock.Anything, "", matchers.AddressMatcher(testBeaconAddress), implementationSelector).
+					Return(addressWord(testImplementationAddress), nil)
+			},
+		},
+		{
+			name: "EIP-1822 proxiable slot",
+			setup: func(mbc *mocks.MockBlockchainClient) {
+				mbc.On("GetStorageAt", mock.Anything, proxyAddress.String(), eip1822ProxiableSlot).
+					Return(addressWord(testImplementationAddress), nil)
+			},
+		},
+		{
+			name: "EIP-1167 minimal proxy",
+			setup: func(mbc *mocks.MockBlockchainClient) {
+				code := append([]byte{}, eip1167Prefix...)
+				code = append(code, common.HexToAddress(testImplementationAddress).Bytes()...)
+				code = append(code, eip1167Suffix...)
+				mbc.On("GetCode", mock.Anything, proxyAddress.String()).Return(code, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader, mockExplorer, _ := setupProxyTestLoader(tt.setup)
+
+			mockExplorer.On("GetContract", mock.Anything, matchers.AddressMatcher(testProxyAddress)).
+				Return(&blockexplorer.ContractInfo{ABI: testProxyABI}, nil)
+			mockExplorer.On("GetContract", mock.Anything, matchers.AddressMatcher(testImplementationAddress)).
+				Return(&blockexplorer.ContractInfo{ABI: testImplementationABI}, nil)
+
+			result, err := loader.LoadABIByAddress(ctx, *proxyAddress)
+			require.NoError(t, err)
+			assert.Equal(t, testImplementationABI, result)
+
+			cached, found := loader.abiCache.Load(proxyAddress.String())
+			require.True(t, found)
+			assert.Equal(t, testImplementationABI, cached)
+		})
+	}
+
+	t.Run("Unverified proxy resolved through storage", func(t *testing.T) {
+		loader, mockExplorer, _ := setupProxyTestLoader(func(mbc *mocks.MockBlockchainClient) {
+			mbc.On("GetStorageAt", mock.Anything, proxyAddress.String(), eip1967ImplementationSlot).
+				Return(addressWord(testImplementationAddress), nil)
+		})
+
+		mockExplorer.On("GetContract", mock.Anything, matchers.AddressMatcher(testProxyAddress)).
+			Return(&blockexplorer.ContractInfo{ABI: ""}, nil)
+		mockExplorer.On("GetContract", mock.Anything, matchers.AddressMatcher(testImplementationAddress)).
+			Return(&blockexplorer.ContractInfo{ABI: testImplementationABI}, nil)
+
+		result, err := loader.LoadABIByAddress(ctx, *proxyAddress)
+		require.NoError(t, err)
+		assert.Equal(t, testImplementationABI, result)
+	})
+
+	t.Run("Storage read errors fall back to direct ABI", func(t *testing.T) {
+		loader, mockExplorer, _ := setupProxyTestLoader(func(mbc *mocks.MockBlockchainClient) {
+			mbc.On("GetStorageAt", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil, coreerrors.NewRPCError(assert.AnError))
+			mbc.On("GetCode", mock.Anything, mock.Anything).
+				Return(nil, coreerrors.NewRPCError(assert.AnError))
+		})
+
+		mockExplorer.On("GetContract", mock.Anything, matchers.AddressMatcher(testProxyAddress)).
+			Return(&blockexplorer.ContractInfo{ABI: testProxyABI}, nil)
+
+		result, err := loader.LoadABIByAddress(ctx, *proxyAddress)
+		require.NoError(t, err)
+		assert.Equal(t, testProxyABI, result)
+	})
+}
@@ -0,0 +1,196 @@
+package abi
+
+import (
+	"bufio"
+	_ "embed"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"vault0/internal/config"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+)
+
+//go:embed resources/signatures.txt
+var embeddedSignatures string
+
+// SignatureDB is an offline database of text signatures keyed by 4-byte function
+// selector and 32-byte event topic. It is used to decode calls and events of
+// contracts whose ABI cannot be resolved.
+type SignatureDB interface {
+	// LookupFunction returns the known signatures for a 0x-prefixed 4-byte selector.
+	// Several signatures may share a selector; they are returned in import order.
+	LookupFunction(selector string) []string
+
+	// LookupEvent returns the known signatures for a 0x-prefixed 32-byte event topic.
+	LookupEvent(topic string) []string
+
+	// Import adds signatures read in the 4byte text format: one signature per
+	// line, optionally preceded by its 0x-prefixed selector or event topic.
+	// Lines that are empty, comments ('#') or invalid are skipped.
+	// Returns the number of new signatures added.
+	Import(r io.Reader) (int, error)
+
+	// ImportFile imports signatures from a local file in the 4byte text format.
+	ImportFile(path string) (int, error)
+}
+
+// signatureDB is an in-memory SignatureDB seeded with the embedded signatures.
+type signatureDB struct {
+	log       logger.Logger
+	mu        sync.RWMutex
+	functions map[string][]string // 4-byte selector -> signatures
+	events    map[string][]string // event topic -> signatures
+}
+
+// NewSignatureDB creates a signature database holding the embedded signatures
+// and, if configured, the signatures of the file at cfg.SignaturesPath.
+func NewSignatureDB(cfg *config.Config, log logger.Logger) (SignatureDB, error) {
+	db := &signatureDB{
+		log:       log,
+		functions: make(map[string][]string),
+		events:    make(map[string][]string),
+	}
+
+	if _, err := db.Import(strings.NewReader(embeddedSignatures)); err != nil {
+		return nil, errors.NewSignatureImportError("embedded", err)
+	}
+
+	if cfg != nil && cfg.SignaturesPath != "" {
+		count, err := db.ImportFile(cfg.SignaturesPath)
+		if err != nil {
+			return nil, err
+		}
+		log.Info("Imported signature database",
+			logger.String("path", cfg.SignaturesPath),
+			logger.Int("signatures", count))
+	}
+
+	return db, nil
+}
+
+// LookupFunction implements SignatureDB.
+func (db *signatureDB) LookupFunction(selector string) []string {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return append([]string(nil), db.functions[strings.ToLower(selector)]...)
+}
+
+// LookupEvent implements SignatureDB.
+func (db *signatureDB) LookupEvent(topic string) []string {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return append([]string(nil), db.events[strings.ToLower(topic)]...)
+}
+
+// ImportFile implements SignatureDB.
+func (db *signatureDB) ImportFile(path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, errors.NewSignatureImportError(path, err)
+	}
+	defer file.Close()
+
+	count, err := db.Import(file)
+	if err != nil {
+		return count, errors.NewSignatureImportError(path, err)
+	}
+
+	return count, nil
+}
+
+// Import implements SignatureDB.
+func (db *signatureDB) Import(r io.Reader) (int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	count := 0
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		added, valid := db.importLine(line)
+		if !valid {
+			db.log.Debug("Skipping invalid signature line", logger.String("line", line))
+		}
+		count += added
+	}
+
+	if err := scanner.Err(); err != nil {
+		return count, err
+	}
+
+	return count, nil
+}
+
+// importLine registers the signature of a single line, verifying that an
+// explicit selector or topic matches the signature hash. Returns the number of
+// new entries and whether the line was valid.
+func (db *signatureDB) importLine(line string) (int, bool) {
+	hash, signature := "", line
+	if strings.HasPrefix(line, "0x") {
+		separator := strings.IndexAny(line, " \t,:")
+		if separator < 0 {
+			return 0, false
+		}
+		hash, signature = strings.ToLower(line[:separator]), strings.TrimLeft(line[separator:], " \t,:")
+	}
+
+	signature = strings.ReplaceAll(signature, " ", "")
+	if _, _, err := parseSignature(signature); err != nil {
+		return 0, false
+	}
+
+	topic := crypto.Keccak256Hash([]byte(signature))
+	selector := hexutil.Encode(topic[:4])
+
+	switch {
+	case hash == "":
+		added := db.add(db.functions, selector, signature)
+		if db.add(db.events, topic.Hex(), signature) {
+			added = true
+		}
+		return boolToInt(added), true
+	case hash == selector:
+		return boolToInt(db.add(db.functions, selector, signature)), true
+	case hash == topic.Hex():
+		return boolToInt(db.add(db.events, topic.Hex(), signature)), true
+	default:
+		return 0, false // Hash doesn't match the signature
+	}
+}
+
+// add registers a signature under a key, returning false for duplicates
+func (db *signatureDB) add(index map[string][]string, key, signature string) bool {
+	for _, existing := range index[key] {
+		if existing == signature {
+			return false
+		}
+	}
+	index[key] = append(index[key], signature)
+	return true
+}
+
+// boolToInt returns 1 for true and 0 for false
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// topicHex formats a log topic consistently with the database keys
+func topicHex(topic string) string {
+	return common.HexToHash(topic).Hex()
+}
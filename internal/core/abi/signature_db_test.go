@@ -0,0 +1,116 @@
+package abi
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/config"
+	"vault0/internal/errors"
+	"vault0/internal/testing/mocks"
+)
+
+const transferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+func TestSignatureDB_Embedded(t *testing.T) {
+	db, err := NewSignatureDB(&config.Config{}, mocks.NewNopLogger())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"transfer(address,uint256)"}, db.LookupFunction("0xa9059cbb"))
+	assert.Equal(t, []string{"transfer(address,uint256)"}, db.LookupFunction("0xA9059CBB"))
+	assert.Equal(t, []string{"Transfer(address,address,uint256)"}, db.LookupEvent(transferTopic))
+	assert.Empty(t, db.LookupFunction("0xdeadbeef"))
+}
+
+func TestSignatureDB_Import(t *testing.T) {
+	db := &signatureDB{
+		log:       mocks.NewNopLogger(),
+		functions: make(map[string][]string),
+		events:    make(map[string][]string),
+	}
+
+	input := strings.Join([]string{
+		"# comment",
+		"",
+		"0xa9059cbb transfer(address,uint256)",
+		"0x095ea7b3,approve(address,uint256)",
+		"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef\tTransfer(address,address,uint256)",
+		"swap((address,uint256)[],bytes)",
+		"0x12345678 transfer(address,uint256)", // Selector mismatch
+		"notASignature",
+		"broken((address)",
+		"0xa9059cbb transfer(address,uint256)", // Duplicate
+	}, "\n")
+
+	count, err := db.Import(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+
+	assert.Equal(t, []string{"transfer(address,uint256)"}, db.LookupFunction("0xa9059cbb"))
+	assert.Equal(t, []string{"approve(address,uint256)"}, db.LookupFunction("0x095ea7b3"))
+	assert.Equal(t, []string{"Transfer(address,address,uint256)"}, db.LookupEvent(transferTopic))
+	assert.Empty(t, db.LookupEvent("0xa9059cbb"), "functions with selectors are not registered as events")
+
+	// Bare signatures are registered as both functions and events
+	assert.Len(t, db.functions, 3)
+	assert.Len(t, db.events, 2)
+}
+
+func TestSignatureDB_ImportFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "signatures.txt")
+	require.NoError(t, os.WriteFile(path, []byte("0xdeadbeef\n0x2e1a7d4d withdraw(uint256)\nfoo(uint256)\n"), 0600))
+
+	db, err := NewSignatureDB(&config.Config{SignaturesPath: path}, mocks.NewNopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"withdraw(uint256)"}, db.LookupFunction("0x2e1a7d4d"))
+	assert.NotEmpty(t, db.LookupFunction("0xa9059cbb"), "embedded signatures are kept")
+
+	count, err := db.ImportFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count, "already imported signatures are not added again")
+
+	_, err = NewSignatureDB(&config.Config{SignaturesPath: filepath.Join(t.TempDir(), "missing.txt")}, mocks.NewNopLogger())
+	require.Error(t, err)
+	assert.True(t, errors.IsError(err, errors.ErrCodeSignatureImportFailed))
+}
+
+func TestParseSignature(t *testing.T) {
+	tests := []struct {
+		signature string
+		name      string
+		types     []string
+		wantErr   bool
+	}{
+		{signature: "pause()", name: "pause", types: []string{}},
+		{signature: "transfer(address,uint256)", name: "transfer", types: []string{"address", "uint256"}},
+		{signature: "aggregate((address,bytes)[])", name: "aggregate", types: []string{"(address,bytes)[]"}},
+		{signature: "f((uint8,(bool,string)),bytes32[2])", name: "f", types: []string{"(uint8,(bool,string))", "bytes32[2]"}},
+		{signature: "noParens", wantErr: true},
+		{signature: "(address)", wantErr: true},
+		{signature: "f(address,)", wantErr: true},
+		{signature: "f(foo)", wantErr: true},
+		{signature: "f((address)", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.signature, func(t *testing.T) {
+			name, arguments, err := parseSignature(tt.signature)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.name, name)
+			typeNames := make([]string, 0, len(arguments))
+			for _, argument := range arguments {
+				typeNames = append(typeNames, argument.Type.String())
+			}
+			assert.Equal(t, tt.types, typeNames)
+		})
+	}
+}
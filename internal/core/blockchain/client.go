@@ -139,6 +139,30 @@ type BlockchainClient interface {
 	//   - Error if the call fails or reverts
 	CallContract(ctx context.Context, from string, to string, data []byte) ([]byte, error)
 
+	// GetStorageAt reads a raw storage slot of a contract at the latest block.
+	// This is used to inspect well-known slots such as those defined by proxy standards.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation, can be used for cancellation
+	//   - address: Contract address whose storage is read
+	//   - slot: Storage slot as a 32-byte hexadecimal string
+	//
+	// Returns:
+	//   - The 32-byte value stored in the slot
+	//   - Error if the storage cannot be read
+	GetStorageAt(ctx context.Context, address string, slot string) ([]byte, error)
+
+	// GetCode retrieves the deployed bytecode of a contract at the latest block.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation, can be used for cancellation
+	//   - address: Contract address
+	//
+	// Returns:
+	//   - Runtime bytecode of the contract (empty for externally owned accounts)
+	//   - Error if the code cannot be retrieved
+	GetCode(ctx context.Context, address string) ([]byte, error)
+
 	// FilterContractLogs retrieves historical logs matching the filter criteria.
 	// Logs are events emitted by smart contracts during transaction execution.
 	//
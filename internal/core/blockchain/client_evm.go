@@ -61,6 +61,8 @@ type EthereumClient interface {
 	EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error)
 	SuggestGasPrice(ctx context.Context) (*big.Int, error)
 	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error)
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
 	SendTransaction(ctx context.Context, tx *ethTypes.Transaction) error
 	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]ethTypes.Log, error)
 	BlockNumber(ctx context.Context) (uint64, error)
@@ -385,6 +387,39 @@ func (c *EVMClient) CallContract(ctx context.Context, from string, to string, da
 	return result, nil
 }
 
+// GetStorageAt implements Blockchain.GetStorageAt
+func (c *EVMClient) GetStorageAt(ctx context.Context, address string, slot string) ([]byte, error) {
+	if err := c.chain.ValidateAddress(address); err != nil {
+		return nil, err
+	}
+
+	slotBytes := common.FromHex(slot)
+	if len(slotBytes) == 0 || len(slotBytes) > common.HashLength {
+		return nil, errors.NewInvalidParameterError("slot", "must be a hex string of at most 32 bytes")
+	}
+
+	value, err := c.client.StorageAt(ctx, common.HexToAddress(address), common.BytesToHash(slotBytes), nil) // Use nil for latest block
+	if err != nil {
+		return nil, errors.NewRPCError(err)
+	}
+
+	return value, nil
+}
+
+// GetCode implements Blockchain.GetCode
+func (c *EVMClient) GetCode(ctx context.Context, address string) ([]byte, error) {
+	if err := c.chain.ValidateAddress(address); err != nil {
+		return nil, err
+	}
+
+	code, err := c.client.CodeAt(ctx, common.HexToAddress(address), nil) // Use nil for latest block
+	if err != nil {
+		return nil, errors.NewRPCError(err)
+	}
+
+	return code, nil
+}
+
 // BroadcastTransaction implements Blockchain.BroadcastTransaction
 func (c *EVMClient) BroadcastTransaction(ctx context.Context, signedTx []byte) (string, error) {
 	var tx ethTypes.Transaction
@@ -3,6 +3,7 @@ package blockchain
 import (
 	"context"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum"
@@ -206,6 +207,64 @@ func TestEVMClient_GetGasPrice(t *testing.T) {
 	mockEth.AssertExpectations(t)
 }
 
+// TestEVMClient_GetStorageAt tests the GetStorageAt method
+func TestEVMClient_GetStorageAt(t *testing.T) {
+	ctx := context.Background()
+
+	// Test address, slot and stored value
+	testAddress := "0x71C7656EC7ab88b098defB751B7401B5f6d8976F"
+	testSlot := "0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc"
+	expectedValue := common.LeftPadBytes(common.HexToAddress("0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984").Bytes(), 32)
+
+	// Create client with mocks
+	client, mockEth := createTestEVMClient(t)
+
+	// Set up the mock to return the expected value
+	mockEth.On("StorageAt", ctx, common.HexToAddress(testAddress), common.HexToHash(testSlot), (*big.Int)(nil)).Return(expectedValue, nil)
+
+	// Test valid address and slot
+	value, err := client.GetStorageAt(ctx, testAddress, testSlot)
+	require.NoError(t, err)
+	assert.Equal(t, expectedValue, value)
+
+	// Test invalid slot
+	value, err = client.GetStorageAt(ctx, testAddress, "0x"+strings.Repeat("ab", 33))
+	assert.Error(t, err)
+	assert.Nil(t, value)
+	assert.True(t, errors.IsError(err, errors.ErrCodeInvalidParameter))
+
+	mockEth.AssertExpectations(t)
+}
+
+// TestEVMClient_GetCode tests the GetCode method
+func TestEVMClient_GetCode(t *testing.T) {
+	ctx := context.Background()
+
+	// Test address and deployed code
+	testAddress := "0x71C7656EC7ab88b098defB751B7401B5f6d8976F"
+	expectedCode := []byte{0x60, 0x80, 0x60, 0x40}
+
+	// Create client with mocks
+	client, mockEth := createTestEVMClient(t)
+
+	// Set up the mock to return the expected value
+	mockEth.On("CodeAt", ctx, common.HexToAddress(testAddress), (*big.Int)(nil)).Return(expectedCode, nil).Once()
+
+	// Test success case
+	code, err := client.GetCode(ctx, testAddress)
+	require.NoError(t, err)
+	assert.Equal(t, expectedCode, code)
+
+	// Test RPC error
+	mockEth.On("CodeAt", ctx, common.HexToAddress(testAddress), (*big.Int)(nil)).Return(nil, ethereum.NotFound).Once()
+	code, err = client.GetCode(ctx, testAddress)
+	assert.Error(t, err)
+	assert.Nil(t, code)
+	assert.True(t, errors.IsError(err, errors.ErrCodeRPCError), "Error should be an RPCError")
+
+	mockEth.AssertExpectations(t)
+}
+
 // TestEVMClient_GetTransaction tests the GetTransaction method
 func TestEVMClient_GetTransaction(t *testing.T) {
 	ctx := context.Background()
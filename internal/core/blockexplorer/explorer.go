@@ -14,12 +14,13 @@ import (
 // supported blockchain.
 type BlockExplorer interface {
 	// GetTransactionHistory retrieves the transaction history for a given address with pagination.
-	// It supports filtering by transaction type (normal, internal, ERC20, ERC721) and block range.
+	// It supports filtering by transaction type (normal, internal, ERC20, ERC721, ERC1155) and block range.
 	// The `any` type in the returned `types.Page[any]` will be one of the following based on `options.TransactionType`:
 	//  - TxTypeNormal:   *NormalTxHistoryEntry
 	//  - TxTypeInternal: *InternalTxHistoryEntry
 	//  - TxTypeERC20:    *ERC20TxHistoryEntry
 	//  - TxTypeERC721:   *ERC721TxHistoryEntry
+	//  - TxTypeERC1155:  *ERC1155TxHistoryEntry
 	GetTransactionHistory(ctx context.Context, address string, options TransactionHistoryOptions, nextToken string) (*types.Page[types.CoreTransaction], error)
 
 	// GetTransactionByHash retrieves detailed information about a specific transaction
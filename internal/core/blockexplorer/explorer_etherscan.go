@@ -604,6 +604,7 @@ func (e *EtherscanExplorer) getERC721TransactionHistory(ctx context.Context, add
 		_ = metadata.Set(types.ERC721TokenSymbolMetadataKey, tx.TokenSymbol)
 		_ = metadata.Set(types.ERC721TokenNameMetadataKey, tx.TokenName)
 		_ = metadata.Set(types.ERC721TokenIDMetadataKey, tokenID.String()) // Store as string, consistent with ERC20 amount
+		_ = metadata.Set(types.ERC721SenderMetadataKey, normalizedFrom)
 		_ = metadata.Set(types.ERC721RecipientMetadataKey, normalizedTo)
 
 		baseTx := types.BaseTransaction{
@@ -629,11 +630,12 @@ func (e *EtherscanExplorer) getERC721TransactionHistory(ctx context.Context, add
 		}
 
 		erc721Entry := &ERC721TxHistoryEntry{
-			Transaction:  txEntry,
-			TokenAddress: tx.ContractAddress,
-			TokenSymbol:  tx.TokenSymbol,
-			TokenName:    tx.TokenName,
-			TokenID:      tokenID,
+			Transaction:    txEntry,
+			TokenAddress:   normalizedContractAddress,
+			TokenSymbol:    tx.TokenSymbol,
+			TokenName:      tx.TokenName,
+			TokenRecipient: normalizedTo,
+			TokenID:        tokenID,
 		}
 		result = append(result, erc721Entry)
 	}
@@ -641,6 +643,105 @@ func (e *EtherscanExplorer) getERC721TransactionHistory(ctx context.Context, add
 	return result, nil
 }
 
+// getERC1155TransactionHistory fetches ERC1155 token transfers for an address
+func (e *EtherscanExplorer) getERC1155TransactionHistory(ctx context.Context, address string, options TransactionHistoryOptions, page, limit int) ([]*ERC1155TxHistoryEntry, error) {
+	params := url.Values{}
+	// Request limit+1 items to determine if there's a next page
+	// Use "token1155tx" action for ERC1155
+	e.setTransactionHistoryParams(params, address, options, "token1155tx", page, limit+1)
+
+	data, err := e.MakeRequest(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var txs []struct {
+		Hash            string `json:"hash"`
+		From            string `json:"from"`
+		To              string `json:"to"` // Recipient of the tokens
+		Gas             string `json:"gas"`
+		GasPrice        string `json:"gasPrice"`
+		GasUsed         string `json:"gasUsed"`
+		Nonce           string `json:"nonce"`
+		BlockNumber     string `json:"blockNumber"`
+		Timestamp       string `json:"timeStamp"`
+		ContractAddress string `json:"contractAddress"` // ERC1155 contract address
+		TokenName       string `json:"tokenName"`
+		TokenSymbol     string `json:"tokenSymbol"`
+		TokenID         string `json:"tokenID"`
+		TokenValue      string `json:"tokenValue"`
+	}
+
+	if err := json.Unmarshal(data, &txs); err != nil {
+		return nil, errors.NewInvalidExplorerResponseError(err, string(data))
+	}
+
+	result := make([]*ERC1155TxHistoryEntry, 0, len(txs))
+	for _, tx := range txs {
+		blockNumber := new(big.Int)
+		blockNumber.SetString(tx.BlockNumber, 10)
+		gasLimit, _ := strconv.ParseUint(tx.Gas, 10, 64)
+		gasPrice := new(big.Int)
+		gasPrice.SetString(tx.GasPrice, 10)
+		gasUsed, _ := strconv.ParseUint(tx.GasUsed, 10, 64)
+		nonce, _ := strconv.ParseUint(tx.Nonce, 10, 64)
+		tokenID := new(big.Int)
+		tokenID.SetString(tx.TokenID, 10)
+		tokenAmount := new(big.Int)
+		tokenAmount.SetString(tx.TokenValue, 10)
+		timestamp, _ := strconv.ParseInt(tx.Timestamp, 10, 64)
+
+		// Normalize addresses
+		normalizedFrom := types.NormalizeAddress(e.chain.Type, tx.From)
+		normalizedTo := types.NormalizeAddress(e.chain.Type, tx.To)
+		normalizedContractAddress := types.NormalizeAddress(e.chain.Type, tx.ContractAddress)
+
+		metadata := types.TxMetadata{}
+		_ = metadata.Set(types.ERC1155TokenAddressMetadataKey, normalizedContractAddress)
+		_ = metadata.Set(types.ERC1155TokenSymbolMetadataKey, tx.TokenSymbol)
+		_ = metadata.Set(types.ERC1155TokenNameMetadataKey, tx.TokenName)
+		_ = metadata.Set(types.ERC1155SenderMetadataKey, normalizedFrom)
+		_ = metadata.Set(types.ERC1155RecipientMetadataKey, normalizedTo)
+		_ = metadata.SetJSON(types.ERC1155TokenIDsMetadataKey, []string{tokenID.String()})
+		_ = metadata.SetJSON(types.ERC1155AmountsMetadataKey, []string{tokenAmount.String()})
+
+		baseTx := types.BaseTransaction{
+			ChainType: e.chain.Type,
+			Hash:      tx.Hash,
+			From:      normalizedFrom,
+			To:        normalizedContractAddress, // Tx interacts with the token contract
+			Value:     big.NewInt(0),             // Native value likely 0
+			Data:      nil,                       // Not provided
+			Nonce:     nonce,
+			GasPrice:  gasPrice,
+			GasLimit:  gasLimit,
+			Type:      types.TransactionTypeERC1155Transfer,
+		}
+
+		txEntry := types.Transaction{
+			BaseTransaction: baseTx,
+			Status:          types.TransactionStatusSuccess,
+			Timestamp:       timestamp,
+			BlockNumber:     blockNumber,
+			Metadata:        metadata,
+			GasUsed:         gasUsed,
+		}
+
+		erc1155Entry := &ERC1155TxHistoryEntry{
+			Transaction:    txEntry,
+			TokenAddress:   normalizedContractAddress,
+			TokenSymbol:    tx.TokenSymbol,
+			TokenName:      tx.TokenName,
+			TokenRecipient: normalizedTo,
+			TokenID:        tokenID,
+			TokenAmount:    tokenAmount,
+		}
+		result = append(result, erc1155Entry)
+	}
+
+	return result, nil
+}
+
 // GetTransactionHistory retrieves transaction history for an address with pagination
 func (e *EtherscanExplorer) GetTransactionHistory(ctx context.Context, address string, options TransactionHistoryOptions, nextToken string) (*types.Page[types.CoreTransaction], error) {
 	if !e.chain.IsValidAddress(address) {
@@ -716,6 +817,17 @@ func (e *EtherscanExplorer) GetTransactionHistory(ctx context.Context, address s
 		} else {
 			fetchErr = err
 		}
+	case TxTypeERC1155:
+		erc1155Txs, err := e.getERC1155TransactionHistory(ctx, address, options, currentPage, limit)
+		if err == nil {
+			fetchedItems = make([]types.CoreTransaction, len(erc1155Txs))
+			for i, tx := range erc1155Txs {
+				fetchedItems[i] = tx
+			}
+			itemLength = len(erc1155Txs)
+		} else {
+			fetchErr = err
+		}
 	default:
 		return nil, errors.NewExplorerError(fmt.Errorf("unsupported transaction type: %s", txType))
 	}
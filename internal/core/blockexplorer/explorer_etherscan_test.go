@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"net/http"
 	"net/url"
 	"strings"
@@ -493,6 +494,75 @@ func TestGetNormalTransactionHistory(t *testing.T) {
 	}
 }
 
+// TestGetERC1155TransactionHistory tests fetching ERC1155 transfers through GetTransactionHistory
+func TestGetERC1155TransactionHistory(t *testing.T) {
+	testAddress := "0x1234567890abcdef1234567890abcdef12345678"
+	responseBody := `{
+		"status": "1",
+		"message": "OK",
+		"result": [{
+			"hash": "0xabcdef1234567890abcdef1234567890abcdef1234567890abcdef1234567890",
+			"from": "0x0987654321fedcba0987654321fedcba09876543",
+			"to": "0x1234567890abcdef1234567890abcdef12345678",
+			"gas": "90000",
+			"gasPrice": "20000000000",
+			"gasUsed": "60000",
+			"nonce": "7",
+			"blockNumber": "1000001",
+			"timeStamp": "1600000100",
+			"contractAddress": "0x76be3b62873462d2142405439777e971754e8e77",
+			"tokenName": "parallel",
+			"tokenSymbol": "LL",
+			"tokenID": "10144",
+			"tokenValue": "3"
+		}]
+	}`
+
+	explorer := blockexplorer.NewEtherscanExplorer(
+		types.Chain{Type: types.ChainTypeEthereum},
+		"https://api.etherscan.io/api",
+		"https://etherscan.io",
+		"TEST_API_KEY",
+		mocks.NewNopLogger(),
+	)
+
+	mockTransport := &mockRoundTripper{
+		responses: map[string]struct {
+			resp *http.Response
+			err  error
+		}{
+			"action=token1155tx": {
+				resp: &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       io.NopCloser(strings.NewReader(responseBody)),
+				},
+			},
+		},
+	}
+	explorer.(*blockexplorer.EtherscanExplorer).HTTPClient = &http.Client{Transport: mockTransport}
+
+	options := blockexplorer.TransactionHistoryOptions{
+		TransactionType: blockexplorer.TxTypeERC1155,
+		Limit:           10,
+	}
+	result, err := explorer.GetTransactionHistory(context.Background(), testAddress, options, "")
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+
+	entry, ok := result.Items[0].(*blockexplorer.ERC1155TxHistoryEntry)
+	require.True(t, ok)
+	assert.Equal(t, types.TransactionTypeERC1155Transfer, entry.GetType())
+	assert.Equal(t, "0x76BE3b62873462d2142405439777e971754E8E77", entry.TokenAddress)
+	assert.Equal(t, big.NewInt(10144), entry.TokenID)
+	assert.Equal(t, big.NewInt(3), entry.TokenAmount)
+	assert.Equal(t, "0x1234567890AbcdEF1234567890aBcdef12345678", entry.TokenRecipient)
+
+	recipient, _ := entry.Metadata.GetString(types.ERC1155RecipientMetadataKey)
+	assert.Equal(t, entry.TokenRecipient, recipient)
+	assert.Equal(t, `["10144"]`, entry.Metadata[types.ERC1155TokenIDsMetadataKey])
+	assert.Equal(t, `["3"]`, entry.Metadata[types.ERC1155AmountsMetadataKey])
+}
+
 // TestMakeRequestSuccess tests the MakeRequest method with successful responses
 func TestMakeRequestSuccess(t *testing.T) {
 	// Setup test cases
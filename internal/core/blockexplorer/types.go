@@ -39,6 +39,9 @@ const (
 	// TxTypeERC721 represents NFT transfer transactions
 	// These track the movement of NFTs (ERC721 tokens) between addresses
 	TxTypeERC721 TransactionType = "erc721"
+	// TxTypeERC1155 represents multi-token transfer transactions
+	// These track the movement of ERC1155 tokens between addresses
+	TxTypeERC1155 TransactionType = "erc1155"
 )
 
 // TransactionHistoryOptions contains parameters for filtering and paginating
@@ -95,3 +98,14 @@ type ERC721TxHistoryEntry struct {
 	TokenRecipient string
 	TokenID        *big.Int
 }
+
+// ERC1155TxHistoryEntry represents an ERC1155 token transfer event from history.
+type ERC1155TxHistoryEntry struct {
+	types.Transaction
+	TokenAddress   string
+	TokenSymbol    string
+	TokenName      string
+	TokenRecipient string
+	TokenID        *big.Int
+	TokenAmount    *big.Int
+}
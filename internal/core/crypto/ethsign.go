@@ -0,0 +1,85 @@
+package crypto
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"vault0/internal/errors"
+)
+
+// EthSignatureLength is the length of an Ethereum r||s||v signature
+const EthSignatureLength = 65
+
+// HashPersonalMessage returns the EIP-191 (version 0x45) digest of a message, as
+// used by personal_sign: keccak256("\x19Ethereum Signed Message:\n" || len(message) || message)
+func HashPersonalMessage(message []byte) []byte {
+	return accounts.TextHash(message)
+}
+
+// HashTypedData returns the EIP-712 digest of a typed data JSON document with
+// the "types", "primaryType", "domain" and "message" fields:
+// keccak256("\x19\x01" || domainSeparator || hashStruct(message))
+func HashTypedData(typedDataJSON []byte) ([]byte, error) {
+	var typedData apitypes.TypedData
+	if err := json.Unmarshal(typedDataJSON, &typedData); err != nil {
+		return nil, errors.NewInvalidTypedDataError(err)
+	}
+	return hashTypedData(typedData)
+}
+
+// HashTypedDataForChain returns the EIP-712 digest of a typed data JSON document
+// signed on the given chain. Documents whose domain names another chain ID are
+// rejected, as their signature would be replayable there.
+func HashTypedDataForChain(typedDataJSON []byte, chainID int64) ([]byte, error) {
+	var typedData apitypes.TypedData
+	if err := json.Unmarshal(typedDataJSON, &typedData); err != nil {
+		return nil, errors.NewInvalidTypedDataError(err)
+	}
+
+	if domainChainID := typedData.Domain.ChainId; domainChainID != nil {
+		if (*big.Int)(domainChainID).Cmp(big.NewInt(chainID)) != 0 {
+			return nil, errors.NewInvalidInputError(
+				fmt.Sprintf("Typed data domain chain ID does not match the wallet chain ID %d", chainID),
+				"domain.chainId", (*big.Int)(domainChainID).String())
+		}
+	}
+	return hashTypedData(typedData)
+}
+
+// hashTypedData returns the EIP-712 digest of a decoded typed data document
+func hashTypedData(typedData apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, errors.NewInvalidTypedDataError(err)
+	}
+	return hash, nil
+}
+
+// RecoverEthAddress recovers the checksummed Ethereum address that produced a
+// 65-byte r||s||v signature over the digest. Both v in {0, 1} and {27, 28} are accepted.
+func RecoverEthAddress(digest, signature []byte) (string, error) {
+	if len(signature) != EthSignatureLength {
+		return "", errors.NewInvalidSignatureError(fmt.Errorf("expected %d bytes, got %d", EthSignatureLength, len(signature)))
+	}
+
+	sig := make([]byte, EthSignatureLength)
+	copy(sig, signature)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+	if sig[64] > 1 {
+		return "", errors.NewInvalidSignatureError(fmt.Errorf("invalid recovery id: %d", signature[64]))
+	}
+
+	publicKey, err := ethCrypto.SigToPub(digest, sig)
+	if err != nil {
+		return "", errors.NewSignatureRecoveryError(err)
+	}
+
+	return ethCrypto.PubkeyToAddress(*publicKey).Hex(), nil
+}
@@ -0,0 +1,130 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"testing"
+
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/errors"
+)
+
+// testTypedData is the "Mail" example from the EIP-712 specification
+const testTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func TestHashPersonalMessage(t *testing.T) {
+	hash := HashPersonalMessage([]byte("Hello World"))
+	assert.Equal(t, "a1de988600a42c4b4ab089b619297c17d53cffae5d5120d82d8a92d0bb3b78f2", hex.EncodeToString(hash))
+}
+
+func TestHashTypedData(t *testing.T) {
+	t.Run("Specification example", func(t *testing.T) {
+		hash, err := HashTypedData([]byte(testTypedData))
+		require.NoError(t, err)
+		assert.Equal(t, "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hex.EncodeToString(hash))
+	})
+
+	t.Run("Malformed JSON", func(t *testing.T) {
+		_, err := HashTypedData([]byte(`{"types":`))
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidTypedData))
+	})
+
+	t.Run("Unknown primary type", func(t *testing.T) {
+		_, err := HashTypedData([]byte(`{"types": {"EIP712Domain": []}, "primaryType": "Missing", "domain": {}, "message": {}}`))
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidTypedData))
+	})
+}
+
+func TestHashTypedDataForChain(t *testing.T) {
+	t.Run("Matching chain ID", func(t *testing.T) {
+		hash, err := HashTypedDataForChain([]byte(testTypedData), 1)
+		require.NoError(t, err)
+		assert.Equal(t, "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hex.EncodeToString(hash))
+	})
+
+	t.Run("Other chain ID", func(t *testing.T) {
+		_, err := HashTypedDataForChain([]byte(testTypedData), 137)
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidInput))
+	})
+
+	t.Run("No chain ID", func(t *testing.T) {
+		_, err := HashTypedDataForChain([]byte(`{
+			"types": {"EIP712Domain": [{"name": "name", "type": "string"}], "Login": [{"name": "nonce", "type": "uint256"}]},
+			"primaryType": "Login",
+			"domain": {"name": "vault0"},
+			"message": {"nonce": 42}
+		}`), 137)
+		require.NoError(t, err)
+	})
+}
+
+func TestRecoverEthAddress(t *testing.T) {
+	// Private key of "Cow" from the EIP-712 specification
+	privateKey := ethCrypto.Keccak256([]byte("cow"))
+	key, err := ethCrypto.ToECDSA(privateKey)
+	require.NoError(t, err)
+
+	digest, err := HashTypedData([]byte(testTypedData))
+	require.NoError(t, err)
+
+	signature, err := ethCrypto.Sign(digest, key)
+	require.NoError(t, err)
+
+	t.Run("Recovery id 0/1", func(t *testing.T) {
+		address, err := RecoverEthAddress(digest, signature)
+		require.NoError(t, err)
+		assert.Equal(t, "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", address)
+	})
+
+	t.Run("Recovery id 27/28", func(t *testing.T) {
+		sig := append([]byte{}, signature...)
+		sig[64] += 27
+		address, err := RecoverEthAddress(digest, sig)
+		require.NoError(t, err)
+		assert.Equal(t, "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", address)
+	})
+
+	t.Run("Invalid length", func(t *testing.T) {
+		_, err := RecoverEthAddress(digest, signature[:64])
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidSignature))
+	})
+
+	t.Run("Invalid recovery id", func(t *testing.T) {
+		sig := append([]byte{}, signature...)
+		sig[64] = 5
+		_, err := RecoverEthAddress(digest, sig)
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidSignature))
+	})
+}
@@ -0,0 +1,340 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+
+	"vault0/internal/errors"
+)
+
+// KDF identifies the key derivation function used to protect a V3 keystore
+type KDF string
+
+const (
+	// KDFScrypt derives the encryption key using scrypt (default for geth and MetaMask)
+	KDFScrypt KDF = "scrypt"
+	// KDFPBKDF2 derives the encryption key using PBKDF2 with HMAC-SHA256
+	KDFPBKDF2 KDF = "pbkdf2"
+)
+
+const (
+	keystoreV3Version = 3
+	keystoreV3Cipher  = "aes-128-ctr"
+	keystoreV3DKLen   = 32
+
+	// Standard parameters used by geth when writing keystore files
+	scryptStandardN  = 1 << 18
+	scryptStandardR  = 8
+	scryptStandardP  = 1
+	pbkdf2Iterations = 262144
+	pbkdf2PRF        = "hmac-sha256"
+
+	// Upper bounds of the parameters accepted from imported keystores, which would
+	// otherwise let a single file use gigabytes of memory or minutes of CPU
+	scryptMaxN          = 1 << 20
+	scryptMaxRP         = 16        // Bounds the work factor r*p
+	scryptMaxMemory     = 256 << 20 // Bounds the memory used, 128*n*r bytes
+	pbkdf2MaxIterations = 4_000_000
+)
+
+// KeystoreV3 is the Web3 Secret Storage (version 3) JSON document
+type KeystoreV3 struct {
+	Address string           `json:"address,omitempty"`
+	Crypto  KeystoreV3Crypto `json:"crypto"`
+	ID      string           `json:"id"`
+	Version int              `json:"version"`
+}
+
+// KeystoreV3Crypto holds the cipher and key derivation parameters of a V3 keystore
+type KeystoreV3Crypto struct {
+	Cipher       string                 `json:"cipher"`
+	CipherText   string                 `json:"ciphertext"`
+	CipherParams KeystoreV3CipherParams `json:"cipherparams"`
+	KDF          KDF                    `json:"kdf"`
+	KDFParams    map[string]any         `json:"kdfparams"`
+	MAC          string                 `json:"mac"`
+}
+
+// KeystoreV3CipherParams holds the AES-CTR initialization vector
+type KeystoreV3CipherParams struct {
+	IV string `json:"iv"`
+}
+
+// kdfParams contains the tunable parameters of the supported key derivation functions
+type kdfParams struct {
+	kdf        KDF
+	scryptN    int
+	scryptR    int
+	scryptP    int
+	iterations int
+}
+
+// defaultKDFParams returns the standard parameters for the given KDF
+func defaultKDFParams(kdf KDF) (kdfParams, error) {
+	switch kdf {
+	case KDFScrypt, "":
+		return kdfParams{kdf: KDFScrypt, scryptN: scryptStandardN, scryptR: scryptStandardR, scryptP: scryptStandardP}, nil
+	case KDFPBKDF2:
+		return kdfParams{kdf: KDFPBKDF2, iterations: pbkdf2Iterations}, nil
+	default:
+		return kdfParams{}, errors.NewInvalidKeystoreFileError(fmt.Sprintf("unsupported kdf: %s", kdf), nil)
+	}
+}
+
+// EncryptKeystoreV3 encrypts a raw 32-byte secp256k1 private key into a Web3 Secret
+// Storage (V3) JSON document protected by the passphrase. The result can be imported
+// into geth, MetaMask and any other wallet that supports the format.
+func EncryptKeystoreV3(privateKey []byte, passphrase string, kdf KDF) ([]byte, error) {
+	params, err := defaultKDFParams(kdf)
+	if err != nil {
+		return nil, err
+	}
+	return encryptKeystoreV3(privateKey, passphrase, params)
+}
+
+// encryptKeystoreV3 encrypts a private key using explicit KDF parameters
+func encryptKeystoreV3(privateKey []byte, passphrase string, params kdfParams) ([]byte, error) {
+	if len(privateKey) != 32 {
+		return nil, errors.NewInvalidKeyError(fmt.Sprintf("invalid private key length: expected 32 bytes, got %d", len(privateKey)), nil)
+	}
+
+	address, err := secp256k1Address(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, errors.NewCryptoError(err)
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, errors.NewCryptoError(err)
+	}
+
+	var derivedKey []byte
+	kdfParamsJSON := map[string]any{
+		"dklen": keystoreV3DKLen,
+		"salt":  hex.EncodeToString(salt),
+	}
+	switch params.kdf {
+	case KDFScrypt:
+		derivedKey, err = scrypt.Key([]byte(passphrase), salt, params.scryptN, params.scryptR, params.scryptP, keystoreV3DKLen)
+		if err != nil {
+			return nil, errors.NewEncryptionError(err)
+		}
+		kdfParamsJSON["n"] = params.scryptN
+		kdfParamsJSON["r"] = params.scryptR
+		kdfParamsJSON["p"] = params.scryptP
+	case KDFPBKDF2:
+		derivedKey = pbkdf2.Key([]byte(passphrase), salt, params.iterations, keystoreV3DKLen, sha256.New)
+		kdfParamsJSON["c"] = params.iterations
+		kdfParamsJSON["prf"] = pbkdf2PRF
+	default:
+		return nil, errors.NewInvalidKeystoreFileError(fmt.Sprintf("unsupported kdf: %s", params.kdf), nil)
+	}
+
+	cipherText, err := aesCTRXOR(derivedKey[:16], privateKey, iv)
+	if err != nil {
+		return nil, errors.NewEncryptionError(err)
+	}
+
+	id, err := newUUIDv4()
+	if err != nil {
+		return nil, errors.NewCryptoError(err)
+	}
+
+	keystore := KeystoreV3{
+		Address: address,
+		Crypto: KeystoreV3Crypto{
+			Cipher:       keystoreV3Cipher,
+			CipherText:   hex.EncodeToString(cipherText),
+			CipherParams: KeystoreV3CipherParams{IV: hex.EncodeToString(iv)},
+			KDF:          params.kdf,
+			KDFParams:    kdfParamsJSON,
+			MAC:          hex.EncodeToString(keystoreV3MAC(derivedKey, cipherText)),
+		},
+		ID:      id,
+		Version: keystoreV3Version,
+	}
+
+	data, err := json.Marshal(keystore)
+	if err != nil {
+		return nil, errors.NewEncryptionError(err)
+	}
+	return data, nil
+}
+
+// DecryptKeystoreV3 decrypts a Web3 Secret Storage (V3) JSON document and returns the
+// raw 32-byte private key. Both scrypt and pbkdf2 (hmac-sha256) documents are supported.
+func DecryptKeystoreV3(data []byte, passphrase string) ([]byte, error) {
+	var keystore KeystoreV3
+	if err := json.Unmarshal(data, &keystore); err != nil {
+		return nil, errors.NewInvalidKeystoreFileError("malformed keystore JSON", err)
+	}
+	if keystore.Version != keystoreV3Version {
+		return nil, errors.NewInvalidKeystoreFileError(fmt.Sprintf("unsupported version: %d", keystore.Version), nil)
+	}
+	if keystore.Crypto.Cipher != keystoreV3Cipher {
+		return nil, errors.NewInvalidKeystoreFileError(fmt.Sprintf("unsupported cipher: %s", keystore.Crypto.Cipher), nil)
+	}
+
+	cipherText, err := hex.DecodeString(keystore.Crypto.CipherText)
+	if err != nil {
+		return nil, errors.NewInvalidKeystoreFileError("invalid ciphertext encoding", err)
+	}
+	iv, err := hex.DecodeString(keystore.Crypto.CipherParams.IV)
+	if err != nil || len(iv) != aes.BlockSize {
+		return nil, errors.NewInvalidKeystoreFileError("invalid iv", err)
+	}
+	mac, err := hex.DecodeString(keystore.Crypto.MAC)
+	if err != nil {
+		return nil, errors.NewInvalidKeystoreFileError("invalid mac encoding", err)
+	}
+
+	derivedKey, err := deriveKeystoreKey(keystore.Crypto, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hmac.Equal(keystoreV3MAC(derivedKey, cipherText), mac) {
+		return nil, errors.NewInvalidPassphraseError()
+	}
+
+	privateKey, err := aesCTRXOR(derivedKey[:16], cipherText, iv)
+	if err != nil {
+		return nil, errors.NewDecryptionError(err)
+	}
+	if len(privateKey) != 32 {
+		return nil, errors.NewInvalidKeystoreFileError(fmt.Sprintf("unexpected private key length: %d", len(privateKey)), nil)
+	}
+	return privateKey, nil
+}
+
+// deriveKeystoreKey derives the AES and MAC key material from the passphrase
+func deriveKeystoreKey(c KeystoreV3Crypto, passphrase string) ([]byte, error) {
+	salt, err := hex.DecodeString(kdfParamString(c.KDFParams, "salt"))
+	if err != nil || len(salt) == 0 {
+		return nil, errors.NewInvalidKeystoreFileError("invalid kdf salt", err)
+	}
+	dkLen := kdfParamInt(c.KDFParams, "dklen")
+	if dkLen != keystoreV3DKLen {
+		return nil, errors.NewInvalidKeystoreFileError(fmt.Sprintf("invalid dklen: %d", dkLen), nil)
+	}
+
+	switch c.KDF {
+	case KDFScrypt:
+		n := kdfParamInt(c.KDFParams, "n")
+		r := kdfParamInt(c.KDFParams, "r")
+		p := kdfParamInt(c.KDFParams, "p")
+		if n <= 1 || n > scryptMaxN || n&(n-1) != 0 {
+			return nil, errors.NewInvalidKeystoreFileError(fmt.Sprintf("unsupported scrypt n: %d", n), nil)
+		}
+		if r <= 0 || p <= 0 || r > scryptMaxRP/p || 128*n*r > scryptMaxMemory {
+			return nil, errors.NewInvalidKeystoreFileError(fmt.Sprintf("unsupported scrypt r and p: %d, %d", r, p), nil)
+		}
+		key, err := scrypt.Key([]byte(passphrase), salt, n, r, p, dkLen)
+		if err != nil {
+			return nil, errors.NewInvalidKeystoreFileError("invalid scrypt parameters", err)
+		}
+		return key, nil
+	case KDFPBKDF2:
+		if prf := kdfParamString(c.KDFParams, "prf"); prf != pbkdf2PRF {
+			return nil, errors.NewInvalidKeystoreFileError(fmt.Sprintf("unsupported pbkdf2 prf: %s", prf), nil)
+		}
+		iterations := kdfParamInt(c.KDFParams, "c")
+		if iterations <= 0 || iterations > pbkdf2MaxIterations {
+			return nil, errors.NewInvalidKeystoreFileError(fmt.Sprintf("invalid pbkdf2 iteration count: %d", iterations), nil)
+		}
+		return pbkdf2.Key([]byte(passphrase), salt, iterations, dkLen, sha256.New), nil
+	default:
+		return nil, errors.NewInvalidKeystoreFileError(fmt.Sprintf("unsupported kdf: %s", c.KDF), nil)
+	}
+}
+
+// keystoreV3MAC computes keccak256(derivedKey[16:32] || ciphertext)
+func keystoreV3MAC(derivedKey, cipherText []byte) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(derivedKey[16:32])
+	hasher.Write(cipherText)
+	return hasher.Sum(nil)
+}
+
+// aesCTRXOR encrypts or decrypts data with AES in CTR mode
+func aesCTRXOR(key, in, iv []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+	return out, nil
+}
+
+// secp256k1Address derives the lowercase hex Ethereum address (without 0x) of a private key
+func secp256k1Address(privateKey []byte) (string, error) {
+	if d := new(big.Int).SetBytes(privateKey); d.Sign() == 0 || d.Cmp(Secp256k1Curve.Params().N) >= 0 {
+		return "", errors.NewInvalidKeyError("private key scalar is out of range", nil)
+	}
+	x, y := Secp256k1Curve.ScalarBaseMult(privateKey)
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(padLeft(x.Bytes(), 32))
+	hasher.Write(padLeft(y.Bytes(), 32))
+	return hex.EncodeToString(hasher.Sum(nil)[12:]), nil
+}
+
+// kdfParamInt reads a numeric KDF parameter decoded from JSON
+func kdfParamInt(params map[string]any, name string) int {
+	switch v := params[name].(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case json.Number:
+		n, _ := v.Int64()
+		return int(n)
+	default:
+		return 0
+	}
+}
+
+// kdfParamString reads a string KDF parameter decoded from JSON
+func kdfParamString(params map[string]any, name string) string {
+	v, _ := params[name].(string)
+	return v
+}
+
+// newUUIDv4 generates a random RFC 4122 version 4 UUID
+func newUUIDv4() (string, error) {
+	var u [16]byte
+	if _, err := io.ReadFull(rand.Reader, u[:]); err != nil {
+		return "", err
+	}
+	u[6] = (u[6] & 0x0f) | 0x40
+	u[8] = (u[8] & 0x3f) | 0x80
+
+	var buf bytes.Buffer
+	buf.WriteString(hex.EncodeToString(u[0:4]))
+	buf.WriteByte('-')
+	buf.WriteString(hex.EncodeToString(u[4:6]))
+	buf.WriteByte('-')
+	buf.WriteString(hex.EncodeToString(u[6:8]))
+	buf.WriteByte('-')
+	buf.WriteString(hex.EncodeToString(u[8:10]))
+	buf.WriteByte('-')
+	buf.WriteString(hex.EncodeToString(u[10:16]))
+	return buf.String(), nil
+}
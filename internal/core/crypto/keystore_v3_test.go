@@ -0,0 +1,179 @@
+package crypto
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/errors"
+)
+
+// Test vectors from the Web3 Secret Storage Definition
+const (
+	testKeystorePassphrase = "testpassword"
+	testKeystorePrivateKey = "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d"
+
+	testKeystorePBKDF2 = `{
+		"crypto": {
+			"cipher": "aes-128-ctr",
+			"cipherparams": {"iv": "6087dab2f9fdbbfaddc31a909735c1e6"},
+			"ciphertext": "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46",
+			"kdf": "pbkdf2",
+			"kdfparams": {
+				"c": 262144,
+				"dklen": 32,
+				"prf": "hmac-sha256",
+				"salt": "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"
+			},
+			"mac": "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"
+		},
+		"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+		"version": 3
+	}`
+
+	testKeystoreScrypt = `{
+		"crypto": {
+			"cipher": "aes-128-ctr",
+			"cipherparams": {"iv": "83dbcc02d8ccb40e466191a123791e0e"},
+			"ciphertext": "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+			"kdf": "scrypt",
+			"kdfparams": {
+				"dklen": 32,
+				"n": 262144,
+				"r": 1,
+				"p": 8,
+				"salt": "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"
+			},
+			"mac": "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"
+		},
+		"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+		"version": 3
+	}`
+)
+
+func TestDecryptKeystoreV3_SpecVectors(t *testing.T) {
+	expected, err := hex.DecodeString(testKeystorePrivateKey)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		keystore string
+	}{
+		{name: "pbkdf2", keystore: testKeystorePBKDF2},
+		{name: "scrypt", keystore: testKeystoreScrypt},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			privateKey, err := DecryptKeystoreV3([]byte(tc.keystore), testKeystorePassphrase)
+			require.NoError(t, err)
+			assert.Equal(t, expected, privateKey)
+		})
+	}
+}
+
+func TestDecryptKeystoreV3_WrongPassphrase(t *testing.T) {
+	_, err := DecryptKeystoreV3([]byte(testKeystorePBKDF2), "wrong")
+	require.Error(t, err)
+	assert.True(t, errors.IsError(err, errors.ErrCodeInvalidPassphrase))
+}
+
+func TestDecryptKeystoreV3_InvalidDocument(t *testing.T) {
+	tests := []struct {
+		name     string
+		keystore string
+	}{
+		{name: "malformed json", keystore: `{"crypto":`},
+		{name: "unsupported version", keystore: `{"version": 1, "crypto": {"cipher": "aes-128-ctr"}}`},
+		{name: "unsupported cipher", keystore: `{"version": 3, "crypto": {"cipher": "aes-256-gcm"}}`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := DecryptKeystoreV3([]byte(tc.keystore), testKeystorePassphrase)
+			require.Error(t, err)
+			assert.True(t, errors.IsError(err, errors.ErrCodeInvalidKeystoreFile))
+		})
+	}
+}
+
+func TestDecryptKeystoreV3_KDFParamBounds(t *testing.T) {
+	withParams := func(keystore string, params map[string]any) string {
+		var doc KeystoreV3
+		require.NoError(t, json.Unmarshal([]byte(keystore), &doc))
+		for name, value := range params {
+			doc.Crypto.KDFParams[name] = value
+		}
+		encoded, err := json.Marshal(doc)
+		require.NoError(t, err)
+		return string(encoded)
+	}
+
+	tests := []struct {
+		name     string
+		keystore string
+	}{
+		{name: "scrypt n too large", keystore: withParams(testKeystoreScrypt, map[string]any{"n": 1 << 21})},
+		{name: "scrypt n not a power of two", keystore: withParams(testKeystoreScrypt, map[string]any{"n": 262143})},
+		{name: "scrypt r*p too large", keystore: withParams(testKeystoreScrypt, map[string]any{"r": 4, "p": 8})},
+		{name: "scrypt memory too large", keystore: withParams(testKeystoreScrypt, map[string]any{"n": 1 << 20, "r": 8, "p": 1})},
+		{name: "scrypt huge r", keystore: withParams(testKeystoreScrypt, map[string]any{"r": 1e18})},
+		{name: "pbkdf2 too many iterations", keystore: withParams(testKeystorePBKDF2, map[string]any{"c": 1e9})},
+		{name: "dklen too large", keystore: withParams(testKeystorePBKDF2, map[string]any{"dklen": 1 << 30})},
+		{name: "dklen too small", keystore: withParams(testKeystorePBKDF2, map[string]any{"dklen": 16})},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := DecryptKeystoreV3([]byte(tc.keystore), testKeystorePassphrase)
+			require.Error(t, err)
+			assert.True(t, errors.IsError(err, errors.ErrCodeInvalidKeystoreFile))
+		})
+	}
+}
+
+func TestEncryptKeystoreV3_RoundTrip(t *testing.T) {
+	privateKey, err := hex.DecodeString(testKeystorePrivateKey)
+	require.NoError(t, err)
+
+	// Use light parameters to keep the test fast
+	tests := []struct {
+		name   string
+		params kdfParams
+	}{
+		{name: "scrypt", params: kdfParams{kdf: KDFScrypt, scryptN: 1 << 12, scryptR: 8, scryptP: 1}},
+		{name: "pbkdf2", params: kdfParams{kdf: KDFPBKDF2, iterations: 1024}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := encryptKeystoreV3(privateKey, testKeystorePassphrase, tc.params)
+			require.NoError(t, err)
+
+			var keystore KeystoreV3
+			require.NoError(t, json.Unmarshal(data, &keystore))
+			assert.Equal(t, 3, keystore.Version)
+			assert.Equal(t, "008aeeda4d805471df9b2a5b0f38a0c3bcba786b", keystore.Address)
+			assert.Equal(t, tc.params.kdf, keystore.Crypto.KDF)
+			assert.Len(t, keystore.ID, 36)
+
+			decrypted, err := DecryptKeystoreV3(data, testKeystorePassphrase)
+			require.NoError(t, err)
+			assert.Equal(t, privateKey, decrypted)
+		})
+	}
+}
+
+func TestEncryptKeystoreV3_InvalidInput(t *testing.T) {
+	_, err := EncryptKeystoreV3(make([]byte, 16), testKeystorePassphrase, KDFScrypt)
+	assert.True(t, errors.IsError(err, errors.ErrCodeInvalidKey))
+
+	_, err = EncryptKeystoreV3(make([]byte, 32), testKeystorePassphrase, KDFScrypt)
+	assert.True(t, errors.IsError(err, errors.ErrCodeInvalidKey))
+
+	_, err = EncryptKeystoreV3(make([]byte, 32), testKeystorePassphrase, KDF("argon2"))
+	assert.True(t, errors.IsError(err, errors.ErrCodeInvalidKeystoreFile))
+}
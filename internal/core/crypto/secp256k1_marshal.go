@@ -119,6 +119,28 @@ func UnmarshalPrivateKey(data []byte) (*ecdsa.PrivateKey, error) {
 	}, nil
 }
 
+// PrivateKeyFromBytes builds a SECP256K1 private key from its raw 32-byte scalar
+func PrivateKeyFromBytes(d []byte) (*ecdsa.PrivateKey, error) {
+	if len(d) != 32 {
+		return nil, fmt.Errorf("invalid private key length: expected 32 bytes, got %d", len(d))
+	}
+
+	scalar := new(big.Int).SetBytes(d)
+	if scalar.Sign() == 0 || scalar.Cmp(Secp256k1Curve.Params().N) >= 0 {
+		return nil, errors.New("private key scalar is out of range")
+	}
+
+	x, y := Secp256k1Curve.ScalarBaseMult(d)
+	return &ecdsa.PrivateKey{
+		D: scalar,
+		PublicKey: ecdsa.PublicKey{
+			Curve: Secp256k1Curve,
+			X:     x,
+			Y:     y,
+		},
+	}, nil
+}
+
 // MarshalPublicKey serializes an ECDSA public key into the uncompressed format.
 // Format: 0x04 || 32-byte X coordinate || 32-byte Y coordinate (65 bytes total).
 func MarshalPublicKey(pub *ecdsa.PublicKey) ([]byte, error) {
@@ -0,0 +1,301 @@
+package crypto
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"vault0/internal/errors"
+)
+
+const (
+	// MaxShares is the maximum number of shares a secret can be split into.
+	// Each share is identified by a distinct non-zero x-coordinate in GF(256).
+	MaxShares = 255
+
+	// splitIDLen is the length of the random identifier shared by the shares of a split
+	splitIDLen = 8
+	// fingerprintLen is the length of the secret fingerprint carried by each share
+	fingerprintLen = 8
+)
+
+// Share is a single Shamir share of a secret. Each byte of the secret is the
+// constant term of an independent random polynomial over GF(256); a share holds
+// the evaluation of every polynomial at the point X.
+type Share struct {
+	// Threshold is the number of shares required to reconstruct the secret
+	Threshold int
+	// X is the evaluation point of the share (1-255)
+	X byte
+	// SplitID identifies the split the share belongs to, so that shares of
+	// different splits are not combined
+	SplitID []byte
+	// Fingerprint is a hash of the secret, checked once it is reconstructed
+	Fingerprint []byte
+	// Y holds the polynomial evaluations, one per secret byte
+	Y []byte
+}
+
+// String encodes the share as "<threshold>-<x>-<hex split id>-<hex fingerprint>-<hex y>",
+// suitable for storing in a file or passing on stdin
+func (s Share) String() string {
+	return fmt.Sprintf("%d-%d-%s-%s-%s", s.Threshold, s.X,
+		hex.EncodeToString(s.SplitID), hex.EncodeToString(s.Fingerprint), hex.EncodeToString(s.Y))
+}
+
+// ParseShare decodes a share produced by Share.String
+func ParseShare(encoded string) (Share, error) {
+	parts := strings.Split(strings.TrimSpace(encoded), "-")
+	if len(parts) != 5 {
+		return Share{}, errors.NewInvalidSecretShareError("expected format <threshold>-<x>-<split id>-<fingerprint>-<hex>")
+	}
+
+	threshold, err := strconv.Atoi(parts[0])
+	if err != nil || threshold < 2 || threshold > MaxShares {
+		return Share{}, errors.NewInvalidSecretShareError(fmt.Sprintf("invalid threshold: %s", parts[0]))
+	}
+
+	x, err := strconv.Atoi(parts[1])
+	if err != nil || x < 1 || x > MaxShares {
+		return Share{}, errors.NewInvalidSecretShareError(fmt.Sprintf("invalid share index: %s", parts[1]))
+	}
+
+	splitID, err := hex.DecodeString(parts[2])
+	if err != nil || len(splitID) != splitIDLen {
+		return Share{}, errors.NewInvalidSecretShareError("invalid split id")
+	}
+
+	fingerprint, err := hex.DecodeString(parts[3])
+	if err != nil || len(fingerprint) != fingerprintLen {
+		return Share{}, errors.NewInvalidSecretShareError("invalid fingerprint")
+	}
+
+	y, err := hex.DecodeString(parts[4])
+	if err != nil || len(y) == 0 {
+		return Share{}, errors.NewInvalidSecretShareError("invalid share data")
+	}
+
+	return Share{Threshold: threshold, X: byte(x), SplitID: splitID, Fingerprint: fingerprint, Y: y}, nil
+}
+
+// ReadShares reads encoded shares from r, one per line, and appends them to the
+// shares already collected until the threshold declared by the first share is
+// reached or r is exhausted. Blank lines are ignored.
+func ReadShares(r io.Reader, shares []Share) ([]Share, error) {
+	scanner := bufio.NewScanner(r)
+	for len(shares) == 0 || len(shares) < shares[0].Threshold {
+		if !scanner.Scan() {
+			break
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		share, err := ParseShare(line)
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, share)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.NewInvalidSecretShareError(fmt.Sprintf("failed to read shares: %v", err))
+	}
+
+	return shares, nil
+}
+
+// SplitSecret splits a secret into n shares so that any threshold of them can
+// reconstruct it, while fewer reveal nothing about the secret
+func SplitSecret(secret []byte, n, threshold int) ([]Share, error) {
+	if len(secret) == 0 {
+		return nil, errors.NewInvalidSecretShareError("secret must not be empty")
+	}
+	if threshold < 2 {
+		return nil, errors.NewInvalidSecretShareError("threshold must be at least 2")
+	}
+	if n < threshold {
+		return nil, errors.NewInvalidSecretShareError("number of shares must be at least the threshold")
+	}
+	if n > MaxShares {
+		return nil, errors.NewInvalidSecretShareError(fmt.Sprintf("number of shares must not exceed %d", MaxShares))
+	}
+
+	splitID := make([]byte, splitIDLen)
+	if _, err := io.ReadFull(rand.Reader, splitID); err != nil {
+		return nil, errors.NewCryptoError(err)
+	}
+	fingerprint := secretFingerprint(splitID, secret)
+
+	shares := make([]Share, n)
+	for i := range shares {
+		shares[i] = Share{
+			Threshold:   threshold,
+			X:           byte(i + 1),
+			SplitID:     splitID,
+			Fingerprint: fingerprint,
+			Y:           make([]byte, len(secret)),
+		}
+	}
+
+	// coefficients[0] is the secret byte, the rest are random
+	coefficients := make([]byte, threshold)
+	for idx, b := range secret {
+		coefficients[0] = b
+		if _, err := io.ReadFull(rand.Reader, coefficients[1:]); err != nil {
+			return nil, errors.NewCryptoError(err)
+		}
+		for i := range shares {
+			shares[i].Y[idx] = gfEvalPolynomial(coefficients, shares[i].X)
+		}
+	}
+
+	// Don't leave the last coefficients lying around in memory
+	for i := range coefficients {
+		coefficients[i] = 0
+	}
+
+	return shares, nil
+}
+
+// CombineShares reconstructs a secret from at least threshold shares using
+// Lagrange interpolation at x = 0, and checks it against the fingerprint of the
+// shares
+func CombineShares(shares []Share) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, errors.NewInvalidSecretShareError("no shares provided")
+	}
+
+	threshold := shares[0].Threshold
+	secretLen := len(shares[0].Y)
+	seen := make(map[byte]bool, len(shares))
+	for _, share := range shares {
+		if !bytes.Equal(share.SplitID, shares[0].SplitID) || !bytes.Equal(share.Fingerprint, shares[0].Fingerprint) {
+			return nil, errors.NewInvalidSecretShareError("shares belong to different splits")
+		}
+		if share.Threshold != threshold {
+			return nil, errors.NewInvalidSecretShareError("shares have mismatching thresholds")
+		}
+		if len(share.Y) != secretLen {
+			return nil, errors.NewInvalidSecretShareError("shares have mismatching lengths")
+		}
+		if share.X == 0 {
+			return nil, errors.NewInvalidSecretShareError("share index must not be zero")
+		}
+		if seen[share.X] {
+			return nil, errors.NewInvalidSecretShareError(fmt.Sprintf("duplicate share index: %d", share.X))
+		}
+		seen[share.X] = true
+	}
+
+	if len(shares) < threshold {
+		return nil, errors.NewInvalidSecretShareError(fmt.Sprintf("need %d shares, got %d", threshold, len(shares)))
+	}
+
+	// Any threshold shares determine the polynomial; use the first ones
+	shares = shares[:threshold]
+
+	// Lagrange basis polynomials evaluated at zero. In GF(256) subtraction is XOR,
+	// so (0 - xj) / (xi - xj) becomes xj / (xi ^ xj).
+	basis := make([]byte, threshold)
+	for i := range shares {
+		basis[i] = 1
+		for j := range shares {
+			if i == j {
+				continue
+			}
+			basis[i] = gfMul(basis[i], gfDiv(shares[j].X, shares[i].X^shares[j].X))
+		}
+	}
+
+	secret := make([]byte, secretLen)
+	for idx := range secret {
+		var value byte
+		for i, share := range shares {
+			value ^= gfMul(basis[i], share.Y[idx])
+		}
+		secret[idx] = value
+	}
+
+	fingerprint := secretFingerprint(shares[0].SplitID, secret)
+	if subtle.ConstantTimeCompare(fingerprint, shares[0].Fingerprint) != 1 {
+		return nil, errors.NewInvalidSecretShareError("reconstructed secret does not match the share fingerprint")
+	}
+
+	return secret, nil
+}
+
+// secretFingerprint computes the fingerprint of a secret, bound to its split
+func secretFingerprint(splitID, secret []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte("vault0-shamir-fingerprint"))
+	h.Write(splitID)
+	h.Write(secret)
+	return h.Sum(nil)[:fingerprintLen]
+}
+
+// GF(256) arithmetic using the AES reduction polynomial x^8 + x^4 + x^3 + x + 1
+// and generator 3 for the log/exp tables
+var (
+	gfExp [510]byte
+	gfLog [256]byte
+)
+
+func init() {
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		gfExp[i] = x
+		gfExp[i+255] = x
+		gfLog[x] = byte(i)
+		x = gfMulSlow(x, 3)
+	}
+}
+
+// gfMulSlow multiplies two field elements without lookup tables
+func gfMulSlow(a, b byte) byte {
+	var p byte
+	for b > 0 {
+		if b&1 != 0 {
+			p ^= a
+		}
+		carry := a & 0x80
+		a <<= 1
+		if carry != 0 {
+			a ^= 0x1b
+		}
+		b >>= 1
+	}
+	return p
+}
+
+// gfMul multiplies two field elements
+func gfMul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+int(gfLog[b])]
+}
+
+// gfDiv divides a by a non-zero b
+func gfDiv(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return gfExp[int(gfLog[a])+255-int(gfLog[b])]
+}
+
+// gfEvalPolynomial evaluates the polynomial with the given coefficients at x using Horner's method
+func gfEvalPolynomial(coefficients []byte, x byte) byte {
+	result := coefficients[len(coefficients)-1]
+	for i := len(coefficients) - 2; i >= 0; i-- {
+		result = gfMul(result, x) ^ coefficients[i]
+	}
+	return result
+}
@@ -0,0 +1,174 @@
+package crypto
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/errors"
+)
+
+func TestShamir(t *testing.T) {
+	secret, err := base64.StdEncoding.DecodeString(TestAESKey)
+	require.NoError(t, err)
+
+	t.Run("Reconstruct from every threshold subset", func(t *testing.T) {
+		shares, err := SplitSecret(secret, 5, 3)
+		require.NoError(t, err)
+		require.Len(t, shares, 5)
+
+		for i := 0; i < len(shares); i++ {
+			for j := i + 1; j < len(shares); j++ {
+				for k := j + 1; k < len(shares); k++ {
+					combined, err := CombineShares([]Share{shares[k], shares[i], shares[j]})
+					require.NoError(t, err)
+					assert.Equal(t, secret, combined)
+				}
+			}
+		}
+	})
+
+	t.Run("Reconstruct from more than threshold shares", func(t *testing.T) {
+		shares, err := SplitSecret(secret, 4, 2)
+		require.NoError(t, err)
+
+		combined, err := CombineShares(shares)
+		require.NoError(t, err)
+		assert.Equal(t, secret, combined)
+	})
+
+	t.Run("Shares differ from the secret", func(t *testing.T) {
+		shares, err := SplitSecret(secret, 3, 2)
+		require.NoError(t, err)
+
+		for _, share := range shares {
+			assert.NotEqual(t, secret, share.Y)
+		}
+	})
+
+	t.Run("Fail with fewer than threshold shares", func(t *testing.T) {
+		shares, err := SplitSecret(secret, 5, 3)
+		require.NoError(t, err)
+
+		_, err = CombineShares(shares[:2])
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidSecretShare))
+	})
+
+	t.Run("Fail with duplicate shares", func(t *testing.T) {
+		shares, err := SplitSecret(secret, 3, 2)
+		require.NoError(t, err)
+
+		_, err = CombineShares([]Share{shares[0], shares[0]})
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidSecretShare))
+	})
+
+	t.Run("Reject invalid parameters", func(t *testing.T) {
+		_, err := SplitSecret(secret, 3, 1)
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidSecretShare))
+
+		_, err = SplitSecret(secret, 2, 3)
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidSecretShare))
+
+		_, err = SplitSecret(secret, 256, 3)
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidSecretShare))
+
+		_, err = SplitSecret(nil, 3, 2)
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidSecretShare))
+	})
+
+	t.Run("Encode and parse shares", func(t *testing.T) {
+		shares, err := SplitSecret(secret, 3, 2)
+		require.NoError(t, err)
+
+		parsed := make([]Share, 0, len(shares))
+		for _, share := range shares {
+			p, err := ParseShare(share.String() + "\n")
+			require.NoError(t, err)
+			assert.Equal(t, share, p)
+			parsed = append(parsed, p)
+		}
+
+		combined, err := CombineShares(parsed[1:])
+		require.NoError(t, err)
+		assert.Equal(t, secret, combined)
+	})
+
+	t.Run("Read shares until threshold", func(t *testing.T) {
+		shares, err := SplitSecret(secret, 4, 2)
+		require.NoError(t, err)
+
+		input := "\n" + shares[3].String() + "\n\n" + shares[1].String() + "\n" + shares[0].String() + "\n"
+		read, err := ReadShares(strings.NewReader(input), nil)
+		require.NoError(t, err)
+		require.Len(t, read, 2)
+
+		combined, err := CombineShares(read)
+		require.NoError(t, err)
+		assert.Equal(t, secret, combined)
+	})
+
+	t.Run("Read remaining shares", func(t *testing.T) {
+		shares, err := SplitSecret(secret, 5, 3)
+		require.NoError(t, err)
+
+		input := shares[2].String() + "\n" + shares[4].String() + "\n" + shares[3].String() + "\n"
+		read, err := ReadShares(strings.NewReader(input), shares[:1])
+		require.NoError(t, err)
+		require.Len(t, read, 3)
+
+		combined, err := CombineShares(read)
+		require.NoError(t, err)
+		assert.Equal(t, secret, combined)
+	})
+
+	t.Run("Reject shares of different splits", func(t *testing.T) {
+		first, err := SplitSecret(secret, 3, 2)
+		require.NoError(t, err)
+		second, err := SplitSecret(make([]byte, len(secret)), 3, 2)
+		require.NoError(t, err)
+
+		_, err = CombineShares([]Share{first[0], second[1]})
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidSecretShare))
+	})
+
+	t.Run("Reject tampered shares", func(t *testing.T) {
+		shares, err := SplitSecret(secret, 3, 2)
+		require.NoError(t, err)
+
+		tampered := shares[1]
+		tampered.Y = append([]byte(nil), tampered.Y...)
+		tampered.Y[0] ^= 0x01
+
+		_, err = CombineShares([]Share{shares[0], tampered})
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidSecretShare))
+	})
+
+	t.Run("Reject malformed shares", func(t *testing.T) {
+		const splitID, fingerprint = "0102030405060708", "0807060504030201"
+		for _, encoded := range []string{
+			"", "2-1", "2-1-aa",
+			"1-1-" + splitID + "-" + fingerprint + "-aa",
+			"2-0-" + splitID + "-" + fingerprint + "-aa",
+			"2-1-" + splitID + "-" + fingerprint + "-zz",
+			"x-1-" + splitID + "-" + fingerprint + "-aa",
+			"2-1-0102-" + fingerprint + "-aa",
+			"2-1-" + splitID + "-zz-aa",
+		} {
+			_, err := ParseShare(encoded)
+			assert.True(t, errors.IsError(err, errors.ErrCodeInvalidSecretShare), encoded)
+		}
+	})
+}
+
+func TestGF256(t *testing.T) {
+	for a := 1; a < 256; a++ {
+		for b := 1; b < 256; b++ {
+			product := gfMul(byte(a), byte(b))
+			require.Equal(t, gfMulSlow(byte(a), byte(b)), product)
+			require.Equal(t, byte(a), gfDiv(product, byte(b)))
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"vault0/internal/errors"
+)
+
+// EthMessageFormat identifies how a payload is hashed before an Ethereum signature
+// is recovered
+type EthMessageFormat string
+
+const (
+	// EthMessageFormatRaw hashes the payload with keccak256
+	EthMessageFormatRaw EthMessageFormat = "raw"
+	// EthMessageFormatDigest uses the payload as a precomputed 32-byte digest
+	EthMessageFormatDigest EthMessageFormat = "digest"
+	// EthMessageFormatPersonal hashes the payload as an EIP-191 personal_sign message
+	EthMessageFormatPersonal EthMessageFormat = "personal_sign"
+	// EthMessageFormatTypedData hashes the payload as an EIP-712 typed data JSON document
+	EthMessageFormatTypedData EthMessageFormat = "typed_data"
+)
+
+// ecdsaSignature is the ASN.1 structure of a DER encoded ECDSA signature
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// HashEthMessage computes the digest an Ethereum signer signs for the given payload format
+func HashEthMessage(format EthMessageFormat, payload []byte) ([]byte, error) {
+	switch format {
+	case EthMessageFormatRaw:
+		return ethCrypto.Keccak256(payload), nil
+	case EthMessageFormatDigest:
+		if len(payload) != 32 {
+			return nil, errors.NewInvalidInputError("Digest must be 32 bytes", "data", len(payload))
+		}
+		return payload, nil
+	case EthMessageFormatPersonal:
+		return HashPersonalMessage(payload), nil
+	case EthMessageFormatTypedData:
+		return HashTypedData(payload)
+	default:
+		return nil, errors.NewInvalidInputError("Unsupported message format", "format", format)
+	}
+}
+
+// ParsePublicKey parses a public key as returned by the keystore. PKIX DER encoded
+// ECDSA (P-256), Ed25519 and RSA keys are supported, as well as 65-byte uncompressed
+// secp256k1 points and raw 32-byte Ed25519 keys.
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	if key, err := x509.ParsePKIXPublicKey(data); err == nil {
+		return key, nil
+	}
+
+	switch len(data) {
+	case 65:
+		key, err := UnmarshalPublicKey(data)
+		if err != nil {
+			return nil, errors.NewInvalidKeyError("invalid secp256k1 public key", err)
+		}
+		return key, nil
+	case ed25519.PublicKeySize:
+		return ed25519.PublicKey(data), nil
+	default:
+		return nil, errors.NewInvalidKeyError("unsupported public key encoding", nil)
+	}
+}
+
+// VerifySignature verifies a signature over data with a public key. Unless the
+// data is prehashed, ECDSA and RSA signatures are checked against the SHA-256
+// hash of the data, mirroring how the keystore signs; Ed25519 always signs the
+// data itself.
+//
+// ECDSA signatures may be ASN.1 DER encoded, 64-byte r||s or 65-byte r||s||v.
+// RSA signatures must be PKCS #1 v1.5 with SHA-256.
+//
+// It returns false for a well-formed signature that does not match, and an error
+// if the key or signature cannot be parsed.
+func VerifySignature(publicKey crypto.PublicKey, data, signature []byte, prehashed bool) (bool, error) {
+	digest := data
+	if !prehashed {
+		h := sha256.Sum256(data)
+		digest = h[:]
+	}
+
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		r, s, err := parseECDSASignature(signature)
+		if err != nil {
+			return false, err
+		}
+		return ecdsa.Verify(key, digest, r, s), nil
+	case ed25519.PublicKey:
+		if len(signature) != ed25519.SignatureSize {
+			return false, errors.NewInvalidSignatureError(fmt.Errorf("expected %d bytes, got %d", ed25519.SignatureSize, len(signature)))
+		}
+		return ed25519.Verify(key, data, signature), nil
+	case *rsa.PublicKey:
+		if len(digest) != sha256.Size {
+			return false, errors.NewInvalidInputError("Digest must be 32 bytes", "data", len(digest))
+		}
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature) == nil, nil
+	default:
+		return false, errors.NewInvalidKeyTypeError("ecdsa, ed25519 or rsa", fmt.Sprintf("%T", publicKey))
+	}
+}
+
+// parseECDSASignature extracts R and S from a DER or fixed-size ECDSA signature
+func parseECDSASignature(signature []byte) (*big.Int, *big.Int, error) {
+	switch len(signature) {
+	case 64, EthSignatureLength:
+		return new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:64]), nil
+	}
+
+	var sig ecdsaSignature
+	rest, err := asn1.Unmarshal(signature, &sig)
+	if err != nil {
+		return nil, nil, errors.NewInvalidSignatureError(err)
+	}
+	if len(rest) > 0 {
+		return nil, nil, errors.NewInvalidSignatureError(fmt.Errorf("trailing data after signature"))
+	}
+	return sig.R, sig.S, nil
+}
@@ -0,0 +1,148 @@
+package crypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"testing"
+
+	ethCrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/errors"
+)
+
+func TestVerifySignature(t *testing.T) {
+	data := []byte("message to verify")
+	digest := sha256.Sum256(data)
+
+	t.Run("ECDSA P-256", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		require.NoError(t, err)
+
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		require.NoError(t, err)
+		signature, err := asn1.Marshal(ecdsaSignature{R: r, S: s})
+		require.NoError(t, err)
+
+		publicKey, err := ParsePublicKey(der)
+		require.NoError(t, err)
+
+		valid, err := VerifySignature(publicKey, data, signature, false)
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		valid, err = VerifySignature(publicKey, digest[:], signature, true)
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		valid, err = VerifySignature(publicKey, []byte("other message"), signature, false)
+		require.NoError(t, err)
+		assert.False(t, valid)
+	})
+
+	t.Run("ECDSA secp256k1", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(Secp256k1Curve, rand.Reader)
+		require.NoError(t, err)
+		raw, err := MarshalPublicKey(&key.PublicKey)
+		require.NoError(t, err)
+
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		require.NoError(t, err)
+		der, err := asn1.Marshal(ecdsaSignature{R: r, S: s})
+		require.NoError(t, err)
+		compact := append(padLeft(r.Bytes(), 32), padLeft(s.Bytes(), 32)...)
+
+		publicKey, err := ParsePublicKey(raw)
+		require.NoError(t, err)
+
+		for _, signature := range [][]byte{der, compact, append(compact, 27)} {
+			valid, err := VerifySignature(publicKey, data, signature, false)
+			require.NoError(t, err)
+			assert.True(t, valid)
+		}
+	})
+
+	t.Run("Ed25519", func(t *testing.T) {
+		publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKIXPublicKey(publicKey)
+		require.NoError(t, err)
+		signature := ed25519.Sign(privateKey, data)
+
+		for _, encoded := range [][]byte{der, publicKey} {
+			parsed, err := ParsePublicKey(encoded)
+			require.NoError(t, err)
+
+			valid, err := VerifySignature(parsed, data, signature, false)
+			require.NoError(t, err)
+			assert.True(t, valid)
+		}
+
+		_, err = VerifySignature(publicKey, data, signature[:10], false)
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidSignature))
+	})
+
+	t.Run("RSA", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		require.NoError(t, err)
+
+		signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+
+		publicKey, err := ParsePublicKey(der)
+		require.NoError(t, err)
+
+		valid, err := VerifySignature(publicKey, data, signature, false)
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		signature[0] ^= 0xff
+		valid, err = VerifySignature(publicKey, data, signature, false)
+		require.NoError(t, err)
+		assert.False(t, valid)
+	})
+
+	t.Run("Invalid inputs", func(t *testing.T) {
+		_, err := ParsePublicKey([]byte{1, 2, 3})
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidKey))
+
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		_, err = VerifySignature(&key.PublicKey, data, []byte{1, 2, 3}, false)
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidSignature))
+	})
+}
+
+func TestHashEthMessage(t *testing.T) {
+	payload := []byte("Hello World")
+
+	raw, err := HashEthMessage(EthMessageFormatRaw, payload)
+	require.NoError(t, err)
+	assert.Equal(t, ethCrypto.Keccak256(payload), raw)
+
+	personal, err := HashEthMessage(EthMessageFormatPersonal, payload)
+	require.NoError(t, err)
+	assert.Equal(t, HashPersonalMessage(payload), personal)
+
+	digest, err := HashEthMessage(EthMessageFormatDigest, raw)
+	require.NoError(t, err)
+	assert.Equal(t, raw, digest)
+
+	_, err = HashEthMessage(EthMessageFormatDigest, payload)
+	assert.True(t, errors.IsError(err, errors.ErrCodeInvalidInput))
+
+	_, err = HashEthMessage(EthMessageFormat("unknown"), payload)
+	assert.True(t, errors.IsError(err, errors.ErrCodeInvalidInput))
+}
@@ -0,0 +1,96 @@
+package fxfeed
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	"vault0/internal/config"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+)
+
+// NewFXFeed creates an FXFeed instance based on the configuration.
+// The Frankfurter API is used when no provider is configured, as it requires no API key.
+func NewFXFeed(cfg *config.Config, log logger.Logger) (FXFeed, error) {
+	providerName := strings.ToLower(strings.TrimSpace(cfg.FX.Provider))
+	if providerName == "" {
+		providerName = "frankfurter"
+	}
+	log = log.With(logger.String("fx_provider_name", providerName))
+
+	switch providerName {
+	case "frankfurter":
+		log.Info("Initializing Frankfurter FX rates provider")
+		provider, err := NewFrankfurterFXFeed(cfg.FX, log)
+		if err != nil {
+			log.Error("Failed to initialize Frankfurter provider", logger.Error(err))
+			return nil, err
+		}
+		return provider, nil
+	case "openexchangerates":
+		log.Info("Initializing Open Exchange Rates FX rates provider")
+		provider, err := NewOpenExchangeRatesFXFeed(cfg.FX, log)
+		if err != nil {
+			log.Error("Failed to initialize Open Exchange Rates provider", logger.Error(err))
+			return nil, err
+		}
+		return provider, nil
+	default:
+		log.Warn("Unsupported FX rates provider configured")
+		return nil, errors.NewFXFeedProviderNotSupported(cfg.FX.Provider)
+	}
+}
+
+// getJSON fetches a URL and decodes its JSON response
+func getJSON(ctx context.Context, client *http.Client, url string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return errors.NewFXFeedRequestFailed(err, "failed to create HTTP request")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.NewFXFeedRequestFailed(err, "failed to execute HTTP request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.NewFXFeedRequestFailed(fmt.Errorf("unexpected status code: %d", resp.StatusCode), "API returned non-OK status")
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(target); err != nil {
+		return errors.NewInvalidFXFeedResponse(err, "failed to decode JSON response")
+	}
+	return nil
+}
+
+// normalizeCurrencies upper-cases currency codes and drops empty and duplicate ones
+func normalizeCurrencies(currencies []string) []string {
+	seen := make(map[string]bool, len(currencies))
+	normalized := make([]string, 0, len(currencies))
+	for _, currency := range currencies {
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+		if currency == "" || currency == BaseCurrency || seen[currency] {
+			continue
+		}
+		seen[currency] = true
+		normalized = append(normalized, currency)
+	}
+	return normalized
+}
+
+// validRates keeps the positive, finite rates keyed by upper-cased currency codes
+func validRates(rates map[string]float64) map[string]float64 {
+	valid := make(map[string]float64, len(rates))
+	for currency, rate := range rates {
+		if rate <= 0 || math.IsNaN(rate) || math.IsInf(rate, 0) {
+			continue
+		}
+		valid[strings.ToUpper(currency)] = rate
+	}
+	return valid
+}
@@ -0,0 +1,81 @@
+package fxfeed
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"vault0/internal/config"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+)
+
+// DefaultFrankfurterAPIURL is the public endpoint of the Frankfurter API
+const DefaultFrankfurterAPIURL = "https://api.frankfurter.app/latest"
+
+// frankfurterResponse is the response of the Frankfurter latest rates endpoint
+type frankfurterResponse struct {
+	Amount float64            `json:"amount"`
+	Base   string             `json:"base"`
+	Date   string             `json:"date"`
+	Rates  map[string]float64 `json:"rates"`
+}
+
+// FrankfurterFXFeed implements the FXFeed interface for the Frankfurter API,
+// which serves the reference rates published daily by the European Central Bank.
+type FrankfurterFXFeed struct {
+	httpClient *http.Client
+	apiURL     string
+	currencies []string
+	log        logger.Logger
+}
+
+// NewFrankfurterFXFeed creates a new FrankfurterFXFeed instance.
+func NewFrankfurterFXFeed(cfg config.FXConfig, log logger.Logger) (*FrankfurterFXFeed, error) {
+	if cfg.APIURL == "" {
+		cfg.APIURL = DefaultFrankfurterAPIURL
+	}
+
+	return &FrankfurterFXFeed{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		apiURL:     cfg.APIURL,
+		currencies: normalizeCurrencies(cfg.Currencies),
+		log:        log.With(logger.String("provider", "frankfurter")),
+	}, nil
+}
+
+// GetRates fetches the latest USD exchange rates from the Frankfurter API.
+func (f *FrankfurterFXFeed) GetRates(ctx context.Context) (*RatesData, error) {
+	urlVal, err := url.Parse(f.apiURL)
+	if err != nil {
+		return nil, errors.NewConfigurationError(fmt.Sprintf("invalid Frankfurter API URL: %s", f.apiURL))
+	}
+
+	query := urlVal.Query()
+	query.Set("from", BaseCurrency)
+	if len(f.currencies) > 0 {
+		query.Set("to", strings.Join(f.currencies, ","))
+	}
+	urlVal.RawQuery = query.Encode()
+
+	var apiResponse frankfurterResponse
+	if err := getJSON(ctx, f.httpClient, urlVal.String(), &apiResponse); err != nil {
+		f.log.Error("Failed to fetch Frankfurter exchange rates", logger.Error(err))
+		return nil, err
+	}
+
+	if !strings.EqualFold(apiResponse.Base, BaseCurrency) {
+		return nil, errors.NewInvalidFXFeedResponse(nil, fmt.Sprintf("unexpected base currency %q", apiResponse.Base))
+	}
+	asOf, err := time.Parse(time.DateOnly, apiResponse.Date)
+	if err != nil {
+		return nil, errors.NewInvalidFXFeedResponse(err, "invalid rates date")
+	}
+
+	rates := validRates(apiResponse.Rates)
+	f.log.Info("Successfully fetched exchange rates", logger.Int("count", len(rates)))
+	return &RatesData{Rates: rates, AsOf: asOf, Source: "frankfurter"}, nil
+}
@@ -0,0 +1,33 @@
+package fxfeed
+
+import (
+	"context"
+	"time"
+)
+
+// BaseCurrency is the currency all exchange rates are quoted against, matching
+// the USD denomination of token prices
+const BaseCurrency = "USD"
+
+// RatesData represents the exchange rates of a provider at a point in time.
+type RatesData struct {
+	// Rates maps ISO 4217 currency codes (e.g., "EUR") to the amount of the currency
+	// one US dollar buys
+	Rates map[string]float64
+	// AsOf is the time the rates were published by the provider
+	AsOf time.Time
+	// Source is the name of the provider the rates come from
+	Source string
+}
+
+// FXFeed defines the interface for fetching fiat exchange rates from external
+// sources like the ECB reference rates or Open Exchange Rates.
+type FXFeed interface {
+	// GetRates fetches the latest USD exchange rates of the configured currencies,
+	// or of all currencies supported by the provider when none are configured.
+	//
+	// Returns:
+	//   - The exchange rates
+	//   - An error if the request fails, e.g., ErrFXFeedRequestFailed, ErrInvalidFXFeedResponse.
+	GetRates(ctx context.Context) (*RatesData, error)
+}
@@ -0,0 +1,100 @@
+package fxfeed
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/config"
+	coreerrors "vault0/internal/errors"
+	"vault0/internal/testing/mocks"
+)
+
+func TestFrankfurterFXFeed_GetRates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "USD", r.URL.Query().Get("from"))
+		assert.Equal(t, "EUR,GBP", r.URL.Query().Get("to"))
+		w.Write([]byte(`{"amount":1.0,"base":"USD","date":"2024-05-03","rates":{"EUR":0.9305,"GBP":0.7962,"XXX":0}}`))
+	}))
+	defer server.Close()
+
+	feed, err := NewFrankfurterFXFeed(config.FXConfig{
+		APIURL:     server.URL,
+		Currencies: []string{"eur", "GBP", "usd", "EUR"},
+	}, mocks.NewNopLogger())
+	require.NoError(t, err)
+
+	rates, err := feed.GetRates(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"EUR": 0.9305, "GBP": 0.7962}, rates.Rates)
+	assert.Equal(t, time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC), rates.AsOf)
+	assert.Equal(t, "frankfurter", rates.Source)
+}
+
+func TestOpenExchangeRatesFXFeed_GetRates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.URL.Query().Get("app_id"))
+		assert.Equal(t, "EUR", r.URL.Query().Get("symbols"))
+		w.Write([]byte(`{"timestamp":1714723200,"base":"USD","rates":{"EUR":0.93,"USD":1}}`))
+	}))
+	defer server.Close()
+
+	feed, err := NewOpenExchangeRatesFXFeed(config.FXConfig{
+		APIURL:     server.URL,
+		APIKey:     "secret",
+		Currencies: []string{"EUR"},
+	}, mocks.NewNopLogger())
+	require.NoError(t, err)
+
+	rates, err := feed.GetRates(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"EUR": 0.93}, rates.Rates)
+	assert.Equal(t, time.Unix(1714723200, 0), rates.AsOf)
+}
+
+func TestFXFeed_GetRates_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		expected string
+	}{
+		{name: "non-OK status", status: http.StatusTooManyRequests, expected: coreerrors.ErrCodeFXFeedRequestFailed},
+		{name: "malformed body", status: http.StatusOK, body: `{`, expected: coreerrors.ErrCodeInvalidFXFeedResponse},
+		{name: "unexpected base", status: http.StatusOK, body: `{"base":"EUR","date":"2024-05-03","rates":{}}`, expected: coreerrors.ErrCodeInvalidFXFeedResponse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			feed, err := NewFrankfurterFXFeed(config.FXConfig{APIURL: server.URL}, mocks.NewNopLogger())
+			require.NoError(t, err)
+
+			_, err = feed.GetRates(context.Background())
+			require.Error(t, err)
+			assert.True(t, coreerrors.IsError(err, tt.expected), err.Error())
+		})
+	}
+}
+
+func TestNewFXFeed(t *testing.T) {
+	feed, err := NewFXFeed(&config.Config{}, mocks.NewNopLogger())
+	require.NoError(t, err)
+	assert.IsType(t, &FrankfurterFXFeed{}, feed)
+
+	_, err = NewFXFeed(&config.Config{FX: config.FXConfig{Provider: "openexchangerates"}}, mocks.NewNopLogger())
+	assert.True(t, coreerrors.IsError(err, coreerrors.ErrCodeConfiguration))
+
+	_, err = NewFXFeed(&config.Config{FX: config.FXConfig{Provider: "unknown"}}, mocks.NewNopLogger())
+	assert.True(t, coreerrors.IsError(err, coreerrors.ErrCodeFXFeedProviderNotSupported))
+}
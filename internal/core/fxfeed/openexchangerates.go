@@ -0,0 +1,87 @@
+package fxfeed
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"vault0/internal/config"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+)
+
+// DefaultOpenExchangeRatesAPIURL is the latest rates endpoint of Open Exchange Rates
+const DefaultOpenExchangeRatesAPIURL = "https://openexchangerates.org/api/latest.json"
+
+// openExchangeRatesResponse is the response of the Open Exchange Rates latest endpoint
+type openExchangeRatesResponse struct {
+	Timestamp int64              `json:"timestamp"`
+	Base      string             `json:"base"`
+	Rates     map[string]float64 `json:"rates"`
+}
+
+// OpenExchangeRatesFXFeed implements the FXFeed interface for the Open Exchange Rates API.
+type OpenExchangeRatesFXFeed struct {
+	httpClient *http.Client
+	apiURL     string
+	appID      string
+	currencies []string
+	log        logger.Logger
+}
+
+// NewOpenExchangeRatesFXFeed creates a new OpenExchangeRatesFXFeed instance.
+func NewOpenExchangeRatesFXFeed(cfg config.FXConfig, log logger.Logger) (*OpenExchangeRatesFXFeed, error) {
+	if cfg.APIKey == "" {
+		return nil, errors.NewConfigurationError("Open Exchange Rates API key is required")
+	}
+	if cfg.APIURL == "" {
+		cfg.APIURL = DefaultOpenExchangeRatesAPIURL
+	}
+
+	return &OpenExchangeRatesFXFeed{
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		apiURL:     cfg.APIURL,
+		appID:      cfg.APIKey,
+		currencies: normalizeCurrencies(cfg.Currencies),
+		log:        log.With(logger.String("provider", "openexchangerates")),
+	}, nil
+}
+
+// GetRates fetches the latest USD exchange rates from the Open Exchange Rates API.
+func (o *OpenExchangeRatesFXFeed) GetRates(ctx context.Context) (*RatesData, error) {
+	urlVal, err := url.Parse(o.apiURL)
+	if err != nil {
+		return nil, errors.NewConfigurationError(fmt.Sprintf("invalid Open Exchange Rates API URL: %s", o.apiURL))
+	}
+
+	query := urlVal.Query()
+	query.Set("app_id", o.appID)
+	query.Set("base", BaseCurrency)
+	if len(o.currencies) > 0 {
+		query.Set("symbols", strings.Join(o.currencies, ","))
+	}
+	urlVal.RawQuery = query.Encode()
+
+	var apiResponse openExchangeRatesResponse
+	if err := getJSON(ctx, o.httpClient, urlVal.String(), &apiResponse); err != nil {
+		o.log.Error("Failed to fetch Open Exchange Rates exchange rates", logger.Error(err))
+		return nil, err
+	}
+
+	if !strings.EqualFold(apiResponse.Base, BaseCurrency) {
+		return nil, errors.NewInvalidFXFeedResponse(nil, fmt.Sprintf("unexpected base currency %q", apiResponse.Base))
+	}
+	if apiResponse.Timestamp <= 0 {
+		return nil, errors.NewInvalidFXFeedResponse(nil, "missing rates timestamp")
+	}
+
+	rates := validRates(apiResponse.Rates)
+	// The base currency is part of the response but carries no information
+	delete(rates, BaseCurrency)
+
+	o.log.Info("Successfully fetched exchange rates", logger.Int("count", len(rates)))
+	return &RatesData{Rates: rates, AsOf: time.Unix(apiResponse.Timestamp, 0), Source: "openexchangerates"}, nil
+}
@@ -93,13 +93,13 @@ func (ks *DBKeyStore) Create(ctx context.Context, name string, keyType types.Key
 	}
 	keyID := strconv.FormatInt(snowflakeID, 10)
 
-	// Create the key
+	// Create the key, its creation time is stored in Unix seconds
 	key := &Key{
 		ID:        keyID,
 		Name:      name,
 		Type:      keyType,
 		Tags:      tags,
-		CreatedAt: time.Now(),
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
 		Curve:     curve,
 	}
 
@@ -183,7 +183,7 @@ func (ks *DBKeyStore) Import(ctx context.Context, name string, keyType types.Key
 		Name:       name,
 		Type:       keyType,
 		Tags:       tags,
-		CreatedAt:  time.Now(),
+		CreatedAt:  time.Now().UTC().Truncate(time.Second),
 		PrivateKey: encryptedPrivateKey,
 		PublicKey:  publicKey,
 		Curve:      curve,
@@ -413,6 +413,11 @@ func (ks *DBKeyStore) Delete(ctx context.Context, id string) error {
 		return errors.NewResourceNotFoundError("key", id)
 	}
 
+	// The signing audit log is kept for deleted keys
+	if _, err := ks.db.ExecuteStatementContext(ctx, "DELETE FROM key_policies WHERE key_id = ?", id); err != nil {
+		return errors.NewDatabaseError(err)
+	}
+
 	return nil
 }
 
@@ -449,17 +454,43 @@ func (ks *DBKeyStore) Sign(ctx context.Context, id string, data []byte, dataType
 		return nil, errors.NewDatabaseError(err)
 	}
 
+	// Release the connection before evaluating the policy
+	rows.Close()
+
 	// Convert key type
 	key.Type = types.KeyType(keyType)
 
-	// Decrypt the private key
-	privateKey, err := ks.encryptor.Decrypt(key.PrivateKey)
-	if err != nil {
-		return nil, err
-	}
+	// Evaluate the key policy before touching the private key
+	req := SignRequestFromContext(ctx)
+	entry := &SigningAuditEntry{
+		KeyID:       key.ID,
+		Operation:   req.Operation,
+		Actor:       ActorFromContext(ctx),
+		DataType:    dataType,
+		Digest:      auditDigest(data, dataType),
+		Destination: req.Destination,
+	}
+	return ks.authorizeAndSign(ctx, entry, func() ([]byte, error) {
+		// Decrypt the private key
+		privateKey, err := ks.encryptor.Decrypt(key.PrivateKey)
+		if err != nil {
+			return nil, err
+		}
 
-	// Sign the data
-	return ks.signData(key.Type, privateKey, data, dataType, curveName)
+		// Sign the data
+		signature, err := ks.signData(key.Type, privateKey, data, dataType, curveName)
+		if err != nil {
+			return nil, err
+		}
+
+		if req.TransactionHash != nil {
+			entry.TransactionHash, err = req.TransactionHash(signature)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return signature, nil
+	})
 }
 
 // signData handles signing based on key type
@@ -583,3 +614,59 @@ func (ks *DBKeyStore) signWithHMAC(keyBytes, data []byte) ([]byte, error) {
 	// Return the HMAC
 	return h.Sum(nil), nil
 }
+
+// ExportV3 exports a secp256k1 key as a Web3 Secret Storage (V3) JSON document
+func (ks *DBKeyStore) ExportV3(ctx context.Context, id string, passphrase string, kdf coreCrypto.KDF) ([]byte, error) {
+	var (
+		keyType    string
+		curveName  string
+		privateKey []byte
+	)
+
+	rows, err := ks.db.ExecuteQueryContext(
+		ctx,
+		"SELECT key_type, curve, private_key FROM keys WHERE id = ?",
+		id,
+	)
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, errors.NewResourceNotFoundError("key", id)
+	}
+
+	if err := rows.Scan(&keyType, &curveName, &privateKey); err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+	rows.Close()
+
+	policy, err := ks.loadPolicy(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if reason := policy.Check(OperationExport, "", ""); reason != "" {
+		return nil, errors.NewKeyPolicyDeniedError(id, reason)
+	}
+
+	if types.KeyType(keyType) != types.KeyTypeECDSA || curveName != types.CurveNameSecp256k1 {
+		return nil, errors.NewKeyNotExportableError(id, "only secp256k1 ECDSA keys can be exported as V3 keystores")
+	}
+
+	// Decrypt the private key
+	decrypted, err := ks.encryptor.Decrypt(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	privKey, err := coreCrypto.UnmarshalPrivateKey(decrypted)
+	if err != nil {
+		return nil, errors.NewInvalidKeyError("failed to parse secp256k1 private key", err)
+	}
+
+	scalar := make([]byte, 32)
+	privKey.D.FillBytes(scalar)
+
+	return coreCrypto.EncryptKeystoreV3(scalar, passphrase, kdf)
+}
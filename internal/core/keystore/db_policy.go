@@ -0,0 +1,417 @@
+package keystore
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"vault0/internal/errors"
+	"vault0/internal/types"
+)
+
+// GetPolicy retrieves the usage policy of a key
+func (ks *DBKeyStore) GetPolicy(ctx context.Context, id string) (*KeyPolicy, error) {
+	if err := ks.ensureKeyExists(ctx, id); err != nil {
+		return nil, err
+	}
+
+	policy, err := ks.loadPolicy(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return &KeyPolicy{KeyID: id}, nil
+	}
+
+	return policy, nil
+}
+
+// SetPolicy creates or replaces the usage policy of a key
+func (ks *DBKeyStore) SetPolicy(ctx context.Context, policy *KeyPolicy) (*KeyPolicy, error) {
+	if policy == nil {
+		return nil, errors.NewMissingParameterError("policy")
+	}
+	if policy.RateLimit < 0 || policy.RateLimitWindow < 0 {
+		return nil, errors.NewInvalidParameterError("rate_limit", "must not be negative")
+	}
+	if policy.RateLimit > 0 && policy.RateLimitWindow < time.Second {
+		return nil, errors.NewInvalidParameterError("rate_limit_window", "must be at least one second when a rate limit is set")
+	}
+
+	if err := ks.ensureKeyExists(ctx, policy.KeyID); err != nil {
+		return nil, err
+	}
+
+	operationsJSON, err := json.Marshal(policy.AllowedOperations)
+	if err != nil {
+		return nil, errors.NewInvalidParameterError("allowed_operations", err.Error())
+	}
+	dataTypesJSON, err := json.Marshal(policy.AllowedDataTypes)
+	if err != nil {
+		return nil, errors.NewInvalidParameterError("allowed_data_types", err.Error())
+	}
+	destinationsJSON, err := json.Marshal(policy.AllowedDestinations)
+	if err != nil {
+		return nil, errors.NewInvalidParameterError("allowed_destinations", err.Error())
+	}
+
+	policy.UpdatedAt = time.Now().UTC()
+
+	_, err = ks.db.ExecuteStatementContext(
+		ctx,
+		`INSERT INTO key_policies (key_id, disabled, allowed_operations, allowed_data_types, rate_limit, rate_limit_window, allowed_destinations, allow_unverified_destinations, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(key_id) DO UPDATE SET
+			disabled = excluded.disabled,
+			allowed_operations = excluded.allowed_operations,
+			allowed_data_types = excluded.allowed_data_types,
+			rate_limit = excluded.rate_limit,
+			rate_limit_window = excluded.rate_limit_window,
+			allowed_destinations = excluded.allowed_destinations,
+			allow_unverified_destinations = excluded.allow_unverified_destinations,
+			updated_at = excluded.updated_at`,
+		policy.KeyID,
+		policy.Disabled,
+		string(operationsJSON),
+		string(dataTypesJSON),
+		policy.RateLimit,
+		int64(policy.RateLimitWindow/time.Second),
+		string(destinationsJSON),
+		policy.AllowUnverifiedDestinations,
+		policy.UpdatedAt,
+	)
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	return policy, nil
+}
+
+// ListSigningAudit retrieves signing audit log entries, newest first
+func (ks *DBKeyStore) ListSigningAudit(ctx context.Context, filter SigningAuditFilter, limit int, nextToken string) (*types.Page[*SigningAuditEntry], error) {
+	if limit <= 0 {
+		limit = 50 // Default limit
+	}
+
+	paginationColumn := "id"
+
+	query := `SELECT id, key_id, operation, actor, data_type, digest, destination, transaction_hash, status, reason, created_at
+		FROM signing_audit_log`
+
+	var (
+		conditions []string
+		args       []any
+	)
+
+	if filter.KeyID != "" {
+		conditions = append(conditions, "key_id = ?")
+		args = append(args, filter.KeyID)
+	}
+	if filter.Actor != "" {
+		conditions = append(conditions, "actor = ?")
+		args = append(args, filter.Actor)
+	}
+	if filter.TransactionHash != "" {
+		conditions = append(conditions, "transaction_hash = ?")
+		args = append(args, filter.TransactionHash)
+	}
+
+	// Handle token-based pagination
+	if nextToken != "" {
+		token, err := types.DecodeNextPageToken(nextToken, paginationColumn)
+		if err != nil {
+			return nil, err
+		}
+
+		if token != nil {
+			value, ok := token.GetValueInt64()
+			if !ok {
+				return nil, errors.NewInvalidPaginationTokenError(nextToken, nil)
+			}
+			conditions = append(conditions, "id < ?")
+			args = append(args, value)
+		}
+	}
+
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query += " ORDER BY id DESC LIMIT ?"
+	args = append(args, limit+1) // Fetch one extra to determine if there are more pages
+
+	rows, err := ks.db.ExecuteQueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+	defer rows.Close()
+
+	var entries []*SigningAuditEntry
+	for rows.Next() {
+		var (
+			entry           SigningAuditEntry
+			destination     sql.NullString
+			transactionHash sql.NullString
+			reason          sql.NullString
+		)
+
+		err := rows.Scan(
+			&entry.ID,
+			&entry.KeyID,
+			&entry.Operation,
+			&entry.Actor,
+			&entry.DataType,
+			&entry.Digest,
+			&destination,
+			&transactionHash,
+			&entry.Status,
+			&reason,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, errors.NewDatabaseError(err)
+		}
+
+		entry.Destination = destination.String
+		entry.TransactionHash = transactionHash.String
+		entry.Reason = reason.String
+
+		entries = append(entries, &entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	generateToken := func(entry *SigningAuditEntry) *types.NextPageToken {
+		return &types.NextPageToken{
+			Column: paginationColumn,
+			Value:  strconv.FormatInt(entry.ID, 10), // Snowflake IDs exceed float64 precision
+		}
+	}
+
+	return types.NewPage(entries, limit, generateToken), nil
+}
+
+// ensureKeyExists returns ErrResourceNotFound if the key does not exist
+func (ks *DBKeyStore) ensureKeyExists(ctx context.Context, id string) error {
+	rows, err := ks.db.ExecuteQueryContext(ctx, "SELECT 1 FROM keys WHERE id = ?", id)
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return errors.NewResourceNotFoundError("key", id)
+	}
+	return nil
+}
+
+// loadPolicy loads the policy of a key, returning nil if the key has none
+func (ks *DBKeyStore) loadPolicy(ctx context.Context, id string) (*KeyPolicy, error) {
+	rows, err := ks.db.ExecuteQueryContext(
+		ctx,
+		`SELECT key_id, disabled, allowed_operations, allowed_data_types, rate_limit, rate_limit_window, allowed_destinations, allow_unverified_destinations, updated_at
+		FROM key_policies WHERE key_id = ?`,
+		id,
+	)
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, nil
+	}
+
+	var (
+		policy           KeyPolicy
+		operationsJSON   sql.NullString
+		dataTypesJSON    sql.NullString
+		destinationsJSON sql.NullString
+		windowSeconds    int64
+	)
+
+	err = rows.Scan(
+		&policy.KeyID,
+		&policy.Disabled,
+		&operationsJSON,
+		&dataTypesJSON,
+		&policy.RateLimit,
+		&windowSeconds,
+		&destinationsJSON,
+		&policy.AllowUnverifiedDestinations,
+		&policy.UpdatedAt,
+	)
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	policy.RateLimitWindow = time.Duration(windowSeconds) * time.Second
+
+	if err := unmarshalPolicyList(operationsJSON, &policy.AllowedOperations); err != nil {
+		return nil, err
+	}
+	if err := unmarshalPolicyList(dataTypesJSON, &policy.AllowedDataTypes); err != nil {
+		return nil, err
+	}
+	if err := unmarshalPolicyList(destinationsJSON, &policy.AllowedDestinations); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// unmarshalPolicyList decodes a nullable JSON array column
+func unmarshalPolicyList[T any](data sql.NullString, target *[]T) error {
+	if !data.Valid || data.String == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(data.String), target); err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+// authorizeAndSign evaluates the key policy for a signing request and, when it is
+// allowed, produces the signature with sign and records it in the audit log. The
+// policy check, the signature and its audit entry form a single transaction in which
+// the policy of the key is locked, so that concurrent requests can't all see the same
+// signature count and exceed the rate limit. Denied requests are recorded in the
+// audit log before the error is returned.
+func (ks *DBKeyStore) authorizeAndSign(ctx context.Context, entry *SigningAuditEntry, sign func() ([]byte, error)) ([]byte, error) {
+	var (
+		signature []byte
+		denied    error
+	)
+
+	err := ks.db.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := ks.lockPolicy(ctx, entry.KeyID); err != nil {
+			return err
+		}
+
+		policy, err := ks.loadPolicy(ctx, entry.KeyID)
+		if err != nil {
+			return err
+		}
+
+		if reason := policy.Check(entry.Operation, entry.DataType, entry.Destination); reason != "" {
+			denied = errors.NewKeyPolicyDeniedError(entry.KeyID, reason)
+			return ks.recordDenied(ctx, entry, reason)
+		}
+
+		if policy.RateLimited() {
+			count, err := ks.countSignatures(ctx, entry.KeyID, time.Now().UTC().Add(-policy.RateLimitWindow))
+			if err != nil {
+				return err
+			}
+			if count >= policy.RateLimit {
+				denied = errors.NewKeyRateLimitedError(entry.KeyID, policy.RateLimit, policy.RateLimitWindow)
+				return ks.recordDenied(ctx, entry, "rate limit exceeded")
+			}
+		}
+
+		signature, err = sign()
+		if err != nil {
+			return err
+		}
+
+		// A signature is only released once it has been recorded
+		entry.Status = AuditStatusSigned
+		return ks.recordAudit(ctx, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if denied != nil {
+		return nil, denied
+	}
+	return signature, nil
+}
+
+// lockPolicy locks the policy of a key until the end of the transaction carried by
+// ctx, serializing the signing requests of the key. SQLite transactions already hold
+// the database write lock from their start.
+func (ks *DBKeyStore) lockPolicy(ctx context.Context, id string) error {
+	_, err := ks.db.ExecuteStatementContext(ctx, "UPDATE key_policies SET rate_limit = rate_limit WHERE key_id = ?", id)
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+// countSignatures counts the signatures produced by a key since the given time
+func (ks *DBKeyStore) countSignatures(ctx context.Context, id string, since time.Time) (int, error) {
+	rows, err := ks.db.ExecuteQueryContext(
+		ctx,
+		"SELECT COUNT(*) FROM signing_audit_log WHERE key_id = ? AND status = ? AND created_at >= ?",
+		id,
+		string(AuditStatusSigned),
+		since,
+	)
+	if err != nil {
+		return 0, errors.NewDatabaseError(err)
+	}
+	defer rows.Close()
+
+	var count int
+	if rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return 0, errors.NewDatabaseError(err)
+		}
+	}
+	return count, nil
+}
+
+// recordDenied appends a denied signing attempt to the audit log
+func (ks *DBKeyStore) recordDenied(ctx context.Context, entry *SigningAuditEntry, reason string) error {
+	entry.Status = AuditStatusDenied
+	entry.Reason = reason
+	return ks.recordAudit(ctx, entry)
+}
+
+// recordAudit appends an entry to the signing audit log
+func (ks *DBKeyStore) recordAudit(ctx context.Context, entry *SigningAuditEntry) error {
+	id, err := ks.db.GenerateID()
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	entry.ID = id
+	entry.CreatedAt = time.Now().UTC()
+
+	_, err = ks.db.ExecuteStatementContext(
+		ctx,
+		`INSERT INTO signing_audit_log (id, key_id, operation, actor, data_type, digest, destination, transaction_hash, status, reason, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		entry.ID,
+		entry.KeyID,
+		string(entry.Operation),
+		entry.Actor,
+		string(entry.DataType),
+		entry.Digest,
+		entry.Destination,
+		entry.TransactionHash,
+		string(entry.Status),
+		entry.Reason,
+		entry.CreatedAt,
+	)
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+
+	return nil
+}
+
+// auditDigest returns the hex-encoded digest recorded for signed data
+func auditDigest(data []byte, dataType DataType) string {
+	if dataType == DataTypeDigest {
+		return hex.EncodeToString(data)
+	}
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
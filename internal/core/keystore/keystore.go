@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"vault0/internal/config"
+	coreCrypto "vault0/internal/core/crypto"
 	"vault0/internal/db"
 	"vault0/internal/errors"
 	"vault0/internal/types"
@@ -180,6 +181,59 @@ type KeyStore interface {
 	// Returns:
 	//   - error: ErrKeyNotFound if key doesn't exist
 	Delete(ctx context.Context, id string) error
+
+	// ExportV3 exports a secp256k1 key as a Web3 Secret Storage (V3) JSON document.
+	// The private key leaves the keystore encrypted with the provided passphrase.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - id: The ID of the key to export
+	//   - passphrase: Passphrase used to encrypt the exported document
+	//   - kdf: Key derivation function to use (scrypt or pbkdf2)
+	//
+	// Returns:
+	//   - []byte: The V3 keystore JSON document
+	//   - error: ErrKeyNotFound if key doesn't exist, ErrKeyNotExportable if the key
+	//     is not a secp256k1 ECDSA key
+	ExportV3(ctx context.Context, id string, passphrase string, kdf coreCrypto.KDF) ([]byte, error)
+
+	// GetPolicy retrieves the usage policy of a key. Keys without a stored
+	// policy return an empty, unrestricted policy.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - id: The ID of the key
+	//
+	// Returns:
+	//   - *KeyPolicy: The key's policy
+	//   - error: ErrKeyNotFound if key doesn't exist
+	GetPolicy(ctx context.Context, id string) (*KeyPolicy, error)
+
+	// SetPolicy creates or replaces the usage policy of a key. The policy is
+	// evaluated before every subsequent Sign and ExportV3 call.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - policy: The policy to store; KeyID identifies the key
+	//
+	// Returns:
+	//   - *KeyPolicy: The stored policy
+	//   - error: ErrKeyNotFound if key doesn't exist, or validation errors
+	SetPolicy(ctx context.Context, policy *KeyPolicy) (*KeyPolicy, error)
+
+	// ListSigningAudit retrieves entries of the append-only signing audit log,
+	// newest first.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - filter: Criteria to narrow the entries
+	//   - limit: Maximum number of entries to retrieve
+	//   - nextToken: Token for retrieving the next page of results
+	//
+	// Returns:
+	//   - *types.Page[*SigningAuditEntry]: Paginated list of audit entries
+	//   - error: Any error that occurred during retrieval
+	ListSigningAudit(ctx context.Context, filter SigningAuditFilter, limit int, nextToken string) (*types.Page[*SigningAuditEntry], error)
 }
 
 // NewKeyStore creates a new KeyStore instance based on the configuration.
@@ -1,18 +1,14 @@
 package keystore
 
 import (
-	"database/sql"
 	"testing"
 
 	"vault0/internal/config"
 	"vault0/internal/core/crypto"
 	"vault0/internal/db"
-	"vault0/internal/logger"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-
-	_ "github.com/mattn/go-sqlite3"
 )
 
 // testConfig creates a config for testing purposes
@@ -26,54 +22,13 @@ func testConfig() *config.Config {
 	}
 }
 
-// setupTestDB sets up a test database with the keys table
-func setupTestDB(t *testing.T) (*db.DB, func()) {
-	sqldb, err := sql.Open("sqlite3", ":memory:")
-	require.NoError(t, err)
-
-	// Create the keys table
-	// Note: Using the same schema as expected by the DBKeyStore implementation
-	_, err = sqldb.Exec(`
-		CREATE TABLE IF NOT EXISTS keys (
-			id TEXT PRIMARY KEY,
-			name TEXT NOT NULL UNIQUE,
-			key_type TEXT NOT NULL,
-			curve TEXT,
-			tags TEXT,
-			private_key BLOB,
-			public_key BLOB,
-			created_at INTEGER NOT NULL
-		)
-	`)
-	require.NoError(t, err)
-
-	// Return a cleanup function
-	cleanup := func() {
-		sqldb.Close()
-	}
-
-	return &db.DB{
-		Conn:   sqldb,
-		Config: testConfig(),
-		Log:    logger.NewNopLogger(),
-	}, cleanup
-}
-
-// setupTestKeyStore creates a test KeyStore instance
-func setupTestKeyStore(t *testing.T) (KeyStore, *db.DB, func()) {
-	db, dbCleanup := setupTestDB(t)
-	cfg := testConfig()
+// newTestKeyStore creates a KeyStore backed by a test database
+func newTestKeyStore(t *testing.T, database *db.DB) KeyStore {
+	t.Helper()
 
-	// Create a DBKeyStore
-	dbKeyStore, err := NewDBKeyStore(db, cfg)
+	keystore, err := NewDBKeyStore(database, testConfig())
 	require.NoError(t, err)
-
-	cleanup := func() {
-		// No need to close the keystore anymore
-		dbCleanup()
-	}
-
-	return dbKeyStore, db, cleanup
+	return keystore
 }
 
 func TestConfig(t *testing.T) {
@@ -21,16 +21,18 @@ import (
 
 // MockKeyStore provides a mock implementation of the KeyStore interface for testing
 type MockKeyStore struct {
-	Keys   map[string]*Key
-	keyGen keygen.KeyGenerator
-	mutex  sync.RWMutex
+	Keys     map[string]*Key
+	Policies map[string]*KeyPolicy
+	keyGen   keygen.KeyGenerator
+	mutex    sync.RWMutex
 }
 
 // NewMockKeyStore creates a new instance of the MockKeyStore
 func NewMockKeyStore() *MockKeyStore {
 	return &MockKeyStore{
-		Keys:   make(map[string]*Key),
-		keyGen: keygen.NewKeyGenerator(),
+		Keys:     make(map[string]*Key),
+		Policies: make(map[string]*KeyPolicy),
+		keyGen:   keygen.NewKeyGenerator(),
 	}
 }
 
@@ -260,5 +262,68 @@ func (ks *MockKeyStore) Delete(ctx context.Context, id string) error {
 
 	// Delete the key
 	delete(ks.Keys, id)
+	delete(ks.Policies, id)
 	return nil
 }
+
+// ExportV3 exports a secp256k1 key as a V3 keystore document
+func (ks *MockKeyStore) ExportV3(ctx context.Context, id string, passphrase string, kdf crypto.KDF) ([]byte, error) {
+	ks.mutex.RLock()
+	defer ks.mutex.RUnlock()
+
+	key, exists := ks.Keys[id]
+	if !exists {
+		return nil, errors.NewKeyNotFoundError(id)
+	}
+
+	if key.Type != types.KeyTypeECDSA || key.Curve != crypto.Secp256k1Curve {
+		return nil, errors.NewKeyNotExportableError(id, "only secp256k1 ECDSA keys can be exported as V3 keystores")
+	}
+
+	privateKey, err := crypto.UnmarshalPrivateKey(key.PrivateKey)
+	if err != nil {
+		return nil, errors.NewInvalidKeyError("failed to parse secp256k1 private key", err)
+	}
+
+	scalar := make([]byte, 32)
+	privateKey.D.FillBytes(scalar)
+
+	return crypto.EncryptKeystoreV3(scalar, passphrase, kdf)
+}
+
+// GetPolicy retrieves the usage policy of a key
+func (ks *MockKeyStore) GetPolicy(ctx context.Context, id string) (*KeyPolicy, error) {
+	ks.mutex.RLock()
+	defer ks.mutex.RUnlock()
+
+	if _, exists := ks.Keys[id]; !exists {
+		return nil, errors.NewKeyNotFoundError(id)
+	}
+
+	if policy, exists := ks.Policies[id]; exists {
+		return policy, nil
+	}
+	return &KeyPolicy{KeyID: id}, nil
+}
+
+// SetPolicy creates or replaces the usage policy of a key
+func (ks *MockKeyStore) SetPolicy(ctx context.Context, policy *KeyPolicy) (*KeyPolicy, error) {
+	ks.mutex.Lock()
+	defer ks.mutex.Unlock()
+
+	if _, exists := ks.Keys[policy.KeyID]; !exists {
+		return nil, errors.NewKeyNotFoundError(policy.KeyID)
+	}
+
+	policy.UpdatedAt = time.Now()
+	ks.Policies[policy.KeyID] = policy
+	return policy, nil
+}
+
+// ListSigningAudit returns an empty page; the mock does not keep an audit log
+func (ks *MockKeyStore) ListSigningAudit(ctx context.Context, filter SigningAuditFilter, limit int, nextToken string) (*types.Page[*SigningAuditEntry], error) {
+	return &types.Page[*SigningAuditEntry]{
+		Items: []*SigningAuditEntry{},
+		Limit: limit,
+	}, nil
+}
@@ -0,0 +1,230 @@
+package keystore
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Operation identifies what a key is being used for. Policies restrict keys to
+// a subset of operations.
+type Operation string
+
+// Supported key operations.
+const (
+	// OperationSign is a generic signing request over arbitrary data or a digest
+	OperationSign Operation = "sign"
+
+	// OperationSignTransaction signs a blockchain transaction
+	OperationSignTransaction Operation = "sign_transaction"
+
+	// OperationSignMessage signs an off-chain message such as EIP-191 or EIP-712 data
+	OperationSignMessage Operation = "sign_message"
+
+	// OperationExport exports the private key material out of the keystore
+	OperationExport Operation = "export"
+)
+
+// AuditStatus is the outcome of a signing attempt recorded in the audit log.
+type AuditStatus string
+
+// Audit statuses.
+const (
+	// AuditStatusSigned indicates a signature was produced
+	AuditStatusSigned AuditStatus = "signed"
+
+	// AuditStatusDenied indicates the key policy rejected the request
+	AuditStatusDenied AuditStatus = "denied"
+)
+
+// KeyPolicy restricts how a key may be used. A zero-value policy allows
+// everything; each non-empty field narrows what is permitted.
+type KeyPolicy struct {
+	// KeyID is the ID of the key the policy applies to
+	KeyID string
+
+	// Disabled blocks every operation on the key
+	Disabled bool
+
+	// AllowedOperations lists the permitted operations (empty means all)
+	AllowedOperations []Operation
+
+	// AllowedDataTypes lists the permitted data types for signing (empty means all)
+	AllowedDataTypes []DataType
+
+	// RateLimit is the maximum number of signatures within RateLimitWindow (0 means unlimited)
+	RateLimit int
+
+	// RateLimitWindow is the sliding window the rate limit applies to
+	RateLimitWindow time.Duration
+
+	// AllowedDestinations lists the addresses transactions may be sent to
+	// (empty means any). Comparison is case-insensitive. When set, signing
+	// operations without a verifiable destination, such as generic digests and
+	// messages, are denied unless AllowUnverifiedDestinations is set.
+	AllowedDestinations []string
+
+	// AllowUnverifiedDestinations permits signing operations whose destination
+	// can't be checked against AllowedDestinations
+	AllowUnverifiedDestinations bool
+
+	// UpdatedAt is when the policy was last changed
+	UpdatedAt time.Time
+}
+
+// SignRequest describes the purpose of a signing operation. It travels in the
+// context so that callers can annotate a Sign call without changing the
+// KeyStore interface; the keystore evaluates it against the key policy and
+// records it in the signing audit log.
+type SignRequest struct {
+	// Operation is the kind of signing being performed (defaults to OperationSign)
+	Operation Operation
+
+	// Destination is the recipient address of a transaction, if any
+	Destination string
+
+	// TransactionHash derives the hash of the transaction being signed from the
+	// raw keystore signature, so it can be recorded in the audit log. Optional.
+	TransactionHash func(signature []byte) (string, error)
+}
+
+// SigningAuditEntry is a single record in the append-only signing audit log.
+type SigningAuditEntry struct {
+	// ID is the unique identifier of the entry
+	ID int64
+
+	// KeyID is the ID of the key that was used
+	KeyID string
+
+	// Operation is the kind of signing that was requested
+	Operation Operation
+
+	// Actor identifies who requested the signature
+	Actor string
+
+	// DataType is how the signed data was provided
+	DataType DataType
+
+	// Digest is the hex-encoded digest that was signed. For raw data it is the
+	// SHA-256 hash of the data.
+	Digest string
+
+	// Destination is the recipient address of a signed transaction, if any
+	Destination string
+
+	// TransactionHash is the hash of the signed transaction, if any
+	TransactionHash string
+
+	// Status is the outcome of the request
+	Status AuditStatus
+
+	// Reason explains why a request was denied
+	Reason string
+
+	// CreatedAt is when the signing attempt happened
+	CreatedAt time.Time
+}
+
+// SigningAuditFilter narrows the entries returned from the signing audit log.
+type SigningAuditFilter struct {
+	// KeyID returns only entries for the given key
+	KeyID string
+
+	// Actor returns only entries requested by the given actor
+	Actor string
+
+	// TransactionHash returns only entries for the given transaction
+	TransactionHash string
+}
+
+// DefaultActor is recorded in the audit log when no actor is set in the context
+const DefaultActor = "system"
+
+type signRequestKey struct{}
+
+type actorKey struct{}
+
+// WithSignRequest returns a context carrying the signing request metadata
+func WithSignRequest(ctx context.Context, req SignRequest) context.Context {
+	return context.WithValue(ctx, signRequestKey{}, req)
+}
+
+// SignRequestFromContext returns the signing request metadata stored in the
+// context, defaulting to a generic OperationSign request
+func SignRequestFromContext(ctx context.Context) SignRequest {
+	req, _ := ctx.Value(signRequestKey{}).(SignRequest)
+	if req.Operation == "" {
+		req.Operation = OperationSign
+	}
+	return req
+}
+
+// WithActor returns a context identifying who is requesting key operations
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext returns the actor stored in the context, or DefaultActor
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return DefaultActor
+}
+
+// Check evaluates a request against the policy, ignoring rate limits which
+// depend on the audit log. It returns a non-empty reason if the request is denied.
+func (p *KeyPolicy) Check(operation Operation, dataType DataType, destination string) string {
+	if p == nil {
+		return ""
+	}
+
+	if p.Disabled {
+		return "key is disabled"
+	}
+
+	if len(p.AllowedOperations) > 0 && !slices.Contains(p.AllowedOperations, operation) {
+		return fmt.Sprintf("operation %s is not allowed", operation)
+	}
+
+	// Exports don't sign anything, so data type and destination rules don't apply
+	if operation == OperationExport {
+		return ""
+	}
+
+	if len(p.AllowedDataTypes) > 0 && !slices.Contains(p.AllowedDataTypes, dataType) {
+		return fmt.Sprintf("data type %s is not allowed", dataType)
+	}
+
+	if len(p.AllowedDestinations) == 0 {
+		return ""
+	}
+
+	// Only transactions carry a destination the keystore can check. Any other
+	// signature, such as of a transaction digest, could bypass the allowlist.
+	if operation != OperationSignTransaction {
+		if p.AllowUnverifiedDestinations {
+			return ""
+		}
+		return fmt.Sprintf("operation %s has no verifiable destination", operation)
+	}
+
+	allowed := slices.ContainsFunc(p.AllowedDestinations, func(address string) bool {
+		return strings.EqualFold(address, destination)
+	})
+	if !allowed {
+		if destination == "" {
+			return "contract deployments are not allowed"
+		}
+		return fmt.Sprintf("destination %s is not allowed", destination)
+	}
+
+	return ""
+}
+
+// RateLimited reports whether the policy enforces a signing rate limit
+func (p *KeyPolicy) RateLimited() bool {
+	return p != nil && p.RateLimit > 0 && p.RateLimitWindow > 0
+}
@@ -0,0 +1,396 @@
+package keystore
+
+import (
+	"context"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/core/crypto"
+	"vault0/internal/db"
+	"vault0/internal/errors"
+	"vault0/internal/testing/dbtest"
+	"vault0/internal/types"
+)
+
+func TestKeyPolicy_Check(t *testing.T) {
+	destination := "0x71C7656EC7ab88b098defB751B7401B5f6d8976F"
+
+	tests := []struct {
+		name        string
+		policy      *KeyPolicy
+		operation   Operation
+		dataType    DataType
+		destination string
+		denied      bool
+	}{
+		{name: "Nil policy", policy: nil, operation: OperationSign, dataType: DataTypeRaw},
+		{name: "Empty policy", policy: &KeyPolicy{}, operation: OperationExport},
+		{name: "Disabled", policy: &KeyPolicy{Disabled: true}, operation: OperationSign, dataType: DataTypeRaw, denied: true},
+		{
+			name:      "Operation allowed",
+			policy:    &KeyPolicy{AllowedOperations: []Operation{OperationSignTransaction}},
+			operation: OperationSignTransaction,
+			dataType:  DataTypeDigest,
+		},
+		{
+			name:      "Operation not allowed",
+			policy:    &KeyPolicy{AllowedOperations: []Operation{OperationSignTransaction}},
+			operation: OperationSignMessage,
+			dataType:  DataTypeDigest,
+			denied:    true,
+		},
+		{
+			name:      "Data type not allowed",
+			policy:    &KeyPolicy{AllowedDataTypes: []DataType{DataTypeDigest}},
+			operation: OperationSign,
+			dataType:  DataTypeRaw,
+			denied:    true,
+		},
+		{
+			name:        "Destination allowed ignoring case",
+			policy:      &KeyPolicy{AllowedDestinations: []string{destination}},
+			operation:   OperationSignTransaction,
+			dataType:    DataTypeDigest,
+			destination: "0x71c7656ec7ab88b098defb751b7401b5f6d8976f",
+		},
+		{
+			name:        "Destination not allowed",
+			policy:      &KeyPolicy{AllowedDestinations: []string{destination}},
+			operation:   OperationSignTransaction,
+			dataType:    DataTypeDigest,
+			destination: "0x0000000000000000000000000000000000000001",
+			denied:      true,
+		},
+		{
+			name:      "Contract deployment with destination allowlist",
+			policy:    &KeyPolicy{AllowedDestinations: []string{destination}},
+			operation: OperationSignTransaction,
+			dataType:  DataTypeDigest,
+			denied:    true,
+		},
+		{
+			name:      "Message without verifiable destination",
+			policy:    &KeyPolicy{AllowedDestinations: []string{destination}},
+			operation: OperationSignMessage,
+			dataType:  DataTypeDigest,
+			denied:    true,
+		},
+		{
+			name:      "Digest without verifiable destination",
+			policy:    &KeyPolicy{AllowedDestinations: []string{destination}},
+			operation: OperationSign,
+			dataType:  DataTypeDigest,
+			denied:    true,
+		},
+		{
+			name:      "Raw data without verifiable destination",
+			policy:    &KeyPolicy{AllowedDestinations: []string{destination}},
+			operation: OperationSign,
+			dataType:  DataTypeRaw,
+			denied:    true,
+		},
+		{
+			name:      "Unverified destinations explicitly allowed",
+			policy:    &KeyPolicy{AllowedDestinations: []string{destination}, AllowUnverifiedDestinations: true},
+			operation: OperationSign,
+			dataType:  DataTypeDigest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reason := tc.policy.Check(tc.operation, tc.dataType, tc.destination)
+			assert.Equal(t, tc.denied, reason != "", reason)
+		})
+	}
+}
+
+func TestSignRequestContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, OperationSign, SignRequestFromContext(ctx).Operation)
+	assert.Equal(t, DefaultActor, ActorFromContext(ctx))
+
+	ctx = WithActor(ctx, "127.0.0.1")
+	ctx = WithSignRequest(ctx, SignRequest{Operation: OperationSignMessage})
+	assert.Equal(t, OperationSignMessage, SignRequestFromContext(ctx).Operation)
+	assert.Equal(t, "127.0.0.1", ActorFromContext(ctx))
+}
+
+func TestDBKeyStore_Policy(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		keystore := newTestKeyStore(t, database)
+
+		ctx := WithActor(context.Background(), "tester")
+		digest := sha256.Sum256([]byte("test data"))
+
+		t.Run("Default policy allows signing and records audit", func(t *testing.T) {
+			key, err := keystore.Create(ctx, "Unrestricted Key", types.KeyTypeECDSA, elliptic.P256(), nil)
+			require.NoError(t, err)
+
+			policy, err := keystore.GetPolicy(ctx, key.ID)
+			require.NoError(t, err)
+			assert.Equal(t, &KeyPolicy{KeyID: key.ID}, policy)
+
+			_, err = keystore.Sign(ctx, key.ID, digest[:], DataTypeDigest)
+			require.NoError(t, err)
+
+			page, err := keystore.ListSigningAudit(ctx, SigningAuditFilter{KeyID: key.ID}, 10, "")
+			require.NoError(t, err)
+			require.Len(t, page.Items, 1)
+			entry := page.Items[0]
+			assert.Equal(t, OperationSign, entry.Operation)
+			assert.Equal(t, "tester", entry.Actor)
+			assert.Equal(t, hex.EncodeToString(digest[:]), entry.Digest)
+			assert.Equal(t, AuditStatusSigned, entry.Status)
+		})
+
+		t.Run("Disabled key is denied and the attempt recorded", func(t *testing.T) {
+			key, err := keystore.Create(ctx, "Disabled Key", types.KeyTypeECDSA, elliptic.P256(), nil)
+			require.NoError(t, err)
+
+			_, err = keystore.SetPolicy(ctx, &KeyPolicy{KeyID: key.ID, Disabled: true})
+			require.NoError(t, err)
+
+			_, err = keystore.Sign(ctx, key.ID, digest[:], DataTypeDigest)
+			assert.True(t, errors.IsError(err, errors.ErrCodeKeyPolicyDenied))
+
+			page, err := keystore.ListSigningAudit(ctx, SigningAuditFilter{KeyID: key.ID}, 10, "")
+			require.NoError(t, err)
+			require.Len(t, page.Items, 1)
+			assert.Equal(t, AuditStatusDenied, page.Items[0].Status)
+			assert.Equal(t, "key is disabled", page.Items[0].Reason)
+		})
+
+		t.Run("Policy round trip", func(t *testing.T) {
+			key, err := keystore.Create(ctx, "Restricted Key", types.KeyTypeECDSA, elliptic.P256(), nil)
+			require.NoError(t, err)
+
+			_, err = keystore.SetPolicy(ctx, &KeyPolicy{
+				KeyID:               key.ID,
+				AllowedOperations:   []Operation{OperationSignTransaction},
+				AllowedDataTypes:    []DataType{DataTypeDigest},
+				RateLimit:           5,
+				RateLimitWindow:     time.Hour,
+				AllowedDestinations: []string{"0x71C7656EC7ab88b098defB751B7401B5f6d8976F"},
+			})
+			require.NoError(t, err)
+
+			policy, err := keystore.GetPolicy(ctx, key.ID)
+			require.NoError(t, err)
+			assert.Equal(t, []Operation{OperationSignTransaction}, policy.AllowedOperations)
+			assert.Equal(t, []DataType{DataTypeDigest}, policy.AllowedDataTypes)
+			assert.Equal(t, 5, policy.RateLimit)
+			assert.Equal(t, time.Hour, policy.RateLimitWindow)
+			assert.Equal(t, []string{"0x71C7656EC7ab88b098defB751B7401B5f6d8976F"}, policy.AllowedDestinations)
+
+			// Generic signing is not an allowed operation
+			_, err = keystore.Sign(ctx, key.ID, digest[:], DataTypeDigest)
+			assert.True(t, errors.IsError(err, errors.ErrCodeKeyPolicyDenied))
+
+			txCtx := WithSignRequest(ctx, SignRequest{
+				Operation:   OperationSignTransaction,
+				Destination: "0x71c7656ec7ab88b098defb751b7401b5f6d8976f",
+				TransactionHash: func(signature []byte) (string, error) {
+					return "0xabc", nil
+				},
+			})
+			_, err = keystore.Sign(txCtx, key.ID, digest[:], DataTypeDigest)
+			require.NoError(t, err)
+
+			page, err := keystore.ListSigningAudit(ctx, SigningAuditFilter{TransactionHash: "0xabc"}, 10, "")
+			require.NoError(t, err)
+			require.Len(t, page.Items, 1)
+			assert.Equal(t, key.ID, page.Items[0].KeyID)
+			assert.Equal(t, OperationSignTransaction, page.Items[0].Operation)
+		})
+
+		t.Run("Destination allowlist can't be bypassed by signing the digest", func(t *testing.T) {
+			key, err := keystore.Create(ctx, "Allowlisted Key", types.KeyTypeECDSA, crypto.Secp256k1Curve, nil)
+			require.NoError(t, err)
+
+			_, err = keystore.SetPolicy(ctx, &KeyPolicy{
+				KeyID:               key.ID,
+				AllowedDestinations: []string{"0x71C7656EC7ab88b098defB751B7401B5f6d8976F"},
+			})
+			require.NoError(t, err)
+
+			// The digest of a transaction to any destination, signed through the generic endpoint
+			_, err = keystore.Sign(ctx, key.ID, digest[:], DataTypeDigest)
+			assert.True(t, errors.IsError(err, errors.ErrCodeKeyPolicyDenied))
+
+			messageCtx := WithSignRequest(ctx, SignRequest{Operation: OperationSignMessage})
+			_, err = keystore.Sign(messageCtx, key.ID, digest[:], DataTypeDigest)
+			assert.True(t, errors.IsError(err, errors.ErrCodeKeyPolicyDenied))
+
+			_, err = keystore.SetPolicy(ctx, &KeyPolicy{
+				KeyID:                       key.ID,
+				AllowedDestinations:         []string{"0x71C7656EC7ab88b098defB751B7401B5f6d8976F"},
+				AllowUnverifiedDestinations: true,
+			})
+			require.NoError(t, err)
+
+			policy, err := keystore.GetPolicy(ctx, key.ID)
+			require.NoError(t, err)
+			assert.True(t, policy.AllowUnverifiedDestinations)
+
+			_, err = keystore.Sign(ctx, key.ID, digest[:], DataTypeDigest)
+			require.NoError(t, err)
+		})
+
+		t.Run("Rate limit", func(t *testing.T) {
+			key, err := keystore.Create(ctx, "Rate Limited Key", types.KeyTypeECDSA, elliptic.P256(), nil)
+			require.NoError(t, err)
+
+			_, err = keystore.SetPolicy(ctx, &KeyPolicy{KeyID: key.ID, RateLimit: 2, RateLimitWindow: time.Minute})
+			require.NoError(t, err)
+
+			for i := 0; i < 2; i++ {
+				_, err = keystore.Sign(ctx, key.ID, digest[:], DataTypeDigest)
+				require.NoError(t, err)
+			}
+
+			_, err = keystore.Sign(ctx, key.ID, digest[:], DataTypeDigest)
+			assert.True(t, errors.IsError(err, errors.ErrCodeKeyRateLimited))
+		})
+
+		t.Run("Export blocked by policy", func(t *testing.T) {
+			privateKey, publicKey, err := generateTestKey(types.KeyTypeECDSA, crypto.Secp256k1Curve)
+			require.NoError(t, err)
+			key, err := keystore.Import(ctx, "Non Exportable Key", types.KeyTypeECDSA, crypto.Secp256k1Curve, privateKey, publicKey, nil)
+			require.NoError(t, err)
+
+			_, err = keystore.SetPolicy(ctx, &KeyPolicy{KeyID: key.ID, AllowedOperations: []Operation{OperationSignTransaction}})
+			require.NoError(t, err)
+
+			_, err = keystore.ExportV3(ctx, key.ID, "passphrase", crypto.KDFPBKDF2)
+			assert.True(t, errors.IsError(err, errors.ErrCodeKeyPolicyDenied))
+		})
+
+		t.Run("Audit pagination", func(t *testing.T) {
+			page, err := keystore.ListSigningAudit(ctx, SigningAuditFilter{Actor: "tester"}, 2, "")
+			require.NoError(t, err)
+			require.Len(t, page.Items, 2)
+			require.NotEmpty(t, page.NextToken)
+
+			next, err := keystore.ListSigningAudit(ctx, SigningAuditFilter{Actor: "tester"}, 2, page.NextToken)
+			require.NoError(t, err)
+			require.NotEmpty(t, next.Items)
+			assert.Less(t, next.Items[0].ID, page.Items[1].ID)
+		})
+
+		t.Run("Missing key", func(t *testing.T) {
+			_, err := keystore.GetPolicy(ctx, "non-existent-id")
+			assert.True(t, errors.IsError(err, errors.ErrCodeResourceNotFound))
+
+			_, err = keystore.SetPolicy(ctx, &KeyPolicy{KeyID: "non-existent-id"})
+			assert.True(t, errors.IsError(err, errors.ErrCodeResourceNotFound))
+		})
+	})
+}
+
+func TestDBKeyStore_ConcurrentRateLimit(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		keystore := newTestKeyStore(t, database)
+
+		ctx := context.Background()
+		key, err := keystore.Create(ctx, "Concurrent Key", types.KeyTypeRSA, nil, nil)
+		require.NoError(t, err)
+
+		const limit, requests = 3, 20
+		_, err = keystore.SetPolicy(ctx, &KeyPolicy{KeyID: key.ID, RateLimit: limit, RateLimitWindow: time.Minute})
+		require.NoError(t, err)
+
+		digest := sha256.Sum256([]byte("test data"))
+		results := make(chan error, requests)
+		start := make(chan struct{})
+		var wg sync.WaitGroup
+		for i := 0; i < requests; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				_, err := keystore.Sign(ctx, key.ID, digest[:], DataTypeDigest)
+				results <- err
+			}()
+		}
+		close(start)
+		wg.Wait()
+		close(results)
+
+		signed := 0
+		for err := range results {
+			if err == nil {
+				signed++
+				continue
+			}
+			assert.True(t, errors.IsError(err, errors.ErrCodeKeyRateLimited), err.Error())
+		}
+		assert.Equal(t, limit, signed)
+	})
+}
+
+func TestSigningAuditLog_AppendOnly(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		keystore := newTestKeyStore(t, database)
+		ctx := context.Background()
+
+		key, err := keystore.Create(ctx, "Audited Key", types.KeyTypeECDSA, elliptic.P256(), nil)
+		require.NoError(t, err)
+		digest := sha256.Sum256([]byte("test data"))
+		_, err = keystore.Sign(ctx, key.ID, digest[:], DataTypeDigest)
+		require.NoError(t, err)
+
+		// The triggers of the audit log reject rewriting it on every backend
+		_, err = database.ExecuteStatementContext(ctx, "UPDATE signing_audit_log SET status = ? WHERE key_id = ?", AuditStatusDenied, key.ID)
+		assert.ErrorContains(t, err, "append-only")
+		_, err = database.ExecuteStatementContext(ctx, "DELETE FROM signing_audit_log WHERE key_id = ?", key.ID)
+		assert.ErrorContains(t, err, "append-only")
+
+		page, err := keystore.ListSigningAudit(ctx, SigningAuditFilter{KeyID: key.ID}, 10, "")
+		require.NoError(t, err)
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, AuditStatusSigned, page.Items[0].Status)
+	})
+}
+
+func TestSigningAuditLog_PaginationBeyondFloat64(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		keystore := newTestKeyStore(t, database)
+		ctx := context.Background()
+
+		key, err := keystore.Create(ctx, "Audited Key", types.KeyTypeECDSA, elliptic.P256(), nil)
+		require.NoError(t, err)
+
+		// Consecutive IDs above 2^53 can't be told apart as float64
+		const firstID = int64(1)<<60 + 1
+		for id := firstID; id < firstID+3; id++ {
+			_, err := database.ExecuteStatementContext(ctx,
+				`INSERT INTO signing_audit_log (id, key_id, operation, actor, data_type, digest, status, created_at)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+				id, key.ID, OperationSign, "tester", DataTypeDigest, "00", AuditStatusSigned, time.Now().UTC())
+			require.NoError(t, err)
+		}
+
+		var ids []int64
+		nextToken := ""
+		for {
+			page, err := keystore.ListSigningAudit(ctx, SigningAuditFilter{KeyID: key.ID}, 1, nextToken)
+			require.NoError(t, err)
+			for _, entry := range page.Items {
+				ids = append(ids, entry.ID)
+			}
+			if page.NextToken == "" {
+				break
+			}
+			nextToken = page.NextToken
+		}
+		assert.Equal(t, []int64{firstID + 2, firstID + 1, firstID}, ids)
+	})
+}
@@ -0,0 +1,200 @@
+package leader
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"vault0/internal/db"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+)
+
+// DBElector implements the Elector interface with a lease stored in the leases table.
+// The holder renews the lease before it expires; any replica may take over a lease
+// that has expired. Expiry times are compared across replicas, whose clocks must
+// agree to well within the renew interval.
+type DBElector struct {
+	db            *db.DB
+	name          string
+	identity      string
+	leaseDuration time.Duration
+	renewInterval time.Duration
+	log           logger.Logger
+
+	mu       sync.Mutex
+	leader   bool
+	cancel   context.CancelFunc
+	done     chan struct{}
+	lost     chan struct{}
+	lostOnce sync.Once
+}
+
+// NewDBElector creates a new DBElector for the named lease
+func NewDBElector(database *db.DB, name, identity string, leaseDuration, renewInterval time.Duration, log logger.Logger) *DBElector {
+	return &DBElector{
+		db:            database,
+		name:          name,
+		identity:      identity,
+		leaseDuration: leaseDuration,
+		renewInterval: renewInterval,
+		log:           log.With(logger.String("lease", name), logger.String("identity", identity)),
+		lost:          make(chan struct{}),
+	}
+}
+
+// Identity implements the Elector interface
+func (e *DBElector) Identity() string {
+	return e.identity
+}
+
+// Campaign implements the Elector interface
+func (e *DBElector) Campaign(ctx context.Context) error {
+	ticker := time.NewTicker(e.renewInterval)
+	defer ticker.Stop()
+
+	e.log.Info("Campaigning for lease")
+	for {
+		acquired, err := e.acquire(ctx)
+		if err != nil {
+			e.log.Warn("Failed to acquire lease", logger.Error(err))
+		}
+		if acquired {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	// Renewals outlive the campaign context, until the lease is resigned
+	renewCtx, cancel := context.WithCancel(context.Background())
+	e.mu.Lock()
+	e.leader = true
+	e.cancel = cancel
+	e.done = make(chan struct{})
+	e.mu.Unlock()
+
+	e.log.Info("Acquired lease")
+	go e.renew(renewCtx, time.Now())
+	return nil
+}
+
+// IsLeader implements the Elector interface
+func (e *DBElector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.leader
+}
+
+// Lost implements the Elector interface
+func (e *DBElector) Lost() <-chan struct{} {
+	return e.lost
+}
+
+// Resign implements the Elector interface
+func (e *DBElector) Resign(ctx context.Context) error {
+	e.mu.Lock()
+	cancel, done := e.cancel, e.done
+	e.cancel = nil
+	e.mu.Unlock()
+
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	<-done
+
+	e.mu.Lock()
+	e.leader = false
+	e.mu.Unlock()
+
+	if err := e.release(ctx); err != nil {
+		e.log.Error("Failed to release lease", logger.Error(err))
+		return err
+	}
+	e.log.Info("Released lease")
+	return nil
+}
+
+// renew renews the lease every renew interval until ctx is done or the lease is lost
+func (e *DBElector) renew(ctx context.Context, renewedAt time.Time) {
+	defer close(e.done)
+
+	ticker := time.NewTicker(e.renewInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		renewed, err := e.acquire(ctx)
+		switch {
+		case renewed:
+			renewedAt = time.Now()
+		case ctx.Err() != nil:
+			return
+		case err == nil:
+			e.log.Error("Lease was taken over by another replica")
+			e.setLost()
+			return
+		case time.Since(renewedAt)+e.renewInterval >= e.leaseDuration:
+			// Give the lease up before it expires, rather than after another replica took it over
+			e.log.Error("Failed to renew lease before it expires", logger.Error(err))
+			e.setLost()
+			return
+		default:
+			e.log.Warn("Failed to renew lease", logger.Error(err))
+		}
+	}
+}
+
+// setLost marks the lease as lost
+func (e *DBElector) setLost() {
+	e.mu.Lock()
+	e.leader = false
+	e.mu.Unlock()
+	e.lostOnce.Do(func() { close(e.lost) })
+}
+
+// acquire acquires or renews the lease, unless another replica holds it and it has not
+// expired. It returns whether this replica holds the lease.
+func (e *DBElector) acquire(ctx context.Context) (bool, error) {
+	now := time.Now()
+	result, err := e.db.ExecuteStatementContext(ctx, `
+		INSERT INTO leases (name, holder, acquired_at, expires_at, updated_at)
+		VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT (name) DO UPDATE SET
+			holder = excluded.holder,
+			acquired_at = CASE WHEN leases.holder = excluded.holder THEN leases.acquired_at ELSE excluded.acquired_at END,
+			expires_at = excluded.expires_at,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE leases.holder = excluded.holder OR leases.expires_at <= ?`,
+		e.name,
+		e.identity,
+		now.UnixMilli(),
+		now.Add(e.leaseDuration).UnixMilli(),
+		now.UnixMilli(),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.NewDatabaseError(err)
+	}
+	return rows == 1, nil
+}
+
+// release deletes the lease if this replica holds it
+func (e *DBElector) release(ctx context.Context) error {
+	_, err := e.db.ExecuteStatementContext(ctx, "DELETE FROM leases WHERE name = ? AND holder = ?", e.name, e.identity)
+	return err
+}
@@ -0,0 +1,112 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"vault0/internal/config"
+	"vault0/internal/db"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+)
+
+const (
+	// WorkersLease is the name of the lease held by the replica running the background workers
+	WorkersLease = "workers"
+
+	// defaultLeaseDuration is the time after which a lease that is not renewed expires
+	defaultLeaseDuration = 15 * time.Second
+
+	// defaultRenewInterval is the interval at which a lease is renewed or tried to be acquired
+	defaultRenewInterval = 5 * time.Second
+)
+
+// Elector elects a single replica among the replicas sharing a database, e.g. to
+// run the background workers that must not run concurrently.
+type Elector interface {
+	// Identity returns the identity of this replica as lease holder
+	Identity() string
+
+	// Campaign blocks until this replica holds the lease, then keeps renewing it in
+	// the background until Resign is called or the lease is lost. It is called once.
+	//
+	// Returns:
+	//   - The context error if ctx is done before the lease is acquired
+	Campaign(ctx context.Context) error
+
+	// IsLeader returns whether this replica currently holds the lease
+	IsLeader() bool
+
+	// Lost returns a channel that is closed when this replica loses the lease it held,
+	// either because another replica took it over or because it could not be renewed
+	// before it expired
+	Lost() <-chan struct{}
+
+	// Resign stops renewing the lease and releases it, so that another replica takes
+	// over without waiting for it to expire
+	Resign(ctx context.Context) error
+}
+
+// NewElector creates the Elector of the replica that runs the background workers.
+// When leader election is disabled, this replica is always elected.
+func NewElector(database *db.DB, cfg *config.Config, log logger.Logger) (Elector, error) {
+	electionCfg := cfg.LeaderElection
+	if !electionCfg.Enabled {
+		return &localElector{lost: make(chan struct{})}, nil
+	}
+
+	identity := electionCfg.Identity
+	if identity == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, errors.NewConfigurationError(fmt.Sprintf("leader_election.identity is required, failed to get the hostname: %v", err))
+		}
+		identity = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+
+	leaseDuration := defaultLeaseDuration
+	if electionCfg.LeaseDuration > 0 {
+		leaseDuration = time.Duration(electionCfg.LeaseDuration) * time.Second
+	}
+	renewInterval := defaultRenewInterval
+	if electionCfg.RenewInterval > 0 {
+		renewInterval = time.Duration(electionCfg.RenewInterval) * time.Second
+	}
+	if renewInterval >= leaseDuration {
+		return nil, errors.NewConfigurationError("leader_election.renew_interval must be shorter than leader_election.lease_duration")
+	}
+
+	return NewDBElector(database, WorkersLease, identity, leaseDuration, renewInterval, log), nil
+}
+
+// localElector elects the only replica when leader election is disabled
+type localElector struct {
+	lost chan struct{}
+}
+
+// Identity implements the Elector interface
+func (e *localElector) Identity() string {
+	return "local"
+}
+
+// Campaign implements the Elector interface
+func (e *localElector) Campaign(ctx context.Context) error {
+	return nil
+}
+
+// IsLeader implements the Elector interface
+func (e *localElector) IsLeader() bool {
+	return true
+}
+
+// Lost implements the Elector interface. The lease of the only replica is never lost.
+func (e *localElector) Lost() <-chan struct{} {
+	return e.lost
+}
+
+// Resign implements the Elector interface
+func (e *localElector) Resign(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,140 @@
+package leader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/config"
+	"vault0/internal/db"
+	"vault0/internal/testing/dbtest"
+	"vault0/internal/testing/mocks"
+)
+
+const (
+	testLeaseDuration = 300 * time.Millisecond
+	testRenewInterval = 50 * time.Millisecond
+)
+
+func newTestElector(database *db.DB, identity string) *DBElector {
+	return NewDBElector(database, "test", identity, testLeaseDuration, testRenewInterval, mocks.NewNopLogger())
+}
+
+func TestDBElector_SingleLeader(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		ctx := context.Background()
+		first := newTestElector(database, "replica-1")
+		second := newTestElector(database, "replica-2")
+
+		require.NoError(t, first.Campaign(ctx))
+		assert.True(t, first.IsLeader())
+
+		// The lease is renewed past its duration, so the second replica is never elected
+		campaignCtx, cancel := context.WithTimeout(ctx, 2*testLeaseDuration)
+		defer cancel()
+		assert.ErrorIs(t, second.Campaign(campaignCtx), context.DeadlineExceeded)
+		assert.False(t, second.IsLeader())
+		assert.True(t, first.IsLeader())
+
+		select {
+		case <-first.Lost():
+			t.Fatal("Expected the leader to keep its lease")
+		default:
+		}
+		require.NoError(t, first.Resign(ctx))
+	})
+}
+
+func TestDBElector_HandoverOnResign(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		ctx := context.Background()
+		first := newTestElector(database, "replica-1")
+		second := newTestElector(database, "replica-2")
+
+		require.NoError(t, first.Campaign(ctx))
+		require.NoError(t, first.Resign(ctx))
+		assert.False(t, first.IsLeader())
+
+		// The released lease is acquired right away, without waiting for it to expire
+		campaignCtx, cancel := context.WithTimeout(ctx, testLeaseDuration/2)
+		defer cancel()
+		require.NoError(t, second.Campaign(campaignCtx))
+		assert.True(t, second.IsLeader())
+		require.NoError(t, second.Resign(ctx))
+	})
+}
+
+func TestDBElector_TakeoverOfExpiredLease(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		ctx := context.Background()
+		first := newTestElector(database, "replica-1")
+		second := newTestElector(database, "replica-2")
+
+		// A replica that stopped renewing without releasing the lease
+		acquired, err := first.acquire(ctx)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		start := time.Now()
+		require.NoError(t, second.Campaign(ctx))
+		assert.GreaterOrEqual(t, time.Since(start), testLeaseDuration-testRenewInterval)
+		assert.True(t, second.IsLeader())
+
+		// The previous holder finds out it lost the lease when it next renews it
+		acquired, err = first.acquire(ctx)
+		require.NoError(t, err)
+		assert.False(t, acquired)
+		require.NoError(t, second.Resign(ctx))
+	})
+}
+
+func TestDBElector_Lost(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		ctx := context.Background()
+		elector := newTestElector(database, "replica-1")
+		require.NoError(t, elector.Campaign(ctx))
+
+		_, err := database.ExecuteStatementContext(ctx, "UPDATE leases SET holder = ? WHERE name = ?", "replica-2", "test")
+		require.NoError(t, err)
+
+		select {
+		case <-elector.Lost():
+		case <-time.After(testLeaseDuration):
+			t.Fatal("Expected the lease to be lost")
+		}
+		assert.False(t, elector.IsLeader())
+
+		// Resigning a lost lease leaves the new holder in place
+		require.NoError(t, elector.Resign(ctx))
+		rows, err := database.ExecuteQueryContext(ctx, "SELECT holder FROM leases WHERE name = ?", "test")
+		require.NoError(t, err)
+		defer rows.Close()
+		require.True(t, rows.Next())
+		var holder string
+		require.NoError(t, rows.Scan(&holder))
+		assert.Equal(t, "replica-2", holder)
+	})
+}
+
+func TestNewElector(t *testing.T) {
+	log := mocks.NewNopLogger()
+
+	elector, err := NewElector(nil, &config.Config{}, log)
+	require.NoError(t, err)
+	require.NoError(t, elector.Campaign(context.Background()))
+	assert.True(t, elector.IsLeader())
+
+	elector, err = NewElector(nil, &config.Config{LeaderElection: config.LeaderElectionConfig{Enabled: true, Identity: "replica-1"}}, log)
+	require.NoError(t, err)
+	assert.Equal(t, "replica-1", elector.Identity())
+
+	_, err = NewElector(nil, &config.Config{LeaderElection: config.LeaderElectionConfig{
+		Enabled:       true,
+		LeaseDuration: 5,
+		RenewInterval: 5,
+	}}, log)
+	assert.Error(t, err)
+}
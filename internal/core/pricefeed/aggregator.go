@@ -0,0 +1,275 @@
+package pricefeed
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+)
+
+const (
+	// defaultMaxDeviation rejects source prices more than 5% away from the median
+	defaultMaxDeviation = 0.05
+	// defaultMaxAge rejects source prices older than an hour
+	defaultMaxAge = time.Hour
+)
+
+// Source is a named price feed queried by an aggregating price feed
+type Source struct {
+	Name string
+	Feed PriceFeed
+}
+
+// AggregationOptions controls how the prices of several sources are combined
+type AggregationOptions struct {
+	// MaxDeviation is the relative distance from the median beyond which a
+	// source price is rejected as an outlier
+	MaxDeviation float64
+	// MaxAge is the age beyond which a source price is considered stale
+	MaxAge time.Duration
+	// MinSources is the number of agreeing sources required to publish a price
+	MinSources int
+}
+
+// AggregatePriceFeed queries several price feeds concurrently and combines their
+// prices into the median of the agreeing, fresh quotes of each asset. Assets are
+// matched by contract address when the sources provide one, or by symbol.
+type AggregatePriceFeed struct {
+	sources []Source
+	opts    AggregationOptions
+	log     logger.Logger
+	now     func() time.Time
+}
+
+// quote is the price of an asset reported by a single source
+type quote struct {
+	source string
+	data   *TokenPriceData
+}
+
+// NewAggregatePriceFeed creates a price feed aggregating the given sources
+func NewAggregatePriceFeed(sources []Source, opts AggregationOptions, log logger.Logger) (*AggregatePriceFeed, error) {
+	if len(sources) == 0 {
+		return nil, errors.NewConfigurationError("at least one price feed source is required")
+	}
+	names := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		if names[source.Name] {
+			return nil, errors.NewConfigurationError(fmt.Sprintf("duplicate price feed source %q", source.Name))
+		}
+		names[source.Name] = true
+	}
+	if opts.MaxDeviation <= 0 {
+		opts.MaxDeviation = defaultMaxDeviation
+	}
+	if opts.MaxAge <= 0 {
+		opts.MaxAge = defaultMaxAge
+	}
+	if opts.MinSources <= 0 {
+		opts.MinSources = 1
+	}
+	if opts.MinSources > len(sources) {
+		return nil, errors.NewConfigurationError(
+			fmt.Sprintf("min_sources is %d but only %d price feed sources are configured", opts.MinSources, len(sources)))
+	}
+
+	return &AggregatePriceFeed{
+		sources: sources,
+		opts:    opts,
+		log:     log.With(logger.String("provider", "aggregate")),
+		now:     time.Now,
+	}, nil
+}
+
+// GetTokenPrices fetches the prices of all sources and aggregates them. Sources
+// that fail are skipped, the request only fails when none of them succeeds.
+func (a *AggregatePriceFeed) GetTokenPrices(ctx context.Context) ([]*TokenPriceData, error) {
+	results := make([][]*TokenPriceData, len(a.sources))
+	errs := make([]error, len(a.sources))
+
+	var wg sync.WaitGroup
+	for i, source := range a.sources {
+		wg.Add(1)
+		go func(i int, source Source) {
+			defer wg.Done()
+			results[i], errs[i] = source.Feed.GetTokenPrices(ctx)
+		}(i, source)
+	}
+	wg.Wait()
+
+	var lastErr error
+	succeeded := 0
+	for i, err := range errs {
+		if err != nil {
+			a.log.Warn("Price feed source failed",
+				logger.String("source", a.sources[i].Name),
+				logger.Error(err))
+			lastErr = err
+			continue
+		}
+		succeeded++
+	}
+	if succeeded == 0 {
+		return nil, errors.NewPriceFeedRequestFailed(lastErr, "all price feed sources failed")
+	}
+
+	quotes := a.groupQuotes(results)
+
+	prices := make([]*TokenPriceData, 0, len(quotes))
+	rejected := 0
+	for key, assetQuotes := range quotes {
+		price, ok := a.aggregate(assetQuotes)
+		if !ok {
+			a.log.Debug("Not enough agreeing sources for asset price",
+				logger.String("asset", key),
+				logger.Int("quotes", len(assetQuotes)))
+			rejected++
+			continue
+		}
+		prices = append(prices, price)
+	}
+
+	// Sort by rank, keeping unranked assets last
+	sort.Slice(prices, func(i, j int) bool {
+		ri, rj := prices[i].Rank, prices[j].Rank
+		if (ri > 0) != (rj > 0) {
+			return ri > 0
+		}
+		if ri != rj {
+			return ri < rj
+		}
+		return prices[i].Symbol < prices[j].Symbol
+	})
+
+	a.log.Info("Aggregated token prices",
+		logger.Int("sources", len(a.sources)),
+		logger.Int("sources_succeeded", succeeded),
+		logger.Int("prices", len(prices)),
+		logger.Int("rejected", rejected))
+
+	return prices, nil
+}
+
+// groupQuotes groups the fresh, valid quotes of all sources by asset. Assets with a
+// contract address are keyed by chain and address, others by symbol. A source quoting an asset by
+// symbol contributes to the contract-keyed asset of the same symbol when there is exactly one,
+// as the symbol doesn't tell which of several such assets it quotes.
+func (a *AggregatePriceFeed) groupQuotes(results [][]*TokenPriceData) map[string][]quote {
+	staleBefore := a.now().Add(-a.opts.MaxAge)
+	quotes := make(map[string][]quote)
+	contractsBySymbol := make(map[string]map[string]bool)
+
+	var bySymbol []quote
+	for i, prices := range results {
+		for _, data := range prices {
+			if data == nil || data.Symbol == "" || data.PriceUSD <= 0 || math.IsNaN(data.PriceUSD) || math.IsInf(data.PriceUSD, 0) {
+				continue
+			}
+			if !data.UpdatedAt.IsZero() && data.UpdatedAt.Before(staleBefore) {
+				continue
+			}
+
+			q := quote{source: a.sources[i].Name, data: data}
+			if data.ContractAddress == "" {
+				bySymbol = append(bySymbol, q)
+				continue
+			}
+			key := string(data.ChainType) + ":" + strings.ToLower(data.ContractAddress)
+			quotes[key] = append(quotes[key], q)
+			symbol := strings.ToUpper(data.Symbol)
+			if contractsBySymbol[symbol] == nil {
+				contractsBySymbol[symbol] = make(map[string]bool)
+			}
+			contractsBySymbol[symbol][key] = true
+		}
+	}
+
+	for _, q := range bySymbol {
+		symbol := strings.ToUpper(q.data.Symbol)
+		key := symbol
+		if contracts := contractsBySymbol[symbol]; len(contracts) == 1 {
+			for contract := range contracts {
+				key = contract
+			}
+		}
+		quotes[key] = append(quotes[key], q)
+	}
+
+	return quotes
+}
+
+// aggregate combines the quotes of an asset into the median of the quotes within
+// the allowed deviation of the overall median. Each source contributes at most once.
+func (a *AggregatePriceFeed) aggregate(quotes []quote) (*TokenPriceData, bool) {
+	// Keep the best ranked quote of each source, as symbols are not unique across assets
+	bySource := make(map[string]quote, len(quotes))
+	for _, q := range quotes {
+		existing, ok := bySource[q.source]
+		if !ok || (q.data.Rank > 0 && (existing.data.Rank <= 0 || q.data.Rank < existing.data.Rank)) {
+			bySource[q.source] = q
+		}
+	}
+
+	values := make([]float64, 0, len(bySource))
+	for _, q := range bySource {
+		values = append(values, q.data.PriceUSD)
+	}
+	center := median(values)
+
+	var accepted []quote
+	for _, q := range bySource {
+		if math.Abs(q.data.PriceUSD-center)/center <= a.opts.MaxDeviation {
+			accepted = append(accepted, q)
+		}
+	}
+	if len(accepted) < a.opts.MinSources || len(accepted) == 0 {
+		return nil, false
+	}
+
+	// Use the metadata of the best ranked accepted quote
+	sort.Slice(accepted, func(i, j int) bool {
+		ri, rj := accepted[i].data.Rank, accepted[j].data.Rank
+		if (ri > 0) != (rj > 0) {
+			return ri > 0
+		}
+		if ri != rj {
+			return ri < rj
+		}
+		return accepted[i].source < accepted[j].source
+	})
+
+	best := *accepted[0].data
+	values = values[:0]
+	best.Sources = make([]string, 0, len(accepted))
+	for _, q := range accepted {
+		values = append(values, q.data.PriceUSD)
+		best.Sources = append(best.Sources, q.source)
+		if q.data.ContractAddress != "" && best.ContractAddress == "" {
+			best.ContractAddress = q.data.ContractAddress
+		}
+		if q.data.UpdatedAt.After(best.UpdatedAt) {
+			best.UpdatedAt = q.data.UpdatedAt
+		}
+	}
+	sort.Strings(best.Sources)
+	best.PriceUSD = median(values)
+
+	return &best, true
+}
+
+// median returns the median of a non-empty list of values
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
@@ -0,0 +1,184 @@
+package pricefeed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	coreerrors "vault0/internal/errors"
+	"vault0/internal/testing/mocks"
+	"vault0/internal/types"
+)
+
+// staticFeed returns fixed prices or an error
+type staticFeed struct {
+	prices []*TokenPriceData
+	err    error
+}
+
+func (f *staticFeed) GetTokenPrices(ctx context.Context) ([]*TokenPriceData, error) {
+	return f.prices, f.err
+}
+
+func price(symbol string, rank int, usd float64, updatedAt time.Time) *TokenPriceData {
+	return &TokenPriceData{Symbol: symbol, Name: symbol, Rank: rank, PriceUSD: usd, UpdatedAt: updatedAt}
+}
+
+func TestAggregatePriceFeed_GetTokenPrices(t *testing.T) {
+	now := time.Now()
+	stale := now.Add(-2 * time.Hour)
+
+	tests := []struct {
+		name            string
+		sources         map[string]*staticFeed
+		minSources      int
+		expected        map[string]float64
+		expectedSources map[string][]string
+	}{
+		{
+			name: "median of agreeing sources",
+			sources: map[string]*staticFeed{
+				"a": {prices: []*TokenPriceData{price("ETH", 2, 3000, now), price("BTC", 1, 60000, now)}},
+				"b": {prices: []*TokenPriceData{price("ETH", 2, 3010, now), price("BTC", 1, 60100, now)}},
+				"c": {prices: []*TokenPriceData{price("eth", 2, 3020, now)}},
+			},
+			expected:        map[string]float64{"ETH": 3010, "BTC": 60050},
+			expectedSources: map[string][]string{"ETH": {"a", "b", "c"}, "BTC": {"a", "b"}},
+		},
+		{
+			name: "outlier rejected",
+			sources: map[string]*staticFeed{
+				"a": {prices: []*TokenPriceData{price("ETH", 2, 3000, now)}},
+				"b": {prices: []*TokenPriceData{price("ETH", 2, 3030, now)}},
+				"c": {prices: []*TokenPriceData{price("ETH", 2, 30, now)}},
+			},
+			expected:        map[string]float64{"ETH": 3015},
+			expectedSources: map[string][]string{"ETH": {"a", "b"}},
+		},
+		{
+			name: "stale and failing sources skipped",
+			sources: map[string]*staticFeed{
+				"a": {prices: []*TokenPriceData{price("ETH", 2, 3000, now)}},
+				"b": {prices: []*TokenPriceData{price("ETH", 2, 2000, stale)}},
+				"c": {err: errors.New("unavailable")},
+			},
+			expected:        map[string]float64{"ETH": 3000},
+			expectedSources: map[string][]string{"ETH": {"a"}},
+		},
+		{
+			name: "not enough agreeing sources",
+			sources: map[string]*staticFeed{
+				"a": {prices: []*TokenPriceData{price("ETH", 2, 3000, now), price("BTC", 1, 60000, now)}},
+				"b": {prices: []*TokenPriceData{price("ETH", 2, 3001, now)}},
+			},
+			minSources:      2,
+			expected:        map[string]float64{"ETH": 3000.5},
+			expectedSources: map[string][]string{"ETH": {"a", "b"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sources []Source
+			for _, name := range []string{"a", "b", "c"} {
+				if feed, ok := tt.sources[name]; ok {
+					sources = append(sources, Source{Name: name, Feed: feed})
+				}
+			}
+
+			feed, err := NewAggregatePriceFeed(sources, AggregationOptions{MinSources: tt.minSources}, mocks.NewNopLogger())
+			require.NoError(t, err)
+
+			prices, err := feed.GetTokenPrices(context.Background())
+			require.NoError(t, err)
+
+			got := make(map[string]float64, len(prices))
+			gotSources := make(map[string][]string, len(prices))
+			for _, p := range prices {
+				got[p.Symbol] = p.PriceUSD
+				gotSources[p.Symbol] = p.Sources
+			}
+			assert.InDeltaMapValues(t, tt.expected, got, 1e-9)
+			assert.Equal(t, tt.expectedSources, gotSources)
+		})
+	}
+}
+
+func TestAggregatePriceFeed_MatchesContractAddress(t *testing.T) {
+	now := time.Now()
+	onChain := price("USDC", 0, 1.0, now)
+	onChain.ContractAddress = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+
+	feed, err := NewAggregatePriceFeed([]Source{
+		{Name: "chain", Feed: &staticFeed{prices: []*TokenPriceData{onChain}}},
+		{Name: "api", Feed: &staticFeed{prices: []*TokenPriceData{price("USDC", 7, 1.002, now)}}},
+	}, AggregationOptions{MinSources: 2}, mocks.NewNopLogger())
+	require.NoError(t, err)
+
+	prices, err := feed.GetTokenPrices(context.Background())
+	require.NoError(t, err)
+	require.Len(t, prices, 1)
+
+	assert.Equal(t, onChain.ContractAddress, prices[0].ContractAddress)
+	assert.Equal(t, 7, prices[0].Rank)
+	assert.InDelta(t, 1.001, prices[0].PriceUSD, 1e-9)
+	assert.Equal(t, []string{"api", "chain"}, prices[0].Sources)
+}
+
+func TestAggregatePriceFeed_AmbiguousSymbol(t *testing.T) {
+	now := time.Now()
+	ethereum := price("USDC", 0, 1.0, now)
+	ethereum.ChainType = types.ChainTypeEthereum
+	ethereum.ContractAddress = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+	polygon := price("USDC", 0, 0.999, now)
+	polygon.ChainType = types.ChainTypePolygon
+	polygon.ContractAddress = "0x3c499c542cEF5E3811e1192ce70d8cC03d5c3359"
+
+	feed, err := NewAggregatePriceFeed([]Source{
+		{Name: "chain", Feed: &staticFeed{prices: []*TokenPriceData{ethereum, polygon}}},
+		{Name: "api", Feed: &staticFeed{prices: []*TokenPriceData{price("USDC", 7, 1.002, now)}}},
+	}, AggregationOptions{MinSources: 1}, mocks.NewNopLogger())
+	require.NoError(t, err)
+
+	prices, err := feed.GetTokenPrices(context.Background())
+	require.NoError(t, err)
+	require.Len(t, prices, 3)
+
+	// The symbol-only quote can't tell which contract it prices, so it stays apart
+	sources := make(map[string][]string)
+	for _, p := range prices {
+		sources[p.ContractAddress] = p.Sources
+	}
+	assert.Equal(t, []string{"chain"}, sources[ethereum.ContractAddress])
+	assert.Equal(t, []string{"chain"}, sources[polygon.ContractAddress])
+	assert.Equal(t, []string{"api"}, sources[""])
+}
+
+func TestAggregatePriceFeed_AllSourcesFail(t *testing.T) {
+	feed, err := NewAggregatePriceFeed([]Source{
+		{Name: "a", Feed: &staticFeed{err: errors.New("down")}},
+		{Name: "b", Feed: &staticFeed{err: errors.New("down")}},
+	}, AggregationOptions{}, mocks.NewNopLogger())
+	require.NoError(t, err)
+
+	_, err = feed.GetTokenPrices(context.Background())
+	require.Error(t, err)
+	assert.True(t, coreerrors.IsError(err, coreerrors.ErrCodePriceFeedRequestFailed))
+}
+
+func TestNewAggregatePriceFeed_Validation(t *testing.T) {
+	feed := &staticFeed{}
+
+	_, err := NewAggregatePriceFeed(nil, AggregationOptions{}, mocks.NewNopLogger())
+	assert.Error(t, err)
+
+	_, err = NewAggregatePriceFeed([]Source{{Name: "a", Feed: feed}, {Name: "a", Feed: feed}}, AggregationOptions{}, mocks.NewNopLogger())
+	assert.Error(t, err)
+
+	_, err = NewAggregatePriceFeed([]Source{{Name: "a", Feed: feed}}, AggregationOptions{MinSources: 2}, mocks.NewNopLogger())
+	assert.Error(t, err)
+}
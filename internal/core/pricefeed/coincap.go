@@ -72,6 +72,7 @@ func (p *CoinCapPriceFeed) mapToTokenPriceData(data *CoinCapPriceData, updatedAt
 		MarketCapUSD: data.MarketCapUSD,
 		VolumeUSD24h: data.VolumeUSD24h,
 		UpdatedAt:    updatedAt,
+		Sources:      []string{"coincap"},
 	}
 }
 
@@ -122,6 +123,10 @@ func (p *CoinCapPriceFeed) GetTokenPrices(ctx context.Context) ([]*TokenPriceDat
 
 	// Set the UpdatedAt timestamp for each token and deduplicate by symbol
 	now := time.Now()
+	if apiResponse.Timestamp > 0 {
+		// Use the time the data was produced, so that stale responses can be detected
+		now = time.UnixMilli(apiResponse.Timestamp)
+	}
 	uniqueTokens := make(map[string]*TokenPriceData)
 	for _, token := range apiResponse.Data {
 		// Map provider-specific data to the abstracted TokenPriceData
@@ -68,6 +68,7 @@ func (c *CoinPaprikaPriceFeed) mapToTokenPriceData(data *CoinPaprikaPriceData, u
 		MarketCapUSD: data.Quotes.USD.MarketCap,
 		VolumeUSD24h: data.Quotes.USD.Volume24h,
 		UpdatedAt:    updatedAt,
+		Sources:      []string{"coinpaprika"},
 	}
 }
 
@@ -2,21 +2,56 @@ package pricefeed
 
 import (
 	"strings"
+	"time"
 
 	"vault0/internal/config"
+	"vault0/internal/core/blockchain"
 	"vault0/internal/errors"
 	"vault0/internal/logger"
 )
 
 // NewPriceFeed creates a PriceFeedProvider instance based on the configuration.
-func NewPriceFeed(cfg *config.Config, log logger.Logger) (PriceFeed, error) {
-	providerName := strings.ToLower(strings.TrimSpace(cfg.PriceFeed.Provider))
+// When several sources are configured, their prices are aggregated.
+func NewPriceFeed(cfg *config.Config, blockchainFactory blockchain.Factory, log logger.Logger) (PriceFeed, error) {
+	if len(cfg.PriceFeed.Sources) == 0 {
+		return newProvider(cfg.PriceFeed, blockchainFactory, log)
+	}
+
+	sources := make([]Source, 0, len(cfg.PriceFeed.Sources))
+	for _, sourceCfg := range cfg.PriceFeed.Sources {
+		provider, err := newProvider(config.PriceFeedConfig{
+			Provider: sourceCfg.Provider,
+			APIURL:   sourceCfg.APIURL,
+			APIKey:   sourceCfg.APIKey,
+			Limit:    sourceCfg.Limit,
+			Tokens:   sourceCfg.Tokens,
+		}, blockchainFactory, log)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, Source{
+			Name: strings.ToLower(strings.TrimSpace(sourceCfg.Provider)),
+			Feed: provider,
+		})
+	}
+
+	log.Info("Initializing aggregate price feed", logger.Int("sources", len(sources)))
+	return NewAggregatePriceFeed(sources, AggregationOptions{
+		MaxDeviation: cfg.PriceFeed.MaxDeviation,
+		MaxAge:       time.Duration(cfg.PriceFeed.MaxAge) * time.Second,
+		MinSources:   cfg.PriceFeed.MinSources,
+	}, log)
+}
+
+// newProvider creates the price feed of a single provider
+func newProvider(cfg config.PriceFeedConfig, blockchainFactory blockchain.Factory, log logger.Logger) (PriceFeed, error) {
+	providerName := strings.ToLower(strings.TrimSpace(cfg.Provider))
 	log = log.With(logger.String("provider_name", providerName))
 
 	switch providerName {
 	case "coincap":
 		log.Info("Initializing CoinCap price feed provider")
-		provider, err := NewCoinCapPriceFeed(cfg.PriceFeed, log)
+		provider, err := NewCoinCapPriceFeed(cfg, log)
 		if err != nil {
 			log.Error("Failed to initialize CoinCap provider", logger.Error(err))
 			// Return the original configuration error from NewCoinCapProvider
@@ -25,15 +60,23 @@ func NewPriceFeed(cfg *config.Config, log logger.Logger) (PriceFeed, error) {
 		return provider, nil
 	case "coinpaprika":
 		log.Info("Initializing CoinPaprika price feed provider")
-		provider, err := NewCoinPaprikaPriceFeed(cfg.PriceFeed, log)
+		provider, err := NewCoinPaprikaPriceFeed(cfg, log)
 		if err != nil {
 			log.Error("Failed to initialize CoinPaprika provider", logger.Error(err))
 			return nil, err
 		}
 		return provider, nil
+	case "onchain":
+		log.Info("Initializing on-chain price feed provider")
+		provider, err := NewOnChainPriceFeed(cfg, blockchainFactory, log)
+		if err != nil {
+			log.Error("Failed to initialize on-chain provider", logger.Error(err))
+			return nil, err
+		}
+		return provider, nil
 	// Add cases for other providers like "coingecko" here in the future
 	default:
 		log.Warn("Unsupported price feed provider configured")
-		return nil, errors.NewPriceFeedProviderNotSupported(cfg.PriceFeed.Provider)
+		return nil, errors.NewPriceFeedProviderNotSupported(cfg.Provider)
 	}
 }
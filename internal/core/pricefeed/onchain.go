@@ -0,0 +1,374 @@
+package pricefeed
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"vault0/internal/config"
+	"vault0/internal/core/blockchain"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/types"
+)
+
+// Oracle kinds supported by the on-chain price feed
+const (
+	OracleChainlink = "chainlink"
+	OracleUniswapV2 = "uniswap_v2"
+	OracleUniswapV3 = "uniswap_v3"
+)
+
+// Method signatures read by the on-chain price feed
+const (
+	latestRoundDataMethod = "latestRoundData()"
+	decimalsMethod        = "decimals()"
+	getReservesMethod     = "getReserves()"
+	slot0Method           = "slot0()"
+	token0Method          = "token0()"
+	token1Method          = "token1()"
+)
+
+// wordSize is the size of an ABI encoded word
+const wordSize = 32
+
+// q96 is 2^96, the fixed point scale of Uniswap V3 square root prices
+var q96 = new(big.Float).SetInt(new(big.Int).Lsh(big.NewInt(1), 96))
+
+// onChainToken is a token priced from an on-chain oracle or pool
+type onChainToken struct {
+	chainType types.ChainType
+	address   common.Address
+	symbol    string
+	name      string
+	oracle    string
+	feed      common.Address
+}
+
+// OnChainPriceFeed implements the PriceFeed interface by reading Chainlink aggregators
+// and Uniswap V2/V3 pools. Tokens are priced by chain and contract address, so it does
+// not suffer from symbol collisions and covers tokens missing from centralized APIs.
+type OnChainPriceFeed struct {
+	tokens            []onChainToken
+	blockchainFactory blockchain.Factory
+	log               logger.Logger
+	now               func() time.Time
+
+	// decimals caches the decimals of the tokens and aggregators read so far
+	decimals    map[string]int
+	decimalsMux sync.Mutex
+}
+
+// NewOnChainPriceFeed creates a new OnChainPriceFeed instance.
+func NewOnChainPriceFeed(cfg config.PriceFeedConfig, blockchainFactory blockchain.Factory, log logger.Logger) (*OnChainPriceFeed, error) {
+	if len(cfg.Tokens) == 0 {
+		return nil, errors.NewConfigurationError("at least one token is required by the on-chain price feed")
+	}
+
+	tokens := make([]onChainToken, 0, len(cfg.Tokens))
+	for _, tokenCfg := range cfg.Tokens {
+		if tokenCfg.Chain == "" || tokenCfg.Symbol == "" {
+			return nil, errors.NewConfigurationError("on-chain price feed tokens require a chain and a symbol")
+		}
+		if !common.IsHexAddress(tokenCfg.Address) {
+			return nil, errors.NewConfigurationError(fmt.Sprintf("invalid on-chain price feed token address %q", tokenCfg.Address))
+		}
+		if !common.IsHexAddress(tokenCfg.Feed) {
+			return nil, errors.NewConfigurationError(fmt.Sprintf("invalid on-chain price feed address %q for %s", tokenCfg.Feed, tokenCfg.Symbol))
+		}
+		oracle := strings.ToLower(strings.TrimSpace(tokenCfg.Oracle))
+		switch oracle {
+		case OracleChainlink, OracleUniswapV2, OracleUniswapV3:
+		default:
+			return nil, errors.NewConfigurationError(fmt.Sprintf("unsupported on-chain oracle %q for %s", tokenCfg.Oracle, tokenCfg.Symbol))
+		}
+
+		name := tokenCfg.Name
+		if name == "" {
+			name = tokenCfg.Symbol
+		}
+		tokens = append(tokens, onChainToken{
+			chainType: types.ChainType(strings.ToLower(tokenCfg.Chain)),
+			address:   common.HexToAddress(tokenCfg.Address),
+			symbol:    tokenCfg.Symbol,
+			name:      name,
+			oracle:    oracle,
+			feed:      common.HexToAddress(tokenCfg.Feed),
+		})
+	}
+
+	return &OnChainPriceFeed{
+		tokens:            tokens,
+		blockchainFactory: blockchainFactory,
+		log:               log.With(logger.String("provider", "onchain")),
+		now:               time.Now,
+		decimals:          make(map[string]int),
+	}, nil
+}
+
+// GetTokenPrices reads the prices of the configured tokens in order, so that pool
+// prices can be converted to USD with the price of a token read before them.
+// Tokens whose price cannot be read are skipped, the request only fails when none
+// of them can be priced.
+func (f *OnChainPriceFeed) GetTokenPrices(ctx context.Context) ([]*TokenPriceData, error) {
+	prices := make([]*TokenPriceData, 0, len(f.tokens))
+	usdPrices := make(map[string]float64, len(f.tokens))
+
+	var lastErr error
+	for _, token := range f.tokens {
+		price, err := f.readPrice(ctx, token, usdPrices)
+		if err != nil {
+			f.log.Warn("Failed to read on-chain token price",
+				logger.String("chain", string(token.chainType)),
+				logger.String("symbol", token.symbol),
+				logger.String("oracle", token.oracle),
+				logger.Error(err))
+			lastErr = err
+			continue
+		}
+		usdPrices[tokenKey(token.chainType, token.address)] = price.PriceUSD
+		prices = append(prices, price)
+	}
+
+	if len(prices) == 0 {
+		return nil, errors.NewPriceFeedRequestFailed(lastErr, "no on-chain token price could be read")
+	}
+
+	f.log.Info("Successfully read on-chain token prices", logger.Int("count", len(prices)))
+	return prices, nil
+}
+
+// readPrice reads the USD price of a token from its oracle
+func (f *OnChainPriceFeed) readPrice(ctx context.Context, token onChainToken, usdPrices map[string]float64) (*TokenPriceData, error) {
+	client, err := f.blockchainFactory.NewClient(token.chainType)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		priceUSD  float64
+		updatedAt time.Time
+	)
+	switch token.oracle {
+	case OracleChainlink:
+		priceUSD, updatedAt, err = f.readChainlinkPrice(ctx, client, token)
+	case OracleUniswapV2:
+		priceUSD, err = f.readPoolPrice(ctx, client, token, usdPrices, f.readUniswapV2Price)
+		updatedAt = f.now()
+	case OracleUniswapV3:
+		priceUSD, err = f.readPoolPrice(ctx, client, token, usdPrices, f.readUniswapV3Price)
+		updatedAt = f.now()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPriceData{
+		ID:              string(token.chainType) + ":" + token.address.Hex(),
+		Symbol:          token.symbol,
+		Name:            token.name,
+		PriceUSD:        priceUSD,
+		UpdatedAt:       updatedAt,
+		ContractAddress: token.address.Hex(),
+		ChainType:       token.chainType,
+		Sources:         []string{"onchain"},
+	}, nil
+}
+
+// readChainlinkPrice reads the latest answer of a Chainlink USD aggregator
+func (f *OnChainPriceFeed) readChainlinkPrice(ctx context.Context, client blockchain.BlockchainClient, token onChainToken) (float64, time.Time, error) {
+	decimals, err := f.readDecimals(ctx, client, token.chainType, token.feed)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	// latestRoundData returns (roundId, answer, startedAt, updatedAt, answeredInRound)
+	words, err := callContract(ctx, client, token.feed, latestRoundDataMethod, 5)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	if words[1][0]&0x80 != 0 {
+		return 0, time.Time{}, errors.NewInvalidPriceFeedResponse(nil, "negative Chainlink answer")
+	}
+	answer := new(big.Int).SetBytes(words[1])
+	if answer.Sign() == 0 {
+		return 0, time.Time{}, errors.NewInvalidPriceFeedResponse(nil, "zero Chainlink answer")
+	}
+	updatedAt := time.Unix(new(big.Int).SetBytes(words[3]).Int64(), 0)
+
+	price, _ := scale(new(big.Float).SetInt(answer), -decimals).Float64()
+	return price, updatedAt, nil
+}
+
+// poolPriceReader reads the price of token0 expressed in token1 from a pool
+type poolPriceReader func(ctx context.Context, client blockchain.BlockchainClient, pool common.Address, decimals0, decimals1 int) (*big.Float, error)
+
+// readPoolPrice reads the price of a token from a Uniswap pool and converts it to USD
+// with the price of the other token of the pool
+func (f *OnChainPriceFeed) readPoolPrice(ctx context.Context, client blockchain.BlockchainClient, token onChainToken, usdPrices map[string]float64, read poolPriceReader) (float64, error) {
+	token0, err := readAddress(ctx, client, token.feed, token0Method)
+	if err != nil {
+		return 0, err
+	}
+	token1, err := readAddress(ctx, client, token.feed, token1Method)
+	if err != nil {
+		return 0, err
+	}
+
+	var quoteToken common.Address
+	switch token.address {
+	case token0:
+		quoteToken = token1
+	case token1:
+		quoteToken = token0
+	default:
+		return 0, errors.NewInvalidPriceFeedResponse(nil,
+			fmt.Sprintf("pool %s does not hold token %s", token.feed.Hex(), token.address.Hex()))
+	}
+
+	decimals0, err := f.readDecimals(ctx, client, token.chainType, token0)
+	if err != nil {
+		return 0, err
+	}
+	decimals1, err := f.readDecimals(ctx, client, token.chainType, token1)
+	if err != nil {
+		return 0, err
+	}
+
+	price, err := read(ctx, client, token.feed, decimals0, decimals1)
+	if err != nil {
+		return 0, err
+	}
+	if price.Sign() <= 0 {
+		return 0, errors.NewInvalidPriceFeedResponse(nil, fmt.Sprintf("empty pool %s", token.feed.Hex()))
+	}
+	if token.address == token1 {
+		price = new(big.Float).Quo(big.NewFloat(1), price)
+	}
+
+	// The other token is assumed to be a USD stablecoin unless it was priced before
+	if quoteUSD, ok := usdPrices[tokenKey(token.chainType, quoteToken)]; ok {
+		price.Mul(price, big.NewFloat(quoteUSD))
+	}
+
+	priceUSD, _ := price.Float64()
+	return priceUSD, nil
+}
+
+// readUniswapV2Price reads the price of token0 in token1 from the reserves of a pair
+func (f *OnChainPriceFeed) readUniswapV2Price(ctx context.Context, client blockchain.BlockchainClient, pool common.Address, decimals0, decimals1 int) (*big.Float, error) {
+	// getReserves returns (reserve0, reserve1, blockTimestampLast)
+	words, err := callContract(ctx, client, pool, getReservesMethod, 3)
+	if err != nil {
+		return nil, err
+	}
+	reserve0 := new(big.Int).SetBytes(words[0])
+	reserve1 := new(big.Int).SetBytes(words[1])
+	if reserve0.Sign() == 0 || reserve1.Sign() == 0 {
+		return big.NewFloat(0), nil
+	}
+
+	amount0 := scale(new(big.Float).SetInt(reserve0), -decimals0)
+	amount1 := scale(new(big.Float).SetInt(reserve1), -decimals1)
+	return new(big.Float).Quo(amount1, amount0), nil
+}
+
+// readUniswapV3Price reads the price of token0 in token1 from the current square root
+// price of a pool
+func (f *OnChainPriceFeed) readUniswapV3Price(ctx context.Context, client blockchain.BlockchainClient, pool common.Address, decimals0, decimals1 int) (*big.Float, error) {
+	// slot0 starts with sqrtPriceX96
+	words, err := callContract(ctx, client, pool, slot0Method, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	sqrtPrice := new(big.Float).Quo(new(big.Float).SetInt(new(big.Int).SetBytes(words[0])), q96)
+	price := new(big.Float).Mul(sqrtPrice, sqrtPrice)
+	return scale(price, decimals0-decimals1), nil
+}
+
+// readDecimals reads the decimals of a token or aggregator, caching the result
+func (f *OnChainPriceFeed) readDecimals(ctx context.Context, client blockchain.BlockchainClient, chainType types.ChainType, address common.Address) (int, error) {
+	key := tokenKey(chainType, address)
+
+	f.decimalsMux.Lock()
+	decimals, ok := f.decimals[key]
+	f.decimalsMux.Unlock()
+	if ok {
+		return decimals, nil
+	}
+
+	words, err := callContract(ctx, client, address, decimalsMethod, 1)
+	if err != nil {
+		return 0, err
+	}
+	value := new(big.Int).SetBytes(words[0])
+	if !value.IsUint64() || value.Uint64() > 77 {
+		return 0, errors.NewInvalidPriceFeedResponse(nil, fmt.Sprintf("invalid decimals of %s", address.Hex()))
+	}
+	decimals = int(value.Uint64())
+
+	f.decimalsMux.Lock()
+	f.decimals[key] = decimals
+	f.decimalsMux.Unlock()
+
+	return decimals, nil
+}
+
+// readAddress calls a method returning a single address
+func readAddress(ctx context.Context, client blockchain.BlockchainClient, contract common.Address, method string) (common.Address, error) {
+	words, err := callContract(ctx, client, contract, method, 1)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return common.BytesToAddress(words[0][wordSize-common.AddressLength:]), nil
+}
+
+// callContract calls a method without arguments and splits the result into at
+// least the given number of ABI words
+func callContract(ctx context.Context, client blockchain.BlockchainClient, contract common.Address, method string, words int) ([][]byte, error) {
+	data := crypto.Keccak256([]byte(method))[:4]
+	result, err := client.CallContract(ctx, types.ZeroAddress, contract.Hex(), data)
+	if err != nil {
+		return nil, errors.NewPriceFeedRequestFailed(err, fmt.Sprintf("failed to call %s on %s", method, contract.Hex()))
+	}
+	if len(result) < words*wordSize {
+		return nil, errors.NewInvalidPriceFeedResponse(
+			fmt.Errorf("invalid response length: got %d, want %d", len(result), words*wordSize),
+			fmt.Sprintf("unexpected %s result from %s", method, contract.Hex()))
+	}
+
+	split := make([][]byte, words)
+	for i := range split {
+		split[i] = result[i*wordSize : (i+1)*wordSize]
+	}
+	return split, nil
+}
+
+// scale multiplies a value by 10^exp
+func scale(value *big.Float, exp int) *big.Float {
+	factor := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(exp))), nil))
+	if exp < 0 {
+		return new(big.Float).Quo(value, factor)
+	}
+	return new(big.Float).Mul(value, factor)
+}
+
+// abs returns the absolute value of an integer
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// tokenKey identifies a contract across chains
+func tokenKey(chainType types.ChainType, address common.Address) string {
+	return string(chainType) + ":" + strings.ToLower(address.Hex())
+}
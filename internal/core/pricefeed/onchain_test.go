@@ -0,0 +1,159 @@
+package pricefeed
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/config"
+	"vault0/internal/core/blockchain"
+	coreerrors "vault0/internal/errors"
+	"vault0/internal/testing/mocks"
+	"vault0/internal/types"
+)
+
+const (
+	wethAddress  = "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"
+	uniAddress   = "0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984"
+	daiAddress   = "0x6B175474E89094C44Da98b954EedeAC495271d0F"
+	tokenAddress = "0x5A5A5A5A5A5A5A5A5A5A5A5A5A5A5A5A5A5A5A5A"
+	ethUsdFeed   = "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419"
+	brokenFeed   = "0x0000000000000000000000000000000000000Bad"
+	uniWethPair  = "0xd3d2E2692501A5c9Ca623199D38826e513033a17"
+	daiTokenPool = "0x1111111111111111111111111111111111111111"
+)
+
+// stubBlockchainFactory returns the same blockchain client for every chain
+type stubBlockchainFactory struct {
+	blockchain.Factory
+	client blockchain.BlockchainClient
+}
+
+func (f *stubBlockchainFactory) NewClient(chainType types.ChainType) (blockchain.BlockchainClient, error) {
+	return f.client, nil
+}
+
+// word ABI encodes an integer
+func word(value *big.Int) []byte {
+	return common.LeftPadBytes(value.Bytes(), 32)
+}
+
+// addressWord ABI encodes an address
+func addressWord(address string) []byte {
+	return common.LeftPadBytes(common.HexToAddress(address).Bytes(), 32)
+}
+
+// units returns value * 10^decimals
+func units(value int64, decimals int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(value), new(big.Int).Exp(big.NewInt(10), big.NewInt(decimals), nil))
+}
+
+func expectCall(client *mocks.MockBlockchainClient, contract, method string, result []byte, err error) {
+	selector := crypto.Keccak256([]byte(method))[:4]
+	client.On("CallContract", mock.Anything, types.ZeroAddress, common.HexToAddress(contract).Hex(), selector).Return(result, err)
+}
+
+func concat(words ...[]byte) []byte {
+	var result []byte
+	for _, w := range words {
+		result = append(result, w...)
+	}
+	return result
+}
+
+func TestOnChainPriceFeed_GetTokenPrices(t *testing.T) {
+	client := mocks.NewMockBlockchainClient()
+	updatedAt := time.Unix(1700000000, 0)
+
+	// WETH from a Chainlink ETH / USD aggregator with 8 decimals
+	expectCall(client, ethUsdFeed, decimalsMethod, word(big.NewInt(8)), nil)
+	expectCall(client, ethUsdFeed, latestRoundDataMethod, concat(
+		word(big.NewInt(1)), word(units(3000, 8)), word(big.NewInt(0)), word(big.NewInt(updatedAt.Unix())), word(big.NewInt(1)),
+	), nil)
+
+	// UNI from a Uniswap V2 UNI / WETH pair holding 1000 UNI and 2 WETH
+	expectCall(client, uniWethPair, token0Method, addressWord(uniAddress), nil)
+	expectCall(client, uniWethPair, token1Method, addressWord(wethAddress), nil)
+	expectCall(client, uniAddress, decimalsMethod, word(big.NewInt(18)), nil)
+	expectCall(client, wethAddress, decimalsMethod, word(big.NewInt(18)), nil)
+	expectCall(client, uniWethPair, getReservesMethod, concat(
+		word(units(1000, 18)), word(units(2, 18)), word(big.NewInt(0)),
+	), nil)
+
+	// A token from a Uniswap V3 DAI / token pool where 1 DAI buys 4 tokens
+	expectCall(client, daiTokenPool, token0Method, addressWord(daiAddress), nil)
+	expectCall(client, daiTokenPool, token1Method, addressWord(tokenAddress), nil)
+	expectCall(client, daiAddress, decimalsMethod, word(big.NewInt(18)), nil)
+	expectCall(client, tokenAddress, decimalsMethod, word(big.NewInt(18)), nil)
+	sqrtPriceX96 := new(big.Int).Lsh(big.NewInt(2), 96)
+	expectCall(client, daiTokenPool, slot0Method, concat(word(sqrtPriceX96), word(big.NewInt(0))), nil)
+
+	// A broken aggregator is skipped
+	expectCall(client, brokenFeed, decimalsMethod, nil, errors.New("execution reverted"))
+
+	feed, err := NewOnChainPriceFeed(config.PriceFeedConfig{Tokens: []config.OnChainPriceConfig{
+		{Chain: "ethereum", Address: wethAddress, Symbol: "WETH", Oracle: "chainlink", Feed: ethUsdFeed},
+		{Chain: "ethereum", Address: daiAddress, Symbol: "DAI", Oracle: "chainlink", Feed: brokenFeed},
+		{Chain: "ethereum", Address: uniAddress, Symbol: "UNI", Name: "Uniswap", Oracle: "uniswap_v2", Feed: uniWethPair},
+		{Chain: "ethereum", Address: tokenAddress, Symbol: "TKN", Oracle: "uniswap_v3", Feed: daiTokenPool},
+	}}, &stubBlockchainFactory{client: client}, mocks.NewNopLogger())
+	require.NoError(t, err)
+
+	prices, err := feed.GetTokenPrices(context.Background())
+	require.NoError(t, err)
+	require.Len(t, prices, 3)
+
+	assert.Equal(t, "WETH", prices[0].Symbol)
+	assert.InDelta(t, 3000, prices[0].PriceUSD, 1e-9)
+	assert.Equal(t, updatedAt, prices[0].UpdatedAt)
+	assert.Equal(t, wethAddress, prices[0].ContractAddress)
+	assert.Equal(t, types.ChainTypeEthereum, prices[0].ChainType)
+
+	assert.Equal(t, "UNI", prices[1].Symbol)
+	assert.Equal(t, "Uniswap", prices[1].Name)
+	assert.InDelta(t, 6, prices[1].PriceUSD, 1e-9)
+
+	assert.Equal(t, "TKN", prices[2].Symbol)
+	assert.InDelta(t, 0.25, prices[2].PriceUSD, 1e-9)
+}
+
+func TestOnChainPriceFeed_AllTokensFail(t *testing.T) {
+	client := mocks.NewMockBlockchainClient()
+	expectCall(client, brokenFeed, decimalsMethod, nil, errors.New("execution reverted"))
+
+	feed, err := NewOnChainPriceFeed(config.PriceFeedConfig{Tokens: []config.OnChainPriceConfig{
+		{Chain: "ethereum", Address: wethAddress, Symbol: "WETH", Oracle: "chainlink", Feed: brokenFeed},
+	}}, &stubBlockchainFactory{client: client}, mocks.NewNopLogger())
+	require.NoError(t, err)
+
+	_, err = feed.GetTokenPrices(context.Background())
+	require.Error(t, err)
+	assert.True(t, coreerrors.IsError(err, coreerrors.ErrCodePriceFeedRequestFailed))
+}
+
+func TestNewOnChainPriceFeed_Validation(t *testing.T) {
+	tests := []struct {
+		name  string
+		token config.OnChainPriceConfig
+	}{
+		{name: "missing chain", token: config.OnChainPriceConfig{Address: wethAddress, Symbol: "WETH", Oracle: "chainlink", Feed: ethUsdFeed}},
+		{name: "invalid address", token: config.OnChainPriceConfig{Chain: "ethereum", Address: "0x123", Symbol: "WETH", Oracle: "chainlink", Feed: ethUsdFeed}},
+		{name: "invalid feed", token: config.OnChainPriceConfig{Chain: "ethereum", Address: wethAddress, Symbol: "WETH", Oracle: "chainlink"}},
+		{name: "unsupported oracle", token: config.OnChainPriceConfig{Chain: "ethereum", Address: wethAddress, Symbol: "WETH", Oracle: "curve", Feed: ethUsdFeed}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewOnChainPriceFeed(config.PriceFeedConfig{Tokens: []config.OnChainPriceConfig{tt.token}}, &stubBlockchainFactory{}, mocks.NewNopLogger())
+			assert.Error(t, err)
+		})
+	}
+}
@@ -3,6 +3,8 @@ package pricefeed
 import (
 	"context"
 	"time"
+
+	"vault0/internal/types"
 )
 
 // TokenPriceData represents the price data for a single token abstracted from various providers.
@@ -16,6 +18,14 @@ type TokenPriceData struct {
 	MarketCapUSD float64   // Market cap in USD
 	VolumeUSD24h float64   // Trading volume in last 24h
 	UpdatedAt    time.Time // Timestamp when the data was fetched/updated locally
+
+	// ContractAddress is the token contract the price applies to, empty when the
+	// provider identifies assets by symbol only
+	ContractAddress string
+	// ChainType is the chain of the token contract, empty when ContractAddress is
+	ChainType types.ChainType
+	// Sources lists the providers that contributed to an aggregated price
+	Sources []string
 }
 
 // PriceFeed defines the interface for fetching token price data
@@ -0,0 +1,225 @@
+package tokendiscovery
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"vault0/internal/core/blockchain"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/types"
+)
+
+// Metadata holds the token details read from a contract
+type Metadata struct {
+	// Token is the discovered token, ready to be registered. It is never marked verified.
+	Token *types.Token
+	// TotalSupply is the token's total supply in its smallest unit, nil if the
+	// contract doesn't expose totalSupply()
+	TotalSupply *big.Int
+}
+
+// Discoverer reads token metadata directly from token contracts
+type Discoverer interface {
+	// Discover reads name(), symbol(), decimals() and totalSupply() from a contract
+	// and detects ERC721 and ERC1155 collections through ERC-165 supportsInterface.
+	// Legacy tokens returning bytes32 instead of string for name and symbol are supported.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - chainType: The blockchain network the contract is deployed on
+	//   - address: The contract address
+	//
+	// Returns:
+	//   - *Metadata: The discovered token details
+	//   - error: ErrInvalidToken if the address is not a token contract, or any blockchain error
+	Discover(ctx context.Context, chainType types.ChainType, address string) (*Metadata, error)
+}
+
+// unknownSymbol is used for contracts that expose no symbol or name, matching
+// the fallback used by the transaction decoder
+const unknownSymbol = "UNKNOWN"
+
+// ERC-165 interface identifiers
+var (
+	erc165InterfaceID  = [4]byte{0x01, 0xff, 0xc9, 0xa7}
+	invalidInterfaceID = [4]byte{0xff, 0xff, 0xff, 0xff}
+	erc721InterfaceID  = [4]byte{0x80, 0xac, 0x58, 0xcd}
+	erc1155InterfaceID = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+)
+
+// Method selectors of the metadata getters
+var (
+	nameSelector              = crypto.Keccak256([]byte(types.ERC20NameMethod))[:4]
+	symbolSelector            = crypto.Keccak256([]byte(types.ERC20SymbolMethod))[:4]
+	decimalsSelector          = crypto.Keccak256([]byte(types.ERC20DecimalsMethod))[:4]
+	totalSupplySelector       = crypto.Keccak256([]byte(types.ERC20TotalSupplyMethod))[:4]
+	supportsInterfaceSelector = crypto.Keccak256([]byte("supportsInterface(bytes4)"))[:4]
+)
+
+var stringArguments = func() abi.Arguments {
+	stringType, _ := abi.NewType("string", "", nil)
+	return abi.Arguments{{Type: stringType}}
+}()
+
+type discoverer struct {
+	blockchainFactory blockchain.Factory
+	log               logger.Logger
+}
+
+// NewDiscoverer creates a new token metadata discoverer
+func NewDiscoverer(blockchainFactory blockchain.Factory, log logger.Logger) Discoverer {
+	return &discoverer{
+		blockchainFactory: blockchainFactory,
+		log:               log.With(logger.String("component", "token_discovery")),
+	}
+}
+
+// Discover reads the metadata of a token contract
+func (d *discoverer) Discover(ctx context.Context, chainType types.ChainType, address string) (*Metadata, error) {
+	addr, err := types.NewAddress(chainType, address)
+	if err != nil {
+		return nil, errors.NewInvalidTokenError("invalid address", err)
+	}
+	if types.IsZeroAddress(addr.Address) {
+		return nil, errors.NewInvalidTokenError("native tokens cannot be discovered", nil)
+	}
+
+	client, err := d.blockchainFactory.NewClient(chainType)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := client.GetCode(ctx, addr.Address)
+	if err != nil {
+		return nil, errors.NewBlockchainError(fmt.Errorf("failed to get code of %s: %w", addr.Address, err))
+	}
+	if len(code) == 0 {
+		return nil, errors.NewInvalidTokenError(fmt.Sprintf("%s is not a contract", addr.Address), nil)
+	}
+
+	token := &types.Token{
+		Address:   addr.Address,
+		ChainType: chainType,
+	}
+
+	token.Type = d.detectNFTStandard(ctx, client, addr.Address)
+
+	token.Name, _ = d.callString(ctx, client, addr.Address, nameSelector)
+	token.Symbol, _ = d.callString(ctx, client, addr.Address, symbolSelector)
+
+	totalSupply, supplyOk := d.callUint(ctx, client, addr.Address, totalSupplySelector)
+	decimals, decimalsOk := d.callUint(ctx, client, addr.Address, decimalsSelector)
+
+	if token.Type == "" {
+		// ERC20 has no ERC-165 identifier; require the mandatory getters instead
+		if !supplyOk || !decimalsOk {
+			return nil, errors.NewInvalidTokenError(fmt.Sprintf("%s does not implement ERC20, ERC721 or ERC1155", addr.Address), nil)
+		}
+		if !decimals.IsUint64() || decimals.Uint64() > 255 {
+			return nil, errors.NewInvalidTokenError(fmt.Sprintf("invalid decimals %s", decimals), nil)
+		}
+		token.Type = types.TokenTypeERC20
+		token.Decimals = uint8(decimals.Uint64())
+	}
+
+	if token.Symbol == "" {
+		token.Symbol = token.Name
+	}
+	if token.Symbol == "" {
+		token.Symbol = unknownSymbol
+	}
+
+	metadata := &Metadata{Token: token}
+	if supplyOk {
+		metadata.TotalSupply = totalSupply
+	}
+
+	d.log.Debug("Discovered token metadata",
+		logger.String("address", token.Address),
+		logger.String("chain_type", string(chainType)),
+		logger.String("symbol", token.Symbol),
+		logger.String("type", string(token.Type)))
+
+	return metadata, nil
+}
+
+// detectNFTStandard returns the NFT standard advertised through ERC-165, or an
+// empty token type if the contract doesn't implement ERC-165 correctly or is not an NFT
+func (d *discoverer) detectNFTStandard(ctx context.Context, client blockchain.BlockchainClient, address string) types.TokenType {
+	// Per ERC-165, a compliant contract supports its own id and rejects 0xffffffff
+	if !d.supportsInterface(ctx, client, address, erc165InterfaceID) ||
+		d.supportsInterface(ctx, client, address, invalidInterfaceID) {
+		return ""
+	}
+
+	switch {
+	case d.supportsInterface(ctx, client, address, erc721InterfaceID):
+		return types.TokenTypeERC721
+	case d.supportsInterface(ctx, client, address, erc1155InterfaceID):
+		return types.TokenTypeERC1155
+	default:
+		return ""
+	}
+}
+
+// supportsInterface calls supportsInterface(bytes4), treating reverts as unsupported
+func (d *discoverer) supportsInterface(ctx context.Context, client blockchain.BlockchainClient, address string, interfaceID [4]byte) bool {
+	data := append(bytes.Clone(supportsInterfaceSelector), common.RightPadBytes(interfaceID[:], 32)...)
+	result, err := client.CallContract(ctx, "", address, data)
+	if err != nil || len(result) < 32 {
+		return false
+	}
+	return new(big.Int).SetBytes(result[:32]).Cmp(big.NewInt(1)) == 0
+}
+
+// callUint calls a getter returning a uint256
+func (d *discoverer) callUint(ctx context.Context, client blockchain.BlockchainClient, address string, selector []byte) (*big.Int, bool) {
+	result, err := client.CallContract(ctx, "", address, selector)
+	if err != nil || len(result) < 32 {
+		return nil, false
+	}
+	return new(big.Int).SetBytes(result[:32]), true
+}
+
+// callString calls a getter returning a string, or a bytes32 for legacy tokens such as MKR
+func (d *discoverer) callString(ctx context.Context, client blockchain.BlockchainClient, address string, selector []byte) (string, bool) {
+	result, err := client.CallContract(ctx, "", address, selector)
+	if err != nil || len(result) == 0 {
+		return "", false
+	}
+	value, ok := decodeString(result)
+	return value, ok
+}
+
+// decodeString decodes an ABI-encoded string, falling back to a NUL-padded bytes32
+func decodeString(data []byte) (string, bool) {
+	if len(data) > 32 {
+		if values, err := stringArguments.Unpack(data); err == nil && len(values) == 1 {
+			if s, ok := values[0].(string); ok {
+				return sanitize(s), true
+			}
+		}
+	}
+	if len(data) == 32 {
+		return sanitize(string(bytes.TrimRight(data, "\x00"))), true
+	}
+	return "", false
+}
+
+// sanitize strips control characters and surrounding whitespace from contract-provided text
+func sanitize(s string) string {
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, s))
+}
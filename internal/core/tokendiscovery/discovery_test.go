@@ -0,0 +1,228 @@
+package tokendiscovery
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/core/blockchain"
+	coreerrors "vault0/internal/errors"
+	"vault0/internal/testing/mocks"
+	"vault0/internal/types"
+)
+
+const testTokenAddress = "0xA00000000000000000000000000000000000000A"
+
+var errReverted = errors.New("execution reverted")
+
+// stubFactory returns the same blockchain client for every chain
+type stubFactory struct {
+	client blockchain.BlockchainClient
+}
+
+func (f *stubFactory) NewClient(chainType types.ChainType) (blockchain.BlockchainClient, error) {
+	return f.client, nil
+}
+
+func (f *stubFactory) NewMonitor(chainType types.ChainType) (blockchain.BLockchainEventMonitor, error) {
+	return nil, errors.New("not implemented")
+}
+
+// uintWord encodes a uint256 return value
+func uintWord(value int64) []byte {
+	return common.LeftPadBytes(big.NewInt(value).Bytes(), 32)
+}
+
+// stringResult encodes a string return value
+func stringResult(t *testing.T, value string) []byte {
+	data, err := stringArguments.Pack(value)
+	require.NoError(t, err)
+	return data
+}
+
+// bytes32Result encodes a NUL-padded bytes32 return value
+func bytes32Result(value string) []byte {
+	return common.RightPadBytes([]byte(value), 32)
+}
+
+// interfaceCall matches a supportsInterface call for the given interface id
+func interfaceCall(interfaceID [4]byte) any {
+	return mock.MatchedBy(func(data []byte) bool {
+		return bytes.Equal(data, append(bytes.Clone(supportsInterfaceSelector), common.RightPadBytes(interfaceID[:], 32)...))
+	})
+}
+
+// selectorCall matches a call without arguments to the given selector
+func selectorCall(selector []byte) any {
+	return mock.MatchedBy(func(data []byte) bool {
+		return bytes.Equal(data, selector)
+	})
+}
+
+// expectNoERC165 makes the contract revert on supportsInterface
+func expectNoERC165(client *mocks.MockBlockchainClient) {
+	client.On("CallContract", mock.Anything, "", testTokenAddress, mock.MatchedBy(func(data []byte) bool {
+		return bytes.HasPrefix(data, supportsInterfaceSelector)
+	})).Return(nil, errReverted)
+}
+
+// expectERC165 makes the contract advertise ERC-165 and the given interfaces
+func expectERC165(client *mocks.MockBlockchainClient, supported ...[4]byte) {
+	client.On("CallContract", mock.Anything, "", testTokenAddress, interfaceCall(erc165InterfaceID)).Return(uintWord(1), nil)
+	client.On("CallContract", mock.Anything, "", testTokenAddress, interfaceCall(invalidInterfaceID)).Return(uintWord(0), nil)
+	for _, id := range []([4]byte){erc721InterfaceID, erc1155InterfaceID} {
+		result := uintWord(0)
+		for _, s := range supported {
+			if s == id {
+				result = uintWord(1)
+			}
+		}
+		client.On("CallContract", mock.Anything, "", testTokenAddress, interfaceCall(id)).Return(result, nil)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		code         []byte
+		setup        func(*testing.T, *mocks.MockBlockchainClient)
+		expected     *types.Token
+		expectSupply *big.Int
+		errCode      string
+	}{
+		{
+			name: "erc20 with string metadata",
+			setup: func(t *testing.T, client *mocks.MockBlockchainClient) {
+				expectNoERC165(client)
+				client.On("CallContract", mock.Anything, "", testTokenAddress, selectorCall(nameSelector)).Return(stringResult(t, "USD Coin"), nil)
+				client.On("CallContract", mock.Anything, "", testTokenAddress, selectorCall(symbolSelector)).Return(stringResult(t, "USDC"), nil)
+				client.On("CallContract", mock.Anything, "", testTokenAddress, selectorCall(decimalsSelector)).Return(uintWord(6), nil)
+				client.On("CallContract", mock.Anything, "", testTokenAddress, selectorCall(totalSupplySelector)).Return(uintWord(1000000), nil)
+			},
+			expected: &types.Token{
+				Address:   testTokenAddress,
+				ChainType: types.ChainTypeEthereum,
+				Symbol:    "USDC",
+				Name:      "USD Coin",
+				Decimals:  6,
+				Type:      types.TokenTypeERC20,
+			},
+			expectSupply: big.NewInt(1000000),
+		},
+		{
+			name: "legacy erc20 with bytes32 metadata",
+			setup: func(t *testing.T, client *mocks.MockBlockchainClient) {
+				expectNoERC165(client)
+				client.On("CallContract", mock.Anything, "", testTokenAddress, selectorCall(nameSelector)).Return(bytes32Result("Maker"), nil)
+				client.On("CallContract", mock.Anything, "", testTokenAddress, selectorCall(symbolSelector)).Return(bytes32Result("MKR"), nil)
+				client.On("CallContract", mock.Anything, "", testTokenAddress, selectorCall(decimalsSelector)).Return(uintWord(18), nil)
+				client.On("CallContract", mock.Anything, "", testTokenAddress, selectorCall(totalSupplySelector)).Return(uintWord(5), nil)
+			},
+			expected: &types.Token{
+				Address:   testTokenAddress,
+				ChainType: types.ChainTypeEthereum,
+				Symbol:    "MKR",
+				Name:      "Maker",
+				Decimals:  18,
+				Type:      types.TokenTypeERC20,
+			},
+			expectSupply: big.NewInt(5),
+		},
+		{
+			name: "erc721 collection",
+			setup: func(t *testing.T, client *mocks.MockBlockchainClient) {
+				expectERC165(client, erc721InterfaceID)
+				client.On("CallContract", mock.Anything, "", testTokenAddress, selectorCall(nameSelector)).Return(stringResult(t, "Bored Ape Yacht Club"), nil)
+				client.On("CallContract", mock.Anything, "", testTokenAddress, selectorCall(symbolSelector)).Return(stringResult(t, "BAYC"), nil)
+				client.On("CallContract", mock.Anything, "", testTokenAddress, selectorCall(decimalsSelector)).Return(nil, errReverted)
+				client.On("CallContract", mock.Anything, "", testTokenAddress, selectorCall(totalSupplySelector)).Return(uintWord(10000), nil)
+			},
+			expected: &types.Token{
+				Address:   testTokenAddress,
+				ChainType: types.ChainTypeEthereum,
+				Symbol:    "BAYC",
+				Name:      "Bored Ape Yacht Club",
+				Type:      types.TokenTypeERC721,
+			},
+			expectSupply: big.NewInt(10000),
+		},
+		{
+			name: "erc1155 collection without metadata",
+			setup: func(t *testing.T, client *mocks.MockBlockchainClient) {
+				expectERC165(client, erc1155InterfaceID)
+				client.On("CallContract", mock.Anything, "", testTokenAddress, mock.Anything).Return(nil, errReverted)
+			},
+			expected: &types.Token{
+				Address:   testTokenAddress,
+				ChainType: types.ChainTypeEthereum,
+				Symbol:    unknownSymbol,
+				Type:      types.TokenTypeERC1155,
+			},
+		},
+		{
+			name: "contract that is not a token",
+			setup: func(t *testing.T, client *mocks.MockBlockchainClient) {
+				expectNoERC165(client)
+				client.On("CallContract", mock.Anything, "", testTokenAddress, mock.Anything).Return(nil, errReverted)
+			},
+			errCode: coreerrors.ErrCodeInvalidToken,
+		},
+		{
+			name:    "externally owned account",
+			code:    []byte{},
+			setup:   func(t *testing.T, client *mocks.MockBlockchainClient) {},
+			errCode: coreerrors.ErrCodeInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := mocks.NewMockBlockchainClient()
+			code := tt.code
+			if code == nil {
+				code = []byte{0x60, 0x80}
+			}
+			client.On("GetCode", mock.Anything, testTokenAddress).Return(code, nil)
+			tt.setup(t, client)
+
+			discoverer := NewDiscoverer(&stubFactory{client: client}, mocks.NewNopLogger())
+			metadata, err := discoverer.Discover(ctx, types.ChainTypeEthereum, testTokenAddress)
+
+			if tt.errCode != "" {
+				require.Error(t, err)
+				assert.True(t, coreerrors.IsError(err, tt.errCode), "unexpected error: %v", err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, metadata.Token)
+			assert.Equal(t, tt.expectSupply, metadata.TotalSupply)
+			assert.False(t, metadata.Token.Verified)
+		})
+	}
+}
+
+func TestDecodeString(t *testing.T) {
+	packed, err := stringArguments.Pack("Wrapped\x00 Ether\n")
+	require.NoError(t, err)
+
+	value, ok := decodeString(packed)
+	assert.True(t, ok)
+	assert.Equal(t, "Wrapped Ether", value)
+
+	value, ok = decodeString(bytes32Result("DAI"))
+	assert.True(t, ok)
+	assert.Equal(t, "DAI", value)
+
+	_, ok = decodeString([]byte{0x01, 0x02})
+	assert.False(t, ok)
+}
@@ -0,0 +1,269 @@
+package tokenrisk
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"unicode"
+
+	"vault0/internal/core/blockexplorer"
+	"vault0/internal/core/tokenstore"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/types"
+)
+
+// Classifier detects spam and scam tokens and transactions, such as airdropped
+// tokens impersonating well-known ones and address-poisoning transfers
+type Classifier interface {
+	// ClassifyToken returns the risk flags of a token. Native and verified tokens
+	// are trusted and never flagged.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - token: The token to classify
+	//
+	// Returns:
+	//   - []types.RiskFlag: The risk flags, empty if the token looks legitimate
+	//   - error: Any error that occurred while loading the verified tokens
+	ClassifyToken(ctx context.Context, token *types.Token) ([]types.RiskFlag, error)
+
+	// ClassifyTransaction returns the risk flags of a transaction, including the flags
+	// of the token it transfers. Addresses involved in the transaction that look like one
+	// of the known counterparties without matching it are reported as address poisoning.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - tx: The transaction to classify
+	//   - knownAddresses: The addresses the wallet previously transacted with
+	//
+	// Returns:
+	//   - []types.RiskFlag: The risk flags, empty if the transaction looks legitimate
+	//   - error: Any error that occurred while classifying the transferred token
+	ClassifyTransaction(ctx context.Context, tx *types.Transaction, knownAddresses []string) ([]types.RiskFlag, error)
+}
+
+// poisoningMatchLength is the number of leading and trailing hex characters an address
+// must share with a known counterparty to be considered a poisoning attempt. Wallets
+// usually abbreviate addresses to their first and last four characters.
+const poisoningMatchLength = 4
+
+type classifier struct {
+	tokenStore      tokenstore.TokenStore
+	explorerFactory blockexplorer.Factory
+	log             logger.Logger
+	// verifiedContracts caches the explorer verification status by chain and address
+	verifiedContracts sync.Map
+}
+
+// NewClassifier creates a new token risk classifier
+func NewClassifier(tokenStore tokenstore.TokenStore, explorerFactory blockexplorer.Factory, log logger.Logger) Classifier {
+	return &classifier{
+		tokenStore:      tokenStore,
+		explorerFactory: explorerFactory,
+		log:             log.With(logger.String("component", "token_risk")),
+	}
+}
+
+// ClassifyToken returns the risk flags of a token
+func (c *classifier) ClassifyToken(ctx context.Context, token *types.Token) ([]types.RiskFlag, error) {
+	if token == nil || token.IsNative() || token.Verified {
+		return nil, nil
+	}
+
+	var flags []types.RiskFlag
+
+	if verified, ok := c.isContractVerified(ctx, token.ChainType, token.Address); ok && !verified {
+		flags = append(flags, types.RiskFlagUnverifiedContract)
+	}
+
+	lookalike, err := c.isLookalikeSymbol(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if lookalike {
+		flags = append(flags, types.RiskFlagLookalikeSymbol)
+	}
+
+	return flags, nil
+}
+
+// ClassifyTransaction returns the risk flags of a transaction
+func (c *classifier) ClassifyTransaction(ctx context.Context, tx *types.Transaction, knownAddresses []string) ([]types.RiskFlag, error) {
+	if tx == nil {
+		return nil, errors.NewInvalidInputError("Transaction cannot be nil", "transaction", nil)
+	}
+
+	var flags []types.RiskFlag
+
+	if tokenAddress, ok := tx.Metadata.GetString(types.ERC20TokenAddressMetadataKey); ok {
+		tokenFlags, err := c.classifyTransferredToken(ctx, tx, tokenAddress)
+		if err != nil {
+			return nil, err
+		}
+		flags = append(flags, tokenFlags...)
+
+		if amount, ok := tx.Metadata.GetBigInt(types.ERC20AmountMetadataKey); ok && amount.Sign() == 0 &&
+			tx.Type == types.TransactionTypeERC20Transfer {
+			flags = append(flags, types.RiskFlagZeroValueTransfer)
+		}
+	}
+
+	recipient, _ := tx.Metadata.GetString(types.ERC20RecipientMetadataKey)
+	if isPoisoningAttempt(knownAddresses, tx.From, tx.To, recipient) {
+		flags = append(flags, types.RiskFlagAddressPoisoning)
+	}
+
+	return types.MergeRiskFlags(flags), nil
+}
+
+// classifyTransferredToken returns the flags of the token a transaction transfers.
+// Registered tokens keep the flags they were classified with.
+func (c *classifier) classifyTransferredToken(ctx context.Context, tx *types.Transaction, tokenAddress string) ([]types.RiskFlag, error) {
+	token, err := c.tokenStore.GetToken(ctx, tokenAddress)
+	if err == nil {
+		return token.RiskFlags, nil
+	}
+	if !errors.IsError(err, errors.ErrCodeResourceNotFound) {
+		return nil, err
+	}
+
+	symbol, _ := tx.Metadata.GetString(types.ERC20TokenSymbolMetadataKey)
+	return c.ClassifyToken(ctx, &types.Token{
+		Address:   tokenAddress,
+		ChainType: tx.ChainType,
+		Symbol:    symbol,
+		Type:      types.TokenTypeERC20,
+	})
+}
+
+// isContractVerified checks whether the token contract source is verified on the block
+// explorer. The second result is false when the status couldn't be determined.
+func (c *classifier) isContractVerified(ctx context.Context, chainType types.ChainType, address string) (bool, bool) {
+	key := string(chainType) + ":" + strings.ToLower(address)
+	if verified, ok := c.verifiedContracts.Load(key); ok {
+		return verified.(bool), true
+	}
+
+	explorer, err := c.explorerFactory.NewExplorer(chainType)
+	if err != nil {
+		c.log.Debug("No block explorer to verify token contract",
+			logger.String("chain_type", string(chainType)),
+			logger.Error(err))
+		return false, false
+	}
+
+	info, err := explorer.GetContract(ctx, address)
+	var verified bool
+	switch {
+	case err == nil:
+		verified = info.IsVerified
+	case errors.IsError(err, errors.ErrCodeExplorerError):
+		// Etherscan-compatible explorers reject ABI requests of unverified contracts
+		verified = false
+	default:
+		c.log.Warn("Failed to check token contract verification",
+			logger.String("address", address),
+			logger.String("chain_type", string(chainType)),
+			logger.Error(err))
+		return false, false
+	}
+
+	c.verifiedContracts.Store(key, verified)
+	return verified, true
+}
+
+// isLookalikeSymbol checks whether a token uses the symbol of a verified token of the
+// same chain, allowing for homoglyphs, digits standing for letters and decorations
+func (c *classifier) isLookalikeSymbol(ctx context.Context, token *types.Token) (bool, error) {
+	skeleton := symbolSkeleton(token.Symbol)
+	if skeleton == "" {
+		return false, nil
+	}
+
+	chainType := token.ChainType
+	page, err := c.tokenStore.ListTokens(ctx, &tokenstore.TokenFilter{ChainType: &chainType}, 0, "")
+	if err != nil {
+		return false, err
+	}
+
+	for _, known := range page.Items {
+		if !known.Verified || strings.EqualFold(known.Address, token.Address) {
+			continue
+		}
+		if symbolSkeleton(known.Symbol) == skeleton {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// homoglyphs maps characters commonly used to impersonate latin letters
+var homoglyphs = map[rune]rune{
+	'0': 'o', '1': 'l', '3': 'e', '5': 's', '8': 'b',
+	'а': 'a', 'в': 'b', 'е': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o',
+	'р': 'p', 'с': 'c', 'т': 't', 'у': 'y', 'х': 'x', 'і': 'i', 'ѕ': 's',
+	'α': 'a', 'β': 'b', 'ε': 'e', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o',
+	'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x',
+}
+
+// symbolSkeleton reduces a symbol to a canonical form so that look-alike symbols
+// such as "USDС" (cyrillic С), "U$DC", "$USDC" or "USDC ✅" share the skeleton of "USDC"
+func symbolSkeleton(symbol string) string {
+	var b strings.Builder
+	// A leading $ is a decoration, while U$DC stands for USDC
+	symbol = strings.TrimLeft(strings.TrimSpace(symbol), "$")
+	for _, r := range strings.ToLower(symbol) {
+		if r == '$' {
+			r = 's'
+		}
+		if mapped, ok := homoglyphs[r]; ok {
+			r = mapped
+		}
+		if r <= unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// isPoisoningAttempt reports whether any candidate address shares its leading and
+// trailing characters with a known address without being that address
+func isPoisoningAttempt(knownAddresses []string, candidates ...string) bool {
+	known := make(map[string]bool, len(knownAddresses))
+	for _, address := range knownAddresses {
+		if normalized := normalizeHex(address); normalized != "" {
+			known[normalized] = true
+		}
+	}
+
+	for _, candidate := range candidates {
+		address := normalizeHex(candidate)
+		if address == "" || known[address] {
+			continue
+		}
+		for other := range known {
+			if len(other) == len(address) &&
+				other[:poisoningMatchLength] == address[:poisoningMatchLength] &&
+				other[len(other)-poisoningMatchLength:] == address[len(address)-poisoningMatchLength:] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// normalizeHex lowercases an address and strips its 0x prefix, returning an empty
+// string for addresses too short to compare or for the zero address
+func normalizeHex(address string) string {
+	if address == "" || types.IsZeroAddress(address) {
+		return ""
+	}
+	normalized := strings.TrimPrefix(strings.ToLower(address), "0x")
+	if len(normalized) < 2*poisoningMatchLength {
+		return ""
+	}
+	return normalized
+}
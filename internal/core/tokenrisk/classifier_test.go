@@ -0,0 +1,222 @@
+package tokenrisk
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/core/blockexplorer"
+	"vault0/internal/core/tokenstore"
+	coreerrors "vault0/internal/errors"
+	"vault0/internal/testing/mocks"
+	"vault0/internal/types"
+)
+
+const (
+	usdcAddress  = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+	spamAddress  = "0x5A5A5A5A5A5A5A5A5A5A5A5A5A5A5A5A5A5A5A5A"
+	walletAddr   = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+	counterparty = "0x1234567890abcdef1234567890abcdef12345678"
+	poisoned     = "0x1234000000000000000000000000000000005678"
+)
+
+// fakeTokenStore serves tokens from memory
+type fakeTokenStore struct {
+	tokenstore.TokenStore
+	tokens []types.Token
+}
+
+func (s *fakeTokenStore) GetToken(ctx context.Context, address string) (*types.Token, error) {
+	for _, token := range s.tokens {
+		if token.Address == address {
+			return &token, nil
+		}
+	}
+	return nil, coreerrors.NewResourceNotFoundError("token", address)
+}
+
+func (s *fakeTokenStore) ListTokens(ctx context.Context, filter *tokenstore.TokenFilter, limit int, nextToken string) (*types.Page[types.Token], error) {
+	var items []types.Token
+	for _, token := range s.tokens {
+		if filter.ChainType == nil || token.ChainType == *filter.ChainType {
+			items = append(items, token)
+		}
+	}
+	return &types.Page[types.Token]{Items: items}, nil
+}
+
+// stubExplorerFactory returns the same block explorer for every chain
+type stubExplorerFactory struct {
+	explorer blockexplorer.BlockExplorer
+}
+
+func (f *stubExplorerFactory) NewExplorer(chainType types.ChainType) (blockexplorer.BlockExplorer, error) {
+	return f.explorer, nil
+}
+
+func newTestClassifier(tokens []types.Token, explorer *mocks.MockBlockExplorer) Classifier {
+	store := &fakeTokenStore{tokens: append([]types.Token{{
+		Address:   usdcAddress,
+		ChainType: types.ChainTypeEthereum,
+		Symbol:    "USDC",
+		Decimals:  6,
+		Type:      types.TokenTypeERC20,
+		Verified:  true,
+	}}, tokens...)}
+	return NewClassifier(store, &stubExplorerFactory{explorer: explorer}, mocks.NewNopLogger())
+}
+
+func TestClassifyToken(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		token    types.Token
+		contract *blockexplorer.ContractInfo
+		err      error
+		expected []types.RiskFlag
+	}{
+		{
+			name:     "verified contract with unique symbol",
+			token:    types.Token{Symbol: "UNI"},
+			contract: &blockexplorer.ContractInfo{IsVerified: true},
+		},
+		{
+			name:     "unverified contract",
+			token:    types.Token{Symbol: "UNI"},
+			err:      coreerrors.NewExplorerError(errors.New("Contract source code not verified")),
+			expected: []types.RiskFlag{types.RiskFlagUnverifiedContract},
+		},
+		{
+			name:     "lookalike symbol with cyrillic letter",
+			token:    types.Token{Symbol: "USDС"},
+			contract: &blockexplorer.ContractInfo{IsVerified: true},
+			expected: []types.RiskFlag{types.RiskFlagLookalikeSymbol},
+		},
+		{
+			name:     "unverified lookalike symbol",
+			token:    types.Token{Symbol: "$U$DC"},
+			err:      coreerrors.NewExplorerError(errors.New("Contract source code not verified")),
+			expected: []types.RiskFlag{types.RiskFlagUnverifiedContract, types.RiskFlagLookalikeSymbol},
+		},
+		{
+			name:  "explorer unavailable",
+			token: types.Token{Symbol: "UNI"},
+			err:   coreerrors.NewExplorerRequestFailedError(errors.New("timeout")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			explorer := mocks.NewMockBlockExplorer()
+			explorer.On("GetContract", mock.Anything, spamAddress).Return(tt.contract, tt.err)
+
+			token := tt.token
+			token.Address = spamAddress
+			token.ChainType = types.ChainTypeEthereum
+			token.Type = types.TokenTypeERC20
+
+			flags, err := newTestClassifier(nil, explorer).ClassifyToken(ctx, &token)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, flags)
+		})
+	}
+}
+
+func TestClassifyToken_TrustsVerifiedTokens(t *testing.T) {
+	explorer := mocks.NewMockBlockExplorer()
+	classifier := newTestClassifier(nil, explorer)
+
+	flags, err := classifier.ClassifyToken(context.Background(), &types.Token{
+		Address:   spamAddress,
+		ChainType: types.ChainTypeEthereum,
+		Symbol:    "USDC",
+		Type:      types.TokenTypeERC20,
+		Verified:  true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, flags)
+	explorer.AssertNotCalled(t, "GetContract", mock.Anything, mock.Anything)
+}
+
+func TestClassifyTransaction(t *testing.T) {
+	ctx := context.Background()
+	flaggedToken := types.Token{
+		Address:   spamAddress,
+		ChainType: types.ChainTypeEthereum,
+		Symbol:    "USDC",
+		Type:      types.TokenTypeERC20,
+		RiskFlags: []types.RiskFlag{types.RiskFlagLookalikeSymbol},
+	}
+
+	tests := []struct {
+		name     string
+		tx       *types.Transaction
+		expected []types.RiskFlag
+	}{
+		{
+			name: "transfer of a verified token to a known counterparty",
+			tx:   erc20Transfer(usdcAddress, counterparty, "1000000"),
+		},
+		{
+			name:     "zero value transfer to a poisoned address",
+			tx:       erc20Transfer(usdcAddress, poisoned, "0"),
+			expected: []types.RiskFlag{types.RiskFlagZeroValueTransfer, types.RiskFlagAddressPoisoning},
+		},
+		{
+			name:     "transfer of a flagged token",
+			tx:       erc20Transfer(spamAddress, walletAddr, "1000000"),
+			expected: []types.RiskFlag{types.RiskFlagLookalikeSymbol},
+		},
+		{
+			name: "native transfer from a poisoned address",
+			tx: &types.Transaction{
+				BaseTransaction: types.BaseTransaction{
+					ChainType: types.ChainTypeEthereum,
+					From:      poisoned,
+					To:        walletAddr,
+					Type:      types.TransactionTypeNative,
+				},
+			},
+			expected: []types.RiskFlag{types.RiskFlagAddressPoisoning},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			classifier := newTestClassifier([]types.Token{flaggedToken}, mocks.NewMockBlockExplorer())
+
+			flags, err := classifier.ClassifyTransaction(ctx, tt.tx, []string{walletAddr, counterparty})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, flags)
+		})
+	}
+}
+
+// erc20Transfer builds an outgoing ERC20 transfer of the test wallet
+func erc20Transfer(tokenAddress, recipient, amount string) *types.Transaction {
+	return &types.Transaction{
+		BaseTransaction: types.BaseTransaction{
+			ChainType: types.ChainTypeEthereum,
+			From:      walletAddr,
+			To:        tokenAddress,
+			Type:      types.TransactionTypeERC20Transfer,
+		},
+		Metadata: types.TxMetadata{
+			types.ERC20TokenAddressMetadataKey: tokenAddress,
+			types.ERC20RecipientMetadataKey:    recipient,
+			types.ERC20AmountMetadataKey:       amount,
+		},
+	}
+}
+
+func TestSymbolSkeleton(t *testing.T) {
+	for _, symbol := range []string{"USDC", "usdc", "USDС", "U$DC", "$USDC", "USDC ✅", "U5DC"} {
+		assert.Equal(t, "usdc", symbolSkeleton(symbol), symbol)
+	}
+	assert.Equal(t, "", symbolSkeleton("🚀"))
+}
@@ -16,6 +16,7 @@ import (
 // dbTokenStore implements the TokenStore interface using an SQL database
 type dbTokenStore struct {
 	db          *db.DB
+	chains      *types.Chains
 	log         logger.Logger
 	tokenEvents chan TokenEvent
 }
@@ -57,13 +58,20 @@ func (s *dbTokenStore) AddToken(ctx context.Context, token *types.Token) error {
 	// Insert the new token
 	_, err = s.db.ExecuteStatementContext(
 		ctx,
-		`INSERT INTO tokens (address, chain_type, symbol, decimals, type) 
-		VALUES (?, ?, ?, ?, ?)`,
+		`INSERT INTO tokens (address, chain_type, symbol, name, decimals, type, verified, logo_uri, tags, risk_flags, fee_on_transfer, rebasing) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		token.Address,
 		token.ChainType,
 		token.Symbol,
+		token.Name,
 		token.Decimals,
 		token.Type,
+		token.Verified,
+		token.LogoURI,
+		joinList(token.Tags),
+		joinList(token.RiskFlags),
+		token.FeeOnTransfer,
+		token.Rebasing,
 	)
 
 	if err != nil {
@@ -93,9 +101,10 @@ func (s *dbTokenStore) GetToken(ctx context.Context, address string) (*types.Tok
 	lowercaseAddress := strings.ToLower(address)
 
 	var token types.Token
+	var tags, riskFlags string
 	rows, err := s.db.ExecuteQueryContext(
 		ctx,
-		`SELECT address, chain_type, symbol, decimals, type 
+		`SELECT address, chain_type, symbol, name, decimals, type, verified, logo_uri, tags, risk_flags, fee_on_transfer, rebasing 
 		FROM tokens 
 		WHERE lower(address) = ?`,
 		lowercaseAddress,
@@ -113,20 +122,29 @@ func (s *dbTokenStore) GetToken(ctx context.Context, address string) (*types.Tok
 		&token.Address,
 		&token.ChainType,
 		&token.Symbol,
+		&token.Name,
 		&token.Decimals,
 		&token.Type,
+		&token.Verified,
+		&token.LogoURI,
+		&tags,
+		&riskFlags,
+		&token.FeeOnTransfer,
+		&token.Rebasing,
 	)
 
 	if err != nil {
 		return nil, err
 	}
+	token.Tags = splitList[string](tags)
+	token.RiskFlags = splitList[types.RiskFlag](riskFlags)
 
 	return &token, nil
 }
 
 // ListTokens retrieves tokens in the store with pagination and filtering
 func (s *dbTokenStore) ListTokens(ctx context.Context, filter *TokenFilter, limit int, nextToken string) (*types.Page[types.Token], error) {
-	query := `SELECT address, chain_type, symbol, decimals, type 
+	query := `SELECT address, chain_type, symbol, name, decimals, type, verified, logo_uri, tags, risk_flags, fee_on_transfer, rebasing 
 		FROM tokens
 		WHERE 1=1` // Base condition to make adding filters easier
 
@@ -144,6 +162,12 @@ func (s *dbTokenStore) ListTokens(ctx context.Context, filter *TokenFilter, limi
 			query += " AND type = ?"
 			args = append(args, *filter.TokenType)
 		}
+
+		// Apply rebasing filter if provided
+		if filter.Rebasing != nil {
+			query += " AND rebasing = ?"
+			args = append(args, *filter.Rebasing)
+		}
 	}
 
 	// Default sort columns
@@ -218,12 +242,19 @@ func (s *dbTokenStore) UpdateToken(ctx context.Context, token *types.Token) erro
 	token.Address = addr.Address
 
 	query := `UPDATE tokens 
-		SET symbol = ?, decimals = ?, type = ?, updated_at = ?
+		SET symbol = ?, name = ?, decimals = ?, type = ?, verified = ?, logo_uri = ?, tags = ?, risk_flags = ?, fee_on_transfer = ?, rebasing = ?, updated_at = ?
 		WHERE address = ? AND chain_type = ?`
 	args := []any{
 		token.Symbol,
+		token.Name,
 		token.Decimals,
 		token.Type,
+		token.Verified,
+		token.LogoURI,
+		joinList(token.Tags),
+		joinList(token.RiskFlags),
+		token.FeeOnTransfer,
+		token.Rebasing,
 		time.Now(),
 		token.Address,
 		token.ChainType,
@@ -310,15 +341,25 @@ func (s *dbTokenStore) scanTokensFromRows(rows *sql.Rows) ([]*types.Token, error
 
 	for rows.Next() {
 		var token types.Token
+		var tags, riskFlags string
 		if err := rows.Scan(
 			&token.Address,
 			&token.ChainType,
 			&token.Symbol,
+			&token.Name,
 			&token.Decimals,
 			&token.Type,
+			&token.Verified,
+			&token.LogoURI,
+			&tags,
+			&riskFlags,
+			&token.FeeOnTransfer,
+			&token.Rebasing,
 		); err != nil {
 			return nil, errors.NewDatabaseError(err)
 		}
+		token.Tags = splitList[string](tags)
+		token.RiskFlags = splitList[types.RiskFlag](riskFlags)
 		tokens = append(tokens, &token)
 	}
 
@@ -378,7 +419,7 @@ func (s *dbTokenStore) ListTokensByAddresses(ctx context.Context, chainType type
 	placeholdersStr := strings.Join(placeholders, ",")
 
 	// Build the query using the placeholders
-	query := `SELECT address, chain_type, symbol, decimals, type 
+	query := `SELECT address, chain_type, symbol, name, decimals, type, verified, logo_uri, tags, risk_flags, fee_on_transfer, rebasing 
 		FROM tokens 
 		WHERE chain_type = ? AND lower(address) IN (` + placeholdersStr + `)`
 
@@ -402,3 +443,26 @@ func (s *dbTokenStore) ListTokensByAddresses(ctx context.Context, chainType type
 
 	return tokenItems, nil
 }
+
+// joinList serializes token tags or risk flags into a comma-separated column value.
+// Both are identifiers and never contain commas.
+func joinList[T ~string](values []T) string {
+	parts := make([]string, len(values))
+	for i, value := range values {
+		parts[i] = string(value)
+	}
+	return strings.Join(parts, ",")
+}
+
+// splitList parses a comma-separated column value
+func splitList[T ~string](value string) []T {
+	if value == "" {
+		return nil
+	}
+	parts := strings.Split(value, ",")
+	values := make([]T, len(parts))
+	for i, part := range parts {
+		values[i] = T(part)
+	}
+	return values
+}
@@ -0,0 +1,264 @@
+package tokenstore
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/types"
+)
+
+// TokenList is a token list following the Uniswap token list schema
+// (https://uniswap.org/tokenlist.schema.json)
+type TokenList struct {
+	Name      string                  `json:"name"`
+	Timestamp string                  `json:"timestamp"`
+	Version   TokenListVersion        `json:"version"`
+	LogoURI   string                  `json:"logoURI,omitempty"`
+	Keywords  []string                `json:"keywords,omitempty"`
+	Tags      map[string]TokenListTag `json:"tags,omitempty"`
+	Tokens    []TokenListToken        `json:"tokens"`
+}
+
+// TokenListVersion is the semantic version of a token list
+type TokenListVersion struct {
+	Major int `json:"major"`
+	Minor int `json:"minor"`
+	Patch int `json:"patch"`
+}
+
+// TokenListTag describes a tag referenced by the tokens of a list
+type TokenListTag struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// TokenListToken is a single token entry of a token list
+type TokenListToken struct {
+	ChainID  int64    `json:"chainId"`
+	Address  string   `json:"address"`
+	Name     string   `json:"name"`
+	Symbol   string   `json:"symbol"`
+	Decimals uint8    `json:"decimals"`
+	LogoURI  string   `json:"logoURI,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// ImportOptions controls how a token list is imported
+type ImportOptions struct {
+	// DryRun computes the diff without changing the store
+	DryRun bool
+	// Prune deletes the verified ERC20 tokens missing from the list on the chains it
+	// covers. Without it, such tokens are only reported as removed.
+	Prune bool
+}
+
+// SkippedToken is a token list entry that couldn't be imported
+type SkippedToken struct {
+	Token  TokenListToken
+	Reason string
+}
+
+// TokenListDiff reports the changes an import made, or would make on a dry run
+type TokenListDiff struct {
+	Added   []types.Token
+	Changed []types.Token
+	Removed []types.Token
+	Skipped []SkippedToken
+}
+
+// ImportTokenList imports a token list, adding new tokens and updating the changed ones.
+// Imported tokens are ERC20 tokens and are marked as verified, clearing any risk flags.
+func (s *dbTokenStore) ImportTokenList(ctx context.Context, list *TokenList, opts ImportOptions) (*TokenListDiff, error) {
+	if list == nil {
+		return nil, errors.NewInvalidInputError("Token list cannot be nil", "list", nil)
+	}
+
+	diff := &TokenListDiff{}
+	listed := make(map[string]bool, len(list.Tokens))
+	coveredChains := make(map[types.ChainType]bool)
+
+	for _, entry := range list.Tokens {
+		chain, err := s.chains.GetByID(entry.ChainID)
+		if err != nil {
+			diff.Skipped = append(diff.Skipped, SkippedToken{Token: entry, Reason: "unsupported chain"})
+			continue
+		}
+
+		token, err := entry.toToken(chain.Type)
+		if err != nil {
+			diff.Skipped = append(diff.Skipped, SkippedToken{Token: entry, Reason: err.Error()})
+			continue
+		}
+
+		key := strings.ToLower(token.Address)
+		if listed[key] {
+			diff.Skipped = append(diff.Skipped, SkippedToken{Token: entry, Reason: "duplicate address"})
+			continue
+		}
+		listed[key] = true
+		coveredChains[chain.Type] = true
+
+		existing, err := s.GetToken(ctx, token.Address)
+		if err != nil {
+			if !errors.IsError(err, errors.ErrCodeResourceNotFound) {
+				return nil, err
+			}
+			diff.Added = append(diff.Added, *token)
+			continue
+		}
+
+		if existing.ChainType != token.ChainType || !existing.IsERC20() {
+			diff.Skipped = append(diff.Skipped, SkippedToken{
+				Token:  entry,
+				Reason: "address registered as " + string(existing.Type) + " token on " + string(existing.ChainType),
+			})
+			continue
+		}
+
+		// Token lists don't describe transfer behavior, keep the configured one
+		token.FeeOnTransfer = existing.FeeOnTransfer
+		token.Rebasing = existing.Rebasing
+		if tokenChanged(existing, token) {
+			diff.Changed = append(diff.Changed, *token)
+		}
+	}
+
+	erc20 := types.TokenTypeERC20
+	for chainType := range coveredChains {
+		filter := &TokenFilter{ChainType: &chainType, TokenType: &erc20}
+		page, err := s.ListTokens(ctx, filter, 0, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, token := range page.Items {
+			// Unverified tokens were discovered from transfers and never came from a list
+			if token.Verified && !listed[strings.ToLower(token.Address)] {
+				diff.Removed = append(diff.Removed, token)
+			}
+		}
+	}
+
+	if opts.DryRun {
+		return diff, nil
+	}
+
+	for i := range diff.Added {
+		if err := s.AddToken(ctx, &diff.Added[i]); err != nil {
+			return nil, err
+		}
+	}
+	for i := range diff.Changed {
+		if err := s.UpdateToken(ctx, &diff.Changed[i]); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Prune {
+		for _, token := range diff.Removed {
+			if err := s.DeleteToken(ctx, token.Address); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	s.log.Info("Imported token list",
+		logger.String("name", list.Name),
+		logger.Int("added", len(diff.Added)),
+		logger.Int("changed", len(diff.Changed)),
+		logger.Int("removed", len(diff.Removed)),
+		logger.Int("skipped", len(diff.Skipped)),
+		logger.Bool("pruned", opts.Prune))
+
+	return diff, nil
+}
+
+// ExportTokenList exports the verified ERC20 tokens of all configured chains as a token list
+func (s *dbTokenStore) ExportTokenList(ctx context.Context, name string) (*TokenList, error) {
+	if name == "" {
+		return nil, errors.NewInvalidInputError("Token list name is required", "name", name)
+	}
+
+	erc20 := types.TokenTypeERC20
+	page, err := s.ListTokens(ctx, &TokenFilter{TokenType: &erc20}, 0, "")
+	if err != nil {
+		return nil, err
+	}
+
+	list := &TokenList{
+		Name:      name,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Version:   TokenListVersion{Major: 1},
+		Tokens:    make([]TokenListToken, 0, len(page.Items)),
+	}
+
+	for _, token := range page.Items {
+		if !token.Verified {
+			continue
+		}
+		chain, ok := s.chains.Chains[token.ChainType]
+		if !ok {
+			continue
+		}
+		list.Tokens = append(list.Tokens, TokenListToken{
+			ChainID:  chain.ID,
+			Address:  token.Address,
+			Name:     token.Name,
+			Symbol:   token.Symbol,
+			Decimals: token.Decimals,
+			LogoURI:  token.LogoURI,
+			Tags:     token.Tags,
+		})
+	}
+
+	sort.Slice(list.Tokens, func(i, j int) bool {
+		if list.Tokens[i].ChainID != list.Tokens[j].ChainID {
+			return list.Tokens[i].ChainID < list.Tokens[j].ChainID
+		}
+		return list.Tokens[i].Symbol < list.Tokens[j].Symbol
+	})
+
+	return list, nil
+}
+
+// toToken converts a token list entry into a verified ERC20 token of the given chain
+func (t TokenListToken) toToken(chainType types.ChainType) (*types.Token, error) {
+	addr, err := types.NewAddress(chainType, t.Address)
+	if err != nil {
+		return nil, errors.NewInvalidTokenError("invalid address", err)
+	}
+	// Normalization pads short addresses, so they are only caught by comparing
+	if !strings.EqualFold(addr.Address, t.Address) {
+		return nil, errors.NewInvalidTokenError("invalid address", errors.NewInvalidAddressError(t.Address))
+	}
+
+	token := &types.Token{
+		Address:   addr.Address,
+		ChainType: chainType,
+		Symbol:    t.Symbol,
+		Name:      t.Name,
+		Decimals:  t.Decimals,
+		Type:      types.TokenTypeERC20,
+		Verified:  true,
+		LogoURI:   t.LogoURI,
+		Tags:      t.Tags,
+	}
+	if err := token.Validate(); err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// tokenChanged reports whether importing a token would modify the stored one
+func tokenChanged(existing, imported *types.Token) bool {
+	return existing.Symbol != imported.Symbol ||
+		existing.Name != imported.Name ||
+		existing.Decimals != imported.Decimals ||
+		existing.LogoURI != imported.LogoURI ||
+		existing.Verified != imported.Verified ||
+		existing.HasRiskFlags() ||
+		strings.Join(existing.Tags, ",") != strings.Join(imported.Tags, ",")
+}
@@ -0,0 +1,155 @@
+package tokenstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/db"
+	"vault0/internal/testing/dbtest"
+	"vault0/internal/testing/mocks"
+	"vault0/internal/types"
+)
+
+const (
+	testUSDCAddress = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+	testWBTCAddress = "0x2260FAC5E5542a773Aa44fBCfeDf7C193bc2C599"
+	testNewAddress  = "0x4444444444444444444444444444444444444444"
+)
+
+// newTestTokenStore creates a token store over the tokens seeded by the migrations
+func newTestTokenStore(database *db.DB) *dbTokenStore {
+	chains := &types.Chains{Chains: map[types.ChainType]types.Chain{
+		types.ChainTypeEthereum: {ID: 1, Type: types.ChainTypeEthereum},
+		types.ChainTypePolygon:  {ID: 137, Type: types.ChainTypePolygon},
+		types.ChainTypeBase:     {ID: 8453, Type: types.ChainTypeBase},
+	}}
+	return NewTokenStore(database, chains, mocks.NewNopLogger()).(*dbTokenStore)
+}
+
+// drainEvents returns the token events emitted so far
+func drainEvents(store *dbTokenStore) []TokenEvent {
+	var events []TokenEvent
+	for {
+		select {
+		case event := <-store.tokenEvents:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+// diffAddresses returns the addresses of a list of tokens
+func diffAddresses(tokens []types.Token) []string {
+	addresses := make([]string, len(tokens))
+	for i, token := range tokens {
+		addresses[i] = token.Address
+	}
+	return addresses
+}
+
+func TestTokenList_ExportImportRoundTrip(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		store := newTestTokenStore(database)
+		ctx := context.Background()
+
+		list, err := store.ExportTokenList(ctx, "Vault0")
+		require.NoError(t, err)
+		assert.Equal(t, "Vault0", list.Name)
+		require.NotEmpty(t, list.Tokens)
+		for i := 1; i < len(list.Tokens); i++ {
+			assert.LessOrEqual(t, list.Tokens[i-1].ChainID, list.Tokens[i].ChainID, "tokens must be sorted by chain")
+		}
+
+		// Re-importing an exported list changes nothing
+		diff, err := store.ImportTokenList(ctx, list, ImportOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, diff.Added)
+		assert.Empty(t, diff.Changed)
+		assert.Empty(t, diff.Removed)
+		assert.Empty(t, diff.Skipped)
+		assert.Empty(t, drainEvents(store))
+
+		_, err = store.ExportTokenList(ctx, "")
+		assert.Error(t, err)
+	})
+}
+
+func TestTokenList_ImportDiff(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		store := newTestTokenStore(database)
+		ctx := context.Background()
+
+		exported, err := store.ExportTokenList(ctx, "Vault0")
+		require.NoError(t, err)
+
+		// Keep the Ethereum tokens but WBTC, rename USDC and add a new token
+		list := &TokenList{Name: "Updated", Version: TokenListVersion{Major: 2}}
+		for _, entry := range exported.Tokens {
+			if entry.ChainID != 1 || entry.Address == testWBTCAddress {
+				continue
+			}
+			if entry.Address == testUSDCAddress {
+				entry.Name = "USD Coin"
+				entry.Tags = []string{"stablecoin"}
+			}
+			list.Tokens = append(list.Tokens, entry)
+		}
+		list.Tokens = append(list.Tokens,
+			TokenListToken{ChainID: 1, Address: testNewAddress, Name: "New Token", Symbol: "NEW", Decimals: 18},
+			TokenListToken{ChainID: 1, Address: testNewAddress, Name: "Duplicate", Symbol: "DUP", Decimals: 18},
+			TokenListToken{ChainID: 999, Address: testNewAddress, Name: "Unknown", Symbol: "UNK", Decimals: 18},
+			TokenListToken{ChainID: 1, Address: "0x1234", Name: "Invalid", Symbol: "INV", Decimals: 18},
+		)
+
+		// A dry run reports the diff without applying it
+		diff, err := store.ImportTokenList(ctx, list, ImportOptions{DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{testNewAddress}, diffAddresses(diff.Added))
+		assert.Empty(t, drainEvents(store))
+		_, err = store.GetToken(ctx, testNewAddress)
+		assert.Error(t, err)
+
+		diff, err = store.ImportTokenList(ctx, list, ImportOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []string{testNewAddress}, diffAddresses(diff.Added))
+		assert.Equal(t, []string{testUSDCAddress}, diffAddresses(diff.Changed))
+		// Only the tokens of the chains covered by the list are reported as removed
+		assert.Equal(t, []string{testWBTCAddress}, diffAddresses(diff.Removed))
+		require.Len(t, diff.Skipped, 3)
+		assert.Equal(t, "duplicate address", diff.Skipped[0].Reason)
+		assert.Equal(t, "unsupported chain", diff.Skipped[1].Reason)
+		assert.Contains(t, diff.Skipped[2].Reason, "invalid address")
+
+		events := drainEvents(store)
+		require.Len(t, events, 2)
+		assert.Equal(t, TokenEventAdded, events[0].EventType)
+		assert.Equal(t, testNewAddress, events[0].Token.Address)
+		assert.Equal(t, TokenEventUpdated, events[1].EventType)
+		assert.Equal(t, testUSDCAddress, events[1].Token.Address)
+
+		usdc, err := store.GetToken(ctx, testUSDCAddress)
+		require.NoError(t, err)
+		assert.Equal(t, "USD Coin", usdc.Name)
+		assert.Equal(t, []string{"stablecoin"}, usdc.Tags)
+
+		// Without pruning, the missing token is kept
+		_, err = store.GetToken(ctx, testWBTCAddress)
+		require.NoError(t, err)
+
+		diff, err = store.ImportTokenList(ctx, list, ImportOptions{Prune: true})
+		require.NoError(t, err)
+		assert.Empty(t, diff.Added)
+		assert.Empty(t, diff.Changed)
+		assert.Equal(t, []string{testWBTCAddress}, diffAddresses(diff.Removed))
+
+		events = drainEvents(store)
+		require.Len(t, events, 1)
+		assert.Equal(t, TokenEventDeleted, events[0].EventType)
+		_, err = store.GetToken(ctx, testWBTCAddress)
+		assert.Error(t, err)
+	})
+}
@@ -30,6 +30,7 @@ type TokenEvent struct {
 type TokenFilter struct {
 	ChainType *types.ChainType // Filter by blockchain type
 	TokenType *types.TokenType // Filter by token type
+	Rebasing  *bool            // Filter by rebasing behavior
 }
 
 // TokenStore defines the interface for managing tokens
@@ -57,6 +58,24 @@ type TokenStore interface {
 	// If an address is not found, it will be skipped in the result
 	ListTokensByAddresses(ctx context.Context, chainType types.ChainType, addresses []string) ([]types.Token, error)
 
+	// ImportTokenList imports a token list in the Uniswap token list format.
+	// New tokens are added and changed tokens updated, emitting the matching token events.
+	// Verified ERC20 tokens missing from the list on the chains it covers are reported
+	// as removed and only deleted when opts.Prune is set.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - list: The token list to import
+	//   - opts: Import options
+	//
+	// Returns:
+	//   - *TokenListDiff: The tokens added, changed, removed and skipped
+	//   - error: Any error that occurred while applying the changes
+	ImportTokenList(ctx context.Context, list *TokenList, opts ImportOptions) (*TokenListDiff, error)
+
+	// ExportTokenList exports the verified ERC20 tokens in the Uniswap token list format
+	ExportTokenList(ctx context.Context, name string) (*TokenList, error)
+
 	// TokenEvents returns a channel that emits token events.
 	// This channel notifies subscribers when tokens are added, updated, or deleted.
 	// The channel is closed when the token store is closed or the subscription is canceled.
@@ -64,10 +83,11 @@ type TokenStore interface {
 }
 
 // NewTokenStore creates a new TokenStore instance
-func NewTokenStore(db *db.DB, log logger.Logger) TokenStore {
+func NewTokenStore(db *db.DB, chains *types.Chains, log logger.Logger) TokenStore {
 	const tokenEventBufferSize = 100
 	return &dbTokenStore{
 		db:          db,
+		chains:      chains,
 		log:         log,
 		tokenEvents: make(chan TokenEvent, tokenEventBufferSize),
 	}
@@ -18,6 +18,30 @@ type Decoder interface {
 	// It returns the specific transaction struct (as `any`) or an error if identification
 	// or parsing fails, or if the method is unknown.
 	DecodeTransaction(ctx context.Context, tx *types.Transaction) (types.CoreTransaction, error)
+
+	// DecodeContractCall decodes the input data of a contract call into the called
+	// method and its named, typed arguments, using the ABI of the called contract.
+	// It works for any contract whose ABI can be resolved, not only known standards.
+	DecodeContractCall(ctx context.Context, tx *types.Transaction) (*types.DecodedCall, error)
+
+	// DecodeEvents decodes transaction receipt logs into named events using the ABI
+	// of each emitting contract. Logs that cannot be decoded are skipped.
+	DecodeEvents(ctx context.Context, chainType types.ChainType, logs []types.Log) []types.DecodedEvent
+
+	// DecodeNFTTransfers extracts the ERC721 and ERC1155 token movements from
+	// transaction receipt logs, one entry per moved token. Logs of other events
+	// and logs that cannot be decoded are skipped.
+	DecodeNFTTransfers(ctx context.Context, chainType types.ChainType, logs []types.Log) []types.NFTTransfer
+
+	// DecodeTokenApprovals extracts the ERC20 allowance changes from the Approval
+	// events of transaction receipt logs. Logs of other events and logs that
+	// cannot be decoded are skipped.
+	DecodeTokenApprovals(ctx context.Context, chainType types.ChainType, logs []types.Log) []types.TokenApproval
+
+	// DecodeTokenTransfers extracts the ERC20 token movements from the Transfer
+	// events of transaction receipt logs, reflecting the amounts actually moved.
+	// Logs of other events and logs that cannot be decoded are skipped.
+	DecodeTokenTransfers(ctx context.Context, chainType types.ChainType, logs []types.Log) []types.TokenTransfer
 }
 
 // NewDecoder creates a new instance of the EVM transaction mapper.
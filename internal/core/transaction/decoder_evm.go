@@ -34,6 +34,12 @@ func decodeTransactionFromMetadata(tx *types.Transaction) (types.CoreTransaction
 	switch tx.Type {
 	case types.TransactionTypeERC20Transfer:
 		return decodeERC20Transfer(tx)
+	case types.TransactionTypeERC20Approval:
+		return decodeERC20Approval(tx)
+	case types.TransactionTypeERC721Transfer:
+		return decodeERC721Transfer(tx)
+	case types.TransactionTypeERC1155Transfer:
+		return decodeERC1155Transfer(tx)
 	case types.TransactionTypeMultiSigWithdrawalRequest:
 		return decodeMultiSigWithdrawalRequest(tx)
 	case types.TransactionTypeMultiSigSignWithdrawal:
@@ -95,7 +101,7 @@ func (m *evmDecoder) DecodeTransaction(ctx context.Context, tx *types.Transactio
 
 	parsedAsERC20, err := parseAndPopulateERC20Metadata(ctx, txCopy, m.abiUtils, m.abiLoader, m.tokenStore)
 	if err != nil {
-		m.logger.Error("Error attempting to parse as ERC20 transfer, proceeding to check MultiSig",
+		m.logger.Error("Error attempting to parse as ERC20 transfer, proceeding to check NFT transfers",
 			logger.String("tx_hash", txCopy.Hash),
 			logger.Error(err),
 		)
@@ -104,6 +110,39 @@ func (m *evmDecoder) DecodeTransaction(ctx context.Context, tx *types.Transactio
 		return decodeERC20Transfer(txCopy)
 	}
 
+	parsedAsApproval, err := parseAndPopulateERC20ApprovalMetadata(ctx, txCopy, m.abiUtils, m.abiLoader, m.tokenStore)
+	if err != nil {
+		m.logger.Error("Error attempting to parse as ERC20 approval",
+			logger.String("tx_hash", txCopy.Hash),
+			logger.Error(err),
+		)
+	} else if parsedAsApproval {
+		m.logger.Debug("Successfully parsed as ERC20Approval", logger.String("tx_hash", txCopy.Hash))
+		return decodeERC20Approval(txCopy)
+	}
+
+	parsedAsERC721, err := parseAndPopulateERC721Metadata(ctx, txCopy, m.abiUtils, m.abiLoader, m.tokenStore)
+	if err != nil {
+		m.logger.Error("Error attempting to parse as ERC721 transfer",
+			logger.String("tx_hash", txCopy.Hash),
+			logger.Error(err),
+		)
+	} else if parsedAsERC721 {
+		m.logger.Debug("Successfully parsed as ERC721Transfer", logger.String("tx_hash", txCopy.Hash))
+		return decodeERC721Transfer(txCopy)
+	}
+
+	parsedAsERC1155, err := parseAndPopulateERC1155Metadata(ctx, txCopy, m.abiUtils, m.abiLoader, m.tokenStore)
+	if err != nil {
+		m.logger.Error("Error attempting to parse as ERC1155 transfer, proceeding to check MultiSig",
+			logger.String("tx_hash", txCopy.Hash),
+			logger.Error(err),
+		)
+	} else if parsedAsERC1155 {
+		m.logger.Debug("Successfully parsed as ERC1155Transfer", logger.String("tx_hash", txCopy.Hash))
+		return decodeERC1155Transfer(txCopy)
+	}
+
 	parsedAsMultiSig, err := parseAndPopulateMultiSigMetadata(ctx, txCopy, m.logger, m.abiUtils, m.abiLoader, m.tokenStore)
 	if err != nil {
 		m.logger.Error("Error attempting to parse as MultiSig",
@@ -127,7 +166,7 @@ func (m *evmDecoder) DecodeTransaction(ctx context.Context, tx *types.Transactio
 		return result, err
 	}
 
-	m.logger.Debug("Transaction data did not match known ERC20 or MultiSig patterns",
+	m.logger.Debug("Transaction data did not match known token transfer, approval or MultiSig patterns",
 		logger.String("tx_hash", tx.Hash))
 
 	return tx, nil
@@ -0,0 +1,243 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"vault0/internal/core/abi"
+	"vault0/internal/core/tokenstore"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/types"
+)
+
+// Precompute ERC20 allowance method IDs and the Approval topic locally for dispatcher logic
+var (
+	erc20ApproveMethodID           = crypto.Keccak256([]byte(types.ERC20ApproveMethod))[:4]
+	erc20IncreaseAllowanceMethodID = crypto.Keccak256([]byte(types.ERC20IncreaseAllowanceMethod))[:4]
+	erc20DecreaseAllowanceMethodID = crypto.Keccak256([]byte(types.ERC20DecreaseAllowanceMethod))[:4]
+	erc20PermitMethodID            = crypto.Keccak256([]byte(types.ERC20PermitMethod))[:4]
+
+	erc20ApprovalTopic = crypto.Keccak256Hash([]byte(types.ERC20ApprovalEvent))
+)
+
+// createERC20ApprovalFromMetadata constructs an ERC20Approval from metadata.
+func createERC20ApprovalFromMetadata(tx *types.Transaction) (*types.ERC20Approval, error) {
+	if tx == nil || tx.Metadata == nil {
+		return nil, errors.NewInvalidParameterError("transaction or metadata cannot be nil", "tx")
+	}
+	tokenAddr, ok := tx.Metadata.GetString(types.ERC20TokenAddressMetadataKey)
+	if !ok {
+		return nil, errors.NewMappingError(tx.Hash, "missing metadata: "+types.ERC20TokenAddressMetadataKey)
+	}
+	owner, ok := tx.Metadata.GetString(types.ERC20OwnerMetadataKey)
+	if !ok {
+		return nil, errors.NewMappingError(tx.Hash, "missing metadata: "+types.ERC20OwnerMetadataKey)
+	}
+	spender, ok := tx.Metadata.GetString(types.ERC20SpenderMetadataKey)
+	if !ok {
+		return nil, errors.NewMappingError(tx.Hash, "missing metadata: "+types.ERC20SpenderMetadataKey)
+	}
+	amount, ok := tx.Metadata.GetBigInt(types.ERC20AmountMetadataKey)
+	if !ok {
+		return nil, errors.NewMappingError(tx.Hash, "missing or invalid metadata: "+types.ERC20AmountMetadataKey)
+	}
+	method, ok := tx.Metadata.GetString(types.ERC20ApprovalMethodMetadataKey)
+	if !ok {
+		method = string(types.ERC20ApproveMethod)
+	}
+	decimals, ok := tx.Metadata.GetUint8(types.ERC20TokenDecimalsMetadataKey)
+	if !ok {
+		decimals = 18 // Default to 18 decimals if not specified
+	}
+	tokenSymbol, _ := tx.Metadata.GetString(types.ERC20TokenSymbolMetadataKey)
+
+	approval := &types.ERC20Approval{
+		Transaction:   *tx.Copy(),
+		TokenAddress:  tokenAddr,
+		TokenSymbol:   tokenSymbol,
+		TokenDecimals: decimals,
+		Owner:         owner,
+		Spender:       spender,
+		Amount:        amount.ToBigInt(),
+		Method:        types.ERC20MethodSignature(method),
+	}
+	approval.Type = types.TransactionTypeERC20Approval
+
+	return approval, nil
+}
+
+// parseAndPopulateERC20ApprovalMetadata attempts to parse tx data as an approve,
+// increaseAllowance, decreaseAllowance or permit call and updates tx.Metadata and
+// tx.Type if successful.
+// Returns true if parsing was successful and metadata was populated.
+func parseAndPopulateERC20ApprovalMetadata(ctx context.Context, tx *types.Transaction, abiUtils abi.ABIUtils, abiLoader abi.ABILoader, ts tokenstore.TokenStore) (bool, error) {
+	if tx == nil {
+		return false, errors.NewInvalidParameterError("transaction cannot be nil", "tx")
+	}
+
+	if tx.BaseTransaction.To == "" || len(tx.Data) < 4 {
+		return false, nil
+	}
+
+	methodID := abiUtils.ExtractMethodID(tx.Data)
+
+	var method types.ERC20MethodSignature
+	switch {
+	case bytes.Equal(methodID, erc20ApproveMethodID):
+		method = types.ERC20ApproveMethod
+	case bytes.Equal(methodID, erc20IncreaseAllowanceMethodID):
+		method = types.ERC20IncreaseAllowanceMethod
+	case bytes.Equal(methodID, erc20DecreaseAllowanceMethodID):
+		method = types.ERC20DecreaseAllowanceMethod
+	case bytes.Equal(methodID, erc20PermitMethodID):
+		method = types.ERC20PermitMethod
+	default:
+		return false, nil
+	}
+
+	// ERC721 shares the approve(address,uint256) selector; leave NFT approvals undecoded
+	tokenInfo, err := ts.GetToken(ctx, tx.BaseTransaction.To)
+	if err != nil {
+		tokenInfo = &types.Token{Address: tx.BaseTransaction.To, Symbol: "UNKNOWN", Decimals: 0}
+	} else if tokenInfo.IsNFT() {
+		return false, nil
+	}
+
+	erc20ABI, err := abiLoader.LoadABIByType(ctx, abi.ABITypeERC20)
+	if err != nil {
+		return false, fmt.Errorf("failed to load ERC20 ABI: %w", err)
+	}
+
+	// The selector resolves the method, so no name is required
+	parsedArgs, err := abiUtils.Unpack(erc20ABI, "", tx.Data)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse %s input: %w", method, err)
+	}
+
+	owner := tx.From
+	var amountKey string
+	switch method {
+	case types.ERC20ApproveMethod:
+		amountKey = "amount"
+	case types.ERC20IncreaseAllowanceMethod:
+		amountKey = "addedValue"
+	case types.ERC20DecreaseAllowanceMethod:
+		amountKey = "subtractedValue"
+	case types.ERC20PermitMethod:
+		// A permit is usually relayed by the spender on behalf of the signing owner
+		ownerAddr, err := abiUtils.GetAddressFromArgs(parsedArgs, "owner")
+		if err != nil {
+			return false, fmt.Errorf("failed to get owner ('owner') from parsed args: %w", err)
+		}
+		owner = ownerAddr.String()
+		amountKey = "value"
+	}
+
+	spenderAddr, err := abiUtils.GetAddressFromArgs(parsedArgs, "spender")
+	if err != nil {
+		return false, fmt.Errorf("failed to get spender ('spender') from parsed args: %w", err)
+	}
+
+	amount, err := abiUtils.GetBigIntFromArgs(parsedArgs, amountKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to get allowance amount ('%s') from parsed args: %w", amountKey, err)
+	}
+
+	if tx.Metadata == nil {
+		tx.Metadata = make(types.TxMetadata)
+	}
+
+	err = tx.Metadata.SetAll(map[string]any{
+		types.ERC20TokenAddressMetadataKey:   tokenInfo.Address,
+		types.ERC20TokenSymbolMetadataKey:    tokenInfo.Symbol,
+		types.ERC20TokenDecimalsMetadataKey:  tokenInfo.Decimals,
+		types.ERC20OwnerMetadataKey:          types.NormalizeAddress(tx.ChainType, owner),
+		types.ERC20SpenderMetadataKey:        spenderAddr.String(),
+		types.ERC20AmountMetadataKey:         amount.ToBigInt(),
+		types.ERC20ApprovalMethodMetadataKey: string(method),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to set ERC20 approval metadata: %w", err)
+	}
+
+	tx.Type = types.TransactionTypeERC20Approval
+
+	return true, nil
+}
+
+// decodeERC20Approval converts a generic transaction to ERC20Approval.
+func decodeERC20Approval(tx *types.Transaction) (*types.ERC20Approval, error) {
+	if tx == nil {
+		return nil, errors.NewInvalidParameterError("transaction cannot be nil", "tx")
+	}
+
+	if tx.Type != types.TransactionTypeERC20Approval {
+		return nil, errors.NewMappingError(tx.Hash, fmt.Sprintf("invalid transaction type %s, expected %s", tx.Type, types.TransactionTypeERC20Approval))
+	}
+	if tx.Metadata == nil {
+		return nil, errors.NewMappingError(tx.Hash, "metadata is required to map to ERC20Approval")
+	}
+
+	return createERC20ApprovalFromMetadata(tx)
+}
+
+// DecodeTokenApprovals extracts the ERC20 allowance changes emitted by Approval events.
+// ERC721 Approval events share the topic but index the token ID, so only logs with
+// three topics are considered.
+func (m *evmDecoder) DecodeTokenApprovals(ctx context.Context, chainType types.ChainType, logs []types.Log) []types.TokenApproval {
+	var approvals []types.TokenApproval
+	var erc20ABI string
+
+	for _, log := range logs {
+		if len(log.Topics) != 3 || common.HexToHash(log.Topics[0]) != erc20ApprovalTopic {
+			continue
+		}
+
+		if erc20ABI == "" {
+			var err error
+			erc20ABI, err = m.abiLoader.LoadABIByType(ctx, abi.ABITypeERC20)
+			if err != nil {
+				m.logger.Warn("Failed to load ERC20 ABI", logger.Error(err))
+				return approvals
+			}
+		}
+
+		event, err := m.abiUtils.DecodeLog(erc20ABI, log)
+		if err != nil {
+			m.logger.Debug("Could not decode ERC20 approval log",
+				logger.String("tx_hash", log.TransactionHash),
+				logger.String("contract_address", log.Address),
+				logger.Error(err))
+			continue
+		}
+
+		args := make(map[string]any, len(event.Arguments))
+		for _, argument := range event.Arguments {
+			args[argument.Name] = argument.Value
+		}
+		owner, _ := args["owner"].(string)
+		spender, _ := args["spender"].(string)
+		amount, ok := parseDecimal(args["value"])
+		if !ok || owner == "" || spender == "" {
+			m.logger.Debug("Invalid ERC20 approval event",
+				logger.String("tx_hash", log.TransactionHash),
+				logger.String("contract_address", log.Address))
+			continue
+		}
+
+		approvals = append(approvals, types.TokenApproval{
+			TokenAddress: types.NormalizeAddress(chainType, event.Address),
+			Owner:        types.NormalizeAddress(chainType, owner),
+			Spender:      types.NormalizeAddress(chainType, spender),
+			Amount:       amount,
+			LogIndex:     event.LogIndex,
+		})
+	}
+
+	return approvals
+}
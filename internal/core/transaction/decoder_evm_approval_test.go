@@ -0,0 +1,208 @@
+package transaction
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	gethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/core/abi"
+	"vault0/internal/core/tokenstore"
+	"vault0/internal/errors"
+	"vault0/internal/types"
+)
+
+const (
+	testTokenAddress = "0x4444444444444444444444444444444444444444"
+	testOwner        = "0x1111111111111111111111111111111111111111"
+	testSpender      = "0x2222222222222222222222222222222222222222"
+	testRelayer      = "0x3333333333333333333333333333333333333333"
+)
+
+// testTokenStore serves the registered tokens, reporting the others as not found
+type testTokenStore struct {
+	tokenstore.TokenStore
+	tokens map[string]*types.Token
+}
+
+func (s *testTokenStore) GetToken(ctx context.Context, address string) (*types.Token, error) {
+	if token, ok := s.tokens[strings.ToLower(address)]; ok {
+		return token, nil
+	}
+	return nil, errors.NewResourceNotFoundError("token", address)
+}
+
+// loadStandardABI parses a standard ABI from the contract resources
+func loadStandardABI(t *testing.T, name string) gethabi.ABI {
+	t.Helper()
+
+	artifact, err := os.ReadFile(testStandardABIPath + name + ".json")
+	require.NoError(t, err)
+	abiJSON, err := abi.ParseArtifactABI(artifact)
+	require.NoError(t, err)
+	parsed, err := gethabi.JSON(strings.NewReader(abiJSON))
+	require.NoError(t, err)
+	return parsed
+}
+
+func TestEvmDecoder_DecodeTransaction_ERC20Approval(t *testing.T) {
+	erc20 := loadStandardABI(t, "erc20")
+	tokenStore := &testTokenStore{tokens: map[string]*types.Token{
+		testTokenAddress: {Address: testTokenAddress, Symbol: "TKN", Decimals: 6, Type: types.TokenTypeERC20},
+		testNFTAddress:   {Address: testNFTAddress, Symbol: "NFT", Type: types.TokenTypeERC721},
+	}}
+	decoder := newTestDecoder(t, tokenStore)
+	ctx := context.Background()
+
+	pack := func(method string, args ...any) []byte {
+		data, err := erc20.Pack(method, args...)
+		require.NoError(t, err)
+		return data
+	}
+	spender := common.HexToAddress(testSpender)
+
+	tests := []struct {
+		name     string
+		to       string
+		from     string
+		data     []byte
+		owner    string
+		amount   int64
+		method   types.ERC20MethodSignature
+		expected bool
+	}{
+		{
+			name:     "approve",
+			to:       testTokenAddress,
+			from:     testOwner,
+			data:     pack("approve", spender, big.NewInt(1000)),
+			owner:    testOwner,
+			amount:   1000,
+			method:   types.ERC20ApproveMethod,
+			expected: true,
+		},
+		{
+			name:     "increaseAllowance",
+			to:       testTokenAddress,
+			from:     testOwner,
+			data:     pack("increaseAllowance", spender, big.NewInt(250)),
+			owner:    testOwner,
+			amount:   250,
+			method:   types.ERC20IncreaseAllowanceMethod,
+			expected: true,
+		},
+		{
+			name:     "decreaseAllowance",
+			to:       testTokenAddress,
+			from:     testOwner,
+			data:     pack("decreaseAllowance", spender, big.NewInt(50)),
+			owner:    testOwner,
+			amount:   50,
+			method:   types.ERC20DecreaseAllowanceMethod,
+			expected: true,
+		},
+		{
+			name: "permit relayed for the signing owner",
+			to:   testTokenAddress,
+			from: testRelayer,
+			data: pack("permit", common.HexToAddress(testOwner), spender, big.NewInt(5000), big.NewInt(1700000000),
+				uint8(27), [32]byte{0x01}, [32]byte{0x02}),
+			owner:    testOwner,
+			amount:   5000,
+			method:   types.ERC20PermitMethod,
+			expected: true,
+		},
+		{
+			name: "ERC721 approve is not an ERC20 approval",
+			to:   testNFTAddress,
+			from: testOwner,
+			data: pack("approve", spender, big.NewInt(7)),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := &types.Transaction{
+				BaseTransaction: types.BaseTransaction{
+					ChainType: types.ChainTypeEthereum,
+					Hash:      testNFTTxHash,
+					From:      tc.from,
+					To:        tc.to,
+					Data:      tc.data,
+					Value:     big.NewInt(0),
+					GasPrice:  big.NewInt(1),
+					Type:      types.TransactionTypeContractCall,
+				},
+				BlockNumber: big.NewInt(1),
+			}
+
+			decoded, err := decoder.DecodeTransaction(ctx, tx)
+			require.NoError(t, err)
+
+			approval, ok := decoded.(*types.ERC20Approval)
+			require.Equal(t, tc.expected, ok, "unexpected decoded type %T", decoded)
+			if !tc.expected {
+				return
+			}
+
+			assert.Equal(t, types.TransactionTypeERC20Approval, approval.Type)
+			assert.Equal(t, testTokenAddress, approval.TokenAddress)
+			assert.Equal(t, "TKN", approval.TokenSymbol)
+			assert.Equal(t, uint8(6), approval.TokenDecimals)
+			assert.True(t, strings.EqualFold(tc.owner, approval.Owner), "owner %s", approval.Owner)
+			assert.True(t, strings.EqualFold(testSpender, approval.Spender), "spender %s", approval.Spender)
+			assert.Equal(t, big.NewInt(tc.amount), approval.Amount)
+			assert.Equal(t, tc.method, approval.Method)
+		})
+	}
+}
+
+func TestEvmDecoder_DecodeTokenApprovals(t *testing.T) {
+	decoder := newTestDecoder(t, nil)
+	ctx := context.Background()
+
+	approvalLog := func(topics []string, amount int64, logIndex uint) types.Log {
+		return types.Log{
+			Address:         testTokenAddress,
+			Topics:          topics,
+			Data:            packArguments(t, "uint256", big.NewInt(amount)),
+			TransactionHash: testNFTTxHash,
+			LogIndex:        logIndex,
+		}
+	}
+
+	logs := []types.Log{
+		approvalLog([]string{erc20ApprovalTopic.Hex(), addressTopic(testOwner), addressTopic(testSpender)}, 1000, 1),
+		// Revocations are approvals of a zero allowance
+		approvalLog([]string{erc20ApprovalTopic.Hex(), addressTopic(testOwner), addressTopic(testRelayer)}, 0, 2),
+		// ERC721 approvals index the token ID
+		{
+			Address: testNFTAddress,
+			Topics:  []string{erc20ApprovalTopic.Hex(), addressTopic(testOwner), addressTopic(testSpender), uint256Topic(7)},
+		},
+		// Other events of the token are ignored
+		approvalLog([]string{erc721TransferTopic.Hex(), addressTopic(testOwner), addressTopic(testSpender)}, 5, 3),
+	}
+
+	approvals := decoder.DecodeTokenApprovals(ctx, types.ChainTypeEthereum, logs)
+	require.Len(t, approvals, 2)
+
+	normalize := func(address string) string {
+		return types.NormalizeAddress(types.ChainTypeEthereum, address)
+	}
+	assert.Equal(t, types.TokenApproval{
+		TokenAddress: normalize(testTokenAddress),
+		Owner:        normalize(testOwner),
+		Spender:      normalize(testSpender),
+		Amount:       big.NewInt(1000),
+		LogIndex:     1,
+	}, approvals[0])
+	assert.Equal(t, normalize(testRelayer), approvals[1].Spender)
+	assert.Equal(t, 0, approvals[1].Amount.Sign())
+}
@@ -11,11 +11,16 @@ import (
 	"vault0/internal/core/abi"
 	"vault0/internal/core/tokenstore"
 	"vault0/internal/errors"
+	"vault0/internal/logger"
 	"vault0/internal/types"
 )
 
-// Precompute ERC20 transfer method ID locally for dispatcher logic
-var erc20TransferMethodID = crypto.Keccak256([]byte("transfer(address,uint256)"))[:4]
+// Precompute ERC20 transfer method ID and the Transfer topic locally for dispatcher logic
+var (
+	erc20TransferMethodID = crypto.Keccak256([]byte("transfer(address,uint256)"))[:4]
+
+	erc20TransferTopic = crypto.Keccak256Hash([]byte(types.ERC20TransferEvent))
+)
 
 // createERC20TransferFromMetadata constructs an ERC20Transfer from metadata.
 func createERC20TransferFromMetadata(tx *types.Transaction) (*types.ERC20Transfer, error) {
@@ -159,3 +164,59 @@ func decodeERC20Transfer(tx *types.Transaction) (*types.ERC20Transfer, error) {
 	// Attempt creation from metadata
 	return createERC20TransferFromMetadata(tx)
 }
+
+// DecodeTokenTransfers extracts the ERC20 token movements emitted by Transfer events.
+// ERC721 Transfer events share the topic but index the token ID, so only logs with
+// three topics are considered.
+func (m *evmDecoder) DecodeTokenTransfers(ctx context.Context, chainType types.ChainType, logs []types.Log) []types.TokenTransfer {
+	var transfers []types.TokenTransfer
+	var erc20ABI string
+
+	for _, log := range logs {
+		if len(log.Topics) != 3 || common.HexToHash(log.Topics[0]) != erc20TransferTopic {
+			continue
+		}
+
+		if erc20ABI == "" {
+			var err error
+			erc20ABI, err = m.abiLoader.LoadABIByType(ctx, abi.ABITypeERC20)
+			if err != nil {
+				m.logger.Warn("Failed to load ERC20 ABI", logger.Error(err))
+				return transfers
+			}
+		}
+
+		event, err := m.abiUtils.DecodeLog(erc20ABI, log)
+		if err != nil {
+			m.logger.Debug("Could not decode ERC20 transfer log",
+				logger.String("tx_hash", log.TransactionHash),
+				logger.String("contract_address", log.Address),
+				logger.Error(err))
+			continue
+		}
+
+		args := make(map[string]any, len(event.Arguments))
+		for _, argument := range event.Arguments {
+			args[argument.Name] = argument.Value
+		}
+		from, _ := args["from"].(string)
+		to, _ := args["to"].(string)
+		amount, ok := parseDecimal(args["value"])
+		if !ok || from == "" || to == "" {
+			m.logger.Debug("Invalid ERC20 transfer event",
+				logger.String("tx_hash", log.TransactionHash),
+				logger.String("contract_address", log.Address))
+			continue
+		}
+
+		transfers = append(transfers, types.TokenTransfer{
+			TokenAddress: types.NormalizeAddress(chainType, event.Address),
+			From:         types.NormalizeAddress(chainType, from),
+			To:           types.NormalizeAddress(chainType, to),
+			Amount:       amount,
+			LogIndex:     event.LogIndex,
+		})
+	}
+
+	return transfers
+}
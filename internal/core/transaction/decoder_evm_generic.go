@@ -0,0 +1,125 @@
+package transaction
+
+import (
+	"context"
+
+	"vault0/internal/core/abi"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/types"
+)
+
+// maxEventABILookups bounds the emitting contracts whose ABI is loaded when decoding
+// the logs of a transaction, as each lookup may query the block explorer. The logs of
+// further contracts are decoded with the standard ABIs and signature database only.
+const maxEventABILookups = 8
+
+// DecodeContractCall implements Decoder. It decodes the call using the ABI of the
+// called contract as resolved by the ABI loader, falling back to a heuristic
+// decoding from the offline signature database.
+func (m *evmDecoder) DecodeContractCall(ctx context.Context, tx *types.Transaction) (*types.DecodedCall, error) {
+	if tx == nil {
+		return nil, errors.NewInvalidParameterError("transaction cannot be nil", "tx")
+	}
+	if tx.BaseTransaction.To == "" || len(tx.Data) < 4 {
+		return nil, errors.NewABIInputDataTooShortError(len(tx.Data), 4)
+	}
+
+	contractAddress, err := types.NewAddress(tx.ChainType, tx.BaseTransaction.To)
+	if err != nil {
+		return nil, err
+	}
+
+	contractABI, err := m.abiLoader.LoadABIByAddress(ctx, *contractAddress)
+	if err == nil {
+		decodedCall, err := m.abiUtils.DecodeCall(contractABI, tx.Data)
+		if err == nil {
+			return decodedCall, nil
+		}
+		if !errors.IsError(err, errors.ErrCodeABIMethodNotFound) {
+			return nil, err
+		}
+	}
+
+	// Without a matching ABI, identify the method by its selector
+	return m.abiUtils.DecodeCallHeuristic(tx.Data)
+}
+
+// DecodeEvents implements Decoder. Each log is decoded using the ABI of the
+// emitting contract, falling back to the standard ERC20 ABI so token transfers
+// and approvals are recognized even for contracts without a resolvable ABI, and
+// finally to a heuristic decoding from the offline signature database.
+func (m *evmDecoder) DecodeEvents(ctx context.Context, chainType types.ChainType, logs []types.Log) []types.DecodedEvent {
+	events := make([]types.DecodedEvent, 0, len(logs))
+	abis := make(map[string]string) // Emitting contract address -> ABI ("" if unavailable)
+
+	for _, log := range logs {
+		if len(log.Topics) == 0 {
+			continue // Anonymous events can't be identified
+		}
+
+		contractABI, loaded := abis[log.Address]
+		if !loaded {
+			if len(abis) < maxEventABILookups {
+				contractABI = m.loadEventABI(ctx, chainType, log.Address)
+			}
+			abis[log.Address] = contractABI
+		}
+
+		event, err := m.decodeLog(ctx, contractABI, log)
+		if err != nil {
+			m.logger.Debug("Could not decode receipt log",
+				logger.String("tx_hash", log.TransactionHash),
+				logger.String("contract_address", log.Address),
+				logger.Error(err))
+			continue
+		}
+
+		events = append(events, *event)
+	}
+
+	return events
+}
+
+// loadEventABI loads the ABI of a contract emitting logs, returning an empty
+// string if it cannot be resolved
+func (m *evmDecoder) loadEventABI(ctx context.Context, chainType types.ChainType, address string) string {
+	contractAddress, err := types.NewAddress(chainType, address)
+	if err != nil {
+		return ""
+	}
+
+	contractABI, err := m.abiLoader.LoadABIByAddress(ctx, *contractAddress)
+	if err != nil {
+		m.logger.Debug("ABI unavailable for log emitter",
+			logger.String("contract_address", address),
+			logger.Error(err))
+		return ""
+	}
+
+	return contractABI
+}
+
+// decodeLog decodes a single log with the contract ABI, falling back to the ERC20 ABI
+// and then to the offline signature database
+func (m *evmDecoder) decodeLog(ctx context.Context, contractABI string, log types.Log) (*types.DecodedEvent, error) {
+	if contractABI != "" {
+		event, err := m.abiUtils.DecodeLog(contractABI, log)
+		if err == nil {
+			return event, nil
+		}
+		if !errors.IsError(err, errors.ErrCodeABIEventNotFound) {
+			return nil, err
+		}
+	}
+
+	erc20ABI, err := m.abiLoader.LoadABIByType(ctx, abi.ABITypeERC20)
+	if err == nil {
+		// Failures fall through, e.g. ERC721 transfers share the ERC20 topic but index the token ID
+		if event, err := m.abiUtils.DecodeLog(erc20ABI, log); err == nil {
+			return event, nil
+		}
+	}
+
+	return m.abiUtils.DecodeLogHeuristic(log)
+}
@@ -0,0 +1,470 @@
+package transaction
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"vault0/internal/core/abi"
+	"vault0/internal/core/tokenstore"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/types"
+)
+
+// Precompute NFT method IDs and event topics locally for dispatcher logic
+var (
+	erc721SafeTransferFromMethodID       = crypto.Keccak256([]byte(types.ERC721SafeTransferFromMethod))[:4]
+	erc721SafeTransferFromDataMethodID   = crypto.Keccak256([]byte(types.ERC721SafeTransferFromDataMethod))[:4]
+	erc721TransferFromMethodID           = crypto.Keccak256([]byte(types.ERC721TransferFromMethod))[:4]
+	erc1155SafeTransferFromMethodID      = crypto.Keccak256([]byte(types.ERC1155SafeTransferFromMethod))[:4]
+	erc1155SafeBatchTransferFromMethodID = crypto.Keccak256([]byte(types.ERC1155SafeBatchTransferFromMethod))[:4]
+
+	erc721TransferTopic        = crypto.Keccak256Hash([]byte(types.ERC721TransferEvent))
+	erc1155TransferSingleTopic = crypto.Keccak256Hash([]byte(types.ERC1155TransferSingleEvent))
+	erc1155TransferBatchTopic  = crypto.Keccak256Hash([]byte(types.ERC1155TransferBatchEvent))
+)
+
+// createERC721TransferFromMetadata constructs an ERC721Transfer from metadata.
+func createERC721TransferFromMetadata(tx *types.Transaction) (*types.ERC721Transfer, error) {
+	if tx == nil || tx.Metadata == nil {
+		return nil, errors.NewInvalidParameterError("transaction or metadata cannot be nil", "tx")
+	}
+	tokenAddr, ok := tx.Metadata.GetString(types.ERC721TokenAddressMetadataKey)
+	if !ok {
+		return nil, errors.NewMappingError(tx.Hash, "missing metadata: "+types.ERC721TokenAddressMetadataKey)
+	}
+	recipient, ok := tx.Metadata.GetString(types.ERC721RecipientMetadataKey)
+	if !ok {
+		return nil, errors.NewMappingError(tx.Hash, "missing metadata: "+types.ERC721RecipientMetadataKey)
+	}
+	tokenID, ok := tx.Metadata.GetBigInt(types.ERC721TokenIDMetadataKey)
+	if !ok {
+		return nil, errors.NewMappingError(tx.Hash, "missing or invalid metadata: "+types.ERC721TokenIDMetadataKey)
+	}
+	sender, ok := tx.Metadata.GetString(types.ERC721SenderMetadataKey)
+	if !ok {
+		sender = tx.From // The transaction sender owns the token unless an operator moved it
+	}
+	tokenSymbol, _ := tx.Metadata.GetString(types.ERC721TokenSymbolMetadataKey)
+	tokenName, _ := tx.Metadata.GetString(types.ERC721TokenNameMetadataKey)
+
+	erc721Tx := &types.ERC721Transfer{
+		Transaction:  *tx.Copy(),
+		TokenAddress: tokenAddr,
+		TokenSymbol:  tokenSymbol,
+		TokenName:    tokenName,
+		TokenID:      tokenID.ToBigInt(),
+		Sender:       sender,
+		Recipient:    recipient,
+	}
+	erc721Tx.Type = types.TransactionTypeERC721Transfer // Ensure type is correct
+
+	return erc721Tx, nil
+}
+
+// createERC1155TransferFromMetadata constructs an ERC1155Transfer from metadata.
+func createERC1155TransferFromMetadata(tx *types.Transaction) (*types.ERC1155Transfer, error) {
+	if tx == nil || tx.Metadata == nil {
+		return nil, errors.NewInvalidParameterError("transaction or metadata cannot be nil", "tx")
+	}
+	tokenAddr, ok := tx.Metadata.GetString(types.ERC1155TokenAddressMetadataKey)
+	if !ok {
+		return nil, errors.NewMappingError(tx.Hash, "missing metadata: "+types.ERC1155TokenAddressMetadataKey)
+	}
+	recipient, ok := tx.Metadata.GetString(types.ERC1155RecipientMetadataKey)
+	if !ok {
+		return nil, errors.NewMappingError(tx.Hash, "missing metadata: "+types.ERC1155RecipientMetadataKey)
+	}
+	tokenIDs, ok := getBigIntListFromMetadata(tx.Metadata, types.ERC1155TokenIDsMetadataKey)
+	if !ok {
+		return nil, errors.NewMappingError(tx.Hash, "missing or invalid metadata: "+types.ERC1155TokenIDsMetadataKey)
+	}
+	amounts, ok := getBigIntListFromMetadata(tx.Metadata, types.ERC1155AmountsMetadataKey)
+	if !ok || len(amounts) != len(tokenIDs) {
+		return nil, errors.NewMappingError(tx.Hash, "missing or invalid metadata: "+types.ERC1155AmountsMetadataKey)
+	}
+	sender, ok := tx.Metadata.GetString(types.ERC1155SenderMetadataKey)
+	if !ok {
+		sender = tx.From
+	}
+	tokenSymbol, _ := tx.Metadata.GetString(types.ERC1155TokenSymbolMetadataKey)
+	tokenName, _ := tx.Metadata.GetString(types.ERC1155TokenNameMetadataKey)
+
+	erc1155Tx := &types.ERC1155Transfer{
+		Transaction:  *tx.Copy(),
+		TokenAddress: tokenAddr,
+		TokenSymbol:  tokenSymbol,
+		TokenName:    tokenName,
+		Sender:       sender,
+		Recipient:    recipient,
+		TokenIDs:     tokenIDs,
+		Amounts:      amounts,
+	}
+	erc1155Tx.Type = types.TransactionTypeERC1155Transfer // Ensure type is correct
+
+	return erc1155Tx, nil
+}
+
+// parseAndPopulateERC721Metadata attempts to parse tx data as an ERC721 transfer
+// and updates tx.Metadata and tx.Type if successful. As transferFrom shares its
+// selector with ERC20, it is only recognized for tokens registered as ERC721.
+// Returns true if parsing was successful and metadata was populated.
+func parseAndPopulateERC721Metadata(ctx context.Context, tx *types.Transaction, abiUtils abi.ABIUtils, abiLoader abi.ABILoader, ts tokenstore.TokenStore) (bool, error) {
+	if tx == nil {
+		return false, errors.NewInvalidParameterError("transaction cannot be nil", "tx")
+	}
+	if tx.BaseTransaction.To == "" || len(tx.Data) < 4 {
+		return false, nil
+	}
+
+	methodID := abiUtils.ExtractMethodID(tx.Data)
+	isTransferFrom := bytes.Equal(methodID, erc721TransferFromMethodID)
+	if !isTransferFrom && !bytes.Equal(methodID, erc721SafeTransferFromMethodID) && !bytes.Equal(methodID, erc721SafeTransferFromDataMethodID) {
+		return false, nil
+	}
+
+	tokenInfo, err := ts.GetToken(ctx, tx.BaseTransaction.To)
+	if isTransferFrom && (err != nil || tokenInfo.Type != types.TokenTypeERC721) {
+		return false, nil // Most likely an ERC20 transferFrom
+	}
+	if err != nil {
+		// Use fallback details if token is not registered in the store.
+		tokenInfo = &types.Token{Address: tx.BaseTransaction.To, Symbol: "UNKNOWN"}
+	}
+
+	erc721ABI, err := abiLoader.LoadABIByType(ctx, abi.ABITypeERC721)
+	if err != nil {
+		return false, fmt.Errorf("failed to load ERC721 ABI: %w", err)
+	}
+
+	// Overloaded safeTransferFrom variants are resolved by selector
+	parsedArgs, err := abiUtils.Unpack(erc721ABI, "", tx.Data)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse ERC721 transfer input: %w", err)
+	}
+
+	fromAddr, err := abiUtils.GetAddressFromArgs(parsedArgs, "from")
+	if err != nil {
+		return false, fmt.Errorf("failed to get sender address ('from') from parsed args: %w", err)
+	}
+	toAddr, err := abiUtils.GetAddressFromArgs(parsedArgs, "to")
+	if err != nil {
+		return false, fmt.Errorf("failed to get recipient address ('to') from parsed args: %w", err)
+	}
+	tokenID, err := abiUtils.GetBigIntFromArgs(parsedArgs, "tokenId")
+	if err != nil {
+		return false, fmt.Errorf("failed to get token ID ('tokenId') from parsed args: %w", err)
+	}
+
+	if tx.Metadata == nil {
+		tx.Metadata = make(types.TxMetadata)
+	}
+
+	err = tx.Metadata.SetAll(map[string]any{
+		types.ERC721TokenAddressMetadataKey: tokenInfo.Address,
+		types.ERC721TokenSymbolMetadataKey:  tokenInfo.Symbol,
+		types.ERC721SenderMetadataKey:       fromAddr.String(),
+		types.ERC721RecipientMetadataKey:    toAddr.String(),
+		types.ERC721TokenIDMetadataKey:      tokenID.ToBigInt(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to set ERC721 metadata: %w", err)
+	}
+
+	tx.Type = types.TransactionTypeERC721Transfer
+
+	return true, nil
+}
+
+// parseAndPopulateERC1155Metadata attempts to parse tx data as an ERC1155 single
+// or batch transfer and updates tx.Metadata and tx.Type if successful.
+// Returns true if parsing was successful and metadata was populated.
+func parseAndPopulateERC1155Metadata(ctx context.Context, tx *types.Transaction, abiUtils abi.ABIUtils, abiLoader abi.ABILoader, ts tokenstore.TokenStore) (bool, error) {
+	if tx == nil {
+		return false, errors.NewInvalidParameterError("transaction cannot be nil", "tx")
+	}
+	if tx.BaseTransaction.To == "" || len(tx.Data) < 4 {
+		return false, nil
+	}
+
+	methodID := abiUtils.ExtractMethodID(tx.Data)
+	isBatch := bytes.Equal(methodID, erc1155SafeBatchTransferFromMethodID)
+	if !isBatch && !bytes.Equal(methodID, erc1155SafeTransferFromMethodID) {
+		return false, nil
+	}
+
+	erc1155ABI, err := abiLoader.LoadABIByType(ctx, abi.ABITypeERC1155)
+	if err != nil {
+		return false, fmt.Errorf("failed to load ERC1155 ABI: %w", err)
+	}
+
+	parsedArgs, err := abiUtils.Unpack(erc1155ABI, "", tx.Data)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse ERC1155 transfer input: %w", err)
+	}
+
+	fromAddr, err := abiUtils.GetAddressFromArgs(parsedArgs, "from")
+	if err != nil {
+		return false, fmt.Errorf("failed to get sender address ('from') from parsed args: %w", err)
+	}
+	toAddr, err := abiUtils.GetAddressFromArgs(parsedArgs, "to")
+	if err != nil {
+		return false, fmt.Errorf("failed to get recipient address ('to') from parsed args: %w", err)
+	}
+
+	var tokenIDs, amounts []*big.Int
+	if isBatch {
+		var ok bool
+		if tokenIDs, ok = parsedArgs["ids"].([]*big.Int); !ok {
+			return false, errors.NewABIArgumentInvalidTypeError("ids", "[]*big.Int", fmt.Sprintf("%T", parsedArgs["ids"]))
+		}
+		if amounts, ok = parsedArgs["values"].([]*big.Int); !ok {
+			return false, errors.NewABIArgumentInvalidTypeError("values", "[]*big.Int", fmt.Sprintf("%T", parsedArgs["values"]))
+		}
+		if len(tokenIDs) != len(amounts) {
+			return false, fmt.Errorf("mismatched ERC1155 batch lengths: %d ids, %d values", len(tokenIDs), len(amounts))
+		}
+	} else {
+		tokenID, err := abiUtils.GetBigIntFromArgs(parsedArgs, "id")
+		if err != nil {
+			return false, fmt.Errorf("failed to get token ID ('id') from parsed args: %w", err)
+		}
+		amount, err := abiUtils.GetBigIntFromArgs(parsedArgs, "value")
+		if err != nil {
+			return false, fmt.Errorf("failed to get amount ('value') from parsed args: %w", err)
+		}
+		tokenIDs = []*big.Int{tokenID.ToBigInt()}
+		amounts = []*big.Int{amount.ToBigInt()}
+	}
+
+	tokenInfo, err := ts.GetToken(ctx, tx.BaseTransaction.To)
+	if err != nil {
+		tokenInfo = &types.Token{Address: tx.BaseTransaction.To, Symbol: "UNKNOWN"}
+	}
+
+	if tx.Metadata == nil {
+		tx.Metadata = make(types.TxMetadata)
+	}
+
+	err = tx.Metadata.SetAll(map[string]any{
+		types.ERC1155TokenAddressMetadataKey: tokenInfo.Address,
+		types.ERC1155TokenSymbolMetadataKey:  tokenInfo.Symbol,
+		types.ERC1155SenderMetadataKey:       fromAddr.String(),
+		types.ERC1155RecipientMetadataKey:    toAddr.String(),
+	})
+	if err == nil {
+		err = setBigIntListMetadata(tx.Metadata, types.ERC1155TokenIDsMetadataKey, tokenIDs)
+	}
+	if err == nil {
+		err = setBigIntListMetadata(tx.Metadata, types.ERC1155AmountsMetadataKey, amounts)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to set ERC1155 metadata: %w", err)
+	}
+
+	tx.Type = types.TransactionTypeERC1155Transfer
+
+	return true, nil
+}
+
+// decodeERC721Transfer converts a generic transaction to ERC721Transfer.
+func decodeERC721Transfer(tx *types.Transaction) (*types.ERC721Transfer, error) {
+	if tx == nil {
+		return nil, errors.NewInvalidParameterError("transaction cannot be nil", "tx")
+	}
+	if tx.Type != types.TransactionTypeERC721Transfer {
+		return nil, errors.NewMappingError(tx.Hash, fmt.Sprintf("invalid transaction type %s, expected %s", tx.Type, types.TransactionTypeERC721Transfer))
+	}
+	if tx.Metadata == nil {
+		return nil, errors.NewMappingError(tx.Hash, "metadata is required to map to ERC721Transfer")
+	}
+
+	return createERC721TransferFromMetadata(tx)
+}
+
+// decodeERC1155Transfer converts a generic transaction to ERC1155Transfer.
+func decodeERC1155Transfer(tx *types.Transaction) (*types.ERC1155Transfer, error) {
+	if tx == nil {
+		return nil, errors.NewInvalidParameterError("transaction cannot be nil", "tx")
+	}
+	if tx.Type != types.TransactionTypeERC1155Transfer {
+		return nil, errors.NewMappingError(tx.Hash, fmt.Sprintf("invalid transaction type %s, expected %s", tx.Type, types.TransactionTypeERC1155Transfer))
+	}
+	if tx.Metadata == nil {
+		return nil, errors.NewMappingError(tx.Hash, "metadata is required to map to ERC1155Transfer")
+	}
+
+	return createERC1155TransferFromMetadata(tx)
+}
+
+// DecodeNFTTransfers implements Decoder. ERC721 transfers are identified by a
+// Transfer event whose token ID is indexed, which tells them apart from ERC20
+// transfers sharing the same topic.
+func (m *evmDecoder) DecodeNFTTransfers(ctx context.Context, chainType types.ChainType, logs []types.Log) []types.NFTTransfer {
+	var transfers []types.NFTTransfer
+
+	for _, log := range logs {
+		if len(log.Topics) == 0 {
+			continue
+		}
+
+		var (
+			abiType  abi.SupportedABIType
+			standard types.TokenType
+		)
+		switch common.HexToHash(log.Topics[0]) {
+		case erc721TransferTopic:
+			if len(log.Topics) != 4 {
+				continue // ERC20 transfer
+			}
+			abiType, standard = abi.ABITypeERC721, types.TokenTypeERC721
+		case erc1155TransferSingleTopic, erc1155TransferBatchTopic:
+			abiType, standard = abi.ABITypeERC1155, types.TokenTypeERC1155
+		default:
+			continue
+		}
+
+		standardABI, err := m.abiLoader.LoadABIByType(ctx, abiType)
+		if err != nil {
+			m.logger.Warn("Failed to load NFT standard ABI",
+				logger.String("abi_type", string(abiType)),
+				logger.Error(err))
+			continue
+		}
+
+		event, err := m.abiUtils.DecodeLog(standardABI, log)
+		if err != nil {
+			m.logger.Debug("Could not decode NFT transfer log",
+				logger.String("tx_hash", log.TransactionHash),
+				logger.String("contract_address", log.Address),
+				logger.Error(err))
+			continue
+		}
+
+		decoded, err := nftTransfersFromEvent(chainType, standard, event)
+		if err != nil {
+			m.logger.Debug("Invalid NFT transfer event",
+				logger.String("tx_hash", log.TransactionHash),
+				logger.String("contract_address", log.Address),
+				logger.Error(err))
+			continue
+		}
+		transfers = append(transfers, decoded...)
+	}
+
+	return transfers
+}
+
+// nftTransfersFromEvent converts a decoded Transfer, TransferSingle or TransferBatch
+// event into one NFT transfer per moved token
+func nftTransfersFromEvent(chainType types.ChainType, standard types.TokenType, event *types.DecodedEvent) ([]types.NFTTransfer, error) {
+	args := make(map[string]any, len(event.Arguments))
+	for _, argument := range event.Arguments {
+		args[argument.Name] = argument.Value
+	}
+
+	tokenAddress := types.NormalizeAddress(chainType, event.Address)
+	from, _ := args["from"].(string)
+	to, _ := args["to"].(string)
+	transfer := types.NFTTransfer{
+		TokenAddress: tokenAddress,
+		Standard:     standard,
+		From:         types.NormalizeAddress(chainType, from),
+		To:           types.NormalizeAddress(chainType, to),
+		LogIndex:     event.LogIndex,
+	}
+
+	var tokenIDs, amounts []*big.Int
+	switch event.Name {
+	case "Transfer":
+		tokenID, ok := parseDecimal(args["tokenId"])
+		if !ok {
+			return nil, fmt.Errorf("invalid tokenId %v", args["tokenId"])
+		}
+		tokenIDs, amounts = []*big.Int{tokenID}, []*big.Int{big.NewInt(1)}
+	case "TransferSingle":
+		tokenID, idOk := parseDecimal(args["id"])
+		amount, valueOk := parseDecimal(args["value"])
+		if !idOk || !valueOk {
+			return nil, fmt.Errorf("invalid id %v or value %v", args["id"], args["value"])
+		}
+		tokenIDs, amounts = []*big.Int{tokenID}, []*big.Int{amount}
+	case "TransferBatch":
+		var idsOk, valuesOk bool
+		tokenIDs, idsOk = parseDecimalList(args["ids"])
+		amounts, valuesOk = parseDecimalList(args["values"])
+		if !idsOk || !valuesOk || len(tokenIDs) != len(amounts) {
+			return nil, fmt.Errorf("invalid ids %v or values %v", args["ids"], args["values"])
+		}
+	default:
+		return nil, fmt.Errorf("unexpected event %s", event.Name)
+	}
+
+	transfers := make([]types.NFTTransfer, 0, len(tokenIDs))
+	for i := range tokenIDs {
+		t := transfer
+		t.TokenID = tokenIDs[i]
+		t.Amount = amounts[i]
+		transfers = append(transfers, t)
+	}
+
+	return transfers, nil
+}
+
+// parseDecimal parses a decimal string as normalized by the ABI decoder
+func parseDecimal(value any) (*big.Int, bool) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, false
+	}
+	return new(big.Int).SetString(s, 10)
+}
+
+// parseDecimalList parses a list of decimal strings as normalized by the ABI decoder
+func parseDecimalList(value any) ([]*big.Int, bool) {
+	items, ok := value.([]any)
+	if !ok {
+		return nil, false
+	}
+	result := make([]*big.Int, 0, len(items))
+	for _, item := range items {
+		n, ok := parseDecimal(item)
+		if !ok {
+			return nil, false
+		}
+		result = append(result, n)
+	}
+	return result, true
+}
+
+// setBigIntListMetadata stores a list of integers as a JSON list of decimal strings
+func setBigIntListMetadata(metadata types.TxMetadata, key string, values []*big.Int) error {
+	items := make([]string, 0, len(values))
+	for _, v := range values {
+		items = append(items, v.String())
+	}
+	return metadata.SetJSON(key, items)
+}
+
+// getBigIntListFromMetadata reads a JSON list of decimal strings stored by setBigIntListMetadata
+func getBigIntListFromMetadata(metadata types.TxMetadata, key string) ([]*big.Int, bool) {
+	var items []string
+	if !metadata.GetJSON(key, &items) {
+		return nil, false
+	}
+	result := make([]*big.Int, 0, len(items))
+	for _, item := range items {
+		n, ok := new(big.Int).SetString(strings.TrimSpace(item), 10)
+		if !ok {
+			return nil, false
+		}
+		result = append(result, n)
+	}
+	return result, true
+}
@@ -0,0 +1,147 @@
+package transaction
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	gethabi "github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/config"
+	"vault0/internal/core/abi"
+	"vault0/internal/core/tokenstore"
+	"vault0/internal/testing/mocks"
+	"vault0/internal/types"
+)
+
+const (
+	testNFTAddress      = "0x5555555555555555555555555555555555555555"
+	testNFTFrom         = "0x1111111111111111111111111111111111111111"
+	testNFTTo           = "0x2222222222222222222222222222222222222222"
+	testNFTOperator     = "0x3333333333333333333333333333333333333333"
+	testNFTTxHash       = "0xabababababababababababababababababababababababababababababababab"
+	testStandardABIPath = "../../../contracts/resources/"
+)
+
+// newTestDecoder creates an EVM decoder loading the standard token ABIs from the
+// contract resources
+func newTestDecoder(t *testing.T, tokenStore tokenstore.TokenStore) *evmDecoder {
+	t.Helper()
+
+	log := mocks.NewNopLogger()
+	abiUtils, err := abi.NewABIUtils(types.ChainTypeEthereum, nil, log)
+	require.NoError(t, err)
+
+	cfg := &config.Config{ABIMapping: map[string]string{
+		"erc20":   testStandardABIPath + "erc20.json",
+		"erc721":  testStandardABIPath + "erc721.json",
+		"erc1155": testStandardABIPath + "erc1155.json",
+	}}
+	abiLoader := abi.NewABILoader(types.ChainTypeEthereum, cfg, nil, nil, abiUtils, nil, log)
+
+	return NewEvmDecoder(tokenStore, log, abiUtils, abiLoader).(*evmDecoder)
+}
+
+// addressTopic returns the topic of an indexed address
+func addressTopic(address string) string {
+	return common.BytesToHash(common.HexToAddress(address).Bytes()).Hex()
+}
+
+// uint256Topic returns the topic of an indexed uint256
+func uint256Topic(value int64) string {
+	return common.BigToHash(big.NewInt(value)).Hex()
+}
+
+// packArguments ABI-encodes the non-indexed values of a log
+func packArguments(t *testing.T, typeName string, values ...any) []byte {
+	t.Helper()
+
+	argType, err := gethabi.NewType(typeName, "", nil)
+	require.NoError(t, err)
+	arguments := make(gethabi.Arguments, len(values))
+	for i := range values {
+		arguments[i] = gethabi.Argument{Type: argType}
+	}
+
+	data, err := arguments.Pack(values...)
+	require.NoError(t, err)
+	return data
+}
+
+func TestEvmDecoder_DecodeNFTTransfers(t *testing.T) {
+	decoder := newTestDecoder(t, nil)
+	ctx := context.Background()
+
+	from := types.NormalizeAddress(types.ChainTypeEthereum, testNFTFrom)
+	to := types.NormalizeAddress(types.ChainTypeEthereum, testNFTTo)
+	tokenAddress := types.NormalizeAddress(types.ChainTypeEthereum, testNFTAddress)
+
+	tests := []struct {
+		name     string
+		log      types.Log
+		expected []types.NFTTransfer
+	}{
+		{
+			name: "ERC721 Transfer",
+			log: types.Log{
+				Address:  testNFTAddress,
+				Topics:   []string{erc721TransferTopic.Hex(), addressTopic(testNFTFrom), addressTopic(testNFTTo), uint256Topic(7)},
+				LogIndex: 3,
+			},
+			expected: []types.NFTTransfer{
+				{TokenAddress: tokenAddress, Standard: types.TokenTypeERC721, From: from, To: to, TokenID: big.NewInt(7), Amount: big.NewInt(1), LogIndex: 3},
+			},
+		},
+		{
+			name: "ERC20 Transfer is ignored",
+			log: types.Log{
+				Address: testNFTAddress,
+				Topics:  []string{erc721TransferTopic.Hex(), addressTopic(testNFTFrom), addressTopic(testNFTTo)},
+				Data:    packArguments(t, "uint256", big.NewInt(7)),
+			},
+		},
+		{
+			name: "ERC1155 TransferSingle",
+			log: types.Log{
+				Address:  testNFTAddress,
+				Topics:   []string{erc1155TransferSingleTopic.Hex(), addressTopic(testNFTOperator), addressTopic(testNFTFrom), addressTopic(testNFTTo)},
+				Data:     packArguments(t, "uint256", big.NewInt(42), big.NewInt(5)),
+				LogIndex: 4,
+			},
+			expected: []types.NFTTransfer{
+				{TokenAddress: tokenAddress, Standard: types.TokenTypeERC1155, From: from, To: to, TokenID: big.NewInt(42), Amount: big.NewInt(5), LogIndex: 4},
+			},
+		},
+		{
+			name: "ERC1155 TransferBatch",
+			log: types.Log{
+				Address:  testNFTAddress,
+				Topics:   []string{erc1155TransferBatchTopic.Hex(), addressTopic(testNFTOperator), addressTopic(testNFTFrom), addressTopic(testNFTTo)},
+				Data:     packArguments(t, "uint256[]", []*big.Int{big.NewInt(1), big.NewInt(2)}, []*big.Int{big.NewInt(10), big.NewInt(20)}),
+				LogIndex: 5,
+			},
+			expected: []types.NFTTransfer{
+				{TokenAddress: tokenAddress, Standard: types.TokenTypeERC1155, From: from, To: to, TokenID: big.NewInt(1), Amount: big.NewInt(10), LogIndex: 5},
+				{TokenAddress: tokenAddress, Standard: types.TokenTypeERC1155, From: from, To: to, TokenID: big.NewInt(2), Amount: big.NewInt(20), LogIndex: 5},
+			},
+		},
+		{
+			name: "Unrelated event is ignored",
+			log: types.Log{
+				Address: testNFTAddress,
+				Topics:  []string{common.Hash{0x01}.Hex()},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.log.TransactionHash = testNFTTxHash
+			transfers := decoder.DecodeNFTTransfers(ctx, types.ChainTypeEthereum, []types.Log{tc.log})
+			assert.Equal(t, tc.expected, transfers)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/elliptic"
 
+	"vault0/internal/core/crypto"
 	"vault0/internal/core/keystore"
 	"vault0/internal/types"
 )
@@ -76,3 +77,22 @@ func (m *MockKeyStore) Sign(ctx context.Context, id string, data []byte, dataTyp
 	}
 	return nil, nil
 }
+
+func (m *MockKeyStore) ExportV3(ctx context.Context, id string, passphrase string, kdf crypto.KDF) ([]byte, error) {
+	return nil, nil
+}
+
+func (m *MockKeyStore) GetPolicy(ctx context.Context, id string) (*keystore.KeyPolicy, error) {
+	return &keystore.KeyPolicy{KeyID: id}, nil
+}
+
+func (m *MockKeyStore) SetPolicy(ctx context.Context, policy *keystore.KeyPolicy) (*keystore.KeyPolicy, error) {
+	return policy, nil
+}
+
+func (m *MockKeyStore) ListSigningAudit(ctx context.Context, filter keystore.SigningAuditFilter, limit int, nextToken string) (*types.Page[*keystore.SigningAuditEntry], error) {
+	return &types.Page[*keystore.SigningAuditEntry]{
+		Items: []*keystore.SigningAuditEntry{},
+		Limit: limit,
+	}, nil
+}
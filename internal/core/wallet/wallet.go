@@ -87,6 +87,24 @@ type WalletManager interface {
 	//   - error: Any error during transaction creation
 	CreateTokenTransaction(ctx context.Context, tokenAddress, toAddress string, amount *big.Int, options types.TransactionOptions) (*types.Transaction, error)
 
+	// CreateNFTTransaction creates an unsigned transaction that transfers a
+	// non-fungible token (ERC721) or a multi-token balance (ERC1155) using
+	// the standard's safeTransferFrom method.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - standard: Token standard of the contract (erc721 or erc1155)
+	//   - tokenAddress: Contract address of the NFT collection
+	//   - toAddress: Recipient's blockchain address
+	//   - tokenID: Identifier of the token within the contract
+	//   - amount: Number of tokens to transfer; must be 1 for ERC721
+	//   - options: Chain-specific transaction parameters (gas price, nonce, etc.)
+	//
+	// Returns:
+	//   - *types.Transaction: Unsigned transaction ready for signing
+	//   - error: Any error during transaction creation
+	CreateNFTTransaction(ctx context.Context, standard types.TokenType, tokenAddress, toAddress string, tokenID, amount *big.Int, options types.TransactionOptions) (*types.Transaction, error)
+
 	// SignTransaction signs a transaction using the wallet's private key.
 	// The signing process follows chain-specific requirements:
 	//   - For EVM chains: Signs according to EIP-155
@@ -120,4 +138,32 @@ type WalletManager interface {
 	//                       The 'Value' field will contain the native currency amount.
 	//   - error: Any error encountered during ABI encoding or transaction creation.
 	CreateContractCallTransaction(ctx context.Context, contractAddress string, value *big.Int, abiString string, method string, args []any, options types.TransactionOptions) (*types.Transaction, error)
+
+	// SignMessage signs an arbitrary message using the chain's message signing
+	// convention:
+	//   - For EVM chains: EIP-191 personal_sign
+	//     (keccak256("\x19Ethereum Signed Message:\n" || len(message) || message))
+	//
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - message: The raw message bytes to sign
+	//
+	// Returns:
+	//   - []byte: The signature (for EVM chains, 65-byte r||s||v with v in {27, 28})
+	//   - error: Any error during signing
+	SignMessage(ctx context.Context, message []byte) ([]byte, error)
+
+	// SignTypedData signs structured data:
+	//   - For EVM chains: EIP-712 typed data JSON with "types", "primaryType",
+	//     "domain" and "message" fields
+	//
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - typedData: The typed data JSON document
+	//
+	// Returns:
+	//   - []byte: The signature (for EVM chains, 65-byte r||s||v with v in {27, 28})
+	//   - error: ErrInvalidTypedData if the document is malformed, ErrInvalidInput if its
+	//     domain chain ID is not the wallet's, or any other signing error
+	SignTypedData(ctx context.Context, typedData []byte) ([]byte, error)
 }
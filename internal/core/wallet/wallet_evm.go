@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 
 	coreAbi "vault0/internal/core/abi"
+	coreCrypto "vault0/internal/core/crypto"
 	"vault0/internal/core/keystore"
 	"vault0/internal/errors"
 	"vault0/internal/logger"
@@ -170,6 +171,88 @@ func (w *EVMWallet) CreateTokenTransaction(ctx context.Context, tokenAddress, to
 	return tx, nil
 }
 
+// CreateNFTTransaction creates an ERC721 or ERC1155 safeTransferFrom transaction
+func (w *EVMWallet) CreateNFTTransaction(ctx context.Context, standard types.TokenType, tokenAddress, toAddress string, tokenID, amount *big.Int, options types.TransactionOptions) (*types.Transaction, error) {
+	// Derive wallet address
+	fromAddress, err := w.DeriveAddress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Validate toAddress and tokenAddress
+	_, err = types.NewAddress(w.chain.Type, toAddress)
+	if err != nil {
+		return nil, err
+	}
+	_, err = types.NewAddress(w.chain.Type, tokenAddress)
+	if err != nil {
+		return nil, err
+	}
+	if tokenID == nil || tokenID.Sign() < 0 {
+		return nil, errors.NewInvalidParameterError("token_id", "must be a non-negative integer")
+	}
+	// Validate amount
+	if amount == nil || amount.Cmp(big.NewInt(0)) <= 0 {
+		return nil, errors.NewInvalidAmountError(amount.String())
+	}
+
+	from := common.HexToAddress(fromAddress)
+	to := common.HexToAddress(toAddress)
+
+	var data []byte
+	switch standard {
+	case types.TokenTypeERC721:
+		if amount.Cmp(big.NewInt(1)) != 0 {
+			return nil, errors.NewInvalidAmountError(amount.String())
+		}
+		erc721ABI, err := w.abiLoader.LoadABIByType(ctx, coreAbi.ABITypeERC721)
+		if err != nil {
+			return nil, err
+		}
+		data, err = w.abiUtils.Pack(erc721ABI, string(types.ERC721SafeTransferFromMethod), from, to, tokenID)
+		if err != nil {
+			return nil, err
+		}
+	case types.TokenTypeERC1155:
+		erc1155ABI, err := w.abiLoader.LoadABIByType(ctx, coreAbi.ABITypeERC1155)
+		if err != nil {
+			return nil, err
+		}
+		data, err = w.abiUtils.Pack(erc1155ABI, string(types.ERC1155SafeTransferFromMethod), from, to, tokenID, amount, []byte{})
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.NewInvalidParameterError("standard", "unsupported NFT standard: "+string(standard))
+	}
+
+	gasPrice := options.GasPrice
+	if gasPrice == nil || gasPrice.Cmp(big.NewInt(0)) == 0 {
+		gasPrice = big.NewInt(int64(w.chain.DefaultGasPrice))
+	}
+
+	// safeTransferFrom may invoke the recipient's onERC721Received/onERC1155Received hook
+	gasLimit := options.GasLimit
+	if gasLimit == 0 {
+		gasLimit = 150000
+	}
+
+	tx := &types.Transaction{
+		BaseTransaction: types.BaseTransaction{
+			ChainType: w.chain.Type,
+			From:      fromAddress,
+			To:        tokenAddress,
+			Value:     big.NewInt(0),
+			Data:      data,
+			Nonce:     options.Nonce,
+			GasPrice:  gasPrice,
+			GasLimit:  gasLimit,
+			Type:      types.TransactionTypeContractCall,
+		},
+	}
+
+	return tx, nil
+}
+
 // SignTransaction signs a transaction with the wallet's key
 func (w *EVMWallet) SignTransaction(ctx context.Context, tx *types.Transaction) ([]byte, error) {
 	fromAddress, err := w.DeriveAddress(ctx)
@@ -201,12 +284,86 @@ func (w *EVMWallet) signEVMTransaction(ctx context.Context, tx *ethTypes.Transac
 	// Compute the transaction hash that needs to be signed
 	hash := signer.Hash(tx)
 
+	// Describe the transaction to the keystore so its policy can be enforced
+	// and the resulting transaction hash recorded in the signing audit log
+	var destination string
+	if tx.To() != nil {
+		destination = tx.To().Hex()
+	}
+	signCtx := keystore.WithSignRequest(ctx, keystore.SignRequest{
+		Operation:   keystore.OperationSignTransaction,
+		Destination: destination,
+		TransactionHash: func(der []byte) (string, error) {
+			signature, err := w.ethereumSignature(ctx, hash.Bytes(), der)
+			if err != nil {
+				return "", err
+			}
+			signedTx, err := tx.WithSignature(signer, signature)
+			if err != nil {
+				return "", errors.NewSignatureRecoveryError(err)
+			}
+			return signedTx.Hash().Hex(), nil
+		},
+	})
+
+	signature, err := w.signDigest(signCtx, hash.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the signed transaction with the signature
+	signedTx, err := tx.WithSignature(signer, signature)
+	if err != nil {
+		return nil, errors.NewSignatureRecoveryError(err)
+	}
+
+	// Return the RLP-encoded transaction, ready for broadcasting
+	return signedTx.MarshalBinary()
+}
+
+// SignMessage signs a message following EIP-191 (personal_sign)
+func (w *EVMWallet) SignMessage(ctx context.Context, message []byte) ([]byte, error) {
+	ctx = keystore.WithSignRequest(ctx, keystore.SignRequest{Operation: keystore.OperationSignMessage})
+	return w.signEthereumDigest(ctx, coreCrypto.HashPersonalMessage(message))
+}
+
+// SignTypedData signs an EIP-712 typed data JSON document. Documents whose domain
+// names another chain than the wallet's are rejected.
+func (w *EVMWallet) SignTypedData(ctx context.Context, typedData []byte) ([]byte, error) {
+	hash, err := coreCrypto.HashTypedDataForChain(typedData, w.chain.ID)
+	if err != nil {
+		return nil, err
+	}
+	ctx = keystore.WithSignRequest(ctx, keystore.SignRequest{Operation: keystore.OperationSignMessage})
+	return w.signEthereumDigest(ctx, hash)
+}
+
+// signEthereumDigest signs a digest and returns an r||s||v signature with v in {27, 28},
+// the format expected by wallets and by ecrecover in smart contracts
+func (w *EVMWallet) signEthereumDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	signature, err := w.signDigest(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+	signature[64] += 27
+	return signature, nil
+}
+
+// signDigest signs a 32-byte digest with the wallet's key and returns a 65-byte
+// r||s||v signature with a low S value and v in {0, 1}
+func (w *EVMWallet) signDigest(ctx context.Context, digest []byte) ([]byte, error) {
 	// Sign the hash using the keystore
-	signature, err := w.keyStore.Sign(ctx, w.keyID, hash.Bytes(), keystore.DataTypeDigest)
+	signature, err := w.keyStore.Sign(ctx, w.keyID, digest, keystore.DataTypeDigest)
 	if err != nil {
 		return nil, err // Don't wrap keystore errors
 	}
 
+	return w.ethereumSignature(ctx, digest, signature)
+}
+
+// ethereumSignature converts a DER-encoded keystore signature over a digest into
+// a 65-byte r||s||v signature with a low S value and v in {0, 1}
+func (w *EVMWallet) ethereumSignature(ctx context.Context, digest, signature []byte) ([]byte, error) {
 	// Parse the DER-encoded signature into R and S components
 	type ecdsaSignature struct {
 		R, S *big.Int
@@ -241,11 +398,13 @@ func (w *EVMWallet) signEVMTransaction(ctx context.Context, tx *ethTypes.Transac
 
 	// Test recovery ID {0, 1} to find the correct v
 	for recoveryID := 0; recoveryID <= 1; recoveryID++ {
-		testSig := append(rBytes, sBytes...)
-		testSig = append(testSig, byte(recoveryID))
+		testSig := make([]byte, 65)
+		copy(testSig[:32], rBytes)
+		copy(testSig[32:64], sBytes)
+		testSig[64] = byte(recoveryID)
 
 		// Attempt to recover the public key
-		recoveredPubKeyBytes, err := crypto.Ecrecover(hash.Bytes(), testSig)
+		recoveredPubKeyBytes, err := crypto.Ecrecover(digest, testSig)
 		if err != nil {
 			continue
 		}
@@ -256,20 +415,7 @@ func (w *EVMWallet) signEVMTransaction(ctx context.Context, tx *ethTypes.Transac
 
 		// Check if the recovered public key matches the expected one
 		if recoveredPubKey.Equal(publicKey) {
-			// Found the correct v value, now create the final signature in the format expected by go-ethereum
-			signature := make([]byte, 65)
-			copy(signature[:32], rBytes)
-			copy(signature[32:64], sBytes)
-			signature[64] = byte(recoveryID)
-
-			// Create the signed transaction with the signature
-			signedTx, err := tx.WithSignature(signer, signature)
-			if err != nil {
-				return nil, errors.NewSignatureRecoveryError(err)
-			}
-
-			// Return the RLP-encoded transaction, ready for broadcasting
-			return signedTx.MarshalBinary()
+			return testSig, nil
 		}
 	}
 
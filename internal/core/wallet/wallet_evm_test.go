@@ -112,6 +112,22 @@ func (m *MockABIUtils) GetUint64FromArgs(args map[string]any, key string) (uint6
 	return 0, nil
 }
 
+func (m *MockABIUtils) DecodeCall(contractABI string, inputData []byte) (*types.DecodedCall, error) {
+	return nil, nil
+}
+
+func (m *MockABIUtils) DecodeLog(contractABI string, log types.Log) (*types.DecodedEvent, error) {
+	return nil, nil
+}
+
+func (m *MockABIUtils) DecodeCallHeuristic(inputData []byte) (*types.DecodedCall, error) {
+	return nil, nil
+}
+
+func (m *MockABIUtils) DecodeLogHeuristic(log types.Log) (*types.DecodedEvent, error) {
+	return nil, nil
+}
+
 // MockABILoader implements the ABILoader interface for testing
 type MockABILoader struct {
 	LoadABIByTypeFunc    func(ctx context.Context, abiType coreAbi.SupportedABIType) (string, error)
@@ -356,6 +372,71 @@ func TestCreateTokenTransaction(t *testing.T) {
 	assert.Error(t, err, "CreateTokenTransaction should fail with invalid toAddress")
 }
 
+// TestCreateNFTTransaction tests ERC721 and ERC1155 safeTransferFrom transactions
+func TestCreateNFTTransaction(t *testing.T) {
+	wallet, ks := setupTest(t)
+	ctx := context.Background()
+
+	privKey, err := ecdsa.GenerateKey(coreCrypto.Secp256k1Curve, rand.Reader)
+	require.NoError(t, err)
+	pubKeyBytes, err := coreCrypto.MarshalPublicKey(&privKey.PublicKey)
+	require.NoError(t, err)
+
+	ks.GetPublicKeyFunc = func(ctx context.Context, id string) (*keystore.Key, error) {
+		return &keystore.Key{
+			ID:        id,
+			Type:      types.KeyTypeECDSA,
+			Curve:     coreCrypto.Secp256k1Curve,
+			PublicKey: pubKeyBytes,
+		}, nil
+	}
+
+	var packedMethod string
+	var packedArgs []any
+	wallet.abiUtils.(*MockABIUtils).PackFunc = func(contractABI string, methodName string, args ...any) ([]byte, error) {
+		packedMethod = methodName
+		packedArgs = args
+		return []byte{0x42, 0x84, 0x2e, 0x0e}, nil
+	}
+
+	tokenAddress := "0xBC4CA0EdA7647A8aB7C2061c2E118A18a936f13D"
+	toAddress := "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+	fromAddress := crypto.PubkeyToAddress(privKey.PublicKey)
+
+	t.Run("erc721", func(t *testing.T) {
+		tx, err := wallet.CreateNFTTransaction(ctx, types.TokenTypeERC721, tokenAddress, toAddress, big.NewInt(42), big.NewInt(1), types.TransactionOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, tokenAddress, tx.To)
+		assert.Equal(t, fromAddress.Hex(), tx.From)
+		assert.Equal(t, big.NewInt(0), tx.Value)
+		assert.Equal(t, types.TransactionTypeContractCall, tx.Type)
+		assert.Equal(t, string(types.ERC721SafeTransferFromMethod), packedMethod)
+		require.Len(t, packedArgs, 3)
+		assert.Equal(t, fromAddress, packedArgs[0])
+		assert.Equal(t, common.HexToAddress(toAddress), packedArgs[1])
+		assert.Equal(t, big.NewInt(42), packedArgs[2])
+	})
+
+	t.Run("erc721 amount must be one", func(t *testing.T) {
+		_, err := wallet.CreateNFTTransaction(ctx, types.TokenTypeERC721, tokenAddress, toAddress, big.NewInt(42), big.NewInt(2), types.TransactionOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("erc1155", func(t *testing.T) {
+		_, err := wallet.CreateNFTTransaction(ctx, types.TokenTypeERC1155, tokenAddress, toAddress, big.NewInt(7), big.NewInt(5), types.TransactionOptions{GasLimit: 90000})
+		require.NoError(t, err)
+		assert.Equal(t, string(types.ERC1155SafeTransferFromMethod), packedMethod)
+		require.Len(t, packedArgs, 5)
+		assert.Equal(t, big.NewInt(7), packedArgs[2])
+		assert.Equal(t, big.NewInt(5), packedArgs[3])
+	})
+
+	t.Run("unsupported standard", func(t *testing.T) {
+		_, err := wallet.CreateNFTTransaction(ctx, types.TokenTypeERC20, tokenAddress, toAddress, big.NewInt(1), big.NewInt(1), types.TransactionOptions{})
+		assert.Error(t, err)
+	})
+}
+
 // TestSignTransaction tests the SignTransaction method with DER-encoded keys
 func TestSignTransaction(t *testing.T) {
 	wallet, ks := setupTest(t)
@@ -453,6 +534,88 @@ func TestSignTransaction(t *testing.T) {
 	})
 }
 
+// TestSignMessageAndTypedData tests EIP-191 and EIP-712 signing
+func TestSignMessageAndTypedData(t *testing.T) {
+	wallet, ks := setupTest(t)
+	ctx := context.Background()
+
+	privKey, err := ecdsa.GenerateKey(coreCrypto.Secp256k1Curve, rand.Reader)
+	require.NoError(t, err)
+
+	pubKeyBytes, err := coreCrypto.MarshalPublicKey(&privKey.PublicKey)
+	require.NoError(t, err)
+
+	address := crypto.PubkeyToAddress(privKey.PublicKey).Hex()
+	ks.GetPublicKeyFunc = func(ctx context.Context, id string) (*keystore.Key, error) {
+		return &keystore.Key{
+			ID:        "test",
+			Type:      types.KeyTypeECDSA,
+			Curve:     coreCrypto.Secp256k1Curve,
+			PublicKey: pubKeyBytes,
+		}, nil
+	}
+	ks.SignFunc = func(ctx context.Context, id string, data []byte, dataType keystore.DataType) ([]byte, error) {
+		r, s, err := ecdsa.Sign(rand.Reader, privKey, data)
+		if err != nil {
+			return nil, err
+		}
+		return asn1.Marshal(ecdsaSignature{R: r, S: s})
+	}
+
+	t.Run("personal message", func(t *testing.T) {
+		message := []byte("Sign in to vault0")
+
+		signature, err := wallet.SignMessage(ctx, message)
+		require.NoError(t, err)
+		require.Len(t, signature, 65)
+		assert.Contains(t, []byte{27, 28}, signature[64])
+
+		recovered, err := coreCrypto.RecoverEthAddress(coreCrypto.HashPersonalMessage(message), signature)
+		require.NoError(t, err)
+		assert.Equal(t, address, recovered)
+	})
+
+	t.Run("typed data", func(t *testing.T) {
+		typedData := []byte(`{
+			"types": {
+				"EIP712Domain": [{"name": "name", "type": "string"}, {"name": "chainId", "type": "uint256"}],
+				"Login": [{"name": "nonce", "type": "uint256"}]
+			},
+			"primaryType": "Login",
+			"domain": {"name": "vault0", "chainId": 1},
+			"message": {"nonce": 42}
+		}`)
+
+		signature, err := wallet.SignTypedData(ctx, typedData)
+		require.NoError(t, err)
+		require.Len(t, signature, 65)
+
+		digest, err := coreCrypto.HashTypedData(typedData)
+		require.NoError(t, err)
+		recovered, err := coreCrypto.RecoverEthAddress(digest, signature)
+		require.NoError(t, err)
+		assert.Equal(t, address, recovered)
+	})
+
+	t.Run("invalid typed data", func(t *testing.T) {
+		_, err := wallet.SignTypedData(ctx, []byte(`{}`))
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidTypedData))
+	})
+
+	t.Run("typed data for another chain", func(t *testing.T) {
+		_, err := wallet.SignTypedData(ctx, []byte(`{
+			"types": {
+				"EIP712Domain": [{"name": "name", "type": "string"}, {"name": "chainId", "type": "uint256"}],
+				"Login": [{"name": "nonce", "type": "uint256"}]
+			},
+			"primaryType": "Login",
+			"domain": {"name": "vault0", "chainId": 137},
+			"message": {"nonce": 42}
+		}`))
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidInput))
+	})
+}
+
 // TestNewEVMWalletValidation tests the validation in NewEVMWallet
 func TestNewEVMWalletValidation(t *testing.T) {
 	ks := &MockKeyStore{}
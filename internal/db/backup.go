@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/mattn/go-sqlite3"
+
+	"vault0/internal/errors"
+)
+
+// Backup copies the database into a new SQLite file at destPath using the SQLite
+// online backup API. The copy is a consistent snapshot of the database, taken
+// while the application keeps serving requests.
+func (db *DB) Backup(ctx context.Context, destPath string) error {
+	if db.Dialect == DialectPostgres {
+		return errors.NewNotImplementedError("online backup of PostgreSQL databases, use pg_dump instead")
+	}
+
+	destDB, err := sql.Open("sqlite3", destPath)
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	defer destDB.Close()
+
+	destConn, err := destDB.Conn(ctx)
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	defer destConn.Close()
+
+	srcConn, err := db.Conn.Conn(ctx)
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	defer srcConn.Close()
+
+	err = destConn.Raw(func(destDriverConn any) error {
+		return srcConn.Raw(func(srcDriverConn any) error {
+			dest, ok := destDriverConn.(*sqlite3.SQLiteConn)
+			if !ok {
+				return fmt.Errorf("unexpected destination connection type %T", destDriverConn)
+			}
+			src, ok := srcDriverConn.(*sqlite3.SQLiteConn)
+			if !ok {
+				return fmt.Errorf("unexpected source connection type %T", srcDriverConn)
+			}
+
+			backup, err := dest.Backup("main", src, "main")
+			if err != nil {
+				return err
+			}
+			// Copy all pages in a single step, so that the snapshot is not restarted by
+			// writes committed while it is taken
+			if _, err := backup.Step(-1); err != nil {
+				backup.Close()
+				return err
+			}
+			return backup.Finish()
+		})
+	})
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+// SchemaVersion returns the version of the last migration applied to the database,
+// and whether that migration failed part way
+func (db *DB) SchemaVersion(ctx context.Context) (uint, bool, error) {
+	rows, err := db.ExecuteQueryContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1")
+	if err != nil {
+		return 0, false, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return 0, false, errors.NewDatabaseError(err)
+		}
+		return 0, false, nil
+	}
+
+	var version uint
+	var dirty bool
+	if err := rows.Scan(&version, &dirty); err != nil {
+		return 0, false, errors.NewDatabaseError(err)
+	}
+	return version, dirty, nil
+}
+
+// CheckIntegrity verifies the structure of a SQLite database file
+func (db *DB) CheckIntegrity(ctx context.Context) error {
+	if db.Dialect == DialectPostgres {
+		return nil
+	}
+
+	rows, err := db.ExecuteQueryContext(ctx, "PRAGMA integrity_check")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var problems []string
+	for rows.Next() {
+		var result string
+		if err := rows.Scan(&result); err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		if result != "ok" {
+			problems = append(problems, result)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return errors.NewDatabaseError(err)
+	}
+
+	if len(problems) > 0 {
+		return errors.NewDatabaseError(fmt.Errorf("integrity check failed: %v", problems))
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"vault0/internal/config"
+	"vault0/internal/testing/mocks"
+)
+
+func TestBackup(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	if _, err := db.ExecuteStatementContext(ctx, "INSERT INTO items (name) VALUES (?), (?)", "a", "b"); err != nil {
+		t.Fatalf("Failed to insert items: %v", err)
+	}
+	if _, err := db.ExecuteStatementContext(ctx, "CREATE TABLE schema_migrations (version INTEGER, dirty BOOLEAN)"); err != nil {
+		t.Fatalf("Failed to create schema_migrations: %v", err)
+	}
+	if _, err := db.ExecuteStatementContext(ctx, "INSERT INTO schema_migrations (version, dirty) VALUES (?, ?)", 7, false); err != nil {
+		t.Fatalf("Failed to insert schema version: %v", err)
+	}
+
+	destPath := filepath.Join(t.TempDir(), "backup.db")
+	if err := db.Backup(ctx, destPath); err != nil {
+		t.Fatalf("Backup failed: %v", err)
+	}
+
+	backup, err := NewDatabase(&config.Config{DBPath: destPath}, nil, mocks.NewNopLogger())
+	if err != nil {
+		t.Fatalf("Failed to open backup: %v", err)
+	}
+	defer backup.Close()
+
+	if count := countItems(t, ctx, backup); count != 2 {
+		t.Errorf("Expected 2 items in the backup, got %d", count)
+	}
+	if err := backup.CheckIntegrity(ctx); err != nil {
+		t.Errorf("Integrity check failed: %v", err)
+	}
+
+	version, dirty, err := backup.SchemaVersion(ctx)
+	if err != nil {
+		t.Fatalf("SchemaVersion failed: %v", err)
+	}
+	if version != 7 || dirty {
+		t.Errorf("Expected clean schema version 7, got %d (dirty %t)", version, dirty)
+	}
+}
+
+func TestBackup_PostgresNotImplemented(t *testing.T) {
+	db := &DB{Dialect: DialectPostgres}
+	if err := db.Backup(context.Background(), filepath.Join(t.TempDir(), "backup.db")); err == nil {
+		t.Error("Expected an error backing up a PostgreSQL database")
+	}
+}
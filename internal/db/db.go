@@ -10,6 +10,7 @@ import (
 	"vault0/internal/errors"
 	"vault0/internal/logger"
 
+	_ "github.com/lib/pq"
 	_ "github.com/mattn/go-sqlite3"
 )
 
@@ -19,26 +20,48 @@ type DB struct {
 	Config    *config.Config
 	Snowflake *Snowflake
 	Log       logger.Logger
+	// Dialect is the SQL engine of the connection; the zero value behaves as SQLite
+	Dialect Dialect
 }
 
-// NewDatabase creates a new database connection
+// NewDatabase creates a new database connection to the backend selected by the configuration
 func NewDatabase(cfg *config.Config, snowflake *Snowflake, log logger.Logger) (*DB, error) {
-	// Create a database connection string for SQLite
-	connStr := fmt.Sprintf("file:%s", cfg.DBPath)
+	dialect, err := ParseDialect(cfg.DBDriver)
+	if err != nil {
+		return nil, err
+	}
+
+	var connStr string
+	switch dialect {
+	case DialectPostgres:
+		if cfg.DBURL == "" {
+			return nil, errors.NewConfigurationError("db_url is required when db_driver is postgres")
+		}
+		connStr = cfg.DBURL
+	default:
+		// Create a database connection string for SQLite. Transactions take the write lock
+		// when they begin, and wait for it instead of failing while another one holds it.
+		connStr = fmt.Sprintf("file:%s?_busy_timeout=5000&_txlock=immediate", cfg.DBPath)
+	}
 
 	// Connect to the database
-	conn, err := sql.Open("sqlite3", connStr)
+	conn, err := sql.Open(dialect.DriverName(), connStr)
 	if err != nil {
 		return nil, errors.NewDatabaseError(err)
 	}
 
 	// Verify the connection works
 	if err := conn.Ping(); err != nil {
+		conn.Close()
 		return nil, errors.NewDatabaseError(err)
 	}
 
-	log.Info("Connected to database", logger.String("path", cfg.DBPath))
-	return &DB{Conn: conn, Config: cfg, Log: log, Snowflake: snowflake}, nil
+	if dialect == DialectPostgres {
+		log.Info("Connected to database", logger.String("driver", string(dialect)))
+	} else {
+		log.Info("Connected to database", logger.String("driver", string(dialect)), logger.String("path", cfg.DBPath))
+	}
+	return &DB{Conn: conn, Config: cfg, Log: log, Snowflake: snowflake, Dialect: dialect}, nil
 }
 
 // Close closes the database connection
@@ -58,16 +81,17 @@ func (db *DB) GetConnection() *sql.DB {
 
 // ExecuteQuery executes a query with parameters and returns the result
 func (db *DB) ExecuteQuery(query string, args ...any) (*sql.Rows, error) {
-	rows, err := db.Conn.Query(query, args...)
+	rows, err := db.Conn.Query(db.Dialect.Rebind(query), args...)
 	if err != nil {
 		return nil, errors.NewDatabaseError(err)
 	}
 	return rows, nil
 }
 
-// ExecuteQueryContext executes a query with context and parameters and returns the result
+// ExecuteQueryContext executes a query with context and parameters and returns the result.
+// The query runs within the transaction carried by the context, if any.
 func (db *DB) ExecuteQueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
-	rows, err := db.Conn.QueryContext(ctx, query, args...)
+	rows, err := db.executor(ctx).QueryContext(ctx, db.Dialect.Rebind(query), args...)
 	if err != nil {
 		return nil, errors.NewDatabaseError(err)
 	}
@@ -76,16 +100,17 @@ func (db *DB) ExecuteQueryContext(ctx context.Context, query string, args ...any
 
 // ExecuteStatement executes a statement with parameters
 func (db *DB) ExecuteStatement(query string, args ...any) (sql.Result, error) {
-	result, err := db.Conn.Exec(query, args...)
+	result, err := db.Conn.Exec(db.Dialect.Rebind(query), args...)
 	if err != nil {
 		return nil, errors.NewDatabaseError(err)
 	}
 	return result, nil
 }
 
-// ExecuteStatementContext executes a statement with context and parameters
+// ExecuteStatementContext executes a statement with context and parameters.
+// The statement runs within the transaction carried by the context, if any.
 func (db *DB) ExecuteStatementContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
-	result, err := db.Conn.ExecContext(ctx, query, args...)
+	result, err := db.executor(ctx).ExecContext(ctx, db.Dialect.Rebind(query), args...)
 	if err != nil {
 		return nil, errors.NewDatabaseError(err)
 	}
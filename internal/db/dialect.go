@@ -0,0 +1,74 @@
+package db
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"vault0/internal/errors"
+)
+
+// Dialect identifies the SQL engine behind a database connection
+type Dialect string
+
+const (
+	// DialectSQLite is the embedded SQLite engine, the default backend
+	DialectSQLite Dialect = "sqlite"
+	// DialectPostgres is a PostgreSQL server, which lets several instances share one database
+	DialectPostgres Dialect = "postgres"
+)
+
+// ParseDialect returns the dialect of a configured database driver name.
+// An empty name selects SQLite.
+func ParseDialect(driver string) (Dialect, error) {
+	switch strings.ToLower(strings.TrimSpace(driver)) {
+	case "", "sqlite", "sqlite3":
+		return DialectSQLite, nil
+	case "postgres", "postgresql":
+		return DialectPostgres, nil
+	default:
+		return "", errors.NewConfigurationError(fmt.Sprintf("unsupported database driver: %s", driver))
+	}
+}
+
+// DriverName returns the name of the database/sql driver of the dialect
+func (d Dialect) DriverName() string {
+	if d == DialectPostgres {
+		return "postgres"
+	}
+	return "sqlite3"
+}
+
+// Rebind rewrites the ? placeholders of a query, as produced by the query builders
+// and written in raw statements, into the placeholder syntax of the dialect.
+// Question marks within quoted literals and identifiers are left untouched.
+func (d Dialect) Rebind(query string) string {
+	if d != DialectPostgres || !strings.Contains(query, "?") {
+		return query
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(query) + 8)
+
+	n := 0
+	var quote byte
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case quote != 0:
+			// A doubled quote is an escaped quote and keeps the literal open
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '?':
+			n++
+			sb.WriteByte('$')
+			sb.WriteString(strconv.Itoa(n))
+			continue
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
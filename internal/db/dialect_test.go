@@ -0,0 +1,67 @@
+package db
+
+import "testing"
+
+func TestDialect_Rebind(t *testing.T) {
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		query    string
+		expected string
+	}{
+		{
+			name:     "SQLite keeps placeholders",
+			dialect:  DialectSQLite,
+			query:    "SELECT id FROM users WHERE email = ? AND id > ?",
+			expected: "SELECT id FROM users WHERE email = ? AND id > ?",
+		},
+		{
+			name:     "Zero value behaves as SQLite",
+			query:    "DELETE FROM keys WHERE id = ?",
+			expected: "DELETE FROM keys WHERE id = ?",
+		},
+		{
+			name:     "Postgres numbers placeholders",
+			dialect:  DialectPostgres,
+			query:    "SELECT id FROM users WHERE email = ? AND id > ? LIMIT ?",
+			expected: "SELECT id FROM users WHERE email = $1 AND id > $2 LIMIT $3",
+		},
+		{
+			name:     "Postgres skips quoted literals",
+			dialect:  DialectPostgres,
+			query:    "SELECT '?', \"a?b\" FROM t WHERE metadata->>'recipient' = ? AND note = 'it''s ?'",
+			expected: "SELECT '?', \"a?b\" FROM t WHERE metadata->>'recipient' = $1 AND note = 'it''s ?'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dialect.Rebind(tt.query); got != tt.expected {
+				t.Errorf("Rebind() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseDialect(t *testing.T) {
+	for driver, expected := range map[string]Dialect{
+		"":           DialectSQLite,
+		"sqlite":     DialectSQLite,
+		"sqlite3":    DialectSQLite,
+		"postgres":   DialectPostgres,
+		"PostgreSQL": DialectPostgres,
+	} {
+		got, err := ParseDialect(driver)
+		if err != nil {
+			t.Errorf("ParseDialect(%q) failed: %v", driver, err)
+			continue
+		}
+		if got != expected {
+			t.Errorf("ParseDialect(%q) = %q, expected %q", driver, got, expected)
+		}
+	}
+
+	if _, err := ParseDialect("mysql"); err == nil {
+		t.Error("Expected an error for an unsupported driver")
+	}
+}
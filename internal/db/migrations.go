@@ -1,28 +1,47 @@
 package db
 
 import (
+	"path/filepath"
+
 	"vault0/internal/errors"
 
 	"vault0/internal/logger"
 
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
 	"github.com/golang-migrate/migrate/v4/database/sqlite3"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
 
+// postgresMigrationsDir is the subdirectory of the migrations path holding the
+// PostgreSQL versions of the migrations. Both sets share version numbers.
+const postgresMigrationsDir = "postgres"
+
 // MigrateDatabase applies all available migrations to the database
 func (db *DB) MigrateDatabase() error {
-	// Get the database driver
-	driver, err := sqlite3.WithInstance(db.GetConnection(), &sqlite3.Config{})
+	// Get the database driver and the migrations written for it
+	var (
+		driver database.Driver
+		err    error
+	)
+	migrationsPath := db.Config.MigrationsPath
+	switch db.Dialect {
+	case DialectPostgres:
+		driver, err = postgres.WithInstance(db.GetConnection(), &postgres.Config{})
+		migrationsPath = filepath.Join(migrationsPath, postgresMigrationsDir)
+	default:
+		driver, err = sqlite3.WithInstance(db.GetConnection(), &sqlite3.Config{})
+	}
 	if err != nil {
 		return errors.NewDatabaseError(err)
 	}
 
 	// Create the migrate instance
-	sourceURL := "file://" + db.Config.MigrationsPath
+	sourceURL := "file://" + migrationsPath
 	m, err := migrate.NewWithDatabaseInstance(
 		sourceURL,
-		"sqlite3", // Database name (just a label for migrate)
+		db.Dialect.DriverName(), // Database name (just a label for migrate)
 		driver,
 	)
 	if err != nil {
@@ -35,6 +54,6 @@ func (db *DB) MigrateDatabase() error {
 	}
 
 	db.Log.Info("Database migrations applied successfully",
-		logger.String("path", db.Config.MigrationsPath))
+		logger.String("path", migrationsPath))
 	return nil
 }
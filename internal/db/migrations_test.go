@@ -0,0 +1,394 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+
+	"vault0/internal/config"
+	"vault0/internal/testing/mocks"
+)
+
+// migrationsPath is the migrations directory of the repository, relative to this package
+const migrationsPath = "../../migrations"
+
+// newTestMigration opens an empty SQLite database and returns it with a migrate
+// instance applying the repository migrations to it
+func newTestMigration(t *testing.T) (*DB, *migrate.Migrate) {
+	t.Helper()
+
+	db, err := NewDatabase(&config.Config{DBPath: filepath.Join(t.TempDir(), "test.db")}, nil, mocks.NewNopLogger())
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	driver, err := sqlite3.WithInstance(db.GetConnection(), &sqlite3.Config{})
+	if err != nil {
+		t.Fatalf("Failed to create migration driver: %v", err)
+	}
+	m, err := migrate.NewWithDatabaseInstance("file://"+migrationsPath, DialectSQLite.DriverName(), driver)
+	if err != nil {
+		t.Fatalf("Failed to create migrate instance: %v", err)
+	}
+	return db, m
+}
+
+func TestMigration_KeyTokenPricesByAsset(t *testing.T) {
+	ctx := context.Background()
+	db, m := newTestMigration(t)
+
+	if err := m.Migrate(22); err != nil {
+		t.Fatalf("Failed to migrate to version 22: %v", err)
+	}
+	_, err := db.ExecuteStatementContext(ctx, `
+		INSERT INTO token_prices (symbol, rank, price_usd, supply, market_cap_usd, volume_usd_24h, updated_at, sources)
+		VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, ?), (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, ?)`,
+		"BTC", 1, 65000.5, 19000000, 1.2e12, 3.4e10, "coincap,coinpaprika",
+		"ETH", 2, 3200.25, 120000000, 3.8e11, 1.5e10, "coincap")
+	if err != nil {
+		t.Fatalf("Failed to insert token prices: %v", err)
+	}
+
+	// Upgrading keeps every price as a symbol-only asset
+	if err := m.Migrate(23); err != nil {
+		t.Fatalf("Failed to migrate to version 23: %v", err)
+	}
+	var (
+		symbol, chainType, contractAddress, sources string
+		rank                                        int
+		priceUSD                                    float64
+	)
+	err = db.GetConnection().QueryRowContext(ctx, `
+		SELECT symbol, chain_type, contract_address, rank, price_usd, sources
+		FROM token_prices WHERE asset_id = ?`, "btc").
+		Scan(&symbol, &chainType, &contractAddress, &rank, &priceUSD, &sources)
+	if err != nil {
+		t.Fatalf("Failed to read the migrated BTC price: %v", err)
+	}
+	if symbol != "BTC" || chainType != "" || contractAddress != "" || rank != 1 || priceUSD != 65000.5 || sources != "coincap,coinpaprika" {
+		t.Errorf("Unexpected migrated BTC price: %s %q %q %d %v %s", symbol, chainType, contractAddress, rank, priceUSD, sources)
+	}
+	if count := countTokenPrices(t, ctx, db); count != 2 {
+		t.Errorf("Expected 2 token prices after upgrading, got %d", count)
+	}
+
+	// Downgrading keeps the best ranked price of each symbol
+	_, err = db.ExecuteStatementContext(ctx, `
+		INSERT INTO token_prices (asset_id, symbol, chain_type, contract_address, rank, price_usd, supply, market_cap_usd, volume_usd_24h, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)`,
+		"eth-ethereum-classic", "ETH", "", "", 0, 20.5, 150000000, 3e9, 1e8)
+	if err != nil {
+		t.Fatalf("Failed to insert a token price: %v", err)
+	}
+	if err := m.Migrate(22); err != nil {
+		t.Fatalf("Failed to migrate down to version 22: %v", err)
+	}
+	err = db.GetConnection().QueryRowContext(ctx, "SELECT rank, price_usd FROM token_prices WHERE symbol = ?", "ETH").Scan(&rank, &priceUSD)
+	if err != nil {
+		t.Fatalf("Failed to read the restored ETH price: %v", err)
+	}
+	if rank != 2 || priceUSD != 3200.25 {
+		t.Errorf("Expected the ranked ETH price to be restored, got rank %d at %v", rank, priceUSD)
+	}
+	if count := countTokenPrices(t, ctx, db); count != 2 {
+		t.Errorf("Expected 2 token prices after downgrading, got %d", count)
+	}
+}
+
+func countTokenPrices(t *testing.T, ctx context.Context, db *DB) int {
+	t.Helper()
+
+	var count int
+	if err := db.GetConnection().QueryRowContext(ctx, "SELECT COUNT(*) FROM token_prices").Scan(&count); err != nil {
+		t.Fatalf("Failed to count token prices: %v", err)
+	}
+	return count
+}
+
+// migrationSchema is the schema built by a set of migrations, as far as both dialects
+// must agree on it: the tables with their columns in order, and the tables each index
+// and trigger belongs to
+type migrationSchema struct {
+	Tables   map[string][]string
+	Indexes  map[string]string
+	Triggers map[string]string
+}
+
+func newMigrationSchema() *migrationSchema {
+	return &migrationSchema{Tables: map[string][]string{}, Indexes: map[string]string{}, Triggers: map[string]string{}}
+}
+
+func (s *migrationSchema) clone() *migrationSchema {
+	c := newMigrationSchema()
+	for table, columns := range s.Tables {
+		c.Tables[table] = append([]string(nil), columns...)
+	}
+	for index, table := range s.Indexes {
+		c.Indexes[index] = table
+	}
+	for trigger, table := range s.Triggers {
+		c.Triggers[trigger] = table
+	}
+	return c
+}
+
+var (
+	sqlComment        = regexp.MustCompile(`--[^\n]*`)
+	createTableStmt   = regexp.MustCompile(`(?is)^CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?(\w+)\s*\((.*)\)$`)
+	alterColumnStmt   = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s+(\w+)\s+(ADD|DROP)\s+COLUMN\s+(\w+)`)
+	renameTableStmt   = regexp.MustCompile(`(?is)^ALTER\s+TABLE\s+(\w+)\s+RENAME\s+TO\s+(\w+)$`)
+	dropTableStmt     = regexp.MustCompile(`(?is)^DROP\s+TABLE\s+(?:IF\s+EXISTS\s+)?(\w+)$`)
+	createIndexStmt   = regexp.MustCompile(`(?is)^CREATE\s+(?:UNIQUE\s+)?INDEX\s+(?:IF\s+NOT\s+EXISTS\s+)?(\w+)\s+ON\s+(\w+)`)
+	dropIndexStmt     = regexp.MustCompile(`(?is)^DROP\s+INDEX\s+(?:IF\s+EXISTS\s+)?(\w+)$`)
+	createTriggerStmt = regexp.MustCompile(`(?is)^CREATE\s+TRIGGER\s+(?:IF\s+NOT\s+EXISTS\s+)?(\w+)\s+(?:BEFORE|AFTER|INSTEAD\s+OF)\s+\w+\s+ON\s+(\w+)`)
+	dropTriggerStmt   = regexp.MustCompile(`(?is)^DROP\s+TRIGGER\s+(?:IF\s+EXISTS\s+)?(\w+)`)
+	columnName        = regexp.MustCompile(`^\s*"?(\w+)`)
+)
+
+// apply updates the schema with the DDL statements of a migration. Statements are
+// split on semicolons, which breaks trigger and function bodies apart, but only their
+// CREATE line matters here.
+func (s *migrationSchema) apply(script string) error {
+	for _, stmt := range strings.Split(sqlComment.ReplaceAllString(script, ""), ";") {
+		stmt = strings.TrimSpace(stmt)
+		if m := createTableStmt.FindStringSubmatch(stmt); m != nil {
+			if _, ok := s.Tables[m[1]]; ok {
+				return fmt.Errorf("table %s already exists", m[1])
+			}
+			s.Tables[m[1]] = tableColumns(m[2])
+		} else if m := alterColumnStmt.FindStringSubmatch(stmt); m != nil {
+			columns, ok := s.Tables[m[1]]
+			if !ok {
+				return fmt.Errorf("table %s does not exist", m[1])
+			}
+			if strings.EqualFold(m[2], "ADD") {
+				s.Tables[m[1]] = append(columns, m[3])
+				continue
+			}
+			i := indexOf(columns, m[3])
+			if i < 0 {
+				return fmt.Errorf("column %s.%s does not exist", m[1], m[3])
+			}
+			s.Tables[m[1]] = append(columns[:i:i], columns[i+1:]...)
+		} else if m := renameTableStmt.FindStringSubmatch(stmt); m != nil {
+			columns, ok := s.Tables[m[1]]
+			if !ok {
+				return fmt.Errorf("table %s does not exist", m[1])
+			}
+			delete(s.Tables, m[1])
+			s.Tables[m[2]] = columns
+			s.moveDependents(m[1], m[2])
+		} else if m := dropTableStmt.FindStringSubmatch(stmt); m != nil {
+			delete(s.Tables, m[1])
+			s.moveDependents(m[1], "")
+		} else if m := createIndexStmt.FindStringSubmatch(stmt); m != nil {
+			s.Indexes[m[1]] = m[2]
+		} else if m := dropIndexStmt.FindStringSubmatch(stmt); m != nil {
+			delete(s.Indexes, m[1])
+		} else if m := createTriggerStmt.FindStringSubmatch(stmt); m != nil {
+			s.Triggers[m[1]] = m[2]
+		} else if m := dropTriggerStmt.FindStringSubmatch(stmt); m != nil {
+			delete(s.Triggers, m[1])
+		}
+	}
+	return nil
+}
+
+// moveDependents moves the indexes and triggers of a renamed table, or drops them
+// with the table when to is empty
+func (s *migrationSchema) moveDependents(from, to string) {
+	for _, dependents := range []map[string]string{s.Indexes, s.Triggers} {
+		for name, table := range dependents {
+			if table != from {
+				continue
+			}
+			if to == "" {
+				delete(dependents, name)
+			} else {
+				dependents[name] = to
+			}
+		}
+	}
+}
+
+// tableColumns returns the column names of a CREATE TABLE body, skipping table constraints
+func tableColumns(body string) []string {
+	var (
+		columns []string
+		depth   int
+		start   int
+	)
+	definitions := []string{}
+	for i, r := range body {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				definitions = append(definitions, body[start:i])
+				start = i + 1
+			}
+		}
+	}
+	definitions = append(definitions, body[start:])
+
+	for _, definition := range definitions {
+		m := columnName.FindStringSubmatch(definition)
+		if m == nil {
+			continue
+		}
+		switch strings.ToUpper(m[1]) {
+		case "PRIMARY", "UNIQUE", "FOREIGN", "CHECK", "CONSTRAINT":
+			continue
+		}
+		columns = append(columns, m[1])
+	}
+	return columns
+}
+
+func indexOf(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// migrationFiles returns the names of the migration files of a directory, sorted by version
+func migrationFiles(t *testing.T, dir, suffix string) []string {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"+suffix))
+	if err != nil {
+		t.Fatalf("Failed to list migrations: %v", err)
+	}
+	names := make([]string, len(files))
+	for i, file := range files {
+		names[i] = filepath.Base(file)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func applyMigration(t *testing.T, schema *migrationSchema, dir, name string) {
+	t.Helper()
+
+	script, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		t.Fatalf("Failed to read migration: %v", err)
+	}
+	if err := schema.apply(string(script)); err != nil {
+		t.Fatalf("Failed to apply %s: %v", filepath.Join(dir, name), err)
+	}
+}
+
+// TestMigrations_PostgresMatchesSQLite checks that the PostgreSQL copies of the
+// migrations build the same tables, columns, indexes and triggers as the SQLite
+// ones after every version, both upgrading and downgrading
+func TestMigrations_PostgresMatchesSQLite(t *testing.T) {
+	postgresPath := filepath.Join(migrationsPath, postgresMigrationsDir)
+
+	ups := migrationFiles(t, migrationsPath, ".up.sql")
+	downs := migrationFiles(t, migrationsPath, ".down.sql")
+	if len(ups) == 0 || len(ups) != len(downs) {
+		t.Fatalf("Expected an up and down migration for every version, got %d up and %d down", len(ups), len(downs))
+	}
+	if postgresUps := migrationFiles(t, postgresPath, ".up.sql"); !reflect.DeepEqual(ups, postgresUps) {
+		t.Fatalf("PostgreSQL up migrations differ:\nsqlite:   %v\npostgres: %v", ups, postgresUps)
+	}
+	if postgresDowns := migrationFiles(t, postgresPath, ".down.sql"); !reflect.DeepEqual(downs, postgresDowns) {
+		t.Fatalf("PostgreSQL down migrations differ:\nsqlite:   %v\npostgres: %v", downs, postgresDowns)
+	}
+
+	sqlite, postgres := newMigrationSchema(), newMigrationSchema()
+	versions := []*migrationSchema{sqlite.clone()}
+	for _, name := range ups {
+		applyMigration(t, sqlite, migrationsPath, name)
+		applyMigration(t, postgres, postgresPath, name)
+		if !reflect.DeepEqual(sqlite, postgres) {
+			t.Fatalf("Schemas differ after %s:\nsqlite:   %+v\npostgres: %+v", name, sqlite, postgres)
+		}
+		versions = append(versions, sqlite.clone())
+	}
+
+	for i := len(downs) - 1; i >= 0; i-- {
+		applyMigration(t, sqlite, migrationsPath, downs[i])
+		applyMigration(t, postgres, postgresPath, downs[i])
+		if !reflect.DeepEqual(sqlite, postgres) {
+			t.Fatalf("Schemas differ after %s:\nsqlite:   %+v\npostgres: %+v", downs[i], sqlite, postgres)
+		}
+		if !reflect.DeepEqual(sqlite, versions[i]) {
+			t.Errorf("%s does not restore the previous schema:\ngot:  %+v\nwant: %+v", downs[i], sqlite, versions[i])
+		}
+	}
+}
+
+// TestMigrations_SchemaParsing checks the schema read from the migration files
+// against the one SQLite actually builds from them
+func TestMigrations_SchemaParsing(t *testing.T) {
+	ctx := context.Background()
+	db, m := newTestMigration(t)
+	if err := m.Up(); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+
+	parsed := newMigrationSchema()
+	for _, name := range migrationFiles(t, migrationsPath, ".up.sql") {
+		applyMigration(t, parsed, migrationsPath, name)
+	}
+
+	rows, err := db.ExecuteQueryContext(ctx, `
+		SELECT type, name, tbl_name FROM sqlite_master
+		WHERE name NOT LIKE 'sqlite_%' AND tbl_name != 'schema_migrations'`)
+	if err != nil {
+		t.Fatalf("Failed to read the schema: %v", err)
+	}
+	actual := newMigrationSchema()
+	for rows.Next() {
+		var kind, name, table string
+		if err := rows.Scan(&kind, &name, &table); err != nil {
+			t.Fatalf("Failed to scan the schema: %v", err)
+		}
+		switch kind {
+		case "table":
+			actual.Tables[name] = nil
+		case "index":
+			actual.Indexes[name] = table
+		case "trigger":
+			actual.Triggers[name] = table
+		}
+	}
+	rows.Close()
+
+	for table := range actual.Tables {
+		columns, err := db.ExecuteQueryContext(ctx, fmt.Sprintf("SELECT name FROM pragma_table_info('%s') ORDER BY cid", table))
+		if err != nil {
+			t.Fatalf("Failed to read the columns of %s: %v", table, err)
+		}
+		for columns.Next() {
+			var column string
+			if err := columns.Scan(&column); err != nil {
+				t.Fatalf("Failed to scan the columns of %s: %v", table, err)
+			}
+			actual.Tables[table] = append(actual.Tables[table], column)
+		}
+		columns.Close()
+	}
+
+	if !reflect.DeepEqual(parsed, actual) {
+		t.Errorf("Parsed schema differs from SQLite:\nparsed: %+v\nsqlite: %+v", parsed, actual)
+	}
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+)
+
+// txContextKey is the context key holding the transaction of a unit of work
+type txContextKey struct{}
+
+// executor is implemented by both *sql.DB and *sql.Tx
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// Transactor runs units of work spanning several repositories atomically
+type Transactor interface {
+	// WithTransaction runs fn within a database transaction carried by the context
+	// passed to it. Every repository operation executed with that context takes part
+	// in the transaction, which is committed when fn returns nil and rolled back when
+	// it returns an error or panics.
+	//
+	// When ctx already carries a transaction, fn joins it and the outermost unit of
+	// work decides whether it is committed.
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// NewTransactor returns the database as the Transactor of the services
+func NewTransactor(db *DB) Transactor {
+	return db
+}
+
+// WithTransaction implements the Transactor interface
+func (db *DB) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if InTransaction(ctx) {
+		return fn(ctx)
+	}
+
+	tx, err := db.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			db.Log.Error("Failed to roll back transaction", logger.Error(rbErr))
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+// InTransaction reports whether the context carries a transaction
+func InTransaction(ctx context.Context) bool {
+	_, ok := ctx.Value(txContextKey{}).(*sql.Tx)
+	return ok
+}
+
+// executor returns the transaction carried by the context, or the connection pool
+// when the context carries none
+func (db *DB) executor(ctx context.Context) executor {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db.Conn
+}
+
+// PrepareContext creates a prepared statement within the transaction carried by
+// the context, if any
+func (db *DB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	stmt, err := db.executor(ctx).PrepareContext(ctx, db.Dialect.Rebind(query))
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+	return stmt, nil
+}
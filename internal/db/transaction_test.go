@@ -0,0 +1,178 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"vault0/internal/config"
+	"vault0/internal/testing/mocks"
+)
+
+// newTestDB creates a file backed database with a single items table
+func newTestDB(t *testing.T) *DB {
+	t.Helper()
+
+	cfg := &config.Config{DBPath: filepath.Join(t.TempDir(), "test.db")}
+	db, err := NewDatabase(cfg, nil, mocks.NewNopLogger())
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if _, err := db.ExecuteStatement("CREATE TABLE items (name TEXT PRIMARY KEY)"); err != nil {
+		t.Fatalf("Failed to create table: %v", err)
+	}
+	return db
+}
+
+func countItems(t *testing.T, ctx context.Context, db *DB) int {
+	t.Helper()
+
+	rows, err := db.ExecuteQueryContext(ctx, "SELECT COUNT(*) FROM items")
+	if err != nil {
+		t.Fatalf("Failed to count items: %v", err)
+	}
+	defer rows.Close()
+
+	var count int
+	rows.Next()
+	if err := rows.Scan(&count); err != nil {
+		t.Fatalf("Failed to scan count: %v", err)
+	}
+	return count
+}
+
+func insertItem(ctx context.Context, db *DB, name string) error {
+	_, err := db.ExecuteStatementContext(ctx, "INSERT INTO items (name) VALUES (?)", name)
+	return err
+}
+
+func TestWithTransaction_Commit(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+
+	err := db.WithTransaction(ctx, func(ctx context.Context) error {
+		if !InTransaction(ctx) {
+			t.Error("Expected the context to carry the transaction")
+		}
+		if err := insertItem(ctx, db, "a"); err != nil {
+			return err
+		}
+		if err := insertItem(ctx, db, "b"); err != nil {
+			return err
+		}
+		// Writes are visible within the transaction before it is committed
+		if count := countItems(t, ctx, db); count != 2 {
+			t.Errorf("Expected 2 items within the transaction, got %d", count)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Transaction failed: %v", err)
+	}
+
+	if count := countItems(t, ctx, db); count != 2 {
+		t.Errorf("Expected 2 committed items, got %d", count)
+	}
+}
+
+func TestWithTransaction_RollbackOnError(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	errFailed := errors.New("failed")
+
+	err := db.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := insertItem(ctx, db, "a"); err != nil {
+			return err
+		}
+		return errFailed
+	})
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("Expected the error of the unit of work, got %v", err)
+	}
+
+	if count := countItems(t, ctx, db); count != 0 {
+		t.Errorf("Expected the insert to be rolled back, got %d items", count)
+	}
+}
+
+func TestWithTransaction_RollbackOnPanic(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected the panic to be propagated")
+			}
+		}()
+		_ = db.WithTransaction(ctx, func(ctx context.Context) error {
+			if err := insertItem(ctx, db, "a"); err != nil {
+				return err
+			}
+			panic("unexpected")
+		})
+	}()
+
+	if count := countItems(t, ctx, db); count != 0 {
+		t.Errorf("Expected the insert to be rolled back, got %d items", count)
+	}
+}
+
+func TestWithTransaction_NestedJoinsOuter(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+	errFailed := errors.New("failed")
+
+	err := db.WithTransaction(ctx, func(ctx context.Context) error {
+		// The inner unit of work succeeds but is not committed on its own
+		if err := db.WithTransaction(ctx, func(ctx context.Context) error {
+			return insertItem(ctx, db, "a")
+		}); err != nil {
+			return err
+		}
+		return errFailed
+	})
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("Expected the error of the outer unit of work, got %v", err)
+	}
+
+	if count := countItems(t, ctx, db); count != 0 {
+		t.Errorf("Expected the nested insert to be rolled back, got %d items", count)
+	}
+}
+
+func TestWithTransaction_PrepareContext(t *testing.T) {
+	db := newTestDB(t)
+	ctx := context.Background()
+
+	err := db.WithTransaction(ctx, func(ctx context.Context) error {
+		stmt, err := db.PrepareContext(ctx, "INSERT INTO items (name) VALUES (?)")
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, name := range []string{"a", "b", "c"} {
+			if _, err := stmt.ExecContext(ctx, name); err != nil {
+				return err
+			}
+		}
+		return errors.New("failed")
+	})
+	if err == nil {
+		t.Fatal("Expected the unit of work to fail")
+	}
+
+	if count := countItems(t, ctx, db); count != 0 {
+		t.Errorf("Expected the prepared inserts to be rolled back, got %d items", count)
+	}
+}
+
+func TestInTransaction(t *testing.T) {
+	if InTransaction(context.Background()) {
+		t.Error("Expected a plain context to carry no transaction")
+	}
+}
@@ -3,6 +3,7 @@ package errors
 import (
 	"fmt"
 	"math/big"
+	"time"
 	// Removed: "vault0/internal/types"
 )
 
@@ -45,12 +46,15 @@ const (
 	ErrCodeTransactionBroadcastFailed = "transaction_broadcast_failed"
 
 	// Keystore errors
-	ErrCodeKeystoreError   = "keystore_error"
-	ErrCodeKeyNotFound     = "key_not_found"
-	ErrCodeKeyExists       = "key_exists"
-	ErrCodeInvalidKey      = "invalid_key"
-	ErrCodeSigningError    = "signing_error"
-	ErrCodeInvalidKeystore = "invalid_keystore"
+	ErrCodeKeystoreError    = "keystore_error"
+	ErrCodeKeyNotFound      = "key_not_found"
+	ErrCodeKeyExists        = "key_exists"
+	ErrCodeInvalidKey       = "invalid_key"
+	ErrCodeSigningError     = "signing_error"
+	ErrCodeInvalidKeystore  = "invalid_keystore"
+	ErrCodeKeyNotExportable = "key_not_exportable"
+	ErrCodeKeyPolicyDenied  = "key_policy_denied"
+	ErrCodeKeyRateLimited   = "key_rate_limited"
 
 	// Wallet errors
 	ErrCodeWalletError         = "wallet_error"
@@ -66,6 +70,10 @@ const (
 	ErrCodeEncryptionError      = "encryption_error"
 	ErrCodeDecryptionError      = "decryption_error"
 	ErrCodeInvalidEncryptionKey = "invalid_encryption_key"
+	ErrCodeInvalidKeystoreFile  = "invalid_keystore_file"
+	ErrCodeInvalidPassphrase    = "invalid_passphrase"
+	ErrCodeInvalidSecretShare   = "invalid_secret_share"
+	ErrCodeInvalidTypedData     = "invalid_typed_data"
 
 	// Block explorer errors
 	ErrCodeExplorerError           = "explorer_error"
@@ -90,6 +98,11 @@ const (
 	ErrCodeInvalidPriceFeedResponse      = "invalid_price_feed_response"
 	ErrCodePriceFeedProviderNotSupported = "price_feed_provider_not_supported"
 
+	// FX rates feed errors
+	ErrCodeFXFeedRequestFailed        = "fx_feed_request_failed"
+	ErrCodeInvalidFXFeedResponse      = "invalid_fx_feed_response"
+	ErrCodeFXFeedProviderNotSupported = "fx_feed_provider_not_supported"
+
 	// Log parsing errors
 	ErrCodeLogTopicIndexOutOfBounds = "log_topic_index_out_of_bounds"
 	ErrCodeLogTopicInvalidFormat    = "log_topic_invalid_format"
@@ -119,6 +132,7 @@ const (
 	// General ABI processing errors (extending existing ErrCodeABIError context)
 	ErrCodeABIParseFailed              = "abi_parse_failed"
 	ErrCodeABIMethodNotFound           = "abi_method_not_found" // Can be used for both by name and by ID
+	ErrCodeABIEventNotFound            = "abi_event_not_found"
 	ErrCodeABIMethodSelectorMismatch   = "abi_method_selector_mismatch"
 	ErrCodeABIInputDataTooShort        = "abi_input_data_too_short"
 	ErrCodeABIInputDataEmpty           = "abi_input_data_empty"
@@ -132,6 +146,9 @@ const (
 
 	// ABI availability errors
 	ErrCodeABIUnavailableOrUnverified = "abi_unavailable_or_unverified"
+
+	// Signature database errors
+	ErrCodeSignatureImportFailed = "signature_import_failed"
 )
 
 // NewConfigurationError creates an error for configuration issues.
@@ -516,6 +533,86 @@ func NewInvalidEncryptionKeyError(key string) *Vault0Error {
 	}
 }
 
+// NewInvalidKeystoreFileError creates a new error for malformed or unsupported keystore files
+func NewInvalidKeystoreFileError(reason string, err error) *Vault0Error {
+	return &Vault0Error{
+		Code:    ErrCodeInvalidKeystoreFile,
+		Message: fmt.Sprintf("Invalid keystore file: %s", reason),
+		Err:     err,
+		Details: map[string]any{
+			"reason": reason,
+		},
+	}
+}
+
+// NewInvalidPassphraseError creates a new error for a passphrase that fails keystore MAC verification
+func NewInvalidPassphraseError() *Vault0Error {
+	return &Vault0Error{
+		Code:    ErrCodeInvalidPassphrase,
+		Message: "Invalid passphrase: could not decrypt keystore",
+	}
+}
+
+// NewInvalidSecretShareError creates a new error for invalid Shamir secret sharing input
+func NewInvalidSecretShareError(reason string) *Vault0Error {
+	return &Vault0Error{
+		Code:    ErrCodeInvalidSecretShare,
+		Message: fmt.Sprintf("Invalid secret share: %s", reason),
+		Details: map[string]any{
+			"reason": reason,
+		},
+	}
+}
+
+// NewInvalidTypedDataError creates a new error for malformed EIP-712 typed data
+func NewInvalidTypedDataError(err error) *Vault0Error {
+	return &Vault0Error{
+		Code:    ErrCodeInvalidTypedData,
+		Message: "Invalid EIP-712 typed data",
+		Err:     err,
+		Details: map[string]any{
+			"error": err.Error(),
+		},
+	}
+}
+
+// NewKeyNotExportableError creates a new error for keys that cannot be exported in the requested format
+func NewKeyNotExportableError(keyID string, reason string) *Vault0Error {
+	return &Vault0Error{
+		Code:    ErrCodeKeyNotExportable,
+		Message: fmt.Sprintf("Key %s cannot be exported: %s", keyID, reason),
+		Details: map[string]any{
+			"key_id": keyID,
+			"reason": reason,
+		},
+	}
+}
+
+// NewKeyPolicyDeniedError creates a new error for operations rejected by a key's usage policy
+func NewKeyPolicyDeniedError(keyID string, reason string) *Vault0Error {
+	return &Vault0Error{
+		Code:    ErrCodeKeyPolicyDenied,
+		Message: fmt.Sprintf("Key %s policy denied the operation: %s", keyID, reason),
+		Details: map[string]any{
+			"key_id": keyID,
+			"reason": reason,
+		},
+	}
+}
+
+// NewKeyRateLimitedError creates a new error for keys that exceeded their signing rate limit
+func NewKeyRateLimitedError(keyID string, limit int, window time.Duration) *Vault0Error {
+	return &Vault0Error{
+		Code:    ErrCodeKeyRateLimited,
+		Message: fmt.Sprintf("Key %s exceeded its rate limit of %d signatures per %s", keyID, limit, window),
+		Details: map[string]any{
+			"key_id": keyID,
+			"limit":  limit,
+			"window": window.String(),
+		},
+	}
+}
+
 // NewResourceNotFoundError creates an error for when a resource is not found
 func NewResourceNotFoundError(resource, id string) *Vault0Error {
 	return &Vault0Error{
@@ -678,6 +775,34 @@ func NewPriceFeedProviderNotSupported(provider string) *Vault0Error {
 	}
 }
 
+// NewFXFeedRequestFailed creates a new error for failed FX rates API requests.
+func NewFXFeedRequestFailed(err error, details string) *Vault0Error {
+	return &Vault0Error{
+		Code:    ErrCodeFXFeedRequestFailed,
+		Message: "FX rates API request failed",
+		Details: map[string]any{"details": details},
+		Err:     err,
+	}
+}
+
+// NewInvalidFXFeedResponse creates a new error for invalid FX rates API responses.
+func NewInvalidFXFeedResponse(err error, details string) *Vault0Error {
+	return &Vault0Error{
+		Code:    ErrCodeInvalidFXFeedResponse,
+		Message: "Invalid FX rates API response",
+		Details: map[string]any{"details": details},
+		Err:     err,
+	}
+}
+
+// NewFXFeedProviderNotSupported creates an error for unsupported FX rates providers.
+func NewFXFeedProviderNotSupported(provider string) *Vault0Error {
+	return &Vault0Error{
+		Code:    ErrCodeFXFeedProviderNotSupported,
+		Message: fmt.Sprintf("FX rates provider '%s' not supported", provider),
+	}
+}
+
 // NewLogTopicIndexOutOfBoundsError creates an error for when a topic index is out of bounds.
 func NewLogTopicIndexOutOfBoundsError(index, count int) *Vault0Error {
 	return &Vault0Error{
@@ -941,6 +1066,29 @@ func NewABIMethodNotFoundError(identifier string, byName bool) *Vault0Error {
 	}
 }
 
+// NewABIEventNotFoundError creates an error when no event in an ABI matches a log topic.
+func NewABIEventNotFoundError(topic string) *Vault0Error {
+	return &Vault0Error{
+		Code:    ErrCodeABIEventNotFound,
+		Message: fmt.Sprintf("Event with topic '%s' not found in ABI", topic),
+		Details: map[string]any{
+			"topic": topic,
+		},
+	}
+}
+
+// NewSignatureImportError creates an error when a signature database file cannot be imported.
+func NewSignatureImportError(source string, err error) *Vault0Error {
+	return &Vault0Error{
+		Code:    ErrCodeSignatureImportFailed,
+		Message: fmt.Sprintf("Failed to import signatures from '%s'", source),
+		Details: map[string]any{
+			"source": source,
+		},
+		Err: err,
+	}
+}
+
 // NewABIMethodSelectorMismatchError creates an error for method selector mismatches.
 func NewABIMethodSelectorMismatchError(methodName string, expectedSelector []byte, actualSelector []byte) *Vault0Error {
 	return &Vault0Error{
@@ -40,9 +40,22 @@ const (
 	ErrCodePriceFeedUpdateFailed = "price_feed_update_failed"
 	ErrCodeDataConversionFailed  = "data_conversion_failed"
 
+	// FX Rate Service Errors
+	ErrCodeFXRateNotFound     = "fx_rate_not_found"
+	ErrCodeFXRateUpdateFailed = "fx_rate_update_failed"
+
 	// Keystore Service Errors
 	ErrCodeKeyInUseByWallet       = "key_in_use_by_wallet"
 	ErrCodeInvalidStateTransition = "invalid_state_transition"
+
+	// Backup Service Errors
+	ErrCodeBackupFailed   = "backup_failed"
+	ErrCodeBackupNotFound = "backup_not_found"
+	ErrCodeInvalidBackup  = "invalid_backup"
+
+	// Webhook Service Errors
+	ErrCodeWebhookNotFound         = "webhook_not_found"
+	ErrCodeWebhookDeliveryNotFound = "webhook_delivery_not_found"
 )
 
 // NewInvalidInputError creates an error for invalid input data with a custom message
@@ -218,11 +231,12 @@ func NewSignerAddressNotFoundError(id int64) *Vault0Error {
 
 // --- Token Price Service Errors ---
 
-// NewTokenPriceNotFoundError creates an error for when token price data is not found.
-func NewTokenPriceNotFoundError(symbol string) *Vault0Error {
+// NewTokenPriceNotFoundError creates an error for when token price data is not found
+// for a symbol or price feed asset ID.
+func NewTokenPriceNotFoundError(identifier string) *Vault0Error {
 	return &Vault0Error{
 		Code:    ErrCodeTokenPriceNotFound,
-		Message: fmt.Sprintf("Token price data not found for symbol: %s", symbol),
+		Message: fmt.Sprintf("Token price data not found for: %s", identifier),
 		Err:     nil,
 	}
 }
@@ -236,6 +250,77 @@ func NewPriceFeedUpdateFailed(err error, reason string) *Vault0Error {
 	}
 }
 
+// NewFXRateNotFoundError creates an error for when no exchange rate is known for a currency.
+func NewFXRateNotFoundError(currency string) *Vault0Error {
+	return &Vault0Error{
+		Code:    ErrCodeFXRateNotFound,
+		Message: fmt.Sprintf("Exchange rate not found for currency: %s", currency),
+		Details: map[string]any{
+			"currency": currency,
+		},
+	}
+}
+
+// NewFXRateUpdateFailed creates an error for failures during the FX rates update process.
+func NewFXRateUpdateFailed(err error, reason string) *Vault0Error {
+	return &Vault0Error{
+		Code:    ErrCodeFXRateUpdateFailed,
+		Message: fmt.Sprintf("Failed to update exchange rates from feed: %s", reason),
+		Err:     err,
+	}
+}
+
+// NewBackupFailedError creates an error for failures while taking or restoring a database backup.
+func NewBackupFailedError(err error, reason string) *Vault0Error {
+	return &Vault0Error{
+		Code:    ErrCodeBackupFailed,
+		Message: fmt.Sprintf("Database backup failed: %s", reason),
+		Err:     err,
+	}
+}
+
+// NewBackupNotFoundError creates an error for when a backup does not exist.
+func NewBackupNotFoundError(name string) *Vault0Error {
+	return &Vault0Error{
+		Code:    ErrCodeBackupNotFound,
+		Message: fmt.Sprintf("Backup not found: %s", name),
+		Details: map[string]any{
+			"name": name,
+		},
+	}
+}
+
+// NewInvalidBackupError creates an error for a backup that fails validation and must not be restored.
+func NewInvalidBackupError(reason string, err error) *Vault0Error {
+	return &Vault0Error{
+		Code:    ErrCodeInvalidBackup,
+		Message: fmt.Sprintf("Invalid backup: %s", reason),
+		Err:     err,
+	}
+}
+
+// NewWebhookNotFoundError creates an error for when a webhook subscription does not exist.
+func NewWebhookNotFoundError(id int64) *Vault0Error {
+	return &Vault0Error{
+		Code:    ErrCodeWebhookNotFound,
+		Message: "Webhook not found",
+		Details: map[string]any{
+			"webhook_id": id,
+		},
+	}
+}
+
+// NewWebhookDeliveryNotFoundError creates an error for when a webhook delivery does not exist.
+func NewWebhookDeliveryNotFoundError(id int64) *Vault0Error {
+	return &Vault0Error{
+		Code:    ErrCodeWebhookDeliveryNotFound,
+		Message: "Webhook delivery not found",
+		Details: map[string]any{
+			"delivery_id": id,
+		},
+	}
+}
+
 // NewKeyInUseByWalletError creates an error for when a key cannot be deleted because it's used by a wallet
 func NewKeyInUseByWalletError(keyID string) *Vault0Error {
 	return &Vault0Error{
@@ -0,0 +1,128 @@
+package abi
+
+import (
+	"context"
+
+	"vault0/internal/core/abi"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/types"
+)
+
+// Service defines the contract ABI registry service interface
+type Service interface {
+	// UploadABI registers an ABI for a contract, replacing any existing one.
+	// When artifact is true, abiJSON is a Hardhat or Truffle artifact and the ABI
+	// is extracted from it; otherwise it must be a plain JSON ABI array.
+	UploadABI(ctx context.Context, chainType types.ChainType, address, name string, abiJSON []byte, artifact bool) (*abi.ContractABI, error)
+
+	// GetABI retrieves the ABI registered for a contract
+	GetABI(ctx context.Context, chainType types.ChainType, address string) (*abi.ContractABI, error)
+
+	// ListABIs retrieves registered ABIs with pagination, optionally filtered by chain
+	ListABIs(ctx context.Context, chainType *types.ChainType, limit int, nextToken string) (*types.Page[*abi.ContractABI], error)
+
+	// DeleteABI removes the ABI registered for a contract
+	DeleteABI(ctx context.Context, chainType types.ChainType, address string) error
+}
+
+// service implements the Service interface
+type service struct {
+	registry abi.ABIRegistry
+	chains   *types.Chains
+	log      logger.Logger
+}
+
+// NewService creates a new contract ABI registry service
+func NewService(registry abi.ABIRegistry, chains *types.Chains, log logger.Logger) Service {
+	return &service{
+		registry: registry,
+		chains:   chains,
+		log:      log,
+	}
+}
+
+// UploadABI implements the Service interface
+func (s *service) UploadABI(ctx context.Context, chainType types.ChainType, address, name string, abiJSON []byte, artifact bool) (*abi.ContractABI, error) {
+	if err := s.validateChain(chainType); err != nil {
+		return nil, err
+	}
+	if address == "" {
+		return nil, errors.NewMissingParameterError("address")
+	}
+	if len(abiJSON) == 0 {
+		return nil, errors.NewMissingParameterError("abi")
+	}
+
+	contractABI := &abi.ContractABI{
+		ChainType: chainType,
+		Address:   address,
+		Name:      name,
+		ABI:       string(abiJSON),
+		Source:    abi.ABISourceUpload,
+	}
+
+	if artifact {
+		extracted, err := abi.ParseArtifactABI(abiJSON)
+		if err != nil {
+			return nil, err
+		}
+		contractABI.ABI = extracted
+		contractABI.Source = abi.ABISourceArtifact
+	}
+
+	if err := s.registry.SaveABI(ctx, contractABI); err != nil {
+		s.log.Error("Failed to register contract ABI",
+			logger.Error(err),
+			logger.String("chain_type", string(chainType)),
+			logger.String("address", address))
+		return nil, err
+	}
+
+	return contractABI, nil
+}
+
+// GetABI implements the Service interface
+func (s *service) GetABI(ctx context.Context, chainType types.ChainType, address string) (*abi.ContractABI, error) {
+	if err := s.validateChain(chainType); err != nil {
+		return nil, err
+	}
+
+	return s.registry.GetABI(ctx, chainType, address)
+}
+
+// ListABIs implements the Service interface
+func (s *service) ListABIs(ctx context.Context, chainType *types.ChainType, limit int, nextToken string) (*types.Page[*abi.ContractABI], error) {
+	if chainType != nil {
+		if err := s.validateChain(*chainType); err != nil {
+			return nil, err
+		}
+	}
+
+	// Set default limit
+	if limit <= 0 {
+		limit = 10
+	}
+
+	return s.registry.ListABIs(ctx, chainType, limit, nextToken)
+}
+
+// DeleteABI implements the Service interface
+func (s *service) DeleteABI(ctx context.Context, chainType types.ChainType, address string) error {
+	if err := s.validateChain(chainType); err != nil {
+		return err
+	}
+
+	return s.registry.DeleteABI(ctx, chainType, address)
+}
+
+// validateChain ensures the chain type is configured
+func (s *service) validateChain(chainType types.ChainType) error {
+	if chainType == "" {
+		return errors.NewMissingParameterError("chain_type")
+	}
+	if _, err := s.chains.Get(chainType); err != nil {
+		return err
+	}
+	return nil
+}
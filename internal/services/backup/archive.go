@@ -0,0 +1,325 @@
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4/source"
+
+	"vault0/internal/config"
+	"vault0/internal/core/crypto"
+	"vault0/internal/db"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+)
+
+// nameTimeFormat formats the creation time in backup names, which sort chronologically
+const nameTimeFormat = "20060102T150405.000Z"
+
+// sqliteJournalSuffixes are the suffixes of the files SQLite keeps next to a database
+var sqliteJournalSuffixes = []string{"-journal", "-wal", "-shm"}
+
+// Verify validates the backup stored in dir without restoring it: the manifest,
+// the checksums of the stored file and of the database, its decryption when it is
+// encrypted, the integrity of the database and its schema version.
+func Verify(ctx context.Context, cfg *config.Config, dir string, log logger.Logger) (*Manifest, error) {
+	manifest, data, err := load(cfg, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// The decrypted database and the journals opening it creates stay in a private directory
+	tmpDir, err := os.MkdirTemp("", "vault0-backup-*")
+	if err != nil {
+		return nil, errors.NewBackupFailedError(err, "failed to create temporary directory")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	tmpPath := filepath.Join(tmpDir, databaseFileName)
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return nil, errors.NewBackupFailedError(err, "failed to write temporary file")
+	}
+
+	if err := checkDatabase(ctx, cfg, manifest, tmpPath, log); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Restore validates the backup stored in dir and replaces the configured SQLite
+// database with it. The current database is kept next to it with a .pre-restore
+// suffix. The server must be stopped while the database is restored.
+func Restore(ctx context.Context, cfg *config.Config, dir string, log logger.Logger) (*Manifest, error) {
+	dialect, err := db.ParseDialect(cfg.DBDriver)
+	if err != nil {
+		return nil, err
+	}
+	if dialect != db.DialectSQLite {
+		return nil, errors.NewNotImplementedError("restore of PostgreSQL databases, use pg_restore instead")
+	}
+
+	manifest, data, err := load(cfg, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stage the database next to its destination so that it is moved in place atomically
+	tmpPath := cfg.DBPath + ".restore"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return nil, errors.NewBackupFailedError(err, "failed to stage the restored database")
+	}
+	if err := checkDatabase(ctx, cfg, manifest, tmpPath, log); err != nil {
+		os.Remove(tmpPath)
+		return nil, err
+	}
+
+	if _, err := os.Stat(cfg.DBPath); err == nil {
+		previous := fmt.Sprintf("%s.pre-restore-%s", cfg.DBPath, time.Now().UTC().Format(nameTimeFormat))
+		// Journals belong to the database they were written for and move along with it
+		for _, suffix := range append([]string{""}, sqliteJournalSuffixes...) {
+			if err := os.Rename(cfg.DBPath+suffix, previous+suffix); err != nil && !os.IsNotExist(err) {
+				os.Remove(tmpPath)
+				return nil, errors.NewBackupFailedError(err, "failed to move the current database aside")
+			}
+		}
+		log.Info("Moved the current database aside", logger.String("path", previous))
+	}
+
+	if err := os.Rename(tmpPath, cfg.DBPath); err != nil {
+		return nil, errors.NewBackupFailedError(err, "failed to move the restored database in place")
+	}
+
+	log.Info("Database restored",
+		logger.String("backup", dir),
+		logger.Int64("schema_version", int64(manifest.SchemaVersion)))
+	return manifest, nil
+}
+
+// create takes a backup of the database into dir, which must not exist yet. The
+// snapshot is encrypted when an encryptor is given.
+func create(ctx context.Context, database *db.DB, dir string, encryptor *crypto.AESEncryptor, log logger.Logger) (manifest *Manifest, err error) {
+	createdAt := time.Now().UTC()
+
+	if err := os.MkdirAll(filepath.Dir(dir), 0o700); err != nil {
+		return nil, errors.NewBackupFailedError(err, "failed to create the backup path")
+	}
+	if err := os.Mkdir(dir, 0o700); err != nil {
+		return nil, errors.NewBackupFailedError(err, "failed to create the backup directory")
+	}
+	// Leave no partial backup behind
+	defer func() {
+		if err != nil {
+			os.RemoveAll(dir)
+		}
+	}()
+
+	snapshotPath := filepath.Join(dir, databaseFileName)
+	if encryptor != nil {
+		// Keep the unencrypted snapshot out of the backup path, only its encrypted copy is stored there
+		tmpDir, err := os.MkdirTemp("", "vault0-backup-*")
+		if err != nil {
+			return nil, errors.NewBackupFailedError(err, "failed to create temporary directory")
+		}
+		defer os.RemoveAll(tmpDir)
+		snapshotPath = filepath.Join(tmpDir, databaseFileName)
+	}
+	if err := database.Backup(ctx, snapshotPath); err != nil {
+		return nil, err
+	}
+
+	schemaVersion, err := inspectDatabase(ctx, snapshotPath, log)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(snapshotPath)
+	if err != nil {
+		return nil, errors.NewBackupFailedError(err, "failed to read the snapshot")
+	}
+
+	manifest = &Manifest{
+		FormatVersion:  ManifestFormatVersion,
+		CreatedAt:      createdAt,
+		Driver:         string(db.DialectSQLite),
+		SchemaVersion:  schemaVersion,
+		File:           databaseFileName,
+		DatabaseSize:   int64(len(data)),
+		DatabaseSHA256: checksum(data),
+	}
+
+	stored := data
+	if encryptor != nil {
+		stored, err = encryptor.Encrypt(data)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Encrypted = true
+		manifest.File = databaseFileName + encryptedFileSuffix
+
+		if err := os.WriteFile(filepath.Join(dir, manifest.File), stored, 0o600); err != nil {
+			return nil, errors.NewBackupFailedError(err, "failed to write the encrypted snapshot")
+		}
+	} else if err := os.Chmod(snapshotPath, 0o600); err != nil {
+		return nil, errors.NewBackupFailedError(err, "failed to restrict access to the snapshot")
+	}
+	manifest.FileSize = int64(len(stored))
+	manifest.FileSHA256 = checksum(stored)
+
+	if err := writeManifest(dir, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// load reads the manifest and the database of the backup stored in dir, verifying
+// the checksums and decrypting the database when needed
+func load(cfg *config.Config, dir string) (*Manifest, []byte, error) {
+	manifest, err := readManifest(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if manifest.FormatVersion != ManifestFormatVersion {
+		return nil, nil, errors.NewInvalidBackupError(fmt.Sprintf("unsupported format version %d", manifest.FormatVersion), nil)
+	}
+	if manifest.Driver != string(db.DialectSQLite) {
+		return nil, nil, errors.NewInvalidBackupError(fmt.Sprintf("unsupported database driver %q", manifest.Driver), nil)
+	}
+
+	// The manifest names a file within the backup directory only
+	data, err := os.ReadFile(filepath.Join(dir, filepath.Base(manifest.File)))
+	if err != nil {
+		return nil, nil, errors.NewInvalidBackupError("failed to read the database file", err)
+	}
+	if int64(len(data)) != manifest.FileSize || checksum(data) != manifest.FileSHA256 {
+		return nil, nil, errors.NewInvalidBackupError("checksum mismatch of the stored file", nil)
+	}
+
+	if manifest.Encrypted {
+		encryptor, err := newEncryptor(cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		if data, err = encryptor.Decrypt(data); err != nil {
+			return nil, nil, errors.NewInvalidBackupError("failed to decrypt the database with the DB encryption key", err)
+		}
+	}
+	if int64(len(data)) != manifest.DatabaseSize || checksum(data) != manifest.DatabaseSHA256 {
+		return nil, nil, errors.NewInvalidBackupError("checksum mismatch of the database", nil)
+	}
+
+	return manifest, data, nil
+}
+
+// checkDatabase verifies the integrity of the database file of a backup and that
+// its schema is the one recorded in the manifest and known to this release
+func checkDatabase(ctx context.Context, cfg *config.Config, manifest *Manifest, path string, log logger.Logger) error {
+	schemaVersion, err := inspectDatabase(ctx, path, log)
+	if err != nil {
+		return errors.NewInvalidBackupError("the database is corrupt", err)
+	}
+	if schemaVersion != manifest.SchemaVersion {
+		return errors.NewInvalidBackupError(fmt.Sprintf("schema version %d does not match the manifest version %d", schemaVersion, manifest.SchemaVersion), nil)
+	}
+
+	latest, err := latestMigrationVersion(cfg.MigrationsPath)
+	if err != nil {
+		return err
+	}
+	if schemaVersion > latest {
+		return errors.NewInvalidBackupError(fmt.Sprintf("schema version %d is newer than the latest migration %d of this release", schemaVersion, latest), nil)
+	}
+	return nil
+}
+
+// inspectDatabase checks the integrity of a SQLite database file and returns its schema version
+func inspectDatabase(ctx context.Context, path string, log logger.Logger) (uint, error) {
+	database, err := db.NewDatabase(&config.Config{DBPath: path}, nil, log)
+	if err != nil {
+		return 0, err
+	}
+	defer database.Close()
+
+	if err := database.CheckIntegrity(ctx); err != nil {
+		return 0, err
+	}
+
+	version, dirty, err := database.SchemaVersion(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if dirty {
+		return 0, errors.NewInvalidBackupError(fmt.Sprintf("migration %d was not applied completely", version), nil)
+	}
+	return version, nil
+}
+
+// latestMigrationVersion returns the version of the latest SQLite migration in the migrations path
+func latestMigrationVersion(path string) (uint, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return 0, errors.NewConfigurationError(fmt.Sprintf("failed to read the migrations path %s: %v", path, err))
+	}
+
+	var latest uint
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		migration, err := source.DefaultParse(entry.Name())
+		if err != nil {
+			continue
+		}
+		latest = max(latest, migration.Version)
+	}
+	return latest, nil
+}
+
+// readManifest reads the manifest of the backup stored in dir
+func readManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.NewBackupNotFoundError(filepath.Base(dir))
+		}
+		return nil, errors.NewInvalidBackupError("failed to read the manifest", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, errors.NewInvalidBackupError("malformed manifest", err)
+	}
+	return &manifest, nil
+}
+
+// writeManifest writes the manifest of the backup stored in dir
+func writeManifest(dir string, manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return errors.NewBackupFailedError(err, "failed to encode the manifest")
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestFileName), data, 0o600); err != nil {
+		return errors.NewBackupFailedError(err, "failed to write the manifest")
+	}
+	return nil
+}
+
+// newEncryptor creates the encryptor of backups from the DB encryption key
+func newEncryptor(cfg *config.Config) (*crypto.AESEncryptor, error) {
+	if cfg.DBEncryptionKey == "" {
+		return nil, errors.NewConfigurationError("db_encryption_key is required to encrypt or decrypt backups")
+	}
+	return crypto.NewAESEncryptorFromBase64(cfg.DBEncryptionKey)
+}
+
+// checksum returns the hex-encoded SHA-256 checksum of data
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
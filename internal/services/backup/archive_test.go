@@ -0,0 +1,276 @@
+package backup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/config"
+	"vault0/internal/core/crypto"
+	"vault0/internal/db"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/testing/dbtest"
+)
+
+// newTestConfig returns the configuration of a migrated SQLite database, backed up
+// into a temporary directory and encrypted with a fresh key when encrypt is set
+func newTestConfig(t *testing.T, database *db.DB, encrypt bool) *config.Config {
+	t.Helper()
+
+	key, err := crypto.GenerateEncryptionKeyBase64(32)
+	require.NoError(t, err)
+
+	cfg := *database.Config
+	cfg.DBEncryptionKey = key
+	cfg.Backup = config.BackupConfig{Path: t.TempDir(), Encrypt: encrypt}
+	return &cfg
+}
+
+// createTestBackup takes a backup of a freshly migrated database
+func createTestBackup(t *testing.T, encrypt bool) (*config.Config, *Backup) {
+	t.Helper()
+
+	database := dbtest.New(t, db.DialectSQLite)
+	cfg := newTestConfig(t, database, encrypt)
+
+	backup, err := NewService(database, cfg, logger.NewNopLogger()).CreateBackup(context.Background())
+	require.NoError(t, err)
+	return cfg, backup
+}
+
+// updateManifest rewrites the manifest of a backup after applying change to it
+func updateManifest(t *testing.T, dir string, change func(manifest *Manifest)) {
+	t.Helper()
+
+	manifest, err := readManifest(dir)
+	require.NoError(t, err)
+	change(manifest)
+	require.NoError(t, writeManifest(dir, manifest))
+}
+
+// backupFiles returns the names of the files stored in a backup directory
+func backupFiles(t *testing.T, dir string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name()
+	}
+	return names
+}
+
+func TestCreateBackup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unencrypted", func(t *testing.T) {
+		cfg, backup := createTestBackup(t, false)
+		assert.False(t, backup.Manifest.Encrypted)
+		assert.Equal(t, backup.Manifest.DatabaseSHA256, backup.Manifest.FileSHA256)
+		assert.ElementsMatch(t, []string{ManifestFileName, databaseFileName}, backupFiles(t, backup.Path))
+
+		manifest, err := Verify(ctx, cfg, backup.Path, logger.NewNopLogger())
+		require.NoError(t, err)
+		assert.NotZero(t, manifest.SchemaVersion)
+	})
+
+	t.Run("encrypted", func(t *testing.T) {
+		cfg, backup := createTestBackup(t, true)
+		assert.True(t, backup.Manifest.Encrypted)
+		assert.NotEqual(t, backup.Manifest.DatabaseSHA256, backup.Manifest.FileSHA256)
+		// The unencrypted snapshot is never written to the backup path
+		assert.ElementsMatch(t, []string{ManifestFileName, databaseFileName + encryptedFileSuffix}, backupFiles(t, backup.Path))
+
+		_, err := Verify(ctx, cfg, backup.Path, logger.NewNopLogger())
+		require.NoError(t, err)
+	})
+}
+
+func TestVerify_Manifest(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		change func(dir string)
+		code   string
+	}{
+		{
+			name:   "missing manifest",
+			change: func(dir string) { os.Remove(filepath.Join(dir, ManifestFileName)) },
+			code:   errors.ErrCodeBackupNotFound,
+		},
+		{
+			name:   "malformed manifest",
+			change: func(dir string) { os.WriteFile(filepath.Join(dir, ManifestFileName), []byte("{"), 0o600) },
+			code:   errors.ErrCodeInvalidBackup,
+		},
+		{
+			name: "unsupported format version",
+			change: func(dir string) {
+				updateManifest(t, dir, func(manifest *Manifest) { manifest.FormatVersion = ManifestFormatVersion + 1 })
+			},
+			code: errors.ErrCodeInvalidBackup,
+		},
+		{
+			name: "unsupported driver",
+			change: func(dir string) {
+				updateManifest(t, dir, func(manifest *Manifest) { manifest.Driver = string(db.DialectPostgres) })
+			},
+			code: errors.ErrCodeInvalidBackup,
+		},
+		{
+			name: "missing database file",
+			change: func(dir string) {
+				updateManifest(t, dir, func(manifest *Manifest) { manifest.File = "missing.db" })
+			},
+			code: errors.ErrCodeInvalidBackup,
+		},
+		{
+			name: "schema version mismatch",
+			change: func(dir string) {
+				updateManifest(t, dir, func(manifest *Manifest) { manifest.SchemaVersion++ })
+			},
+			code: errors.ErrCodeInvalidBackup,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, backup := createTestBackup(t, false)
+			tt.change(backup.Path)
+
+			_, err := Verify(ctx, cfg, backup.Path, logger.NewNopLogger())
+			assert.True(t, errors.IsError(err, tt.code), "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestVerify_ChecksumMismatch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stored file", func(t *testing.T) {
+		cfg, backup := createTestBackup(t, true)
+		path := filepath.Join(backup.Path, backup.Manifest.File)
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		data[len(data)-1] ^= 0xff
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+
+		_, err = Verify(ctx, cfg, backup.Path, logger.NewNopLogger())
+		require.True(t, errors.IsError(err, errors.ErrCodeInvalidBackup))
+		assert.Contains(t, err.Error(), "checksum mismatch of the stored file")
+	})
+
+	t.Run("database", func(t *testing.T) {
+		cfg, backup := createTestBackup(t, true)
+		updateManifest(t, backup.Path, func(manifest *Manifest) { manifest.DatabaseSHA256 = checksum([]byte("other")) })
+
+		_, err := Verify(ctx, cfg, backup.Path, logger.NewNopLogger())
+		require.True(t, errors.IsError(err, errors.ErrCodeInvalidBackup))
+		assert.Contains(t, err.Error(), "checksum mismatch of the database")
+	})
+}
+
+func TestVerify_DecryptionFailure(t *testing.T) {
+	cfg, backup := createTestBackup(t, true)
+
+	// A backup can only be decrypted with the key it was encrypted with
+	otherKey, err := crypto.GenerateEncryptionKeyBase64(32)
+	require.NoError(t, err)
+	cfg.DBEncryptionKey = otherKey
+
+	_, err = Verify(context.Background(), cfg, backup.Path, logger.NewNopLogger())
+	require.True(t, errors.IsError(err, errors.ErrCodeInvalidBackup))
+	assert.Contains(t, err.Error(), "failed to decrypt")
+
+	cfg.DBEncryptionKey = ""
+	_, err = Verify(context.Background(), cfg, backup.Path, logger.NewNopLogger())
+	assert.True(t, errors.IsError(err, errors.ErrCodeConfiguration))
+}
+
+func TestVerify_TemporaryFiles(t *testing.T) {
+	cfg, backup := createTestBackup(t, true)
+
+	// The decrypted database is checked in a private directory removed afterwards
+	tmpDir := t.TempDir()
+	t.Setenv("TMPDIR", tmpDir)
+
+	_, err := Verify(context.Background(), cfg, backup.Path, logger.NewNopLogger())
+	require.NoError(t, err)
+	assert.Empty(t, backupFiles(t, tmpDir))
+}
+
+func TestVerify_NewerSchemaVersion(t *testing.T) {
+	cfg, backup := createTestBackup(t, false)
+
+	// A release knowing only the first migration can't restore a later schema
+	migrations := t.TempDir()
+	for _, name := range []string{"000001_init.up.sql", "000001_init.down.sql"} {
+		require.NoError(t, os.WriteFile(filepath.Join(migrations, name), nil, 0o600))
+	}
+	cfg.MigrationsPath = migrations
+
+	_, err := Verify(context.Background(), cfg, backup.Path, logger.NewNopLogger())
+	require.True(t, errors.IsError(err, errors.ErrCodeInvalidBackup))
+	assert.Contains(t, err.Error(), "newer than the latest migration")
+}
+
+func TestRestore(t *testing.T) {
+	ctx := context.Background()
+	cfg, backup := createTestBackup(t, true)
+
+	// The current database and its journals are moved aside together
+	cfg.DBPath = filepath.Join(t.TempDir(), "vault0.db")
+	require.NoError(t, os.WriteFile(cfg.DBPath, []byte("current"), 0o600))
+	require.NoError(t, os.WriteFile(cfg.DBPath+"-wal", []byte("wal"), 0o600))
+
+	manifest, err := Restore(ctx, cfg, backup.Path, logger.NewNopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, backup.Manifest.SchemaVersion, manifest.SchemaVersion)
+
+	previous, err := filepath.Glob(cfg.DBPath + ".pre-restore-*")
+	require.NoError(t, err)
+	require.Len(t, previous, 2)
+	data, err := os.ReadFile(previous[0])
+	require.NoError(t, err)
+	assert.Equal(t, "current", string(data))
+	data, err = os.ReadFile(previous[1])
+	require.NoError(t, err)
+	assert.Equal(t, previous[0]+"-wal", previous[1])
+	assert.Equal(t, "wal", string(data))
+
+	assert.NoFileExists(t, cfg.DBPath+"-wal")
+	assert.NoFileExists(t, cfg.DBPath+".restore")
+
+	schemaVersion, err := inspectDatabase(ctx, cfg.DBPath, logger.NewNopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, manifest.SchemaVersion, schemaVersion)
+}
+
+func TestRestore_InvalidBackup(t *testing.T) {
+	ctx := context.Background()
+	cfg, backup := createTestBackup(t, false)
+	updateManifest(t, backup.Path, func(manifest *Manifest) { manifest.SchemaVersion++ })
+
+	// A backup failing validation leaves the current database in place
+	cfg.DBPath = filepath.Join(t.TempDir(), "vault0.db")
+	require.NoError(t, os.WriteFile(cfg.DBPath, []byte("current"), 0o600))
+
+	_, err := Restore(ctx, cfg, backup.Path, logger.NewNopLogger())
+	assert.True(t, errors.IsError(err, errors.ErrCodeInvalidBackup))
+
+	data, err := os.ReadFile(cfg.DBPath)
+	require.NoError(t, err)
+	assert.Equal(t, "current", string(data))
+	assert.NoFileExists(t, cfg.DBPath+".restore")
+
+	cfg.DBDriver = string(db.DialectPostgres)
+	_, err = Restore(ctx, cfg, backup.Path, logger.NewNopLogger())
+	assert.True(t, errors.IsError(err, errors.ErrCodeNotImplemented))
+}
@@ -0,0 +1,40 @@
+package backup
+
+import "time"
+
+const (
+	// ManifestFileName is the name of the manifest file within a backup directory.
+	// A backup is complete once its manifest has been written.
+	ManifestFileName = "manifest.json"
+
+	// ManifestFormatVersion is the version of the backup layout described by the manifest
+	ManifestFormatVersion = 1
+
+	// databaseFileName is the name of the database snapshot within a backup directory
+	databaseFileName = "vault0.db"
+
+	// encryptedFileSuffix is appended to the snapshot file name when it is encrypted
+	encryptedFileSuffix = ".enc"
+)
+
+// Manifest describes the contents of a backup and allows it to be validated before
+// it is restored.
+type Manifest struct {
+	FormatVersion  int       `json:"format_version"`  // Version of the backup layout
+	CreatedAt      time.Time `json:"created_at"`      // Time the snapshot was taken
+	Driver         string    `json:"driver"`          // Database backend the snapshot was taken from
+	SchemaVersion  uint      `json:"schema_version"`  // Version of the last migration applied to the database
+	Encrypted      bool      `json:"encrypted"`       // Whether the file is encrypted with the DB encryption key
+	File           string    `json:"file"`            // Name of the stored snapshot file within the backup directory
+	FileSize       int64     `json:"file_size"`       // Size of the stored file in bytes
+	FileSHA256     string    `json:"file_sha256"`     // Hex-encoded SHA-256 checksum of the stored file
+	DatabaseSize   int64     `json:"database_size"`   // Size of the database in bytes
+	DatabaseSHA256 string    `json:"database_sha256"` // Hex-encoded SHA-256 checksum of the database
+}
+
+// Backup represents a backup stored in the backup directory
+type Backup struct {
+	Name     string    // Name of the backup directory
+	Path     string    // Path of the backup directory
+	Manifest *Manifest // Manifest describing the backup
+}
@@ -0,0 +1,181 @@
+package backup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"vault0/internal/config"
+	"vault0/internal/core/crypto"
+	"vault0/internal/db"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+)
+
+// Service defines the interface for database backup operations.
+type Service interface {
+	// CreateBackup takes a consistent online backup of the database into a new
+	// directory under the configured backup path, encrypted with the DB encryption
+	// key when backup encryption is enabled.
+	//
+	// Returns:
+	//   - ErrNotImplemented if the database is not SQLite
+	//   - ErrBackupFailed if the backup could not be written
+	CreateBackup(ctx context.Context) (*Backup, error)
+
+	// ListBackups retrieves the backups stored under the backup path, newest first.
+	ListBackups(ctx context.Context) ([]*Backup, error)
+
+	// GetBackup retrieves a backup by name.
+	//
+	// Returns:
+	//   - ErrBackupNotFound if no backup exists with the name
+	GetBackup(ctx context.Context, name string) (*Backup, error)
+
+	// VerifyBackup validates the checksums, decryption, integrity and schema version
+	// of a backup without restoring it.
+	//
+	// Returns:
+	//   - ErrBackupNotFound if no backup exists with the name
+	//   - ErrInvalidBackup if the backup fails validation
+	VerifyBackup(ctx context.Context, name string) (*Backup, error)
+}
+
+type service struct {
+	db  *db.DB
+	cfg *config.Config
+	log logger.Logger
+	// mu serializes backups, each of which holds the database read lock while it is taken
+	mu sync.Mutex
+}
+
+// NewService creates a new backup service instance.
+func NewService(db *db.DB, cfg *config.Config, log logger.Logger) Service {
+	return &service{
+		db:  db,
+		cfg: cfg,
+		log: log.With(logger.String("service", "backup")),
+	}
+}
+
+// CreateBackup implements the Service interface.
+func (s *service) CreateBackup(ctx context.Context) (*Backup, error) {
+	root, err := s.backupPath()
+	if err != nil {
+		return nil, err
+	}
+
+	var encryptor *crypto.AESEncryptor
+	if s.cfg.Backup.Encrypt {
+		if encryptor, err = newEncryptor(s.cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := "vault0-" + time.Now().UTC().Format(nameTimeFormat)
+	dir := filepath.Join(root, name)
+
+	start := time.Now()
+	manifest, err := create(ctx, s.db, dir, encryptor, s.log)
+	if err != nil {
+		s.log.Error("Failed to create backup", logger.String("path", dir), logger.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("Created backup",
+		logger.String("path", dir),
+		logger.Bool("encrypted", manifest.Encrypted),
+		logger.Int64("size", manifest.DatabaseSize),
+		logger.Duration("duration", time.Since(start)))
+
+	return &Backup{Name: name, Path: dir, Manifest: manifest}, nil
+}
+
+// ListBackups implements the Service interface.
+func (s *service) ListBackups(ctx context.Context) ([]*Backup, error) {
+	root, err := s.backupPath()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*Backup{}, nil
+		}
+		return nil, errors.NewBackupFailedError(err, "failed to read the backup path")
+	}
+
+	backups := make([]*Backup, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		dir := filepath.Join(root, entry.Name())
+		manifest, err := readManifest(dir)
+		if err != nil {
+			// Incomplete backups have no manifest yet
+			continue
+		}
+		backups = append(backups, &Backup{Name: entry.Name(), Path: dir, Manifest: manifest})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].Manifest.CreatedAt.After(backups[j].Manifest.CreatedAt)
+	})
+	return backups, nil
+}
+
+// GetBackup implements the Service interface.
+func (s *service) GetBackup(ctx context.Context, name string) (*Backup, error) {
+	dir, err := s.backupDir(name)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := readManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Backup{Name: name, Path: dir, Manifest: manifest}, nil
+}
+
+// VerifyBackup implements the Service interface.
+func (s *service) VerifyBackup(ctx context.Context, name string) (*Backup, error) {
+	dir, err := s.backupDir(name)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := Verify(ctx, s.cfg, dir, s.log)
+	if err != nil {
+		return nil, err
+	}
+	return &Backup{Name: name, Path: dir, Manifest: manifest}, nil
+}
+
+// backupPath returns the configured directory backups are written to
+func (s *service) backupPath() (string, error) {
+	if s.cfg.Backup.Path == "" {
+		return "", errors.NewConfigurationError("backup.path is required to take backups")
+	}
+	return s.cfg.Backup.Path, nil
+}
+
+// backupDir returns the directory of a backup, rejecting names that point outside the backup path
+func (s *service) backupDir(name string) (string, error) {
+	root, err := s.backupPath()
+	if err != nil {
+		return "", err
+	}
+	if name == "" || name == "." || name == ".." || filepath.Base(name) != name {
+		return "", errors.NewBackupNotFoundError(name)
+	}
+	return filepath.Join(root, name), nil
+}
@@ -0,0 +1,25 @@
+package fxrate
+
+import "time"
+
+// FXRate represents the USD exchange rate of a fiat currency at a point in time.
+type FXRate struct {
+	Currency  string    `db:"currency"`   // ISO 4217 currency code (e.g., "EUR")
+	Rate      float64   `db:"rate"`       // Amount of the currency one US dollar buys
+	AsOf      time.Time `db:"as_of"`      // Time the rate was published by the provider
+	Source    string    `db:"source"`     // Provider the rate comes from
+	CreatedAt time.Time `db:"created_at"` // Timestamp when the rate was stored
+}
+
+// Convert converts a USD amount to the currency of the rate
+func (r *FXRate) Convert(amountUSD float64) float64 {
+	return amountUSD * r.Rate
+}
+
+// FXRateHistoryFilter defines criteria for filtering the rate history of a currency
+type FXRateHistoryFilter struct {
+	// From is an optional inclusive lower bound of the publication time
+	From *time.Time
+	// To is an optional inclusive upper bound of the publication time
+	To *time.Time
+}
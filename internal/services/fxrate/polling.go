@@ -0,0 +1,130 @@
+package fxrate
+
+import (
+	"context"
+	"time"
+
+	"vault0/internal/config"
+	"vault0/internal/core/fxfeed"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+)
+
+type RatePollingService interface {
+	// RefreshRates fetches the latest exchange rates from the configured external
+	// provider and stores them alongside the previously fetched ones.
+	//
+	// Returns:
+	//   - The number of rates stored.
+	//   - An error if fetching or storing fails (e.g., ErrFXRateUpdateFailed).
+	RefreshRates(ctx context.Context) (int64, error)
+
+	// StartRatePolling starts a background scheduler that periodically refreshes
+	// exchange rates at an interval specified in the configuration.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation, used to cancel the job
+	StartRatePolling(ctx context.Context)
+
+	// StopRatePolling stops the rate update scheduler
+	StopRatePolling()
+}
+
+type pollingService struct {
+	repository Repository
+	provider   fxfeed.FXFeed
+	log        logger.Logger
+	config     *config.Config
+
+	jobCtx    context.Context
+	jobCancel context.CancelFunc
+}
+
+func NewPollingService(repo Repository, provider fxfeed.FXFeed, log logger.Logger, cfg *config.Config) RatePollingService {
+	return &pollingService{
+		repository: repo,
+		provider:   provider,
+		log:        log.With(logger.String("service", "fxrate_polling")),
+		config:     cfg,
+	}
+}
+
+// StartRatePolling starts a background scheduler that periodically refreshes exchange rates
+func (s *pollingService) StartRatePolling(ctx context.Context) {
+	// Get interval from config with fallback to default
+	interval := 3600 // Default to 1 hour if not specified
+	if s.config.FX.RefreshInterval > 0 {
+		interval = s.config.FX.RefreshInterval
+	}
+
+	s.jobCtx, s.jobCancel = context.WithCancel(ctx)
+
+	s.log.Info("Starting exchange rate update scheduler",
+		logger.Int("interval_seconds", interval))
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(interval) * time.Second)
+		defer ticker.Stop()
+
+		// Immediately run once at startup
+		if _, err := s.RefreshRates(s.jobCtx); err != nil {
+			s.log.Error("Initial exchange rate update failed", logger.Error(err))
+		}
+
+		for {
+			select {
+			case <-s.jobCtx.Done():
+				s.log.Info("Exchange rate update scheduler stopped")
+				return
+			case <-ticker.C:
+				if _, err := s.RefreshRates(s.jobCtx); err != nil {
+					s.log.Error("Scheduled exchange rate update failed", logger.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// StopRatePolling stops the rate update scheduler
+func (s *pollingService) StopRatePolling() {
+	if s.jobCancel != nil {
+		s.jobCancel()
+		s.jobCancel = nil
+		s.log.Info("Exchange rate update scheduler stopped")
+	}
+}
+
+// RefreshRates implements the RatePollingService interface.
+func (s *pollingService) RefreshRates(ctx context.Context) (int64, error) {
+	data, err := s.provider.GetRates(ctx)
+	if err != nil {
+		return 0, errors.NewFXRateUpdateFailed(err, "failed to fetch data from provider")
+	}
+
+	rates := make([]*FXRate, 0, len(data.Rates))
+	for currency, rate := range data.Rates {
+		rates = append(rates, &FXRate{
+			Currency: currency,
+			Rate:     rate,
+			AsOf:     data.AsOf,
+			Source:   data.Source,
+		})
+	}
+
+	if len(rates) == 0 {
+		s.log.Warn("No exchange rates received from provider")
+		return 0, nil
+	}
+
+	stored, err := s.repository.UpsertMany(ctx, rates)
+	if err != nil {
+		return 0, errors.NewFXRateUpdateFailed(err, "failed to store rates")
+	}
+
+	s.log.Info("Successfully refreshed exchange rates",
+		logger.Int("total_fetched", len(rates)),
+		logger.Int64("stored", stored),
+		logger.Time("as_of", data.AsOf))
+
+	return stored, nil
+}
@@ -0,0 +1,233 @@
+package fxrate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"vault0/internal/db"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/types"
+)
+
+// Repository defines the interface for data access operations related to exchange rates.
+type Repository interface {
+	// UpsertMany stores exchange rates, replacing the rate of a currency already stored
+	// for the same publication time.
+	//
+	// Returns:
+	//   - The number of rates stored.
+	//   - An error if the database operation fails.
+	UpsertMany(ctx context.Context, rates []*FXRate) (int64, error)
+
+	// GetLatest retrieves the most recently published rate of a currency.
+	//
+	// Returns:
+	//   - ErrFXRateNotFound if no rate exists for the currency.
+	GetLatest(ctx context.Context, currency string) (*FXRate, error)
+
+	// GetAt retrieves the rate of a currency in effect at the given time, which is
+	// the latest rate published at or before it.
+	//
+	// Returns:
+	//   - ErrFXRateNotFound if no rate was published for the currency by then.
+	GetAt(ctx context.Context, currency string, at time.Time) (*FXRate, error)
+
+	// ListLatest retrieves the most recently published rate of every stored currency,
+	// ordered by currency.
+	ListLatest(ctx context.Context) ([]*FXRate, error)
+
+	// ListHistory retrieves the rates of a currency from the most to the least recent.
+	// Uses token-based pagination for consistent results.
+	//
+	// Parameters:
+	//   - ctx: The context for the operation
+	//   - currency: The currency to list the rates of
+	//   - filter: Optional filtering criteria
+	//   - limit: Maximum number of items to return (0 for all items)
+	//   - nextToken: Token for pagination (empty string for first page)
+	ListHistory(ctx context.Context, currency string, filter *FXRateHistoryFilter, limit int, nextToken string) (*types.Page[*FXRate], error)
+}
+
+// repository implements Repository interface for SQLite
+type repository struct {
+	db        *db.DB
+	log       logger.Logger
+	structMap *sqlbuilder.Struct
+}
+
+// NewRepository creates a new SQLite repository for exchange rates
+func NewRepository(db *db.DB, log logger.Logger) Repository {
+	return &repository{
+		db:        db,
+		log:       log,
+		structMap: sqlbuilder.NewStruct(new(FXRate)),
+	}
+}
+
+// executeFXRateQuery executes a query and scans the results into FXRate objects
+func (r *repository) executeFXRateQuery(ctx context.Context, sql string, args ...any) ([]*FXRate, error) {
+	rows, err := r.db.ExecuteQueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rates []*FXRate
+	for rows.Next() {
+		var rate FXRate
+		if err := rows.Scan(
+			&rate.Currency,
+			&rate.Rate,
+			&rate.AsOf,
+			&rate.Source,
+			&rate.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		rates = append(rates, &rate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+// UpsertMany stores exchange rates in a single transaction
+func (r *repository) UpsertMany(ctx context.Context, rates []*FXRate) (int64, error) {
+	if len(rates) == 0 {
+		return 0, nil
+	}
+
+	upsertSQL := `INSERT INTO fx_rates (currency, rate, as_of, source, created_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (currency, as_of) DO UPDATE SET rate = excluded.rate, source = excluded.source`
+
+	var stored int64
+	err := r.db.WithTransaction(ctx, func(ctx context.Context) error {
+		stmt, err := r.db.PrepareContext(ctx, upsertSQL)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		now := time.Now().UTC()
+		for _, rate := range rates {
+			rate.AsOf = rate.AsOf.UTC().Truncate(time.Second)
+			rate.CreatedAt = now
+			if _, err := stmt.ExecContext(ctx, rate.Currency, rate.Rate, rate.AsOf, rate.Source, rate.CreatedAt); err != nil {
+				r.log.Error("Failed to store exchange rate",
+					logger.String("currency", rate.Currency),
+					logger.Error(err))
+				continue // Skip this one but continue with others
+			}
+			stored++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return stored, nil
+}
+
+// GetLatest retrieves the most recently published rate of a currency
+func (r *repository) GetLatest(ctx context.Context, currency string) (*FXRate, error) {
+	sb := r.structMap.SelectFrom("fx_rates")
+	sb.Where(sb.Equal("currency", currency))
+	sb.OrderBy("as_of DESC")
+	sb.Limit(1)
+
+	return r.getOne(ctx, sb, currency)
+}
+
+// GetAt retrieves the rate of a currency in effect at the given time
+func (r *repository) GetAt(ctx context.Context, currency string, at time.Time) (*FXRate, error) {
+	sb := r.structMap.SelectFrom("fx_rates")
+	sb.Where(
+		sb.Equal("currency", currency),
+		sb.LessEqualThan("as_of", at.UTC()),
+	)
+	sb.OrderBy("as_of DESC")
+	sb.Limit(1)
+
+	return r.getOne(ctx, sb, currency)
+}
+
+// getOne executes a query expected to return a single rate
+func (r *repository) getOne(ctx context.Context, sb *sqlbuilder.SelectBuilder, currency string) (*FXRate, error) {
+	sql, args := sb.Build()
+	rates, err := r.executeFXRateQuery(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rates) == 0 {
+		return nil, errors.NewFXRateNotFoundError(currency)
+	}
+
+	return rates[0], nil
+}
+
+// ListLatest retrieves the most recently published rate of every stored currency
+func (r *repository) ListLatest(ctx context.Context) ([]*FXRate, error) {
+	sb := r.structMap.SelectFrom("fx_rates")
+	sb.Where("as_of = (SELECT MAX(l.as_of) FROM fx_rates AS l WHERE l.currency = fx_rates.currency)")
+	sb.OrderBy("currency ASC")
+
+	sql, args := sb.Build()
+	return r.executeFXRateQuery(ctx, sql, args...)
+}
+
+// ListHistory retrieves the rates of a currency from the most to the least recent
+func (r *repository) ListHistory(ctx context.Context, currency string, filter *FXRateHistoryFilter, limit int, nextToken string) (*types.Page[*FXRate], error) {
+	sb := r.structMap.SelectFrom("fx_rates")
+	sb.Where(sb.Equal("currency", currency))
+
+	if filter != nil && filter.From != nil {
+		sb.Where(sb.GreaterEqualThan("as_of", filter.From.UTC()))
+	}
+	if filter != nil && filter.To != nil {
+		sb.Where(sb.LessEqualThan("as_of", filter.To.UTC()))
+	}
+
+	sb.OrderBy("as_of DESC")
+
+	// The token holds the publication time of the last rate as unix seconds
+	token, err := types.DecodeNextPageToken(nextToken, "as_of")
+	if err != nil {
+		return nil, err
+	}
+	if token != nil {
+		asOf, ok := token.GetValueInt64()
+		if !ok {
+			return nil, errors.NewInvalidPaginationTokenError(nextToken,
+				fmt.Errorf("as_of value must be an integer, got %T", token.Value))
+		}
+		sb.Where(sb.LessThan("as_of", time.Unix(asOf, 0).UTC()))
+	}
+
+	// Fetch one extra to determine if there are more pages
+	if limit > 0 {
+		sb.Limit(limit + 1)
+	}
+
+	sql, args := sb.Build()
+	rates, err := r.executeFXRateQuery(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewPage(rates, limit, func(rate *FXRate) *types.NextPageToken {
+		return &types.NextPageToken{
+			Column: "as_of",
+			Value:  rate.AsOf.Unix(),
+		}
+	}), nil
+}
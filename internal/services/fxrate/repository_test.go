@@ -0,0 +1,61 @@
+package fxrate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/db"
+	"vault0/internal/errors"
+	"vault0/internal/testing/dbtest"
+	"vault0/internal/testing/mocks"
+)
+
+func TestRepository_Rates(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database, mocks.NewNopLogger())
+		ctx := context.Background()
+
+		yesterday := time.Date(2026, 10, 16, 16, 0, 0, 0, time.UTC)
+		today := yesterday.Add(24 * time.Hour)
+
+		count, err := repo.UpsertMany(ctx, []*FXRate{
+			{Currency: "EUR", Rate: 0.92, AsOf: yesterday, Source: "frankfurter"},
+			{Currency: "EUR", Rate: 0.93, AsOf: today, Source: "frankfurter"},
+			{Currency: "JPY", Rate: 149.5, AsOf: yesterday, Source: "frankfurter"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+
+		// Storing a rate again for the same publication time replaces it
+		_, err = repo.UpsertMany(ctx, []*FXRate{{Currency: "EUR", Rate: 0.94, AsOf: today, Source: "openexchangerates"}})
+		require.NoError(t, err)
+
+		latest, err := repo.GetLatest(ctx, "EUR")
+		require.NoError(t, err)
+		assert.InDelta(t, 0.94, latest.Rate, 1e-9)
+		assert.Equal(t, "openexchangerates", latest.Source)
+		assert.True(t, today.Equal(latest.AsOf))
+
+		at, err := repo.GetAt(ctx, "EUR", today.Add(-time.Hour))
+		require.NoError(t, err)
+		assert.InDelta(t, 0.92, at.Rate, 1e-9)
+
+		_, err = repo.GetAt(ctx, "EUR", yesterday.Add(-time.Hour))
+		assert.True(t, errors.IsError(err, errors.ErrCodeFXRateNotFound))
+
+		rates, err := repo.ListLatest(ctx)
+		require.NoError(t, err)
+		require.Len(t, rates, 2)
+		assert.Equal(t, "EUR", rates[0].Currency)
+		assert.InDelta(t, 0.94, rates[0].Rate, 1e-9)
+		assert.Equal(t, "JPY", rates[1].Currency)
+
+		history, err := repo.ListHistory(ctx, "EUR", nil, 10, "")
+		require.NoError(t, err)
+		assert.Len(t, history.Items, 2)
+	})
+}
@@ -0,0 +1,126 @@
+package fxrate
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"vault0/internal/core/fxfeed"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/types"
+)
+
+// Service defines the interface for exchange rate operations.
+type Service interface {
+	// GetRate retrieves the latest USD exchange rate of a currency. USD itself is
+	// always available with a rate of 1.
+	//
+	// Returns:
+	//   - ErrInvalidInput if the currency is not an ISO 4217 code
+	//   - ErrFXRateNotFound if no rate exists for the currency
+	GetRate(ctx context.Context, currency string) (*FXRate, error)
+
+	// GetRateAt retrieves the USD exchange rate of a currency in effect at the given time.
+	//
+	// Returns:
+	//   - ErrInvalidInput if the currency is not an ISO 4217 code
+	//   - ErrFXRateNotFound if no rate was published for the currency by then
+	GetRateAt(ctx context.Context, currency string, at time.Time) (*FXRate, error)
+
+	// ListLatestRates retrieves the latest USD exchange rate of every known currency.
+	ListLatestRates(ctx context.Context) ([]*FXRate, error)
+
+	// ListRateHistory retrieves a paginated list of the historical USD exchange rates
+	// of a currency, from the most to the least recent.
+	//
+	// Parameters:
+	//   - ctx: The context for the operation
+	//   - currency: The currency to list the rates of
+	//   - filter: Optional filtering criteria
+	//   - limit: Maximum number of items to return (0 for all items)
+	//   - nextToken: Token for pagination (empty string for first page)
+	ListRateHistory(ctx context.Context, currency string, filter *FXRateHistoryFilter, limit int, nextToken string) (*types.Page[*FXRate], error)
+}
+
+type service struct {
+	repository Repository
+	log        logger.Logger
+}
+
+// NewService creates a new exchange rate service instance.
+func NewService(repo Repository, log logger.Logger) Service {
+	return &service{
+		repository: repo,
+		log:        log.With(logger.String("service", "fxrate")),
+	}
+}
+
+// GetRate implements the Service interface.
+func (s *service) GetRate(ctx context.Context, currency string) (*FXRate, error) {
+	currency, err := NormalizeCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	if currency == fxfeed.BaseCurrency {
+		return baseRate(time.Now()), nil
+	}
+
+	return s.repository.GetLatest(ctx, currency)
+}
+
+// GetRateAt implements the Service interface.
+func (s *service) GetRateAt(ctx context.Context, currency string, at time.Time) (*FXRate, error) {
+	currency, err := NormalizeCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	if currency == fxfeed.BaseCurrency {
+		return baseRate(at), nil
+	}
+
+	return s.repository.GetAt(ctx, currency, at)
+}
+
+// ListLatestRates implements the Service interface.
+func (s *service) ListLatestRates(ctx context.Context) ([]*FXRate, error) {
+	return s.repository.ListLatest(ctx)
+}
+
+// ListRateHistory implements the Service interface.
+func (s *service) ListRateHistory(ctx context.Context, currency string, filter *FXRateHistoryFilter, limit int, nextToken string) (*types.Page[*FXRate], error) {
+	currency, err := NormalizeCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// Set default limit if not provided
+	if limit <= 0 {
+		limit = 10
+	}
+
+	return s.repository.ListHistory(ctx, currency, filter, limit, nextToken)
+}
+
+// NormalizeCurrency upper-cases a currency code and validates it is made of three letters.
+// An empty currency defaults to USD.
+func NormalizeCurrency(currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return fxfeed.BaseCurrency, nil
+	}
+	if len(currency) != 3 {
+		return "", errors.NewInvalidInputError("Currency must be a three-letter ISO 4217 code", "currency", currency)
+	}
+	for _, c := range currency {
+		if c < 'A' || c > 'Z' {
+			return "", errors.NewInvalidInputError("Currency must be a three-letter ISO 4217 code", "currency", currency)
+		}
+	}
+	return currency, nil
+}
+
+// baseRate returns the rate of USD to itself
+func baseRate(at time.Time) *FXRate {
+	return &FXRate{Currency: fxfeed.BaseCurrency, Rate: 1, AsOf: at, Source: "identity"}
+}
@@ -3,7 +3,9 @@ package keystore
 import (
 	"context"
 	"crypto/elliptic"
+	"fmt"
 
+	"vault0/internal/core/crypto"
 	"vault0/internal/core/keystore"
 	"vault0/internal/errors"
 	"vault0/internal/logger"
@@ -36,6 +38,30 @@ type Service interface {
 
 	// DeleteKey removes a key from the keystore
 	DeleteKey(ctx context.Context, id string) error
+
+	// ExportKeyV3 exports a secp256k1 key as a passphrase-protected V3 keystore JSON document
+	ExportKeyV3(ctx context.Context, id string, passphrase string, kdf crypto.KDF) ([]byte, error)
+
+	// ImportKeyV3 imports a secp256k1 key from a passphrase-protected V3 keystore JSON document
+	ImportKeyV3(ctx context.Context, name string, keystoreJSON []byte, passphrase string, tags map[string]string) (*keystore.Key, error)
+
+	// VerifySignature checks a signature over data against the public key of a stored key
+	VerifySignature(ctx context.Context, id string, data, signature []byte, rawData bool) (bool, error)
+
+	// VerifyWithPublicKey checks a signature over data against an encoded public key
+	VerifyWithPublicKey(ctx context.Context, publicKey, data, signature []byte, rawData bool) (bool, error)
+
+	// RecoverAddress recovers the Ethereum address that produced a 65-byte signature
+	RecoverAddress(ctx context.Context, format crypto.EthMessageFormat, payload, signature []byte) (string, error)
+
+	// GetKeyPolicy retrieves the usage policy of a key
+	GetKeyPolicy(ctx context.Context, id string) (*keystore.KeyPolicy, error)
+
+	// UpdateKeyPolicy replaces the usage policy of a key
+	UpdateKeyPolicy(ctx context.Context, policy *keystore.KeyPolicy) (*keystore.KeyPolicy, error)
+
+	// ListSigningAudit retrieves signing audit log entries, newest first
+	ListSigningAudit(ctx context.Context, filter keystore.SigningAuditFilter, limit int, nextToken string) (*types.Page[*keystore.SigningAuditEntry], error)
 }
 
 // service implements the Service interface
@@ -291,3 +317,146 @@ func (s *service) DeleteKey(ctx context.Context, id string) error {
 
 	return nil
 }
+
+// ExportKeyV3 implements the Service interface
+func (s *service) ExportKeyV3(ctx context.Context, id string, passphrase string, kdf crypto.KDF) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.NewMissingParameterError("passphrase")
+	}
+
+	data, err := s.keyStore.ExportV3(ctx, id, passphrase, kdf)
+	if err != nil {
+		s.log.Error("Failed to export key",
+			logger.Error(err),
+			logger.String("key_id", id))
+		return nil, err
+	}
+
+	s.log.Warn("Key exported as V3 keystore",
+		logger.String("key_id", id),
+		logger.String("kdf", string(kdf)))
+
+	return data, nil
+}
+
+// ImportKeyV3 implements the Service interface
+func (s *service) ImportKeyV3(ctx context.Context, name string, keystoreJSON []byte, passphrase string, tags map[string]string) (*keystore.Key, error) {
+	if len(keystoreJSON) == 0 {
+		return nil, errors.NewMissingParameterError("keystore")
+	}
+
+	rawKey, err := crypto.DecryptKeystoreV3(keystoreJSON, passphrase)
+	if err != nil {
+		s.log.Error("Failed to decrypt V3 keystore", logger.Error(err))
+		return nil, err
+	}
+
+	privKey, err := crypto.PrivateKeyFromBytes(rawKey)
+	if err != nil {
+		return nil, errors.NewInvalidKeyError("invalid secp256k1 private key", err)
+	}
+
+	privateKey, err := crypto.MarshalPrivateKey(privKey)
+	if err != nil {
+		return nil, errors.NewInvalidKeyError("failed to marshal secp256k1 private key", err)
+	}
+
+	publicKey, err := crypto.MarshalPublicKey(&privKey.PublicKey)
+	if err != nil {
+		return nil, errors.NewInvalidKeyError("failed to marshal secp256k1 public key", err)
+	}
+
+	key, err := s.keyStore.Import(ctx, name, types.KeyTypeECDSA, crypto.Secp256k1Curve, privateKey, publicKey, tags)
+	if err != nil {
+		s.log.Error("Failed to import V3 keystore",
+			logger.Error(err),
+			logger.String("name", name))
+		return nil, err
+	}
+
+	s.log.Info("Key imported from V3 keystore successfully",
+		logger.String("id", key.ID),
+		logger.String("name", key.Name))
+
+	return key, nil
+}
+
+// VerifySignature implements the Service interface
+func (s *service) VerifySignature(ctx context.Context, id string, data, signature []byte, rawData bool) (bool, error) {
+	key, err := s.keyStore.GetPublicKey(ctx, id)
+	if err != nil {
+		s.log.Error("Failed to get key for verification",
+			logger.Error(err),
+			logger.String("key_id", id))
+		return false, err
+	}
+
+	return s.VerifyWithPublicKey(ctx, key.PublicKey, data, signature, rawData)
+}
+
+// VerifyWithPublicKey implements the Service interface
+func (s *service) VerifyWithPublicKey(ctx context.Context, publicKey, data, signature []byte, rawData bool) (bool, error) {
+	if len(publicKey) == 0 {
+		return false, errors.NewMissingParameterError("public_key")
+	}
+	if len(signature) == 0 {
+		return false, errors.NewMissingParameterError("signature")
+	}
+
+	key, err := crypto.ParsePublicKey(publicKey)
+	if err != nil {
+		return false, err
+	}
+
+	return crypto.VerifySignature(key, data, signature, !rawData)
+}
+
+// RecoverAddress implements the Service interface
+func (s *service) RecoverAddress(ctx context.Context, format crypto.EthMessageFormat, payload, signature []byte) (string, error) {
+	digest, err := crypto.HashEthMessage(format, payload)
+	if err != nil {
+		return "", err
+	}
+
+	return crypto.RecoverEthAddress(digest, signature)
+}
+
+// GetKeyPolicy implements the Service interface
+func (s *service) GetKeyPolicy(ctx context.Context, id string) (*keystore.KeyPolicy, error) {
+	return s.keyStore.GetPolicy(ctx, id)
+}
+
+// UpdateKeyPolicy implements the Service interface
+func (s *service) UpdateKeyPolicy(ctx context.Context, policy *keystore.KeyPolicy) (*keystore.KeyPolicy, error) {
+	for _, operation := range policy.AllowedOperations {
+		switch operation {
+		case keystore.OperationSign, keystore.OperationSignTransaction, keystore.OperationSignMessage, keystore.OperationExport:
+		default:
+			return nil, errors.NewInvalidParameterError("allowed_operations", fmt.Sprintf("unsupported operation: %s", operation))
+		}
+	}
+	for _, dataType := range policy.AllowedDataTypes {
+		if dataType != keystore.DataTypeRaw && dataType != keystore.DataTypeDigest {
+			return nil, errors.NewInvalidParameterError("allowed_data_types", fmt.Sprintf("unsupported data type: %s", dataType))
+		}
+	}
+
+	updated, err := s.keyStore.SetPolicy(ctx, policy)
+	if err != nil {
+		s.log.Error("Failed to update key policy",
+			logger.Error(err),
+			logger.String("key_id", policy.KeyID))
+		return nil, err
+	}
+
+	s.log.Info("Key policy updated",
+		logger.String("key_id", policy.KeyID),
+		logger.Bool("disabled", policy.Disabled))
+
+	return updated, nil
+}
+
+// ListSigningAudit implements the Service interface
+func (s *service) ListSigningAudit(ctx context.Context, filter keystore.SigningAuditFilter, limit int, nextToken string) (*types.Page[*keystore.SigningAuditEntry], error) {
+	return s.keyStore.ListSigningAudit(ctx, filter, limit, nextToken)
+}
@@ -199,50 +199,42 @@ func (r *repository) Update(ctx context.Context, signer *Signer) error {
 
 // Delete removes a signer from the database
 func (r *repository) Delete(ctx context.Context, id int64) error {
-	tx, err := r.db.Conn.BeginTx(ctx, nil)
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-
-	// First delete all addresses for this signer
-	// Create a delete builder for addresses
-	addrDb := sqlbuilder.NewDeleteBuilder()
-	addrDb.DeleteFrom("signer_addresses")
-	addrDb.Where(addrDb.Equal("signer_id", id))
-	addrSql, addrArgs := addrDb.Build()
-
-	_, err = tx.ExecContext(ctx, addrSql, addrArgs...)
-	if err != nil {
-		return err
-	}
-
-	// Then delete the signer
-	// Create a delete builder for signer
-	signerDb := sqlbuilder.NewDeleteBuilder()
-	signerDb.DeleteFrom("signers")
-	signerDb.Where(signerDb.Equal("id", id))
-	signerSql, signerArgs := signerDb.Build()
+	return r.db.WithTransaction(ctx, func(ctx context.Context) error {
+		// First delete all addresses for this signer
+		// Create a delete builder for addresses
+		addrDb := sqlbuilder.NewDeleteBuilder()
+		addrDb.DeleteFrom("signer_addresses")
+		addrDb.Where(addrDb.Equal("signer_id", id))
+		addrSql, addrArgs := addrDb.Build()
+
+		_, err := r.db.ExecuteStatementContext(ctx, addrSql, addrArgs...)
+		if err != nil {
+			return err
+		}
 
-	result, err := tx.ExecContext(ctx, signerSql, signerArgs...)
-	if err != nil {
-		return err
-	}
+		// Then delete the signer
+		// Create a delete builder for signer
+		signerDb := sqlbuilder.NewDeleteBuilder()
+		signerDb.DeleteFrom("signers")
+		signerDb.Where(signerDb.Equal("id", id))
+		signerSql, signerArgs := signerDb.Build()
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
+		result, err := r.db.ExecuteStatementContext(ctx, signerSql, signerArgs...)
+		if err != nil {
+			return err
+		}
 
-	if rowsAffected == 0 {
-		return errors.NewSignerNotFoundError(id)
-	}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
 
-	if err = tx.Commit(); err != nil {
-		return err
-	}
+		if rowsAffected == 0 {
+			return errors.NewSignerNotFoundError(id)
+		}
 
-	return nil
+		return nil
+	})
 }
 
 // GetByID retrieves a signer by their unique ID
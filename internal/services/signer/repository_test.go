@@ -0,0 +1,140 @@
+package signer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/db"
+	"vault0/internal/errors"
+	"vault0/internal/testing/dbtest"
+)
+
+// createTestUser stores a user for internal signers to reference
+func createTestUser(t *testing.T, database *db.DB) int64 {
+	t.Helper()
+
+	id, err := database.GenerateID()
+	require.NoError(t, err)
+	_, err = database.ExecuteStatementContext(context.Background(),
+		"INSERT INTO users (id, email, password_hash) VALUES (?, ?, ?)", id, "alice@example.com", "hash")
+	require.NoError(t, err)
+	return id
+}
+
+func TestRepository_CreateAndGet(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database)
+		ctx := context.Background()
+
+		userID := createTestUser(t, database)
+
+		internal := &Signer{Name: "Alice", Type: Internal, UserID: &userID}
+		require.NoError(t, repo.Create(ctx, internal))
+		assert.NotZero(t, internal.ID)
+
+		external := &Signer{Name: "Custodian", Type: External}
+		require.NoError(t, repo.Create(ctx, external))
+
+		got, err := repo.GetByID(ctx, internal.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Alice", got.Name)
+		assert.Equal(t, Internal, got.Type)
+		require.NotNil(t, got.UserID)
+		assert.Equal(t, userID, *got.UserID)
+
+		got, err = repo.GetByID(ctx, external.ID)
+		require.NoError(t, err)
+		assert.Nil(t, got.UserID)
+
+		byUser, err := repo.GetByUserID(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, byUser, 1)
+		assert.Equal(t, internal.ID, byUser[0].ID)
+
+		_, err = repo.GetByID(ctx, external.ID+1)
+		assert.True(t, errors.IsError(err, errors.ErrCodeSignerNotFound))
+	})
+}
+
+func TestRepository_UpdateAndDelete(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database)
+		ctx := context.Background()
+
+		signer := &Signer{Name: "Custodian", Type: External}
+		require.NoError(t, repo.Create(ctx, signer))
+
+		signer.Name = "Backup Custodian"
+		require.NoError(t, repo.Update(ctx, signer))
+
+		updated, err := repo.GetByID(ctx, signer.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Backup Custodian", updated.Name)
+
+		require.NoError(t, repo.Delete(ctx, signer.ID))
+		_, err = repo.GetByID(ctx, signer.ID)
+		assert.True(t, errors.IsError(err, errors.ErrCodeSignerNotFound))
+
+		err = repo.Update(ctx, signer)
+		assert.True(t, errors.IsError(err, errors.ErrCodeSignerNotFound))
+		err = repo.Delete(ctx, signer.ID)
+		assert.True(t, errors.IsError(err, errors.ErrCodeSignerNotFound))
+	})
+}
+
+func TestRepository_Addresses(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database)
+		ctx := context.Background()
+
+		signer := &Signer{Name: "Custodian", Type: External}
+		require.NoError(t, repo.Create(ctx, signer))
+
+		ethereum := &Address{SignerID: signer.ID, ChainType: "ethereum", Address: "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"}
+		require.NoError(t, repo.AddAddress(ctx, ethereum))
+		polygon := &Address{SignerID: signer.ID, ChainType: "polygon", Address: "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"}
+		require.NoError(t, repo.AddAddress(ctx, polygon))
+
+		// An address belongs to a single signer on each chain
+		duplicate := &Address{SignerID: signer.ID, ChainType: "ethereum", Address: ethereum.Address}
+		assert.Error(t, repo.AddAddress(ctx, duplicate))
+
+		addresses, err := repo.GetAddresses(ctx, signer.ID)
+		require.NoError(t, err)
+		assert.Len(t, addresses, 2)
+
+		require.NoError(t, repo.DeleteAddress(ctx, polygon.ID))
+		err = repo.DeleteAddress(ctx, polygon.ID)
+		assert.True(t, errors.IsError(err, errors.ErrCodeSignerAddressNotFound))
+
+		got, err := repo.GetByID(ctx, signer.ID)
+		require.NoError(t, err)
+		require.Len(t, got.Addresses, 1)
+		assert.Equal(t, ethereum.ID, got.Addresses[0].ID)
+	})
+}
+
+func TestRepository_List(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database)
+		ctx := context.Background()
+
+		for _, name := range []string{"A", "B", "C"} {
+			require.NoError(t, repo.Create(ctx, &Signer{Name: name, Type: External}))
+		}
+
+		page, err := repo.List(ctx, 2, "")
+		require.NoError(t, err)
+		assert.Len(t, page.Items, 2)
+		require.NotEmpty(t, page.NextToken)
+		assert.Less(t, page.Items[0].ID, page.Items[1].ID)
+
+		next, err := repo.List(ctx, 2, page.NextToken)
+		require.NoError(t, err)
+		assert.Len(t, next.Items, 1)
+		assert.Empty(t, next.NextToken)
+	})
+}
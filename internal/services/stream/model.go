@@ -0,0 +1,112 @@
+package stream
+
+import (
+	"slices"
+	"time"
+
+	"vault0/internal/types"
+)
+
+// EventType represents the type of a live event
+type EventType string
+
+const (
+	// EventTypeDeposit is published when a wallet receives a new transaction
+	EventTypeDeposit EventType = "transaction.deposit"
+	// EventTypeWithdrawal is published when a wallet sends a new transaction
+	EventTypeWithdrawal EventType = "transaction.withdrawal"
+	// EventTypeBalance is published when a native or token balance of a wallet changes
+	EventTypeBalance EventType = "balance.updated"
+	// EventTypeVaultStatusChanged is published when a vault changes status
+	EventTypeVaultStatusChanged EventType = "vault.status_changed"
+	// EventTypePrice is published when token prices are refreshed
+	EventTypePrice EventType = "price.updated"
+	// EventTypeReset is sent instead of replaying the events missed by a resuming client
+	// when they are too many or no longer retained. The client should reload its state.
+	EventTypeReset EventType = "stream.reset"
+)
+
+// EventTypes lists the event types clients can filter on
+var EventTypes = []EventType{
+	EventTypeDeposit,
+	EventTypeWithdrawal,
+	EventTypeBalance,
+	EventTypeVaultStatusChanged,
+	EventTypePrice,
+}
+
+// IsValid returns whether the event type is one clients can filter on
+func (t EventType) IsValid() bool {
+	return slices.Contains(EventTypes, t)
+}
+
+// Event is a live event. Events are stored so that they can be fanned out by every
+// replica and replayed to resuming clients.
+type Event struct {
+	ID        int64           `db:"id"`
+	Type      EventType       `db:"type"`
+	ChainType types.ChainType `db:"chain_type"` // Empty for events spanning chains
+	WalletID  *int64          `db:"wallet_id"`
+	VaultID   *int64          `db:"vault_id"`
+	Data      string          `db:"data"` // JSON encoded event details
+	CreatedAt time.Time       `db:"created_at"`
+}
+
+// Scope identifies what an event relates to, which clients filter on
+type Scope struct {
+	ChainType types.ChainType
+	WalletID  int64 // Zero when the event does not relate to a wallet
+	VaultID   int64 // Zero when the event does not relate to a vault
+}
+
+// BalanceData holds the details of balance events
+type BalanceData struct {
+	WalletID     int64  `json:"wallet_id,string"`
+	Address      string `json:"address"`
+	TokenAddress string `json:"token_address,omitempty"` // Empty for the native balance
+	Balance      string `json:"balance"`
+}
+
+// PriceData holds the price of a token in price events
+type PriceData struct {
+	AssetID         string  `json:"asset_id"`
+	Symbol          string  `json:"symbol"`
+	ChainType       string  `json:"chain_type,omitempty"`
+	ContractAddress string  `json:"contract_address,omitempty"`
+	PriceUSD        float64 `json:"price_usd"`
+}
+
+// Filter selects the events streamed to a client. Empty lists match everything.
+type Filter struct {
+	EventTypes []EventType
+	ChainTypes []types.ChainType
+	WalletIDs  []int64
+	VaultIDs   []int64
+}
+
+// Matches returns whether the event is streamed to a client with this filter. The chain,
+// wallet and vault filters only apply to the events scoped to a chain, wallet or vault:
+// events spanning them, such as prices, are only filtered by type. An event matches the
+// wallet and vault filters when it relates to any of the wallets or vaults.
+func (f *Filter) Matches(event *Event) bool {
+	if len(f.EventTypes) > 0 && !slices.Contains(f.EventTypes, event.Type) {
+		return false
+	}
+	if event.ChainType != "" && len(f.ChainTypes) > 0 && !slices.Contains(f.ChainTypes, event.ChainType) {
+		return false
+	}
+
+	if event.WalletID == nil && event.VaultID == nil {
+		return true
+	}
+	if len(f.WalletIDs) == 0 && len(f.VaultIDs) == 0 {
+		return true
+	}
+	if event.WalletID != nil && slices.Contains(f.WalletIDs, *event.WalletID) {
+		return true
+	}
+	if event.VaultID != nil && slices.Contains(f.VaultIDs, *event.VaultID) {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,167 @@
+package stream
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"vault0/internal/db"
+	"vault0/internal/errors"
+)
+
+// Repository defines the live event data access interface
+type Repository interface {
+	// Create adds a new event to the database
+	Create(ctx context.Context, event *Event) error
+
+	// Exists checks whether an event is still retained
+	Exists(ctx context.Context, id int64) (bool, error)
+
+	// ListAfter retrieves up to limit events with an ID greater than afterID, in ID order
+	ListAfter(ctx context.Context, afterID int64, limit int) ([]*Event, error)
+
+	// LatestID returns the greatest event ID, or zero when there are no events
+	LatestID(ctx context.Context) (int64, error)
+
+	// DeleteBefore removes the events created before a time and returns their number
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// repository implements Repository using the database
+type repository struct {
+	db        *db.DB
+	structMap *sqlbuilder.Struct
+}
+
+// NewRepository creates a new live event repository
+func NewRepository(db *db.DB) Repository {
+	return &repository{
+		db:        db,
+		structMap: sqlbuilder.NewStruct(new(Event)),
+	}
+}
+
+// executeQuery executes a query and scans the results into Event objects
+func (r *repository) executeQuery(ctx context.Context, query string, args ...any) ([]*Event, error) {
+	rows, err := r.db.ExecuteQueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []*Event{}
+	for rows.Next() {
+		var event Event
+		var walletID, vaultID sql.NullInt64
+
+		err := rows.Scan(
+			&event.ID,
+			&event.Type,
+			&event.ChainType,
+			&walletID,
+			&vaultID,
+			&event.Data,
+			&event.CreatedAt,
+		)
+		if err != nil {
+			return nil, errors.NewDatabaseError(err)
+		}
+
+		// Handle nullable fields
+		if walletID.Valid {
+			event.WalletID = &walletID.Int64
+		}
+		if vaultID.Valid {
+			event.VaultID = &vaultID.Int64
+		}
+
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	return events, nil
+}
+
+// Create adds a new event to the database
+func (r *repository) Create(ctx context.Context, event *Event) error {
+	if event.ID == 0 {
+		var err error
+		event.ID, err = r.db.GenerateID()
+		if err != nil {
+			return err
+		}
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	event.CreatedAt = event.CreatedAt.UTC()
+
+	sql, args := r.structMap.InsertInto("events", event).Build()
+	_, err := r.db.ExecuteStatementContext(ctx, sql, args...)
+	return err
+}
+
+// Exists checks whether an event is still retained
+func (r *repository) Exists(ctx context.Context, id int64) (bool, error) {
+	rows, err := r.db.ExecuteQueryContext(ctx, "SELECT 1 FROM events WHERE id = ?", id)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	exists := rows.Next()
+	if err := rows.Err(); err != nil {
+		return false, errors.NewDatabaseError(err)
+	}
+	return exists, nil
+}
+
+// ListAfter retrieves up to limit events with an ID greater than afterID
+func (r *repository) ListAfter(ctx context.Context, afterID int64, limit int) ([]*Event, error) {
+	sb := r.structMap.SelectFrom("events")
+	sb.Where(sb.GreaterThan("id", afterID))
+	sb.OrderBy("id ASC")
+	sb.Limit(limit)
+
+	sql, args := sb.Build()
+	return r.executeQuery(ctx, sql, args...)
+}
+
+// LatestID returns the greatest event ID
+func (r *repository) LatestID(ctx context.Context) (int64, error) {
+	rows, err := r.db.ExecuteQueryContext(ctx, "SELECT COALESCE(MAX(id), 0) FROM events")
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var id int64
+	if rows.Next() {
+		if err := rows.Scan(&id); err != nil {
+			return 0, errors.NewDatabaseError(err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, errors.NewDatabaseError(err)
+	}
+	return id, nil
+}
+
+// DeleteBefore removes the events created before a time
+func (r *repository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.db.ExecuteStatementContext(ctx, "DELETE FROM events WHERE created_at < ?", before.UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.NewDatabaseError(err)
+	}
+	return deleted, nil
+}
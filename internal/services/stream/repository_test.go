@@ -0,0 +1,81 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/db"
+	"vault0/internal/testing/dbtest"
+	"vault0/internal/types"
+)
+
+func TestRepository_Events(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database)
+		ctx := context.Background()
+
+		latest, err := repo.LatestID(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, latest)
+
+		walletID := int64(42)
+		old := &Event{
+			Type:      EventTypeDeposit,
+			ChainType: types.ChainTypeEthereum,
+			WalletID:  &walletID,
+			Data:      `{"hash":"0x1"}`,
+			CreatedAt: time.Now().Add(-48 * time.Hour),
+		}
+		require.NoError(t, repo.Create(ctx, old))
+		require.NotZero(t, old.ID)
+
+		price := &Event{Type: EventTypePrice, Data: `[]`}
+		require.NoError(t, repo.Create(ctx, price))
+
+		latest, err = repo.LatestID(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, price.ID, latest)
+
+		events, err := repo.ListAfter(ctx, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, old.ID, events[0].ID)
+		assert.Equal(t, types.ChainTypeEthereum, events[0].ChainType)
+		require.NotNil(t, events[0].WalletID)
+		assert.Equal(t, walletID, *events[0].WalletID)
+		assert.Nil(t, events[0].VaultID)
+		assert.Equal(t, `{"hash":"0x1"}`, events[0].Data)
+		assert.Equal(t, price.ID, events[1].ID)
+		assert.Nil(t, events[1].WalletID)
+
+		events, err = repo.ListAfter(ctx, old.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, price.ID, events[0].ID)
+
+		events, err = repo.ListAfter(ctx, 0, 1)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+
+		exists, err := repo.Exists(ctx, old.ID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		deleted, err := repo.DeleteBefore(ctx, time.Now().Add(-24*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		exists, err = repo.Exists(ctx, old.ID)
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		events, err = repo.ListAfter(ctx, 0, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, price.ID, events[0].ID)
+	})
+}
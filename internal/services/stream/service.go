@@ -0,0 +1,397 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+	"time"
+
+	"vault0/internal/config"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/types"
+)
+
+const (
+	// pollBatchSize is the maximum number of events read per query
+	pollBatchSize = 500
+	// subscriberBuffer is the number of events buffered per client. Clients falling
+	// further behind are disconnected, and replay the missed events when they resume.
+	subscriberBuffer = 256
+	// maxReplay is the maximum number of missed events replayed to a resuming client
+	maxReplay = 1000
+	// commitSettleDelay is the time after which an event is assumed to be committed.
+	// Event IDs are generated before the transactions storing them commit, so events
+	// can become visible out of ID order; the read cursor only moves past events this old.
+	commitSettleDelay = 10 * time.Second
+	// pruneInterval is the interval between removals of the events past retention
+	pruneInterval = 10 * time.Minute
+)
+
+// Subscription is the stream of the events matching the filter of a client
+type Subscription struct {
+	// Backlog holds the missed events replayed to a resuming client, oldest first
+	Backlog []*Event
+	// Reset is set when the missed events cannot be replayed, because they are too many
+	// or no longer retained. The client should reload its state and resume from ResumeID.
+	Reset bool
+	// ResumeID is the ID from which a reset client resumes
+	ResumeID int64
+	// Events receives the live events. It is closed when the client falls too far behind
+	// or the stream stops.
+	Events <-chan *Event
+
+	close func()
+}
+
+// Close ends the subscription
+func (s *Subscription) Close() {
+	s.close()
+}
+
+// Service defines the interface for the live event stream
+type Service interface {
+	// Publish records an event and fans it out to the matching clients of every replica.
+	// The event is stored in the transaction carried by ctx if any, so that it is only
+	// streamed once committed.
+	//
+	// Parameters:
+	//   - eventType: The type of the event
+	//   - scope: The chain, wallet and vault the event relates to
+	//   - data: The event details, encoded as JSON
+	Publish(ctx context.Context, eventType EventType, scope Scope, data any) error
+
+	// Subscribe streams the events matching a filter. A client resuming after lastEventID
+	// first receives the events it missed.
+	//
+	// Returns:
+	//   - ErrInvalidInput if the filter is invalid
+	Subscribe(ctx context.Context, filter Filter, lastEventID int64) (*Subscription, error)
+
+	// StartStreaming starts reading the published events and fanning them out to the
+	// clients. It runs in every replica, as all of them serve the API.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation, used to cancel the job
+	StartStreaming(ctx context.Context)
+
+	// StopStreaming stops fanning out events and ends all subscriptions
+	StopStreaming()
+}
+
+// subscriber is a client of the stream
+type subscriber struct {
+	filter Filter
+	events chan *Event
+}
+
+// service implements the Service interface
+type service struct {
+	repository Repository
+	chains     *types.Chains
+	log        logger.Logger
+	config     *config.Config
+
+	// mu guards the subscribers and the read position, so that events are fanned out
+	// in a consistent order with the replays of resuming clients
+	mu          sync.Mutex
+	subscribers map[*subscriber]struct{}
+	cursor      int64               // All events up to this ID have been fanned out
+	recent      map[int64]time.Time // Events after the cursor that have been fanned out, by creation time
+	notify      chan struct{}
+
+	jobCtx    context.Context
+	jobCancel context.CancelFunc
+}
+
+// NewService creates a new live event stream service instance
+func NewService(repository Repository, chains *types.Chains, cfg *config.Config, log logger.Logger) Service {
+	return &service{
+		repository:  repository,
+		chains:      chains,
+		log:         log.With(logger.String("service", "stream")),
+		config:      cfg,
+		subscribers: make(map[*subscriber]struct{}),
+		recent:      make(map[int64]time.Time),
+		notify:      make(chan struct{}, 1),
+	}
+}
+
+// Publish implements the Service interface
+func (s *service) Publish(ctx context.Context, eventType EventType, scope Scope, data any) error {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return errors.NewOperationFailedError("encode stream event", err)
+	}
+
+	event := &Event{
+		Type:      eventType,
+		ChainType: scope.ChainType,
+		Data:      string(encoded),
+	}
+	if scope.WalletID != 0 {
+		event.WalletID = &scope.WalletID
+	}
+	if scope.VaultID != 0 {
+		event.VaultID = &scope.VaultID
+	}
+
+	if err := s.repository.Create(ctx, event); err != nil {
+		s.log.Error("Failed to record stream event",
+			logger.Error(err),
+			logger.String("event_type", string(eventType)))
+		return err
+	}
+
+	// Wake up the fan-out of this replica rather than waiting for the next poll
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Subscribe implements the Service interface
+func (s *service) Subscribe(ctx context.Context, filter Filter, lastEventID int64) (*Subscription, error) {
+	if err := s.validateFilter(filter); err != nil {
+		return nil, err
+	}
+
+	sub := &subscriber{filter: filter, events: make(chan *Event, subscriberBuffer)}
+	subscription := &Subscription{
+		Events: sub.events,
+		close:  func() { s.unsubscribe(sub) },
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if lastEventID > 0 {
+		backlog, err := s.replay(ctx, filter, lastEventID)
+		if err != nil {
+			return nil, err
+		}
+		if backlog == nil {
+			subscription.Reset = true
+			subscription.ResumeID = s.cursor
+		}
+		subscription.Backlog = backlog
+	}
+
+	s.subscribers[sub] = struct{}{}
+	s.log.Debug("Stream client subscribed", logger.Int("subscribers", len(s.subscribers)))
+	return subscription, nil
+}
+
+// replay returns the events after lastEventID matching a filter that have already been
+// fanned out, or nil when they can't be replayed. It must be called with mu held.
+func (s *service) replay(ctx context.Context, filter Filter, lastEventID int64) ([]*Event, error) {
+	exists, err := s.repository.Exists(ctx, lastEventID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	backlog := []*Event{}
+	after := lastEventID
+	for {
+		events, err := s.repository.ListAfter(ctx, after, pollBatchSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range events {
+			// Events not fanned out yet are streamed live
+			if _, ok := s.recent[event.ID]; event.ID > s.cursor && !ok {
+				continue
+			}
+			if !filter.Matches(event) {
+				continue
+			}
+			if len(backlog) == maxReplay {
+				return nil, nil
+			}
+			backlog = append(backlog, event)
+		}
+
+		if len(events) < pollBatchSize {
+			return backlog, nil
+		}
+		after = events[len(events)-1].ID
+	}
+}
+
+// unsubscribe removes a client from the stream
+func (s *service) unsubscribe(sub *subscriber) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subscribers[sub]; ok {
+		delete(s.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+// StartStreaming starts reading the published events and fanning them out
+func (s *service) StartStreaming(ctx context.Context) {
+	interval := 1
+	if s.config.Stream.PollInterval > 0 {
+		interval = s.config.Stream.PollInterval
+	}
+
+	s.jobCtx, s.jobCancel = context.WithCancel(ctx)
+
+	// Only the events published from now on are fanned out, earlier ones are replayed
+	latestID, err := s.repository.LatestID(s.jobCtx)
+	if err != nil {
+		s.log.Error("Failed to read the latest stream event", logger.Error(err))
+	}
+	s.mu.Lock()
+	s.cursor = latestID
+	s.mu.Unlock()
+
+	s.log.Info("Starting live event stream",
+		logger.Int("poll_interval_seconds", interval))
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(interval) * time.Second)
+		defer ticker.Stop()
+		pruneTicker := time.NewTicker(pruneInterval)
+		defer pruneTicker.Stop()
+
+		s.prune(s.jobCtx)
+
+		for {
+			select {
+			case <-s.jobCtx.Done():
+				return
+			case <-ticker.C:
+			case <-s.notify:
+			case <-pruneTicker.C:
+				s.prune(s.jobCtx)
+				continue
+			}
+
+			if err := s.poll(s.jobCtx); err != nil && s.jobCtx.Err() == nil {
+				s.log.Error("Failed to read stream events", logger.Error(err))
+			}
+		}
+	}()
+}
+
+// StopStreaming stops fanning out events and ends all subscriptions
+func (s *service) StopStreaming() {
+	if s.jobCancel == nil {
+		return
+	}
+	s.jobCancel()
+	s.jobCancel = nil
+
+	s.mu.Lock()
+	for sub := range s.subscribers {
+		delete(s.subscribers, sub)
+		close(sub.events)
+	}
+	s.mu.Unlock()
+
+	s.log.Info("Live event stream stopped")
+}
+
+// poll fans out the events published since the last poll
+func (s *service) poll(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	after := s.cursor
+	for {
+		events, err := s.repository.ListAfter(ctx, after, pollBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			if _, ok := s.recent[event.ID]; ok {
+				continue
+			}
+			s.recent[event.ID] = event.CreatedAt
+			s.dispatch(event)
+		}
+
+		if len(events) < pollBatchSize {
+			break
+		}
+		after = events[len(events)-1].ID
+	}
+
+	// Move the cursor past the events old enough for no earlier one to be pending
+	ids := make([]int64, 0, len(s.recent))
+	for id := range s.recent {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	settled := time.Now().Add(-commitSettleDelay)
+	for _, id := range ids {
+		if s.recent[id].After(settled) {
+			break
+		}
+		s.cursor = id
+		delete(s.recent, id)
+	}
+	return nil
+}
+
+// dispatch sends an event to the matching clients. It must be called with mu held.
+func (s *service) dispatch(event *Event) {
+	for sub := range s.subscribers {
+		if !sub.filter.Matches(event) {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			// The client resumes from its last received event when it reconnects
+			delete(s.subscribers, sub)
+			close(sub.events)
+			s.log.Warn("Disconnected slow stream client", logger.Int64("event_id", event.ID))
+		}
+	}
+}
+
+// prune removes the events past retention
+func (s *service) prune(ctx context.Context) {
+	retention := 24
+	if s.config.Stream.Retention > 0 {
+		retention = s.config.Stream.Retention
+	}
+
+	deleted, err := s.repository.DeleteBefore(ctx, time.Now().Add(-time.Duration(retention)*time.Hour))
+	if err != nil {
+		if ctx.Err() == nil {
+			s.log.Error("Failed to prune stream events", logger.Error(err))
+		}
+		return
+	}
+	if deleted > 0 {
+		s.log.Debug("Pruned stream events", logger.Int64("count", deleted))
+	}
+}
+
+// validateFilter checks the event types and chains of a filter
+func (s *service) validateFilter(filter Filter) error {
+	for _, eventType := range filter.EventTypes {
+		if !eventType.IsValid() {
+			return errors.NewInvalidInputError("Unsupported event type", "types", eventType)
+		}
+	}
+	for _, chainType := range filter.ChainTypes {
+		if _, err := s.chains.Get(chainType); err != nil {
+			return errors.NewInvalidInputError("Unsupported chain type", "chain_types", chainType)
+		}
+	}
+	return nil
+}
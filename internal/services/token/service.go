@@ -2,8 +2,12 @@ package token
 
 import (
 	"context"
+	"fmt"
 
+	"vault0/internal/core/tokendiscovery"
+	"vault0/internal/core/tokenrisk"
 	"vault0/internal/core/tokenstore"
+	"vault0/internal/errors"
 	"vault0/internal/logger"
 	"vault0/internal/types"
 )
@@ -14,32 +18,63 @@ type Service interface {
 	// nextToken is used for token-based pagination (empty string for first page)
 	ListTokens(ctx context.Context, filter *tokenstore.TokenFilter, limit int, nextToken string) (*types.Page[types.Token], error)
 
-	// AddToken adds a new token
+	// AddToken adds a new token as verified. When the symbol or type is missing,
+	// the missing details are discovered from the token contract.
 	AddToken(ctx context.Context, token *types.Token) error
 
+	// DiscoverToken reads a token's metadata from its contract without registering it
+	DiscoverToken(ctx context.Context, chainType types.ChainType, address string) (*tokendiscovery.Metadata, error)
+
+	// RegisterToken returns the registered token for an address, discovering and
+	// registering it as unverified if it is unknown. Used for tokens seen in wallet transfers.
+	// Registered tokens are classified and flagged when they look like spam or scams.
+	RegisterToken(ctx context.Context, chainType types.ChainType, address string) (*types.Token, error)
+
 	// DeleteToken removes a token by address
 	DeleteToken(ctx context.Context, address string) error
 
-	// VerifyToken checks if a token exists by address
+	// VerifyToken checks a registered token against its contract, refreshing its
+	// metadata and marking it as verified, which clears its risk flags
 	VerifyToken(ctx context.Context, address string) (*types.Token, error)
 
+	// ImportTokenList imports a token list in the Uniswap token list format and
+	// reports the tokens added, changed, removed and skipped
+	ImportTokenList(ctx context.Context, list *tokenstore.TokenList, opts tokenstore.ImportOptions) (*tokenstore.TokenListDiff, error)
+
+	// ExportTokenList exports the verified ERC20 tokens in the Uniswap token list format
+	ExportTokenList(ctx context.Context, name string) (*tokenstore.TokenList, error)
+
 	// GetToken retrieves a token by address
 	GetToken(ctx context.Context, address string) (*types.Token, error)
 
 	// UpdateToken updates a token's symbol, type, and decimals by address
 	UpdateToken(ctx context.Context, address string, symbol string, tokenType types.TokenType, decimals uint8) error
+
+	// SetTokenBehavior flags an ERC20 token as fee-on-transfer or rebasing, so that
+	// wallet balances are derived from receipt logs and balanceOf reads instead of
+	// the transferred amounts
+	SetTokenBehavior(ctx context.Context, address string, feeOnTransfer, rebasing bool) (*types.Token, error)
 }
 
 // service implements the Service interface
 type service struct {
 	tokenStore tokenstore.TokenStore
+	discoverer tokendiscovery.Discoverer
+	classifier tokenrisk.Classifier
 	log        logger.Logger
 }
 
 // NewService creates a new token service instance
-func NewService(tokenStore tokenstore.TokenStore, log logger.Logger) Service {
+func NewService(
+	tokenStore tokenstore.TokenStore,
+	discoverer tokendiscovery.Discoverer,
+	classifier tokenrisk.Classifier,
+	log logger.Logger,
+) Service {
 	return &service{
 		tokenStore: tokenStore,
+		discoverer: discoverer,
+		classifier: classifier,
 		log:        log,
 	}
 }
@@ -83,6 +118,34 @@ func (s *service) ListTokens(ctx context.Context, filter *tokenstore.TokenFilter
 
 // AddToken implements the Service interface
 func (s *service) AddToken(ctx context.Context, token *types.Token) error {
+	if token == nil {
+		return errors.NewInvalidTokenError("token is nil", nil)
+	}
+
+	// Fill in the details the caller didn't provide from the contract
+	if token.Symbol == "" || token.Type == "" {
+		metadata, err := s.DiscoverToken(ctx, token.ChainType, token.Address)
+		if err != nil {
+			return err
+		}
+		discovered := metadata.Token
+		token.Address = discovered.Address
+		if token.Symbol == "" {
+			token.Symbol = discovered.Symbol
+		}
+		if token.Name == "" {
+			token.Name = discovered.Name
+		}
+		if token.Type == "" {
+			token.Type = discovered.Type
+			token.Decimals = discovered.Decimals
+		}
+	}
+
+	// Tokens added explicitly are trusted
+	token.Verified = true
+	token.RiskFlags = nil
+
 	// Validate the token
 	if err := token.Validate(); err != nil {
 		s.log.Error("Token validation failed",
@@ -112,6 +175,92 @@ func (s *service) AddToken(ctx context.Context, token *types.Token) error {
 	return nil
 }
 
+// DiscoverToken implements the Service interface
+func (s *service) DiscoverToken(ctx context.Context, chainType types.ChainType, address string) (*tokendiscovery.Metadata, error) {
+	if chainType == "" {
+		return nil, errors.NewInvalidParameterError("chain_type", "cannot be empty")
+	}
+	if address == "" {
+		return nil, errors.NewInvalidParameterError("address", "cannot be empty")
+	}
+
+	metadata, err := s.discoverer.Discover(ctx, chainType, address)
+	if err != nil {
+		s.log.Error("Failed to discover token",
+			logger.Error(err),
+			logger.String("address", address),
+			logger.String("chain_type", string(chainType)))
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// RegisterToken implements the Service interface
+func (s *service) RegisterToken(ctx context.Context, chainType types.ChainType, address string) (*types.Token, error) {
+	token, err := s.tokenStore.GetToken(ctx, address)
+	if err == nil {
+		return token, nil
+	}
+	if !errors.IsError(err, errors.ErrCodeResourceNotFound) {
+		return nil, err
+	}
+
+	metadata, err := s.DiscoverToken(ctx, chainType, address)
+	if err != nil {
+		return nil, err
+	}
+
+	token = metadata.Token
+	token.Verified = false
+	token.RiskFlags, err = s.classifier.ClassifyToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tokenStore.AddToken(ctx, token); err != nil {
+		// Another transfer of the same token may have registered it concurrently
+		if errors.IsError(err, errors.ErrCodeResourceExists) {
+			return s.tokenStore.GetToken(ctx, token.Address)
+		}
+		s.log.Error("Failed to register discovered token",
+			logger.Error(err),
+			logger.String("address", token.Address),
+			logger.String("chain_type", string(chainType)))
+		return nil, err
+	}
+
+	s.log.Info("Registered unverified token",
+		logger.String("symbol", token.Symbol),
+		logger.String("address", token.Address),
+		logger.String("chain_type", string(chainType)),
+		logger.String("token_type", string(token.Type)),
+		logger.Any("risk_flags", token.RiskFlags))
+
+	return token, nil
+}
+
+// ImportTokenList implements the Service interface
+func (s *service) ImportTokenList(ctx context.Context, list *tokenstore.TokenList, opts tokenstore.ImportOptions) (*tokenstore.TokenListDiff, error) {
+	diff, err := s.tokenStore.ImportTokenList(ctx, list, opts)
+	if err != nil {
+		s.log.Error("Failed to import token list", logger.Error(err), logger.Bool("dry_run", opts.DryRun))
+		return nil, err
+	}
+
+	return diff, nil
+}
+
+// ExportTokenList implements the Service interface
+func (s *service) ExportTokenList(ctx context.Context, name string) (*tokenstore.TokenList, error) {
+	list, err := s.tokenStore.ExportTokenList(ctx, name)
+	if err != nil {
+		s.log.Error("Failed to export token list", logger.Error(err), logger.String("name", name))
+		return nil, err
+	}
+
+	return list, nil
+}
+
 // GetToken implements the Service interface
 func (s *service) GetToken(ctx context.Context, address string) (*types.Token, error) {
 	token, err := s.tokenStore.GetToken(ctx, address)
@@ -155,6 +304,45 @@ func (s *service) VerifyToken(ctx context.Context, address string) (*types.Token
 		return nil, err
 	}
 
+	// Native tokens have no contract to check
+	if token.IsNative() {
+		return token, nil
+	}
+
+	metadata, err := s.DiscoverToken(ctx, token.ChainType, token.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	discovered := metadata.Token
+	if discovered.Type != token.Type {
+		return nil, errors.NewInvalidTokenError(
+			fmt.Sprintf("token is registered as %s but the contract implements %s", token.Type, discovered.Type), nil)
+	}
+	if token.IsERC20() && discovered.Decimals != token.Decimals {
+		return nil, errors.NewInvalidTokenError(
+			fmt.Sprintf("token is registered with %d decimals but the contract reports %d", token.Decimals, discovered.Decimals), nil)
+	}
+
+	if token.Verified && token.Name != "" && !token.HasRiskFlags() {
+		return token, nil
+	}
+
+	if token.Name == "" {
+		token.Name = discovered.Name
+	}
+	token.Verified = true
+	token.RiskFlags = nil
+	if err := s.tokenStore.UpdateToken(ctx, token); err != nil {
+		s.log.Error("Failed to mark token as verified", logger.Error(err), logger.String("address", address))
+		return nil, err
+	}
+
+	s.log.Info("Token verified",
+		logger.String("address", token.Address),
+		logger.String("symbol", token.Symbol),
+		logger.String("chain_type", string(token.ChainType)))
+
 	return token, nil
 }
 
@@ -202,3 +390,31 @@ func (s *service) UpdateToken(ctx context.Context, address string, symbol string
 
 	return nil
 }
+
+// SetTokenBehavior implements the Service interface
+func (s *service) SetTokenBehavior(ctx context.Context, address string, feeOnTransfer, rebasing bool) (*types.Token, error) {
+	token, err := s.tokenStore.GetToken(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.IsERC20() && (feeOnTransfer || rebasing) {
+		return nil, errors.NewInvalidTokenError(
+			fmt.Sprintf("transfer behavior only applies to ERC20 tokens, not %s", token.Type), nil)
+	}
+
+	token.FeeOnTransfer = feeOnTransfer
+	token.Rebasing = rebasing
+	if err := s.tokenStore.UpdateToken(ctx, token); err != nil {
+		s.log.Error("Failed to update token behavior", logger.Error(err), logger.String("address", address))
+		return nil, err
+	}
+
+	s.log.Info("Token behavior updated",
+		logger.String("address", token.Address),
+		logger.String("symbol", token.Symbol),
+		logger.Bool("fee_on_transfer", feeOnTransfer),
+		logger.Bool("rebasing", rebasing))
+
+	return token, nil
+}
@@ -1,22 +1,47 @@
 package tokenprice
 
-import "time"
+import (
+	"strings"
+	"time"
+
+	"vault0/internal/types"
+)
 
 // TokenPrice represents the stored price data for a token in the database.
 // Note the use of `float64` for numerical fields which might require careful
 // handling regarding precision, especially for financial data.
 type TokenPrice struct {
-	Symbol       string    `db:"symbol"`         // Token symbol (e.g., "BTC") - Primary Key
-	Rank         int       `db:"rank"`           // Market cap rank
-	PriceUSD     float64   `db:"price_usd"`      // Price in USD
-	Supply       float64   `db:"supply"`         // Circulating supply
-	MarketCapUSD float64   `db:"market_cap_usd"` // Market cap in USD
-	VolumeUSD24h float64   `db:"volume_usd_24h"` // Trading volume in last 24h USD
-	UpdatedAt    time.Time `db:"updated_at"`     // Timestamp when the data was last updated in the DB
+	AssetID         string    `db:"asset_id"`         // Price feed asset ID (e.g., "btc-bitcoin") - Primary Key
+	Symbol          string    `db:"symbol"`           // Token symbol (e.g., "BTC")
+	ChainType       string    `db:"chain_type"`       // Chain of the priced contract, empty for symbol-only assets
+	ContractAddress string    `db:"contract_address"` // Priced token contract, empty for symbol-only assets
+	Rank            int       `db:"rank"`             // Market cap rank
+	PriceUSD        float64   `db:"price_usd"`        // Price in USD
+	Supply          float64   `db:"supply"`           // Circulating supply
+	MarketCapUSD    float64   `db:"market_cap_usd"`   // Market cap in USD
+	VolumeUSD24h    float64   `db:"volume_usd_24h"`   // Trading volume in last 24h USD
+	UpdatedAt       time.Time `db:"updated_at"`       // Timestamp when the data was last updated in the DB
+	Sources         string    `db:"sources"`          // Comma-separated price feed sources that contributed to the price
 }
 
 // TokenPriceFilter defines criteria for filtering token prices
 type TokenPriceFilter struct {
 	// Symbols is an optional list of token symbols to filter by
 	Symbols []string
+	// AssetIDs is an optional list of price feed asset IDs to filter by
+	AssetIDs []string
+}
+
+// TokenPriceMapping maps a token to the price feed asset its value is derived from
+type TokenPriceMapping struct {
+	ChainType    types.ChainType `db:"chain_type"`
+	TokenAddress string          `db:"token_address"`
+	AssetID      string          `db:"asset_id"`
+	CreatedAt    time.Time       `db:"created_at"`
+	UpdatedAt    time.Time       `db:"updated_at"`
+}
+
+// ContractAssetID returns the asset ID of a price read for a token contract
+func ContractAssetID(chainType types.ChainType, address string) string {
+	return string(chainType) + ":" + strings.ToLower(address)
 }
@@ -8,6 +8,7 @@ import (
 	"vault0/internal/core/pricefeed"
 	"vault0/internal/errors"
 	"vault0/internal/logger"
+	"vault0/internal/services/stream"
 )
 
 type PricePoolingService interface {
@@ -35,17 +36,19 @@ type pollingService struct {
 	provider   pricefeed.PriceFeed
 	log        logger.Logger
 	config     *config.Config
+	stream     stream.Service
 
 	jobCtx    context.Context
 	jobCancel context.CancelFunc
 }
 
-func NewPollingService(repo Repository, provider pricefeed.PriceFeed, log logger.Logger, cfg *config.Config) PricePoolingService {
+func NewPollingService(repo Repository, provider pricefeed.PriceFeed, log logger.Logger, cfg *config.Config, streamService stream.Service) PricePoolingService {
 	return &pollingService{
 		repository: repo,
 		provider:   provider,
 		log:        log,
 		config:     cfg,
+		stream:     streamService,
 	}
 }
 
@@ -155,5 +158,24 @@ func (s *pollingService) RefreshTokenPrices(ctx context.Context) (int64, error)
 		logger.Int("total_valid", len(pricesToUpsert)),
 		logger.Int64("rows_affected", affected))
 
+	s.publishPrices(ctx, pricesToUpsert)
 	return affected, nil
 }
+
+// publishPrices streams the refreshed token prices
+func (s *pollingService) publishPrices(ctx context.Context, prices []*TokenPrice) {
+	data := make([]stream.PriceData, len(prices))
+	for i, price := range prices {
+		data[i] = stream.PriceData{
+			AssetID:         price.AssetID,
+			Symbol:          price.Symbol,
+			ChainType:       price.ChainType,
+			ContractAddress: price.ContractAddress,
+			PriceUSD:        price.PriceUSD,
+		}
+	}
+
+	if err := s.stream.Publish(ctx, stream.EventTypePrice, stream.Scope{}, data); err != nil {
+		s.log.Error("Failed to publish token prices", logger.Error(err))
+	}
+}
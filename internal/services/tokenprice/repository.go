@@ -16,7 +16,7 @@ import (
 
 // Repository defines the interface for data access operations related to token prices.
 type Repository interface {
-	// UpsertMany inserts new token prices or updates existing ones based on the Symbol.
+	// UpsertMany inserts new token prices or updates existing ones based on the AssetID.
 	// It should perform this operation efficiently, potentially using batching or specific
 	// database features for bulk upserts.
 	//
@@ -26,6 +26,7 @@ type Repository interface {
 	UpsertMany(ctx context.Context, prices []*TokenPrice) (int64, error)
 
 	// GetBySymbol retrieves the stored price data for a specific token symbol.
+	// When several assets share the symbol, the best ranked one is returned.
 	//
 	// Returns:
 	//   - A pointer to the TokenPrice if found.
@@ -33,6 +34,22 @@ type Repository interface {
 	//   - Other database errors (e.g., ErrDatabaseOperationFailed).
 	GetBySymbol(ctx context.Context, symbol string) (*TokenPrice, error)
 
+	// GetByAssetID retrieves the stored price data of a price feed asset.
+	//
+	// Returns:
+	//   - A pointer to the TokenPrice if found.
+	//   - ErrTokenPriceNotFound if no price data exists for the asset.
+	//   - Other database errors (e.g., ErrDatabaseOperationFailed).
+	GetByAssetID(ctx context.Context, assetID string) (*TokenPrice, error)
+
+	// GetByContract retrieves the stored price data read for a token contract.
+	//
+	// Returns:
+	//   - A pointer to the TokenPrice if found.
+	//   - ErrTokenPriceNotFound if no price data exists for the contract.
+	//   - Other database errors (e.g., ErrDatabaseOperationFailed).
+	GetByContract(ctx context.Context, chainType types.ChainType, address string) (*TokenPrice, error)
+
 	// List retrieves a paginated list of stored token prices, optionally filtered and sorted.
 	// Uses token-based pagination for consistent results.
 	//
@@ -46,13 +63,32 @@ type Repository interface {
 	//   - A page of token prices with pagination information
 	//   - An error if the database operation fails
 	List(ctx context.Context, filter *TokenPriceFilter, limit int, nextToken string) (*types.Page[*TokenPrice], error)
+
+	// UpsertMapping creates or replaces the price feed asset mapped to a token.
+	UpsertMapping(ctx context.Context, mapping *TokenPriceMapping) error
+
+	// GetMapping retrieves the price feed asset mapped to a token.
+	//
+	// Returns:
+	//   - ErrResourceNotFound if the token is not mapped.
+	GetMapping(ctx context.Context, chainType types.ChainType, tokenAddress string) (*TokenPriceMapping, error)
+
+	// DeleteMapping removes the price feed asset mapped to a token.
+	//
+	// Returns:
+	//   - ErrResourceNotFound if the token is not mapped.
+	DeleteMapping(ctx context.Context, chainType types.ChainType, tokenAddress string) error
+
+	// ListMappings retrieves all token price mappings ordered by chain and token address.
+	ListMappings(ctx context.Context) ([]*TokenPriceMapping, error)
 }
 
 // repository implements Repository interface for SQLite
 type repository struct {
-	db        *db.DB
-	log       logger.Logger
-	structMap *sqlbuilder.Struct
+	db               *db.DB
+	log              logger.Logger
+	structMap        *sqlbuilder.Struct
+	mappingStructMap *sqlbuilder.Struct
 }
 
 // NewRepository creates a new SQLite repository for token prices
@@ -61,9 +97,10 @@ func NewRepository(db *db.DB, log logger.Logger) Repository {
 	structMap := sqlbuilder.NewStruct(new(TokenPrice))
 
 	return &repository{
-		db:        db,
-		log:       log,
-		structMap: structMap,
+		db:               db,
+		log:              log,
+		structMap:        structMap,
+		mappingStructMap: sqlbuilder.NewStruct(new(TokenPriceMapping)),
 	}
 }
 
@@ -92,37 +129,43 @@ func (r *repository) executeTokenPriceQuery(ctx context.Context, sql string, arg
 }
 
 // UpsertMany inserts or updates multiple token prices in the database
-// First finds existing tokens by symbols, then updates them, and inserts new ones
+// First finds existing tokens by asset IDs, then updates them, and inserts new ones
 func (r *repository) UpsertMany(ctx context.Context, prices []*TokenPrice) (int64, error) {
 	if len(prices) == 0 {
 		return 0, nil
 	}
 
-	// Start a transaction
-	conn := r.db.GetConnection()
-	tx, err := conn.BeginTx(ctx, nil)
+	// Store all prices in a single transaction
+	var totalAffected int64
+	err := r.db.WithTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		totalAffected, err = r.upsertMany(ctx, prices)
+		return err
+	})
 	if err != nil {
+		r.log.Error("Failed to store token prices", logger.Error(err))
 		return 0, err
 	}
-	defer tx.Rollback()
 
+	return totalAffected, nil
+}
+
+// upsertMany updates the stored prices and inserts the new ones
+func (r *repository) upsertMany(ctx context.Context, prices []*TokenPrice) (int64, error) {
 	// Update timestamp for all prices
 	now := time.Now()
 	for i := range prices {
 		prices[i].UpdatedAt = now
 	}
 
-	// Extract symbols for lookup
-	symbols := make([]string, 0, len(prices))
-	pricesBySymbol := make(map[string]*TokenPrice, len(prices))
+	// Extract asset IDs for lookup
+	assetIDs := make([]string, 0, len(prices))
 	for _, price := range prices {
-		upperSymbol := strings.ToUpper(price.Symbol)
-		symbols = append(symbols, upperSymbol)
-		pricesBySymbol[upperSymbol] = price
+		assetIDs = append(assetIDs, price.AssetID)
 	}
 
-	// Use FindBySymbols to get existing price data
-	existingPrices, err := r.mapPriceBySymbol(ctx, symbols)
+	// Use mapPriceByAssetID to get existing price data
+	existingPrices, err := r.mapPriceByAssetID(ctx, assetIDs)
 	if err != nil {
 		r.log.Error("Failed to find existing token prices", logger.Error(err))
 		return 0, err
@@ -133,7 +176,7 @@ func (r *repository) UpsertMany(ctx context.Context, prices []*TokenPrice) (int6
 	var toInsert []*TokenPrice
 
 	for _, price := range prices {
-		if _, exists := existingPrices[strings.ToUpper(price.Symbol)]; exists {
+		if _, exists := existingPrices[price.AssetID]; exists {
 			toUpdate = append(toUpdate, price)
 		} else {
 			toInsert = append(toInsert, price)
@@ -146,14 +189,14 @@ func (r *repository) UpsertMany(ctx context.Context, prices []*TokenPrice) (int6
 	// Prepare update statement if needed
 	if len(toUpdate) > 0 {
 		updateSQL := `UPDATE token_prices SET 
-			rank = ?, price_usd = ?, supply = ?, 
-			market_cap_usd = ?, volume_usd_24h = ?, updated_at = ? 
-			WHERE symbol = ?`
+			symbol = ?, chain_type = ?, contract_address = ?, rank = ?, price_usd = ?, supply = ?, 
+			market_cap_usd = ?, volume_usd_24h = ?, updated_at = ?, sources = ? 
+			WHERE asset_id = ?`
 
 		// For debugging, log the SQL
 		r.log.Debug("Generated update SQL", logger.String("sql", updateSQL))
 
-		updateStmt, err := tx.Prepare(updateSQL)
+		updateStmt, err := r.db.PrepareContext(ctx, updateSQL)
 		if err != nil {
 			r.log.Error("Failed to prepare update statement", logger.Error(err))
 			return 0, err
@@ -164,18 +207,22 @@ func (r *repository) UpsertMany(ctx context.Context, prices []*TokenPrice) (int6
 		for _, price := range toUpdate {
 			result, err := updateStmt.ExecContext(
 				ctx,
+				price.Symbol,
+				price.ChainType,
+				price.ContractAddress,
 				price.Rank,
 				price.PriceUSD,
 				price.Supply,
 				price.MarketCapUSD,
 				price.VolumeUSD24h,
 				price.UpdatedAt,
-				price.Symbol, // WHERE clause param
+				price.Sources,
+				price.AssetID, // WHERE clause param
 			)
 
 			if err != nil {
 				r.log.Error("Failed to update token price",
-					logger.String("symbol", price.Symbol),
+					logger.String("asset_id", price.AssetID),
 					logger.String("sql", updateSQL),
 					logger.Error(err))
 				continue // Skip this one but continue with others
@@ -193,13 +240,14 @@ func (r *repository) UpsertMany(ctx context.Context, prices []*TokenPrice) (int6
 	if len(toInsert) > 0 {
 		// Create a manual SQL statement for insert
 		insertSQL := `INSERT INTO token_prices (
-			symbol, rank, price_usd, supply, market_cap_usd, volume_usd_24h, updated_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?)`
+			asset_id, symbol, chain_type, contract_address, rank, price_usd, supply, 
+			market_cap_usd, volume_usd_24h, updated_at, sources
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 		// For debugging, log the SQL
 		r.log.Debug("Generated insert SQL", logger.String("sql", insertSQL))
 
-		insertStmt, err := tx.Prepare(insertSQL)
+		insertStmt, err := r.db.PrepareContext(ctx, insertSQL)
 		if err != nil {
 			r.log.Error("Failed to prepare insert statement", logger.Error(err))
 			return 0, err
@@ -210,18 +258,22 @@ func (r *repository) UpsertMany(ctx context.Context, prices []*TokenPrice) (int6
 		for _, price := range toInsert {
 			_, err := insertStmt.ExecContext(
 				ctx,
+				price.AssetID,
 				price.Symbol,
+				price.ChainType,
+				price.ContractAddress,
 				price.Rank,
 				price.PriceUSD,
 				price.Supply,
 				price.MarketCapUSD,
 				price.VolumeUSD24h,
 				price.UpdatedAt,
+				price.Sources,
 			)
 
 			if err != nil {
 				r.log.Error("Failed to insert token price",
-					logger.String("symbol", price.Symbol),
+					logger.String("asset_id", price.AssetID),
 					logger.String("sql", insertSQL),
 					logger.Error(err))
 				continue // Skip this one but continue with others
@@ -232,16 +284,10 @@ func (r *repository) UpsertMany(ctx context.Context, prices []*TokenPrice) (int6
 		}
 	}
 
-	// Commit the transaction
-	if err := tx.Commit(); err != nil {
-		r.log.Error("Failed to commit transaction", logger.Error(err))
-		return 0, err
-	}
-
 	return totalAffected, nil
 }
 
-// GetBySymbol retrieves a token price by its symbol
+// GetBySymbol retrieves the best ranked token price with the given symbol
 func (r *repository) GetBySymbol(ctx context.Context, symbol string) (*TokenPrice, error) {
 	// Convert symbol to uppercase for consistency
 	upperSymbol := strings.ToUpper(symbol)
@@ -249,6 +295,8 @@ func (r *repository) GetBySymbol(ctx context.Context, symbol string) (*TokenPric
 	// Create a struct-based select builder
 	sb := r.structMap.SelectFrom("token_prices")
 	sb.Where(sb.Equal("symbol", upperSymbol))
+	// Prefer ranked assets, unranked ones are typically long-tail tokens
+	sb.OrderBy("CASE WHEN rank > 0 THEN 0 ELSE 1 END", "rank ASC", "updated_at DESC")
 	sb.Limit(1)
 
 	// Build the SQL and args
@@ -267,38 +315,77 @@ func (r *repository) GetBySymbol(ctx context.Context, symbol string) (*TokenPric
 	return prices[0], nil
 }
 
-// mapPriceBySymbol retrieves token prices for a list of symbols
-func (r *repository) mapPriceBySymbol(ctx context.Context, symbols []string) (map[string]*TokenPrice, error) {
-	if len(symbols) == 0 {
+// GetByAssetID retrieves a token price by its price feed asset ID
+func (r *repository) GetByAssetID(ctx context.Context, assetID string) (*TokenPrice, error) {
+	sb := r.structMap.SelectFrom("token_prices")
+	sb.Where(sb.Equal("asset_id", assetID))
+	sb.Limit(1)
+
+	sql, args := sb.Build()
+	prices, err := r.executeTokenPriceQuery(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(prices) == 0 {
+		return nil, errors.NewTokenPriceNotFoundError(assetID)
+	}
+
+	return prices[0], nil
+}
+
+// GetByContract retrieves a token price read for a token contract
+func (r *repository) GetByContract(ctx context.Context, chainType types.ChainType, address string) (*TokenPrice, error) {
+	sb := r.structMap.SelectFrom("token_prices")
+	sb.Where(
+		sb.Equal("chain_type", string(chainType)),
+		sb.Equal("contract_address", strings.ToLower(address)),
+	)
+	sb.OrderBy("CASE WHEN rank > 0 THEN 0 ELSE 1 END", "rank ASC")
+	sb.Limit(1)
+
+	sql, args := sb.Build()
+	prices, err := r.executeTokenPriceQuery(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(prices) == 0 {
+		return nil, errors.NewTokenPriceNotFoundError(ContractAssetID(chainType, address))
+	}
+
+	return prices[0], nil
+}
+
+// mapPriceByAssetID retrieves token prices for a list of asset IDs
+func (r *repository) mapPriceByAssetID(ctx context.Context, assetIDs []string) (map[string]*TokenPrice, error) {
+	if len(assetIDs) == 0 {
 		return make(map[string]*TokenPrice), nil
 	}
 
-	// Normalize symbols to uppercase for consistent comparison
-	upperSymbols := make([]any, len(symbols))
-	for i, symbol := range symbols {
-		upperSymbols[i] = strings.ToUpper(symbol)
+	ids := make([]any, len(assetIDs))
+	for i, assetID := range assetIDs {
+		ids[i] = assetID
 	}
 
 	// Create a select builder for the query
 	sb := r.structMap.SelectFrom("token_prices")
-	sb.Where(sb.In("symbol", upperSymbols...))
-	// Order by rank
-	sb.OrderBy("rank ASC")
+	sb.Where(sb.In("asset_id", ids...))
 
 	// Build and execute the query
 	sql, args := sb.Build()
 	prices, err := r.executeTokenPriceQuery(ctx, sql, args...)
 	if err != nil {
-		r.log.Error("Failed to find token prices by symbols",
-			logger.Int("symbol_count", len(symbols)),
+		r.log.Error("Failed to find token prices by asset IDs",
+			logger.Int("asset_count", len(assetIDs)),
 			logger.Error(err))
 		return nil, err
 	}
 
-	// Create a map of symbol to token price
+	// Create a map of asset ID to token price
 	result := make(map[string]*TokenPrice, len(prices))
 	for _, price := range prices {
-		result[price.Symbol] = price
+		result[price.AssetID] = price
 	}
 
 	return result, nil
@@ -321,6 +408,15 @@ func (r *repository) List(ctx context.Context, filter *TokenPriceFilter, limit i
 		sb.Where(sb.In("symbol", upperSymbols...))
 	}
 
+	// Apply filtering if asset IDs are provided
+	if filter != nil && len(filter.AssetIDs) > 0 {
+		assetIDs := make([]any, len(filter.AssetIDs))
+		for i, assetID := range filter.AssetIDs {
+			assetIDs[i] = assetID
+		}
+		sb.Where(sb.In("asset_id", assetIDs...))
+	}
+
 	// Apply sorting by rank for consistent pagination
 	sb.OrderBy("rank ASC")
 
@@ -371,16 +467,143 @@ func ScanTokenPrice(rows interface {
 }) (*TokenPrice, error) {
 	var price TokenPrice
 	err := rows.Scan(
+		&price.AssetID,
 		&price.Symbol,
+		&price.ChainType,
+		&price.ContractAddress,
 		&price.Rank,
 		&price.PriceUSD,
 		&price.Supply,
 		&price.MarketCapUSD,
 		&price.VolumeUSD24h,
 		&price.UpdatedAt,
+		&price.Sources,
 	)
 	if err != nil {
 		return nil, err
 	}
 	return &price, nil
 }
+
+// UpsertMapping creates or replaces the price feed asset mapped to a token
+func (r *repository) UpsertMapping(ctx context.Context, mapping *TokenPriceMapping) error {
+	mapping.TokenAddress = strings.ToLower(mapping.TokenAddress)
+	now := time.Now()
+	mapping.UpdatedAt = now
+
+	existing, err := r.GetMapping(ctx, mapping.ChainType, mapping.TokenAddress)
+	if err != nil && !errors.IsError(err, errors.ErrCodeResourceNotFound) {
+		return err
+	}
+
+	if existing != nil {
+		mapping.CreatedAt = existing.CreatedAt
+
+		ub := r.mappingStructMap.Update("token_price_mappings", mapping)
+		ub.Where(
+			ub.Equal("chain_type", string(mapping.ChainType)),
+			ub.Equal("token_address", mapping.TokenAddress),
+		)
+		sql, args := ub.Build()
+		if _, err := r.db.ExecuteStatementContext(ctx, sql, args...); err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		return nil
+	}
+
+	mapping.CreatedAt = now
+	ib := r.mappingStructMap.InsertInto("token_price_mappings", mapping)
+	sql, args := ib.Build()
+	if _, err := r.db.ExecuteStatementContext(ctx, sql, args...); err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+// GetMapping retrieves the price feed asset mapped to a token
+func (r *repository) GetMapping(ctx context.Context, chainType types.ChainType, tokenAddress string) (*TokenPriceMapping, error) {
+	sb := r.mappingStructMap.SelectFrom("token_price_mappings")
+	sb.Where(
+		sb.Equal("chain_type", string(chainType)),
+		sb.Equal("token_address", strings.ToLower(tokenAddress)),
+	)
+	sb.Limit(1)
+
+	sql, args := sb.Build()
+	mappings, err := r.executeMappingQuery(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(mappings) == 0 {
+		return nil, errors.NewResourceNotFoundError("token_price_mapping", tokenAddress)
+	}
+
+	return mappings[0], nil
+}
+
+// DeleteMapping removes the price feed asset mapped to a token
+func (r *repository) DeleteMapping(ctx context.Context, chainType types.ChainType, tokenAddress string) error {
+	db := sqlbuilder.NewDeleteBuilder()
+	db.DeleteFrom("token_price_mappings")
+	db.Where(
+		db.Equal("chain_type", string(chainType)),
+		db.Equal("token_address", strings.ToLower(tokenAddress)),
+	)
+
+	sql, args := db.Build()
+	result, err := r.db.ExecuteStatementContext(ctx, sql, args...)
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.NewResourceNotFoundError("token_price_mapping", tokenAddress)
+	}
+
+	return nil
+}
+
+// ListMappings retrieves all token price mappings
+func (r *repository) ListMappings(ctx context.Context) ([]*TokenPriceMapping, error) {
+	sb := r.mappingStructMap.SelectFrom("token_price_mappings")
+	sb.OrderBy("chain_type ASC", "token_address ASC")
+
+	sql, args := sb.Build()
+	return r.executeMappingQuery(ctx, sql, args...)
+}
+
+// executeMappingQuery executes a query and scans the results into TokenPriceMapping objects
+func (r *repository) executeMappingQuery(ctx context.Context, sql string, args ...any) ([]*TokenPriceMapping, error) {
+	rows, err := r.db.ExecuteQueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var mappings []*TokenPriceMapping
+	for rows.Next() {
+		var mapping TokenPriceMapping
+		if err := rows.Scan(
+			&mapping.ChainType,
+			&mapping.TokenAddress,
+			&mapping.AssetID,
+			&mapping.CreatedAt,
+			&mapping.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, &mapping)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return mappings, nil
+}
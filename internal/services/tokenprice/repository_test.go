@@ -0,0 +1,86 @@
+package tokenprice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/db"
+	"vault0/internal/errors"
+	"vault0/internal/testing/dbtest"
+	"vault0/internal/testing/mocks"
+	"vault0/internal/types"
+)
+
+func TestRepository_UpsertAndGet(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database, mocks.NewNopLogger())
+		ctx := context.Background()
+		now := time.Now().UTC()
+
+		prices := []*TokenPrice{
+			{AssetID: "usdc-usd-coin", Symbol: "USDC", Rank: 6, PriceUSD: 1.0001, Supply: 3.2e10, MarketCapUSD: 3.2e10, VolumeUSD24h: 5e9, UpdatedAt: now, Sources: "coinpaprika"},
+			{AssetID: "usdc-bridged", Symbol: "USDC", Rank: 900, PriceUSD: 0.998, Supply: 1e6, MarketCapUSD: 1e6, VolumeUSD24h: 1e4, UpdatedAt: now},
+			// Supplies of some tokens exceed the range of fixed-point columns
+			{AssetID: "shib-shiba-inu", Symbol: "SHIB", Rank: 15, PriceUSD: 0.00002417, Supply: 5.89e14, MarketCapUSD: 1.4e10, VolumeUSD24h: 4e8, UpdatedAt: now},
+		}
+		count, err := repo.UpsertMany(ctx, prices)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+
+		// The best ranked asset wins when several share a symbol
+		price, err := repo.GetBySymbol(ctx, "USDC")
+		require.NoError(t, err)
+		assert.Equal(t, "usdc-usd-coin", price.AssetID)
+		assert.InDelta(t, 1.0001, price.PriceUSD, 1e-9)
+
+		shib, err := repo.GetByAssetID(ctx, "shib-shiba-inu")
+		require.NoError(t, err)
+		assert.InDelta(t, 0.00002417, shib.PriceUSD, 1e-12)
+		assert.InEpsilon(t, 5.89e14, shib.Supply, 1e-9)
+
+		prices[0].PriceUSD = 0.9999
+		_, err = repo.UpsertMany(ctx, prices[:1])
+		require.NoError(t, err)
+		price, err = repo.GetByAssetID(ctx, "usdc-usd-coin")
+		require.NoError(t, err)
+		assert.InDelta(t, 0.9999, price.PriceUSD, 1e-9)
+
+		_, err = repo.GetBySymbol(ctx, "DOGE")
+		assert.True(t, errors.IsError(err, errors.ErrCodeTokenPriceNotFound))
+
+		page, err := repo.List(ctx, &TokenPriceFilter{Symbols: []string{"USDC"}}, 10, "")
+		require.NoError(t, err)
+		assert.Len(t, page.Items, 2)
+	})
+}
+
+func TestRepository_Mappings(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database, mocks.NewNopLogger())
+		ctx := context.Background()
+		tokenAddress := "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+
+		mapping := &TokenPriceMapping{ChainType: types.ChainTypeEthereum, TokenAddress: tokenAddress, AssetID: "usdc-usd-coin"}
+		require.NoError(t, repo.UpsertMapping(ctx, mapping))
+
+		mapping.AssetID = "usdc-bridged"
+		require.NoError(t, repo.UpsertMapping(ctx, mapping))
+
+		got, err := repo.GetMapping(ctx, types.ChainTypeEthereum, tokenAddress)
+		require.NoError(t, err)
+		assert.Equal(t, "usdc-bridged", got.AssetID)
+
+		mappings, err := repo.ListMappings(ctx)
+		require.NoError(t, err)
+		assert.Len(t, mappings, 1)
+
+		require.NoError(t, repo.DeleteMapping(ctx, types.ChainTypeEthereum, tokenAddress))
+		mappings, err = repo.ListMappings(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, mappings)
+	})
+}
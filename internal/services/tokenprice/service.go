@@ -6,6 +6,7 @@ import (
 
 	"vault0/internal/config"
 	"vault0/internal/core/pricefeed"
+	"vault0/internal/core/tokenstore"
 	"vault0/internal/errors" // Custom errors package
 	"vault0/internal/logger"
 	"vault0/internal/types"
@@ -33,20 +34,58 @@ type Service interface {
 	//   - A page of token prices with pagination information
 	//   - An error if the database operation fails
 	ListTokenPrices(ctx context.Context, filter *TokenPriceFilter, limit int, nextToken string) (*types.Page[*TokenPrice], error)
+
+	// GetTokenPriceForToken retrieves the price of a token, resolved in order from:
+	//   - The price feed asset explicitly mapped to the token
+	//   - A price read for the token contract
+	//   - The best ranked price with the token symbol, for native and verified tokens only,
+	//     as unverified tokens commonly reuse the symbols of established ones
+	//
+	// Returns:
+	//   - A pointer to the TokenPrice if found.
+	//   - ErrTokenPriceNotFound if the token cannot be priced.
+	GetTokenPriceForToken(ctx context.Context, token *types.Token) (*TokenPrice, error)
+
+	// GetTokenPricesForTokens retrieves the prices of several tokens as GetTokenPriceForToken.
+	//
+	// Returns:
+	//   - The prices in the order of the tokens, nil for tokens that cannot be priced
+	//   - An error if the database operation fails
+	GetTokenPricesForTokens(ctx context.Context, tokens []*types.Token) ([]*TokenPrice, error)
+
+	// SetTokenPriceMapping maps a token to the price feed asset its value is derived from,
+	// replacing any existing mapping.
+	//
+	// Returns:
+	//   - The stored mapping
+	//   - ErrResourceNotFound if the token is unknown
+	//   - ErrTokenPriceNotFound if no price data exists for the asset
+	SetTokenPriceMapping(ctx context.Context, chainType types.ChainType, tokenAddress, assetID string) (*TokenPriceMapping, error)
+
+	// DeleteTokenPriceMapping removes the price feed asset mapped to a token.
+	//
+	// Returns:
+	//   - ErrResourceNotFound if the token is not mapped
+	DeleteTokenPriceMapping(ctx context.Context, chainType types.ChainType, tokenAddress string) error
+
+	// ListTokenPriceMappings retrieves all token price mappings.
+	ListTokenPriceMappings(ctx context.Context) ([]*TokenPriceMapping, error)
 }
 
 type service struct {
 	repository Repository
 	provider   pricefeed.PriceFeed
+	tokenStore tokenstore.TokenStore
 	log        logger.Logger
 	config     *config.Config
 }
 
 // NewService creates a new token price service instance.
-func NewService(repo Repository, provider pricefeed.PriceFeed, log logger.Logger, cfg *config.Config) Service {
+func NewService(repo Repository, provider pricefeed.PriceFeed, tokenStore tokenstore.TokenStore, log logger.Logger, cfg *config.Config) Service {
 	return &service{
 		repository: repo,
 		provider:   provider,
+		tokenStore: tokenStore,
 		log:        log.With(logger.String("service", "tokenprice")),
 		config:     cfg,
 	}
@@ -94,6 +133,99 @@ func (s *service) ListTokenPrices(ctx context.Context, filter *TokenPriceFilter,
 	return page, nil
 }
 
+// GetTokenPriceForToken implements the Service interface.
+func (s *service) GetTokenPriceForToken(ctx context.Context, token *types.Token) (*TokenPrice, error) {
+	if token == nil {
+		return nil, errors.NewInvalidInputError("Token is required", "token", nil)
+	}
+
+	mapping, err := s.repository.GetMapping(ctx, token.ChainType, token.Address)
+	if err != nil && !errors.IsError(err, errors.ErrCodeResourceNotFound) {
+		return nil, err
+	}
+	if mapping != nil {
+		return s.repository.GetByAssetID(ctx, mapping.AssetID)
+	}
+
+	if !token.IsNative() {
+		price, err := s.repository.GetByContract(ctx, token.ChainType, token.Address)
+		if err == nil || !errors.IsError(err, errors.ErrCodeTokenPriceNotFound) {
+			return price, err
+		}
+		if !token.Verified || token.HasRiskFlags() {
+			return nil, err
+		}
+	}
+
+	return s.repository.GetBySymbol(ctx, token.Symbol)
+}
+
+// GetTokenPricesForTokens implements the Service interface.
+func (s *service) GetTokenPricesForTokens(ctx context.Context, tokens []*types.Token) ([]*TokenPrice, error) {
+	prices := make([]*TokenPrice, len(tokens))
+	for i, token := range tokens {
+		price, err := s.GetTokenPriceForToken(ctx, token)
+		if err != nil {
+			if errors.IsError(err, errors.ErrCodeTokenPriceNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		prices[i] = price
+	}
+	return prices, nil
+}
+
+// SetTokenPriceMapping implements the Service interface.
+func (s *service) SetTokenPriceMapping(ctx context.Context, chainType types.ChainType, tokenAddress, assetID string) (*TokenPriceMapping, error) {
+	assetID = strings.TrimSpace(assetID)
+	if assetID == "" {
+		return nil, errors.NewInvalidInputError("Asset ID cannot be empty", "asset_id", "")
+	}
+
+	token, err := s.tokenStore.GetToken(ctx, tokenAddress)
+	if err != nil {
+		return nil, err
+	}
+	if token.ChainType != chainType {
+		return nil, errors.NewResourceNotFoundError("token", tokenAddress)
+	}
+
+	if _, err := s.repository.GetByAssetID(ctx, assetID); err != nil {
+		return nil, err
+	}
+
+	mapping := &TokenPriceMapping{
+		ChainType:    token.ChainType,
+		TokenAddress: token.Address,
+		AssetID:      assetID,
+	}
+	if err := s.repository.UpsertMapping(ctx, mapping); err != nil {
+		s.log.Error("Failed to store token price mapping",
+			logger.String("chain_type", string(chainType)),
+			logger.String("token_address", tokenAddress),
+			logger.String("asset_id", assetID),
+			logger.Error(err))
+		return nil, err
+	}
+
+	s.log.Info("Token price mapping set",
+		logger.String("chain_type", string(chainType)),
+		logger.String("token_address", tokenAddress),
+		logger.String("asset_id", assetID))
+	return mapping, nil
+}
+
+// DeleteTokenPriceMapping implements the Service interface.
+func (s *service) DeleteTokenPriceMapping(ctx context.Context, chainType types.ChainType, tokenAddress string) error {
+	return s.repository.DeleteMapping(ctx, chainType, tokenAddress)
+}
+
+// ListTokenPriceMappings implements the Service interface.
+func (s *service) ListTokenPriceMappings(ctx context.Context) ([]*TokenPriceMapping, error) {
+	return s.repository.ListMappings(ctx)
+}
+
 // convertProviderDataToTokenPrice converts the data structure from the price feed provider
 // to the service's internal TokenPrice model, returning a DataConversionFailed error on failure.
 func convertProviderDataToTokenPrice(data *pricefeed.TokenPriceData) (*TokenPrice, error) {
@@ -102,7 +234,9 @@ func convertProviderDataToTokenPrice(data *pricefeed.TokenPriceData) (*TokenPric
 		return nil, errors.NewDataConversionFailed(nil, "token symbol is empty", map[string]any{"provider_id": data.ID})
 	}
 
-	return &TokenPrice{
+	// Prices read for a contract are identified by it, others by the provider asset ID
+	price := &TokenPrice{
+		AssetID:      strings.ToLower(strings.TrimSpace(data.ID)),
 		Symbol:       symbol,
 		Rank:         data.Rank,
 		PriceUSD:     data.PriceUSD,
@@ -110,5 +244,16 @@ func convertProviderDataToTokenPrice(data *pricefeed.TokenPriceData) (*TokenPric
 		MarketCapUSD: data.MarketCapUSD,
 		VolumeUSD24h: data.VolumeUSD24h,
 		UpdatedAt:    data.UpdatedAt,
-	}, nil
+		Sources:      strings.Join(data.Sources, ","),
+	}
+	if data.ContractAddress != "" && data.ChainType != "" {
+		price.AssetID = ContractAssetID(data.ChainType, data.ContractAddress)
+		price.ChainType = string(data.ChainType)
+		price.ContractAddress = strings.ToLower(data.ContractAddress)
+	}
+	if price.AssetID == "" {
+		return nil, errors.NewDataConversionFailed(nil, "token asset ID is empty", map[string]any{"symbol": symbol})
+	}
+
+	return price, nil
 }
@@ -100,5 +100,77 @@ func (t *blockchainTransformer) TransformTransaction(ctx context.Context, tx *ty
 	tx.Type = typedTx.GetType()
 	tx.Metadata = typedTx.GetMetadata()
 
+	var logs []types.Log
+	if receipt != nil {
+		logs = receipt.Logs
+	}
+	populateDecodedMetadata(ctx, t.log, decoder, tx, logs)
+
 	return nil
 }
+
+// populateDecodedMetadata stores the generically decoded contract call, receipt
+// events, token and NFT transfers and token approvals in the transaction metadata. Decoding failures are
+// logged and skipped, as not every contract has a resolvable ABI.
+func populateDecodedMetadata(ctx context.Context, log logger.Logger, decoder transaction.Decoder, tx *types.Transaction, logs []types.Log) {
+	if tx.Metadata == nil {
+		tx.Metadata = make(types.TxMetadata)
+	}
+
+	if tx.BaseTransaction.To != "" && len(tx.Data) >= 4 && !tx.Metadata.Contains(types.DecodedCallMetadataKey) {
+		decodedCall, err := decoder.DecodeContractCall(ctx, tx)
+		if err != nil {
+			log.Debug("Could not decode contract call",
+				logger.String("tx_hash", tx.Hash),
+				logger.Error(err))
+		} else if err := tx.Metadata.SetJSON(types.DecodedCallMetadataKey, decodedCall); err != nil {
+			log.Warn("Failed to store decoded contract call",
+				logger.String("tx_hash", tx.Hash),
+				logger.Error(err))
+		}
+	}
+
+	if len(logs) > 0 && !tx.Metadata.Contains(types.DecodedEventsMetadataKey) {
+		// An empty list is stored too, marking the logs as decoded so that they aren't
+		// looked up again when the transaction is processed anew
+		events := decoder.DecodeEvents(ctx, tx.ChainType, logs)
+		if err := tx.Metadata.SetJSON(types.DecodedEventsMetadataKey, events); err != nil {
+			log.Warn("Failed to store decoded events",
+				logger.String("tx_hash", tx.Hash),
+				logger.Error(err))
+		}
+	}
+
+	if len(logs) > 0 && !tx.Metadata.Contains(types.NFTTransfersMetadataKey) {
+		transfers := decoder.DecodeNFTTransfers(ctx, tx.ChainType, logs)
+		if len(transfers) > 0 {
+			if err := tx.Metadata.SetJSON(types.NFTTransfersMetadataKey, transfers); err != nil {
+				log.Warn("Failed to store NFT transfers",
+					logger.String("tx_hash", tx.Hash),
+					logger.Error(err))
+			}
+		}
+	}
+
+	if len(logs) > 0 && !tx.Metadata.Contains(types.TokenTransfersMetadataKey) {
+		transfers := decoder.DecodeTokenTransfers(ctx, tx.ChainType, logs)
+		if len(transfers) > 0 {
+			if err := tx.Metadata.SetJSON(types.TokenTransfersMetadataKey, transfers); err != nil {
+				log.Warn("Failed to store token transfers",
+					logger.String("tx_hash", tx.Hash),
+					logger.Error(err))
+			}
+		}
+	}
+
+	if len(logs) > 0 && !tx.Metadata.Contains(types.TokenApprovalsMetadataKey) {
+		approvals := decoder.DecodeTokenApprovals(ctx, tx.ChainType, logs)
+		if len(approvals) > 0 {
+			if err := tx.Metadata.SetJSON(types.TokenApprovalsMetadataKey, approvals); err != nil {
+				log.Warn("Failed to store token approvals",
+					logger.String("tx_hash", tx.Hash),
+					logger.Error(err))
+			}
+		}
+	}
+}
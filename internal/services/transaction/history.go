@@ -11,6 +11,7 @@ import (
 	"vault0/internal/core/blockchain"
 	"vault0/internal/core/blockexplorer"
 	"vault0/internal/core/tokenstore"
+	"vault0/internal/db"
 	"vault0/internal/errors"
 	"vault0/internal/logger"
 	"vault0/internal/types"
@@ -43,6 +44,7 @@ func NewHistoryService(
 	transformer TransformerService,
 	repository Repository,
 	tokenStore tokenstore.TokenStore,
+	transactor db.Transactor,
 ) HistoryService {
 	service := &historyService{
 		config:               config,
@@ -52,6 +54,7 @@ func NewHistoryService(
 		transformerService:   transformer,
 		repository:           repository,
 		tokenStore:           tokenStore,
+		transactor:           transactor,
 		syncMutex:            sync.RWMutex{},
 		syncAddresses:        make(map[string]addressSyncInfo),
 		historyEventsChan:    make(chan *TransactionEvent, 100),
@@ -78,6 +81,7 @@ type historyService struct {
 	transformerService   TransformerService
 	repository           Repository
 	tokenStore           tokenstore.TokenStore
+	transactor           db.Transactor
 }
 
 // addressSyncInfo holds the address and start block number for syncing
@@ -322,40 +326,11 @@ func (s *historyService) syncTransactionsForAddressByType(ctx context.Context, a
 		}
 
 		// Save or update transaction
-		existingTx, err := s.repository.GetByHash(ctx, serviceTx.Hash)
-		if err != nil && !errors.IsError(err, errors.ErrCodeTransactionNotFound) {
-			s.log.Error("Error checking for existing transaction",
-				logger.String("tx_hash", serviceTx.Hash),
-				logger.Error(err))
+		isNewTransaction, err := s.saveTransaction(ctx, serviceTx)
+		if err != nil {
 			continue
 		}
 
-		var isNewTransaction bool
-		if existingTx != nil {
-			// Update existing transaction with new data
-			serviceTx.ID = existingTx.ID
-			if err := s.repository.Update(ctx, serviceTx); err != nil {
-				s.log.Error("Failed to update transaction",
-					logger.String("tx_hash", serviceTx.Hash),
-					logger.Error(err))
-				continue
-			}
-			s.log.Debug("Updated existing transaction",
-				logger.String("tx_hash", serviceTx.Hash))
-			isNewTransaction = false
-		} else {
-			// Create new transaction
-			if err := s.repository.Create(ctx, serviceTx); err != nil {
-				s.log.Error("Failed to create transaction",
-					logger.String("tx_hash", serviceTx.Hash),
-					logger.Error(err))
-				continue
-			}
-			s.log.Debug("Created new transaction",
-				logger.String("tx_hash", serviceTx.Hash))
-			isNewTransaction = true
-		}
-
 		// Emit transaction event
 		event := &TransactionEvent{
 			Transaction: transformedTx,
@@ -379,6 +354,48 @@ func (s *historyService) syncTransactionsForAddressByType(ctx context.Context, a
 	return nil
 }
 
+// saveTransaction creates a synced transaction or updates it if it is already stored,
+// reporting whether it was created. The lookup and the write share one transaction.
+func (s *historyService) saveTransaction(ctx context.Context, serviceTx *Transaction) (bool, error) {
+	var isNew bool
+	err := s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		existingTx, err := s.repository.GetByHash(ctx, serviceTx.Hash)
+		if err != nil && !errors.IsError(err, errors.ErrCodeTransactionNotFound) {
+			s.log.Error("Error checking for existing transaction",
+				logger.String("tx_hash", serviceTx.Hash),
+				logger.Error(err))
+			return err
+		}
+
+		if existingTx != nil {
+			// Update existing transaction with new data
+			serviceTx.ID = existingTx.ID
+			if err := s.repository.Update(ctx, serviceTx); err != nil {
+				s.log.Error("Failed to update transaction",
+					logger.String("tx_hash", serviceTx.Hash),
+					logger.Error(err))
+				return err
+			}
+			s.log.Debug("Updated existing transaction",
+				logger.String("tx_hash", serviceTx.Hash))
+			return nil
+		}
+
+		// Create new transaction
+		if err := s.repository.Create(ctx, serviceTx); err != nil {
+			s.log.Error("Failed to create transaction",
+				logger.String("tx_hash", serviceTx.Hash),
+				logger.Error(err))
+			return err
+		}
+		s.log.Debug("Created new transaction",
+			logger.String("tx_hash", serviceTx.Hash))
+		isNew = true
+		return nil
+	})
+	return isNew, err
+}
+
 // isValidERC20Token checks if the ERC20 token in the transaction exists in the token store
 // Returns true if the token is valid, false otherwise
 func (s *historyService) isValidERC20Token(ctx context.Context, tx *types.Transaction) bool {
@@ -431,8 +448,23 @@ func (s *historyService) syncTransactionsForAddress(ctx context.Context, address
 		// Continue with other transaction types despite error
 	}
 
+	// Then sync NFT transfers
+	if err := s.syncTransactionsForAddressByType(ctx, address, blockexplorer.TxTypeERC721); err != nil {
+		s.log.Error("Failed to sync ERC721 transactions for address",
+			logger.String("address", address.String()),
+			logger.Error(err))
+		// Continue with other transaction types despite error
+	}
+
+	if err := s.syncTransactionsForAddressByType(ctx, address, blockexplorer.TxTypeERC1155); err != nil {
+		s.log.Error("Failed to sync ERC1155 transactions for address",
+			logger.String("address", address.String()),
+			logger.Error(err))
+		// Continue with other transaction types despite error
+	}
+
 	// Could add support for other transaction types in the future
-	// such as blockexplorer.TxTypeInternal, blockexplorer.TxTypeERC721
+	// such as blockexplorer.TxTypeInternal
 
 	return nil
 }
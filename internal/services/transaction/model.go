@@ -217,6 +217,7 @@ type Filter struct {
 	MinBlock     *big.Int
 	MaxBlock     *big.Int
 	TokenAddress *string // For filtering by contract address
+	HideFlagged  bool    // Excludes transactions flagged as spam or scams
 }
 
 // Helper method to check if any filter criteria are set
@@ -230,5 +231,6 @@ func (f *Filter) IsEmpty() bool {
 		f.BlockNumber == nil &&
 		f.MinBlock == nil &&
 		f.MaxBlock == nil &&
-		f.TokenAddress == nil)
+		f.TokenAddress == nil &&
+		!f.HideFlagged)
 }
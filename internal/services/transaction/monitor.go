@@ -8,6 +8,7 @@ import (
 	"time"
 	"vault0/internal/core/blockchain"
 	"vault0/internal/core/transaction"
+	"vault0/internal/db"
 	"vault0/internal/errors"
 	"vault0/internal/logger"
 	"vault0/internal/types"
@@ -53,6 +54,7 @@ func NewMonitorService(
 	transformer TransformerService,
 	txFactory transaction.Factory,
 	repository Repository,
+	transactor db.Transactor,
 ) MonitorService {
 	return &monitorService{
 		monitorMutex:          sync.RWMutex{},
@@ -63,6 +65,7 @@ func NewMonitorService(
 		transformer:           transformer,
 		txFactory:             txFactory,
 		repository:            repository,
+		transactor:            transactor,
 	}
 }
 
@@ -80,6 +83,7 @@ type monitorService struct {
 	transformer       TransformerService  // Transformer for raw transactions
 	txFactory         transaction.Factory // Factory for creating transaction mappers
 	repository        Repository          // Repository for persisting transactions
+	transactor        db.Transactor       // Runs the lookup and storage of a transaction atomically
 }
 
 // MonitorAddress adds an address to the list of monitored addresses.
@@ -298,49 +302,59 @@ func (s *monitorService) saveTransaction(ctx context.Context, tx *types.Transact
 		return false, fmt.Errorf("failed to convert transaction to service model")
 	}
 
-	// Check if transaction already exists
-	existingTx, err := s.repository.GetByHash(ctx, tx.Hash)
-	if err == nil && existingTx != nil {
-		// Transaction already exists, update it
-		existingTx.Status = serviceTx.Status
-		if serviceTx.BlockNumber != nil {
-			existingTx.BlockNumber = serviceTx.BlockNumber
-		}
-		if tx.GasUsed > 0 {
-			existingTx.GasUsed = sql.NullInt64{
-				Int64: int64(tx.GasUsed),
-				Valid: true,
+	// Look up and store the transaction in one transaction so that a transaction
+	// seen concurrently is not created twice
+	var isNew bool
+	err := s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		// Check if transaction already exists
+		existingTx, err := s.repository.GetByHash(ctx, tx.Hash)
+		if err == nil && existingTx != nil {
+			// Transaction already exists, update it
+			existingTx.Status = serviceTx.Status
+			if serviceTx.BlockNumber != nil {
+				existingTx.BlockNumber = serviceTx.BlockNumber
 			}
-		}
-		if tx.Timestamp > 0 {
-			existingTx.Timestamp = sql.NullInt64{
-				Int64: tx.Timestamp,
-				Valid: true,
+			if tx.GasUsed > 0 {
+				existingTx.GasUsed = sql.NullInt64{
+					Int64: int64(tx.GasUsed),
+					Valid: true,
+				}
 			}
+			if tx.Timestamp > 0 {
+				existingTx.Timestamp = sql.NullInt64{
+					Int64: tx.Timestamp,
+					Valid: true,
+				}
+			}
+			existingTx.UpdatedAt = time.Now()
+
+			err = s.repository.Update(ctx, existingTx)
+			if err != nil {
+				s.log.Error("Failed to update transaction",
+					logger.String("tx_hash", tx.Hash),
+					logger.Error(err),
+				)
+				return err
+			}
+			return nil
 		}
-		existingTx.UpdatedAt = time.Now()
 
-		err = s.repository.Update(ctx, existingTx)
+		// Transaction doesn't exist, create it
+		err = s.repository.Create(ctx, serviceTx)
 		if err != nil {
-			s.log.Error("Failed to update transaction",
+			s.log.Error("Failed to save transaction",
 				logger.String("tx_hash", tx.Hash),
 				logger.Error(err),
 			)
-			return false, err
+			return err
 		}
-		return false, nil
-	}
-
-	// Transaction doesn't exist, create it
-	err = s.repository.Create(ctx, serviceTx)
+		isNew = true
+		return nil
+	})
 	if err != nil {
-		s.log.Error("Failed to save transaction",
-			logger.String("tx_hash", tx.Hash),
-			logger.Error(err),
-		)
 		return false, err
 	}
-	return true, nil
+	return isNew, nil
 }
 
 // processRawTransactionEvents listens to raw events from a specific blockchain monitor,
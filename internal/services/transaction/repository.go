@@ -37,6 +37,10 @@ type Repository interface {
 
 	// UpdateTransactionStatus updates only the status and updated_at fields of a transaction by its hash.
 	UpdateTransactionStatus(ctx context.Context, txHash string, status types.TransactionStatus) error
+
+	// ListCounterparties retrieves the distinct addresses involved in the most recent
+	// transactions of a wallet that were not flagged as spam, including the wallet's own address
+	ListCounterparties(ctx context.Context, walletID int64, limit int) ([]string, error)
 }
 
 // repository implements Repository interface for SQLite
@@ -209,6 +213,10 @@ func (r *repository) List(ctx context.Context, filter *Filter, limit int, nextTo
 		if filter.MaxBlock != nil {
 			sb.Where(sb.LE("block_number", filter.MaxBlock.String()))
 		}
+
+		if filter.HideFlagged {
+			sb.Where(sb.IsNull("metadata->>'" + types.RiskFlagsMetadataKey + "'"))
+		}
 	}
 
 	// Always exclude soft-deleted records unless explicitly requested (not supported by current filter)
@@ -402,3 +410,47 @@ func (r *repository) UpdateTransactionStatus(ctx context.Context, txHash string,
 
 	return nil
 }
+
+// ListCounterparties retrieves the addresses involved in the recent unflagged transactions of a wallet
+func (r *repository) ListCounterparties(ctx context.Context, walletID int64, limit int) ([]string, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("from_address", "to_address", "COALESCE(metadata->>'recipient', '')")
+	sb.From("transactions")
+	sb.Where(
+		sb.E("wallet_id", walletID),
+		sb.IsNull("deleted_at"),
+		sb.IsNull("metadata->>'"+types.RiskFlagsMetadataKey+"'"),
+	)
+	sb.OrderBy("id").Desc()
+	if limit > 0 {
+		sb.Limit(limit)
+	}
+
+	sql, args := sb.Build()
+	rows, err := r.db.ExecuteQueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+	defer rows.Close()
+
+	seen := make(map[string]bool)
+	var addresses []string
+	for rows.Next() {
+		var from, to, recipient string
+		if err := rows.Scan(&from, &to, &recipient); err != nil {
+			return nil, errors.NewDatabaseError(err)
+		}
+		for _, address := range []string{from, to, recipient} {
+			if address != "" && !seen[address] {
+				seen[address] = true
+				addresses = append(addresses, address)
+			}
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	return addresses, nil
+}
@@ -0,0 +1,108 @@
+package transaction
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/db"
+	"vault0/internal/testing/dbtest"
+	"vault0/internal/testing/mocks"
+	"vault0/internal/types"
+)
+
+const (
+	testWalletAddress    = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+	testTokenAddress     = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+	testRecipientAddress = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	testSenderAddress    = "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359"
+)
+
+func newTestTransaction(hash, from, to string, metadata types.TxMetadata) *Transaction {
+	value := types.NewBigInt(big.NewInt(1000))
+	gasPrice := types.NewBigInt(big.NewInt(20000000000))
+	blockNumber := types.NewBigInt(big.NewInt(19000000))
+
+	return &Transaction{
+		WalletID:    sql.NullInt64{Int64: 1, Valid: true},
+		Chain:       types.ChainTypeEthereum,
+		Hash:        hash,
+		From:        from,
+		To:          to,
+		Value:       &value,
+		Nonce:       7,
+		GasPrice:    &gasPrice,
+		GasLimit:    21000,
+		Type:        types.TransactionTypeContractCall,
+		Status:      types.TransactionStatusSuccess,
+		Timestamp:   sql.NullInt64{Int64: 1700000000, Valid: true},
+		BlockNumber: &blockNumber,
+		Metadata:    metadata,
+	}
+}
+
+func TestRepository_CreateAndGetTransaction(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database, mocks.NewNopLogger())
+		ctx := context.Background()
+
+		tx := newTestTransaction("0x01", testWalletAddress, testTokenAddress, types.TxMetadata{"recipient": testRecipientAddress})
+		tx.Data = []byte{0xa9, 0x05, 0x9c, 0xbb}
+		require.NoError(t, repo.Create(ctx, tx))
+
+		got, err := repo.GetByHash(ctx, "0x01")
+		require.NoError(t, err)
+		assert.Equal(t, tx.ID, got.ID)
+		assert.Equal(t, "1000", got.Value.String())
+		assert.Equal(t, "19000000", got.BlockNumber.String())
+		assert.Equal(t, uint64(21000), got.GasLimit)
+		assert.Equal(t, tx.Data, got.Data)
+		assert.Equal(t, testRecipientAddress, got.Metadata["recipient"])
+
+		require.NoError(t, repo.UpdateTransactionStatus(ctx, "0x01", types.TransactionStatusFailed))
+		got, err = repo.GetByHash(ctx, "0x01")
+		require.NoError(t, err)
+		assert.Equal(t, types.TransactionStatusFailed, got.Status)
+	})
+}
+
+func TestRepository_ListByMetadata(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database, mocks.NewNopLogger())
+		ctx := context.Background()
+
+		transfer := newTestTransaction("0x01", testWalletAddress, testTokenAddress, types.TxMetadata{
+			"recipient":     testRecipientAddress,
+			"token_address": testTokenAddress,
+		})
+		spam := newTestTransaction("0x02", testSenderAddress, testWalletAddress, types.TxMetadata{
+			types.RiskFlagsMetadataKey: "spam",
+		})
+		require.NoError(t, repo.Create(ctx, transfer))
+		require.NoError(t, repo.Create(ctx, spam))
+
+		chainType := types.ChainTypeEthereum
+		recipient := testRecipientAddress
+		page, err := repo.List(ctx, &Filter{ChainType: &chainType, Address: &recipient}, 10, "")
+		require.NoError(t, err)
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, "0x01", page.Items[0].Hash)
+
+		page, err = repo.List(ctx, &Filter{HideFlagged: true}, 10, "")
+		require.NoError(t, err)
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, "0x01", page.Items[0].Hash)
+
+		counterparties, err := repo.ListCounterparties(ctx, 1, 10)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{
+			types.NormalizeAddress(chainType, testWalletAddress),
+			types.NormalizeAddress(chainType, testTokenAddress),
+			testRecipientAddress,
+		}, counterparties)
+	})
+}
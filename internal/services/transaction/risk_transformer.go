@@ -0,0 +1,78 @@
+package transaction
+
+import (
+	"context"
+	"vault0/internal/core/tokenrisk"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/types"
+)
+
+// counterpartyHistoryLimit is the number of recent wallet transactions whose
+// addresses are compared against for address poisoning
+const counterpartyHistoryLimit = 200
+
+// RiskTransformer defines methods for flagging spam and scam transactions
+type RiskTransformer interface {
+	// TransformTransaction stores the risk flags of a transaction in its metadata
+	TransformTransaction(ctx context.Context, tx *types.Transaction) error
+}
+
+// NewRiskTransformer creates a new risk transformer
+func NewRiskTransformer(
+	log logger.Logger,
+	repository Repository,
+	classifier tokenrisk.Classifier,
+) RiskTransformer {
+	return &riskTransformer{
+		log:        log,
+		repository: repository,
+		classifier: classifier,
+	}
+}
+
+type riskTransformer struct {
+	log        logger.Logger
+	repository Repository
+	classifier tokenrisk.Classifier
+}
+
+// TransformTransaction flags spam and scam transactions. It must run after the wallet ID
+// transformer, as address poisoning is detected against the wallet's counterparties.
+func (t *riskTransformer) TransformTransaction(ctx context.Context, tx *types.Transaction) error {
+	if tx == nil {
+		return errors.NewInvalidInputError("Transaction cannot be nil", "transaction", nil)
+	}
+
+	var knownAddresses []string
+	if walletID, ok := tx.Metadata.GetInt64(types.WalletIDMetadaKey); ok {
+		addresses, err := t.repository.ListCounterparties(ctx, walletID, counterpartyHistoryLimit)
+		if err != nil {
+			return err
+		}
+		knownAddresses = addresses
+	}
+
+	flags, err := t.classifier.ClassifyTransaction(ctx, tx, knownAddresses)
+	if err != nil {
+		return err
+	}
+
+	if len(flags) == 0 {
+		delete(tx.Metadata, types.RiskFlagsMetadataKey)
+		return nil
+	}
+
+	if tx.Metadata == nil {
+		tx.Metadata = make(types.TxMetadata)
+	}
+	if err := tx.Metadata.SetJSON(types.RiskFlagsMetadataKey, flags); err != nil {
+		return err
+	}
+
+	t.log.Info("Flagged transaction",
+		logger.String("tx_hash", tx.Hash),
+		logger.Any("risk_flags", flags))
+
+	return nil
+}
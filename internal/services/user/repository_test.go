@@ -0,0 +1,86 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/db"
+	"vault0/internal/errors"
+	"vault0/internal/testing/dbtest"
+)
+
+func TestRepository_CreateAndGet(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database)
+		ctx := context.Background()
+
+		user := &User{Email: "alice@example.com", PasswordHash: "hash"}
+		require.NoError(t, repo.Create(ctx, user))
+		assert.NotZero(t, user.ID)
+
+		byID, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, user.Email, byID.Email)
+		assert.Equal(t, user.PasswordHash, byID.PasswordHash)
+		assert.WithinDuration(t, user.CreatedAt, byID.CreatedAt, time.Millisecond)
+
+		byEmail, err := repo.GetByEmail(ctx, user.Email)
+		require.NoError(t, err)
+		require.NotNil(t, byEmail)
+		assert.Equal(t, user.ID, byEmail.ID)
+
+		missing, err := repo.GetByEmail(ctx, "bob@example.com")
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+
+		_, err = repo.GetByID(ctx, user.ID+1)
+		assert.True(t, errors.IsError(err, errors.ErrCodeUserNotFound))
+	})
+}
+
+func TestRepository_UpdateAndDelete(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database)
+		ctx := context.Background()
+
+		user := &User{Email: "alice@example.com", PasswordHash: "hash"}
+		require.NoError(t, repo.Create(ctx, user))
+
+		user.Email = "alice@example.org"
+		require.NoError(t, repo.Update(ctx, user))
+
+		updated, err := repo.GetByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "alice@example.org", updated.Email)
+
+		require.NoError(t, repo.Delete(ctx, user.ID))
+		err = repo.Delete(ctx, user.ID)
+		assert.True(t, errors.IsError(err, errors.ErrCodeResourceNotFound))
+	})
+}
+
+func TestRepository_List(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database)
+		ctx := context.Background()
+
+		for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+			require.NoError(t, repo.Create(ctx, &User{Email: email}))
+		}
+
+		page, err := repo.List(ctx, 2, "")
+		require.NoError(t, err)
+		assert.Len(t, page.Items, 2)
+		assert.NotEmpty(t, page.NextToken)
+		assert.Less(t, page.Items[0].ID, page.Items[1].ID)
+
+		all, err := repo.List(ctx, 0, "")
+		require.NoError(t, err)
+		assert.Len(t, all.Items, 3)
+		assert.Empty(t, all.NextToken)
+	})
+}
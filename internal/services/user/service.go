@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 
+	"vault0/internal/db"
 	"vault0/internal/errors"
 	"vault0/internal/logger"
 	"vault0/internal/services/signer"
@@ -40,14 +41,16 @@ type service struct {
 	log           logger.Logger
 	repository    Repository
 	signerService signer.Service
+	transactor    db.Transactor
 }
 
 // NewService creates a new user service
-func NewService(log logger.Logger, repository Repository, signerSvc signer.Service) Service {
+func NewService(log logger.Logger, repository Repository, signerSvc signer.Service, transactor db.Transactor) Service {
 	return &service{
 		log:           log,
 		repository:    repository,
 		signerService: signerSvc,
+		transactor:    transactor,
 	}
 }
 
@@ -123,28 +126,37 @@ func (s *service) UpdateUser(ctx context.Context, id int64, email, password stri
 
 // DeleteUser removes a user
 func (s *service) DeleteUser(ctx context.Context, id int64) error {
-	// Check if user is associated with any signers before deleting
-	signers, err := s.signerService.FindSignersByUserID(ctx, id)
-	if err != nil {
-		s.log.Error("Failed to check signer association for user",
-			logger.Int64("user_id", id),
-			logger.Error(err))
-		return err
-	}
+	// Check the signer association and delete in one transaction so that no signer
+	// can be associated with the user in between
+	err := s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		// Check if user is associated with any signers before deleting
+		signers, err := s.signerService.FindSignersByUserID(ctx, id)
+		if err != nil {
+			s.log.Error("Failed to check signer association for user",
+				logger.Int64("user_id", id),
+				logger.Error(err))
+			return err
+		}
 
-	// If signers exist for the user, prevent deletion
-	if len(signers) > 0 {
-		s.log.Warn("Attempted to delete user associated with signers",
-			logger.Int64("user_id", id),
-			logger.Int("signer_count", len(signers)))
-		return errors.NewUserAssociatedWithSignerError(id)
-	}
+		// If signers exist for the user, prevent deletion
+		if len(signers) > 0 {
+			s.log.Warn("Attempted to delete user associated with signers",
+				logger.Int64("user_id", id),
+				logger.Int("signer_count", len(signers)))
+			return errors.NewUserAssociatedWithSignerError(id)
+		}
 
-	// Proceed with deletion if no signers are associated
-	if err := s.repository.Delete(ctx, id); err != nil {
-		s.log.Error("Failed to delete user from repository",
-			logger.Int64("user_id", id),
-			logger.Error(err))
+		// Proceed with deletion if no signers are associated
+		if err := s.repository.Delete(ctx, id); err != nil {
+			s.log.Error("Failed to delete user from repository",
+				logger.Int64("user_id", id),
+				logger.Error(err))
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
 		return err
 	}
 
@@ -0,0 +1,96 @@
+package vault
+
+import (
+	"context"
+
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/services/stream"
+	"vault0/internal/services/webhook"
+	"vault0/internal/types"
+)
+
+// storeStatusChange stores the status and details of a vault after its contract was
+// changed on chain and runs notify in the same transaction. As the stored status must
+// follow the contract, it is stored on its own when the notification fails.
+func (s *service) storeStatusChange(ctx context.Context, vault *Vault, notify func(ctx context.Context) error) error {
+	store := func(ctx context.Context) error {
+		if err := s.repo.UpdateStatus(ctx, vault.ID, vault.Status); err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		if err := s.repo.Update(ctx, vault.ID, vault); err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		return nil
+	}
+
+	var notifyErr error
+	err := s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		if err := store(ctx); err != nil {
+			return err
+		}
+		notifyErr = notify(ctx)
+		return notifyErr
+	})
+	if err == nil || notifyErr == nil {
+		return err
+	}
+
+	s.log.Error("Failed to notify vault status change, storing it without notification",
+		logger.Int64("vault_id", vault.ID),
+		logger.String("status", string(vault.Status)),
+		logger.Error(notifyErr))
+	return s.transactor.WithTransaction(ctx, store)
+}
+
+// publishStatusChange notifies the webhooks and the live event stream of a vault status transition
+func (s *service) publishStatusChange(ctx context.Context, vault *Vault, previousStatus VaultStatus, txHash string) error {
+	if err := s.publishEvent(ctx, webhook.EventTypeVaultStatusChanged, vault, previousStatus, txHash); err != nil {
+		return err
+	}
+
+	scope := stream.Scope{ChainType: types.ChainType(vault.ChainType), WalletID: vault.WalletID, VaultID: vault.ID}
+	if err := s.streamService.Publish(ctx, stream.EventTypeVaultStatusChanged, scope, newVaultData(vault, previousStatus, txHash)); err != nil {
+		s.log.Error("Failed to publish stream event",
+			logger.Error(err),
+			logger.String("event_type", string(stream.EventTypeVaultStatusChanged)),
+			logger.Int64("vault_id", vault.ID))
+		return err
+	}
+	return nil
+}
+
+// publishEvent notifies the webhooks of a vault event. Failures are logged and returned,
+// so that callers running in a transaction can roll back the change they notify.
+func (s *service) publishEvent(ctx context.Context, eventType webhook.EventType, vault *Vault, previousStatus VaultStatus, txHash string) error {
+	event := &webhook.Event{
+		Type:      eventType,
+		ChainType: types.ChainType(vault.ChainType),
+		Address:   vault.Address,
+		Data:      newVaultData(vault, previousStatus, txHash),
+	}
+
+	if err := s.webhookService.Publish(ctx, event); err != nil {
+		s.log.Error("Failed to publish webhook event",
+			logger.Error(err),
+			logger.String("event_type", string(eventType)),
+			logger.Int64("vault_id", vault.ID))
+		return err
+	}
+	return nil
+}
+
+// newVaultData returns the details of a vault event
+func newVaultData(vault *Vault, previousStatus VaultStatus, txHash string) webhook.VaultData {
+	return webhook.VaultData{
+		VaultID:             vault.ID,
+		Name:                vault.Name,
+		WalletID:            vault.WalletID,
+		PreviousStatus:      string(previousStatus),
+		Status:              string(vault.Status),
+		RecoveryAddress:     vault.RecoveryAddress,
+		RecoveryRequestedAt: vault.RecoveryRequestTimestamp,
+		FailureReason:       vault.FailureReason,
+		TxHash:              txHash,
+	}
+}
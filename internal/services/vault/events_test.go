@@ -0,0 +1,41 @@
+package vault
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/db"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/testing/dbtest"
+)
+
+func TestService_StoreStatusChange(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database, logger.NewNopLogger())
+		s := &service{repo: repo, transactor: database, log: logger.NewNopLogger()}
+		ctx := context.Background()
+
+		vault := newTestVault(1, testVaultTxHash)
+		require.NoError(t, repo.Create(ctx, vault))
+
+		vault.Status = VaultStatusRecovering
+		require.NoError(t, s.storeStatusChange(ctx, vault, func(ctx context.Context) error { return nil }))
+		got, err := repo.GetByID(ctx, vault.ID)
+		require.NoError(t, err)
+		assert.Equal(t, VaultStatusRecovering, got.Status)
+
+		// The status follows the contract, it is stored even when it can't be notified
+		vault.Status = VaultStatusRecovered
+		err = s.storeStatusChange(ctx, vault, func(ctx context.Context) error {
+			return errors.NewOperationFailedError("publish", nil)
+		})
+		require.NoError(t, err)
+		got, err = repo.GetByID(ctx, vault.ID)
+		require.NoError(t, err)
+		assert.Equal(t, VaultStatusRecovered, got.Status)
+	})
+}
@@ -48,82 +48,93 @@ type MonitorService interface {
 
 // ProcessVaultDeploymentSuccess is called when deployment is confirmed.
 func (s *service) ProcessVaultDeploymentSuccess(ctx context.Context, vaultID int64, contractAddress, txHash string) error {
-	vault, err := s.repo.GetByID(ctx, vaultID)
-	if err != nil {
-		s.log.Error("Failed to get vault for deployment success update", logger.Int64("vault_id", vaultID), logger.Error(err))
-		if errors.IsError(err, errors.ErrCodeNotFound) {
-			return errors.NewVaultNotFoundError(vaultID)
+	// Read the vault and store its transition in one transaction so that concurrent
+	// transitions cannot overwrite each other
+	return s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		vault, err := s.repo.GetByID(ctx, vaultID)
+		if err != nil {
+			s.log.Error("Failed to get vault for deployment success update", logger.Int64("vault_id", vaultID), logger.Error(err))
+			if errors.IsError(err, errors.ErrCodeNotFound) {
+				return errors.NewVaultNotFoundError(vaultID)
+			}
+			return err
 		}
-		return err
-	}
 
-	currentStatus := VaultStatus(vault.Status)
-	targetStatus := VaultStatusActive
+		currentStatus := VaultStatus(vault.Status)
+		targetStatus := VaultStatusActive
 
-	if !CanTransition(currentStatus, targetStatus) {
-		s.log.Warn("Cannot transition vault to Active after deployment success",
-			logger.Int64("vault_id", vaultID),
-			logger.String("current_status", string(currentStatus)),
-			logger.String("target_status", string(targetStatus)))
-		if currentStatus == VaultStatusActive {
-			return nil
+		if !CanTransition(currentStatus, targetStatus) {
+			s.log.Warn("Cannot transition vault to Active after deployment success",
+				logger.Int64("vault_id", vaultID),
+				logger.String("current_status", string(currentStatus)),
+				logger.String("target_status", string(targetStatus)))
+			if currentStatus == VaultStatusActive {
+				return nil
+			}
+			return errors.NewInvalidStateTransitionError(string(currentStatus), string(targetStatus))
 		}
-		return errors.NewInvalidStateTransitionError(string(currentStatus), string(targetStatus))
-	}
 
-	vault.Status = targetStatus
-	vault.UpdatedAt = time.Now()
+		vault.Status = targetStatus
+		vault.UpdatedAt = time.Now()
 
-	if err := s.repo.Update(ctx, vault.ID, vault); err != nil {
-		s.log.Error("Failed to update vault status to Active after deployment success",
-			logger.Int64("vault_id", vaultID),
-			logger.String("contract_address", contractAddress),
-			logger.Error(err))
-		return err
-	}
+		if err := s.repo.UpdateStatus(ctx, vault.ID, vault.Status); err != nil {
+			s.log.Error("Failed to update vault status to Active after deployment success",
+				logger.Int64("vault_id", vaultID),
+				logger.String("contract_address", contractAddress),
+				logger.Error(err))
+			return err
+		}
 
-	s.log.Info("Vault successfully activated", logger.Int64("vault_id", vaultID), logger.String("contract_address", contractAddress))
-	return nil
+		s.log.Info("Vault successfully activated", logger.Int64("vault_id", vaultID), logger.String("contract_address", contractAddress))
+		return s.publishStatusChange(ctx, vault, currentStatus, vault.TxHash)
+	})
 }
 
 // ProcessVaultDeploymentFailure is called when deployment fails.
 func (s *service) ProcessVaultDeploymentFailure(ctx context.Context, vaultID int64, errorMsg string) error {
-	vault, err := s.repo.GetByID(ctx, vaultID)
-	if err != nil {
-		s.log.Error("Failed to get vault for deployment failure update", logger.Int64("vault_id", vaultID), logger.Error(err))
-		if errors.IsError(err, errors.ErrCodeNotFound) {
-			return errors.NewVaultNotFoundError(vaultID)
+	// Read the vault and store its transition in one transaction
+	return s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		vault, err := s.repo.GetByID(ctx, vaultID)
+		if err != nil {
+			s.log.Error("Failed to get vault for deployment failure update", logger.Int64("vault_id", vaultID), logger.Error(err))
+			if errors.IsError(err, errors.ErrCodeNotFound) {
+				return errors.NewVaultNotFoundError(vaultID)
+			}
+			return err
 		}
-		return err
-	}
 
-	currentStatus := VaultStatus(vault.Status)
-	targetStatus := VaultStatusFailed
+		currentStatus := VaultStatus(vault.Status)
+		targetStatus := VaultStatusFailed
 
-	if !CanTransition(currentStatus, targetStatus) {
-		s.log.Warn("Cannot transition vault to Failed after deployment failure",
-			logger.Int64("vault_id", vaultID),
-			logger.String("current_status", string(currentStatus)),
-			logger.String("target_status", string(targetStatus)))
-		if currentStatus == VaultStatusFailed || currentStatus == VaultStatusActive {
-			return nil
+		if !CanTransition(currentStatus, targetStatus) {
+			s.log.Warn("Cannot transition vault to Failed after deployment failure",
+				logger.Int64("vault_id", vaultID),
+				logger.String("current_status", string(currentStatus)),
+				logger.String("target_status", string(targetStatus)))
+			if currentStatus == VaultStatusFailed || currentStatus == VaultStatusActive {
+				return nil
+			}
+			return errors.NewInvalidStateTransitionError(string(currentStatus), string(targetStatus))
 		}
-		return errors.NewInvalidStateTransitionError(string(currentStatus), string(targetStatus))
-	}
 
-	vault.Status = targetStatus
-	vault.FailureReason = &errorMsg
+		vault.Status = targetStatus
+		vault.FailureReason = &errorMsg
 
-	if err := s.repo.Update(ctx, vault.ID, vault); err != nil {
-		s.log.Error("Failed to update vault status to Failed after deployment failure",
-			logger.Int64("vault_id", vaultID),
-			logger.String("error_msg", errorMsg),
-			logger.Error(err))
-		return err
-	}
+		err = s.repo.UpdateStatus(ctx, vault.ID, vault.Status)
+		if err == nil {
+			err = s.repo.Update(ctx, vault.ID, vault)
+		}
+		if err != nil {
+			s.log.Error("Failed to update vault status to Failed after deployment failure",
+				logger.Int64("vault_id", vaultID),
+				logger.String("error_msg", errorMsg),
+				logger.Error(err))
+			return err
+		}
 
-	s.log.Warn("Vault deployment failed", logger.Int64("vault_id", vaultID), logger.String("reason", errorMsg))
-	return nil
+		s.log.Warn("Vault deployment failed", logger.Int64("vault_id", vaultID), logger.String("reason", errorMsg))
+		return s.publishStatusChange(ctx, vault, currentStatus, vault.TxHash)
+	})
 }
 
 // --- Deployment Monitoring Logic --
@@ -462,12 +473,15 @@ func (s *service) checkAndExecuteRecoveryForVault(ctx context.Context, vault *Va
 	vault.Status = targetStatus
 	vault.UpdatedAt = now
 
-	if err := s.repo.Update(ctx, vault.ID, vault); err != nil {
-		s.log.Error("CRITICAL: Recovery executed on contract but database update failed",
+	err = s.storeStatusChange(ctx, vault, func(ctx context.Context) error {
+		return s.publishStatusChange(ctx, vault, currentStatus, txHash)
+	})
+	if err != nil {
+		s.log.Error("CRITICAL: Recovery executed on contract but vault status update failed",
 			logger.Int64("vault_id", vault.ID),
 			logger.String("tx_hash", txHash),
 			logger.Error(err))
-		return txHash, errors.NewDatabaseError(err)
+		return txHash, err
 	}
 
 	s.log.Info("Polling: Vault status updated to recovered", logger.Int64("vault_id", vault.ID))
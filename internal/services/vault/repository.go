@@ -198,7 +198,7 @@ func (r *repository) Create(ctx context.Context, vault *Vault) error {
 			id, name, contract_name, wallet_id, chain_type, tx_hash, recovery_address, signers,
 			status, quorum, address, recovery_request_timestamp,
 			failure_reason, created_at, updated_at, deleted_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	// Handle nullable pointers correctly when preparing args
 	var recoveryTimestampArg sql.NullTime
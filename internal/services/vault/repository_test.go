@@ -0,0 +1,140 @@
+package vault
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/db"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/testing/dbtest"
+	"vault0/internal/types"
+)
+
+const (
+	testVaultAddress  = "0x71C7656EC7ab88b098defB751B7401B5f6d8976F"
+	testVaultTxHash   = "0xabababababababababababababababababababababababababababababababab"
+	testRecoveryOwner = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+)
+
+// newTestVault returns a pending vault of a wallet
+func newTestVault(walletID int64, txHash string) *Vault {
+	return &Vault{
+		Name:            "Treasury",
+		ContractName:    "MultiSigWallet",
+		WalletID:        walletID,
+		ChainType:       string(types.ChainTypeEthereum),
+		TxHash:          txHash,
+		RecoveryAddress: testRecoveryOwner,
+		Signers:         types.JSONArray{testRecoveryOwner, testVaultAddress},
+		Quorum:          2,
+	}
+}
+
+func TestRepository_CreateAndGet(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database, logger.NewNopLogger())
+		ctx := context.Background()
+
+		vault := newTestVault(1, testVaultTxHash)
+		require.NoError(t, repo.Create(ctx, vault))
+		assert.NotZero(t, vault.ID)
+		assert.Equal(t, VaultStatusPending, vault.Status)
+
+		got, err := repo.GetByID(ctx, vault.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Treasury", got.Name)
+		assert.Equal(t, vault.Signers, got.Signers)
+		assert.Equal(t, 2, got.Quorum)
+		assert.Empty(t, got.Address)
+		assert.Nil(t, got.RecoveryRequestTimestamp)
+
+		byHash, err := repo.GetByHash(ctx, testVaultTxHash)
+		require.NoError(t, err)
+		assert.Equal(t, vault.ID, byHash.ID)
+
+		_, err = repo.GetByID(ctx, vault.ID+1)
+		assert.True(t, errors.IsError(err, errors.ErrCodeNotFound))
+
+		invalid := newTestVault(1, testVaultTxHash)
+		invalid.Quorum = 0
+		assert.True(t, errors.IsError(repo.Create(ctx, invalid), errors.ErrCodeValidationError))
+	})
+}
+
+func TestRepository_UpdateAndDelete(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database, logger.NewNopLogger())
+		ctx := context.Background()
+
+		vault := newTestVault(1, testVaultTxHash)
+		require.NoError(t, repo.Create(ctx, vault))
+
+		require.NoError(t, repo.UpdateStatus(ctx, vault.ID, VaultStatusDeploying))
+		got, err := repo.GetByID(ctx, vault.ID)
+		require.NoError(t, err)
+		assert.Equal(t, VaultStatusDeploying, got.Status)
+
+		requestedAt := time.Now().UTC().Truncate(time.Second)
+		reason := "deployment reverted"
+		vault.Name = "Operations"
+		vault.Status = VaultStatusRecovering
+		vault.RecoveryRequestTimestamp = &requestedAt
+		vault.FailureReason = &reason
+		require.NoError(t, repo.Update(ctx, vault.ID, vault))
+
+		got, err = repo.GetByID(ctx, vault.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Operations", got.Name)
+		// Update leaves the status to UpdateStatus, so that it doesn't overwrite concurrent transitions
+		assert.Equal(t, VaultStatusDeploying, got.Status)
+		require.NotNil(t, got.RecoveryRequestTimestamp)
+		assert.WithinDuration(t, requestedAt, *got.RecoveryRequestTimestamp, time.Second)
+		require.NotNil(t, got.FailureReason)
+		assert.Equal(t, reason, *got.FailureReason)
+
+		// Deleted vaults are hidden and can't be changed
+		require.NoError(t, repo.Delete(ctx, vault.ID))
+		_, err = repo.GetByID(ctx, vault.ID)
+		assert.True(t, errors.IsError(err, errors.ErrCodeNotFound))
+		assert.True(t, errors.IsError(repo.UpdateStatus(ctx, vault.ID, VaultStatusActive), errors.ErrCodeNotFound))
+		assert.True(t, errors.IsError(repo.Update(ctx, vault.ID, vault), errors.ErrCodeNotFound))
+		assert.True(t, errors.IsError(repo.Delete(ctx, vault.ID), errors.ErrCodeNotFound))
+	})
+}
+
+func TestRepository_List(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database, logger.NewNopLogger())
+		ctx := context.Background()
+
+		var vaults []*Vault
+		for i, walletID := range []int64{1, 1, 2} {
+			vault := newTestVault(walletID, testVaultTxHash[:len(testVaultTxHash)-1]+string(rune('0'+i)))
+			require.NoError(t, repo.Create(ctx, vault))
+			vaults = append(vaults, vault)
+		}
+		require.NoError(t, repo.UpdateStatus(ctx, vaults[2].ID, VaultStatusActive))
+
+		walletID := int64(1)
+		page, err := repo.List(ctx, VaultFilter{WalletID: &walletID}, 1, "")
+		require.NoError(t, err)
+		assert.Len(t, page.Items, 1)
+		require.NotEmpty(t, page.NextToken)
+
+		next, err := repo.List(ctx, VaultFilter{WalletID: &walletID}, 1, page.NextToken)
+		require.NoError(t, err)
+		require.Len(t, next.Items, 1)
+		assert.NotEqual(t, page.Items[0].ID, next.Items[0].ID)
+
+		active := VaultStatusActive
+		page, err = repo.List(ctx, VaultFilter{Status: &active}, 10, "")
+		require.NoError(t, err)
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, vaults[2].ID, page.Items[0].ID)
+	})
+}
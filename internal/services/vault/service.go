@@ -9,9 +9,12 @@ import (
 	"vault0/internal/config"
 	"vault0/internal/core/contract"
 	coreWallet "vault0/internal/core/wallet"
+	"vault0/internal/db"
 	"vault0/internal/errors"
 	"vault0/internal/logger"
+	"vault0/internal/services/stream"
 	"vault0/internal/services/wallet"
+	"vault0/internal/services/webhook"
 	"vault0/internal/types"
 )
 
@@ -123,6 +126,9 @@ type service struct {
 	walletFactory   coreWallet.Factory
 	log             logger.Logger
 	cfg             *config.Config
+	transactor      db.Transactor
+	webhookService  webhook.Service
+	streamService   stream.Service
 
 	recoveryPollingCtx         context.Context
 	recoveryPollingCancel      context.CancelFunc
@@ -140,6 +146,9 @@ func NewService(
 	walletFactory coreWallet.Factory,
 	log logger.Logger,
 	cfg *config.Config,
+	transactor db.Transactor,
+	webhookService webhook.Service,
+	streamService stream.Service,
 ) Service {
 	depInterval := defaultDeploymentInterval
 	if cfg != nil && cfg.Vault.DeploymentUpdateInterval > 0 {
@@ -158,6 +167,9 @@ func NewService(
 		walletFactory:      walletFactory,
 		log:                log,
 		cfg:                cfg,
+		transactor:         transactor,
+		webhookService:     webhookService,
+		streamService:      streamService,
 		deploymentInterval: depInterval,
 		recoveryInterval:   recInterval,
 	}
@@ -369,18 +381,22 @@ func (s *service) ExecuteRecovery(ctx context.Context, vaultID int64) (string, e
 	}
 
 	// Update vault status in DB
+	previousStatus := vault.Status
 	targetStatus := VaultStatusRecovered
 	now := time.Now()
 	vault.Status = targetStatus
 	// Keep RecoveryRequestTimestamp for record
 	vault.UpdatedAt = now
 
-	if err := s.repo.Update(ctx, vault.ID, vault); err != nil {
-		s.log.Error("CRITICAL: Recovery executed on contract but database update failed",
+	err = s.storeStatusChange(ctx, vault, func(ctx context.Context) error {
+		return s.publishStatusChange(ctx, vault, previousStatus, txHash)
+	})
+	if err != nil {
+		s.log.Error("CRITICAL: Recovery executed on contract but vault status update failed",
 			logger.Int64("vault_id", vaultID),
 			logger.String("tx_hash", txHash),
 			logger.Error(err))
-		return txHash, errors.NewDatabaseError(err)
+		return txHash, err
 	}
 
 	s.log.Info("Vault status updated to recovered", logger.Int64("vault_id", vaultID))
@@ -670,13 +686,19 @@ func (s *service) StartRecovery(ctx context.Context, vaultID int64) (string, err
 	vault.RecoveryRequestTimestamp = &now
 	vault.UpdatedAt = now
 
-	if err := s.repo.Update(ctx, vault.ID, vault); err != nil {
-		s.log.Error("CRITICAL: Recovery requested on contract but database update failed",
+	err = s.storeStatusChange(ctx, vault, func(ctx context.Context) error {
+		if err := s.publishStatusChange(ctx, vault, currentStatus, txHash); err != nil {
+			return err
+		}
+		return s.publishEvent(ctx, webhook.EventTypeVaultRecoveryRequested, vault, currentStatus, txHash)
+	})
+	if err != nil {
+		s.log.Error("CRITICAL: Recovery requested on contract but vault status update failed",
 			logger.Int64("vault_id", vaultID),
 			logger.String("tx_hash", txHash),
 			logger.Error(err))
-		// Return DB error but also the txHash so it can potentially be tracked
-		return txHash, errors.NewDatabaseError(err)
+		// Return the error but also the txHash so it can potentially be tracked
+		return txHash, err
 	}
 
 	s.log.Info("Vault status updated to recovering", logger.Int64("vault_id", vaultID))
@@ -765,12 +787,15 @@ func (s *service) CancelRecovery(ctx context.Context, vaultID int64) (string, er
 	vault.RecoveryRequestTimestamp = nil
 	vault.UpdatedAt = now
 
-	if err := s.repo.Update(ctx, vault.ID, vault); err != nil {
-		s.log.Error("CRITICAL: Recovery cancelled on contract but database update failed",
+	err = s.storeStatusChange(ctx, vault, func(ctx context.Context) error {
+		return s.publishStatusChange(ctx, vault, currentStatus, txHash)
+	})
+	if err != nil {
+		s.log.Error("CRITICAL: Recovery cancelled on contract but vault status update failed",
 			logger.Int64("vault_id", vaultID),
 			logger.String("tx_hash", txHash),
 			logger.Error(err))
-		return txHash, errors.NewDatabaseError(err)
+		return txHash, err
 	}
 
 	s.log.Info("Vault status updated to active after recovery cancellation", logger.Int64("vault_id", vaultID))
@@ -0,0 +1,264 @@
+package wallet
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	coreAbi "vault0/internal/core/abi"
+	"vault0/internal/core/blockchain"
+	"vault0/internal/core/contract"
+	coreWallet "vault0/internal/core/wallet"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/types"
+)
+
+// AllowanceService tracks the ERC20 allowances granted by managed wallets and
+// allows revoking them. Allowances are always refreshed from the token contract
+// through allowance() calls, so the stored value reflects the on-chain state
+// regardless of how it was changed (approve, increaseAllowance, permit or spending).
+type AllowanceService interface {
+	// RefreshAllowance reads the current allowance from the token contract and stores it.
+	// A zero allowance removes the entry.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - chainType: The blockchain network type
+	//   - address: The owner wallet's address
+	//   - tokenAddress: The ERC20 token contract address
+	//   - spender: The address allowed to spend the tokens
+	//
+	// Returns:
+	//   - *TokenAllowance: The refreshed allowance
+	//   - error: ErrWalletNotFound if the wallet doesn't exist, or any contract call error
+	RefreshAllowance(ctx context.Context, chainType types.ChainType, address, tokenAddress, spender string) (*TokenAllowance, error)
+
+	// ProcessApprovals refreshes the allowances touched by ERC20 Approval events whose
+	// owner is a managed wallet. Approvals of other owners are ignored.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - chainType: The blockchain network the approvals happened on
+	//   - approvals: The approvals extracted from a transaction
+	//
+	// Returns:
+	//   - error: The first error that occurred while refreshing an allowance
+	ProcessApprovals(ctx context.Context, chainType types.ChainType, approvals []types.TokenApproval) error
+
+	// ListAllowances retrieves the outstanding allowances of a wallet with token-based pagination
+	ListAllowances(ctx context.Context, chainType types.ChainType, address string, limit int, nextToken string) (*types.Page[*TokenAllowance], error)
+
+	// RevokeAllowance submits an approve(spender, 0) transaction from the owner wallet.
+	// The stored allowance is removed once the resulting Approval event is processed.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - chainType: The blockchain network type
+	//   - address: The owner wallet's address
+	//   - tokenAddress: The ERC20 token contract address
+	//   - spender: The address whose allowance is revoked
+	//
+	// Returns:
+	//   - string: The hash of the broadcast transaction
+	//   - error: ErrWalletNotFound if the wallet doesn't exist, or any transaction error
+	RevokeAllowance(ctx context.Context, chainType types.ChainType, address, tokenAddress, spender string) (string, error)
+}
+
+type allowanceService struct {
+	log             logger.Logger
+	repository      Repository
+	walletFactory   coreWallet.Factory
+	contractFactory contract.Factory
+	blockchains     blockchain.Factory
+	abiFactory      coreAbi.Factory
+}
+
+// NewAllowanceService creates a new allowance service
+func NewAllowanceService(
+	log logger.Logger,
+	repository Repository,
+	walletFactory coreWallet.Factory,
+	contractFactory contract.Factory,
+	blockchains blockchain.Factory,
+	abiFactory coreAbi.Factory,
+) AllowanceService {
+	return &allowanceService{
+		log:             log,
+		repository:      repository,
+		walletFactory:   walletFactory,
+		contractFactory: contractFactory,
+		blockchains:     blockchains,
+		abiFactory:      abiFactory,
+	}
+}
+
+// contractManager creates a contract manager acting on behalf of a managed wallet
+func (s *allowanceService) contractManager(ctx context.Context, wallet *Wallet) (contract.ContractManager, error) {
+	walletManager, err := s.walletFactory.NewManager(ctx, wallet.ChainType, wallet.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	return s.contractFactory.NewManager(ctx, walletManager)
+}
+
+// loadERC20ABI loads the standard ERC20 ABI for a chain
+func (s *allowanceService) loadERC20ABI(ctx context.Context, chainType types.ChainType) (string, error) {
+	abiLoader, err := s.abiFactory.NewABILoader(chainType)
+	if err != nil {
+		return "", err
+	}
+	return abiLoader.LoadABIByType(ctx, coreAbi.ABITypeERC20)
+}
+
+// RefreshAllowance reads the current allowance from the token contract and stores it
+func (s *allowanceService) RefreshAllowance(ctx context.Context, chainType types.ChainType, address, tokenAddress, spender string) (*TokenAllowance, error) {
+	wallet, err := s.repository.GetByAddress(ctx, chainType, address)
+	if err != nil {
+		return nil, err
+	}
+	return s.refreshAllowance(ctx, wallet, tokenAddress, spender)
+}
+
+func (s *allowanceService) refreshAllowance(ctx context.Context, wallet *Wallet, tokenAddress, spender string) (*TokenAllowance, error) {
+	if _, err := types.NewAddress(wallet.ChainType, tokenAddress); err != nil {
+		return nil, err
+	}
+	if _, err := types.NewAddress(wallet.ChainType, spender); err != nil {
+		return nil, err
+	}
+
+	erc20ABI, err := s.loadERC20ABI(ctx, wallet.ChainType)
+	if err != nil {
+		return nil, err
+	}
+
+	contractManager, err := s.contractManager(ctx, wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, err := contractManager.CallMethod(ctx, tokenAddress, erc20ABI, "allowance",
+		common.HexToAddress(wallet.Address), common.HexToAddress(spender))
+	if err != nil {
+		return nil, err
+	}
+	if len(outputs) != 1 {
+		return nil, errors.NewInvalidContractCallError(tokenAddress, fmt.Errorf("unexpected allowance result %v", outputs))
+	}
+	amount, ok := outputs[0].(*big.Int)
+	if !ok {
+		return nil, errors.NewInvalidContractCallError(tokenAddress, fmt.Errorf("unexpected allowance result type %T", outputs[0]))
+	}
+
+	if err := s.repository.UpdateTokenAllowance(ctx, wallet, tokenAddress, spender, amount); err != nil {
+		return nil, err
+	}
+
+	return s.repository.GetTokenAllowance(ctx, wallet.ID, tokenAddress, spender)
+}
+
+// ProcessApprovals refreshes the allowances touched by Approval events of managed wallets
+func (s *allowanceService) ProcessApprovals(ctx context.Context, chainType types.ChainType, approvals []types.TokenApproval) error {
+	var firstErr error
+	for _, approval := range approvals {
+		wallet, err := s.repository.GetByAddress(ctx, chainType, approval.Owner)
+		if err != nil {
+			if errors.IsError(err, errors.ErrCodeWalletNotFound) {
+				continue // Not one of our wallets
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		if _, err := s.refreshAllowance(ctx, wallet, approval.TokenAddress, approval.Spender); err != nil {
+			s.log.Error("Failed to refresh token allowance",
+				logger.Error(err),
+				logger.Int64("wallet_id", wallet.ID),
+				logger.String("token_address", approval.TokenAddress),
+				logger.String("spender", approval.Spender))
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// ListAllowances retrieves the outstanding allowances of a wallet
+func (s *allowanceService) ListAllowances(ctx context.Context, chainType types.ChainType, address string, limit int, nextToken string) (*types.Page[*TokenAllowance], error) {
+	wallet, err := s.repository.GetByAddress(ctx, chainType, address)
+	if err != nil {
+		return nil, err
+	}
+	return s.repository.ListTokenAllowances(ctx, wallet.ID, limit, nextToken)
+}
+
+// RevokeAllowance submits an approve(spender, 0) transaction from the owner wallet
+func (s *allowanceService) RevokeAllowance(ctx context.Context, chainType types.ChainType, address, tokenAddress, spender string) (string, error) {
+	if tokenAddress == "" {
+		return "", errors.NewInvalidInputError("Token address is required", "token_address", "")
+	}
+	if spender == "" {
+		return "", errors.NewInvalidInputError("Spender is required", "spender", "")
+	}
+
+	wallet, err := s.repository.GetByAddress(ctx, chainType, address)
+	if err != nil {
+		return "", err
+	}
+	if _, err := types.NewAddress(chainType, spender); err != nil {
+		return "", err
+	}
+
+	erc20ABI, err := s.loadERC20ABI(ctx, chainType)
+	if err != nil {
+		return "", err
+	}
+
+	contractManager, err := s.contractManager(ctx, wallet)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := s.blockchains.NewClient(chainType)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := client.GetNonce(ctx, wallet.Address)
+	if err != nil {
+		return "", err
+	}
+	gasPrice, err := client.GetGasPrice(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	opts := contract.ExecutionOptions{
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+		Value:    big.NewInt(0),
+	}
+	txHash, err := contractManager.ExecuteMethod(ctx, tokenAddress, erc20ABI, "approve", opts,
+		common.HexToAddress(spender), big.NewInt(0))
+	if err != nil {
+		s.log.Error("Failed to revoke token allowance",
+			logger.Error(err),
+			logger.Int64("wallet_id", wallet.ID),
+			logger.String("token_address", tokenAddress),
+			logger.String("spender", spender))
+		return "", err
+	}
+
+	s.log.Info("Allowance revocation submitted",
+		logger.String("tx_hash", txHash),
+		logger.Int64("wallet_id", wallet.ID),
+		logger.String("token_address", tokenAddress),
+		logger.String("spender", spender))
+
+	return txHash, nil
+}
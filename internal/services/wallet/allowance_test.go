@@ -0,0 +1,178 @@
+package wallet
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/config"
+	coreAbi "vault0/internal/core/abi"
+	"vault0/internal/core/blockchain"
+	"vault0/internal/core/contract"
+	coreWallet "vault0/internal/core/wallet"
+	"vault0/internal/db"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/testing/dbtest"
+	"vault0/internal/testing/mocks"
+	"vault0/internal/types"
+)
+
+const testSpenderAddress = "0x2222222222222222222222222222222222222222"
+
+// testContractManager mocks the contract calls and transactions of a wallet
+type testContractManager struct {
+	contract.ContractManager
+	mock.Mock
+	// calledInTransaction records whether a contract was called within a database transaction
+	calledInTransaction bool
+}
+
+func (m *testContractManager) CallMethod(ctx context.Context, contractAddress, contractABI, method string, args ...any) ([]any, error) {
+	m.calledInTransaction = m.calledInTransaction || db.InTransaction(ctx)
+	ret := m.Called(contractAddress, method, args)
+	outputs, _ := ret.Get(0).([]any)
+	return outputs, ret.Error(1)
+}
+
+func (m *testContractManager) ExecuteMethod(ctx context.Context, contractAddress, contractABI, method string, options contract.ExecutionOptions, args ...any) (string, error) {
+	ret := m.Called(contractAddress, method, options, args)
+	return ret.String(0), ret.Error(1)
+}
+
+// testContractFactory returns the same contract manager for every wallet
+type testContractFactory struct {
+	manager contract.ContractManager
+}
+
+func (f *testContractFactory) NewManager(ctx context.Context, wallet coreWallet.WalletManager) (contract.ContractManager, error) {
+	return f.manager, nil
+}
+
+// testWalletFactory returns no wallet manager, as the contract manager is mocked
+type testWalletFactory struct{}
+
+func (f *testWalletFactory) NewManager(ctx context.Context, chainType types.ChainType, keyID string) (coreWallet.WalletManager, error) {
+	return nil, nil
+}
+
+// testBlockchainFactory returns the same client for every chain
+type testBlockchainFactory struct {
+	blockchain.Factory
+	client blockchain.BlockchainClient
+}
+
+func (f *testBlockchainFactory) NewClient(chainType types.ChainType) (blockchain.BlockchainClient, error) {
+	return f.client, nil
+}
+
+// testABIFactory loads the standard ABIs from the contract resources
+type testABIFactory struct {
+	coreAbi.Factory
+}
+
+func (f *testABIFactory) NewABILoader(chainType types.ChainType) (coreAbi.ABILoader, error) {
+	cfg := &config.Config{ABIMapping: map[string]string{"erc20": "../../../contracts/resources/erc20.json"}}
+	return coreAbi.NewABILoader(chainType, cfg, nil, nil, nil, nil, logger.NewNopLogger()), nil
+}
+
+func TestAllowanceService_RefreshAllowance(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database)
+		manager := &testContractManager{}
+		service := NewAllowanceService(logger.NewNopLogger(), repo, &testWalletFactory{},
+			&testContractFactory{manager: manager}, &testBlockchainFactory{}, &testABIFactory{})
+		ctx := context.Background()
+		wallet := createTestWallet(t, repo)
+
+		allowanceArgs := []any{common.HexToAddress(wallet.Address), common.HexToAddress(testSpenderAddress)}
+		manager.On("CallMethod", testTokenAddress, "allowance", allowanceArgs).Return([]any{big.NewInt(1000)}, nil).Once()
+
+		allowance, err := service.RefreshAllowance(ctx, types.ChainTypeEthereum, wallet.Address, testTokenAddress, testSpenderAddress)
+		require.NoError(t, err)
+		assert.Equal(t, "1000", allowance.Amount.String())
+
+		page, err := service.ListAllowances(ctx, types.ChainTypeEthereum, wallet.Address, 10, "")
+		require.NoError(t, err)
+		assert.Len(t, page.Items, 1)
+
+		// A spent or revoked allowance removes the entry
+		manager.On("CallMethod", testTokenAddress, "allowance", allowanceArgs).Return([]any{big.NewInt(0)}, nil).Once()
+		_, err = service.RefreshAllowance(ctx, types.ChainTypeEthereum, wallet.Address, testTokenAddress, testSpenderAddress)
+		require.NoError(t, err)
+
+		page, err = service.ListAllowances(ctx, types.ChainTypeEthereum, wallet.Address, 10, "")
+		require.NoError(t, err)
+		assert.Empty(t, page.Items)
+
+		// Unexpected results of the token contract are rejected
+		manager.On("CallMethod", testTokenAddress, "allowance", allowanceArgs).Return([]any{"1000"}, nil).Once()
+		_, err = service.RefreshAllowance(ctx, types.ChainTypeEthereum, wallet.Address, testTokenAddress, testSpenderAddress)
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidContractCall))
+
+		_, err = service.RefreshAllowance(ctx, types.ChainTypeEthereum, testOtherWalletAddress, testTokenAddress, testSpenderAddress)
+		assert.True(t, errors.IsError(err, errors.ErrCodeWalletNotFound))
+
+		manager.AssertExpectations(t)
+	})
+}
+
+func TestAllowanceService_ProcessApprovals(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database)
+		manager := &testContractManager{}
+		service := NewAllowanceService(logger.NewNopLogger(), repo, &testWalletFactory{},
+			&testContractFactory{manager: manager}, &testBlockchainFactory{}, &testABIFactory{})
+		ctx := context.Background()
+		wallet := createTestWallet(t, repo)
+
+		manager.On("CallMethod", testTokenAddress, "allowance", mock.Anything).Return([]any{big.NewInt(500)}, nil).Once()
+
+		// Approvals granted by other owners are ignored
+		err := service.ProcessApprovals(ctx, types.ChainTypeEthereum, []types.TokenApproval{
+			{TokenAddress: testTokenAddress, Owner: wallet.Address, Spender: testSpenderAddress, Amount: big.NewInt(500)},
+			{TokenAddress: testTokenAddress, Owner: testOtherWalletAddress, Spender: testSpenderAddress, Amount: big.NewInt(700)},
+		})
+		require.NoError(t, err)
+
+		allowance, err := repo.GetTokenAllowance(ctx, wallet.ID, testTokenAddress, testSpenderAddress)
+		require.NoError(t, err)
+		assert.Equal(t, "500", allowance.Amount.String())
+		manager.AssertExpectations(t)
+	})
+}
+
+func TestAllowanceService_RevokeAllowance(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database)
+		manager := &testContractManager{}
+		client := mocks.NewMockBlockchainClient()
+		service := NewAllowanceService(logger.NewNopLogger(), repo, &testWalletFactory{},
+			&testContractFactory{manager: manager}, &testBlockchainFactory{client: client}, &testABIFactory{})
+		ctx := context.Background()
+		wallet := createTestWallet(t, repo)
+
+		_, err := service.RevokeAllowance(ctx, types.ChainTypeEthereum, wallet.Address, testTokenAddress, "")
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidInput))
+		_, err = service.RevokeAllowance(ctx, types.ChainTypeEthereum, wallet.Address, "", testSpenderAddress)
+		assert.True(t, errors.IsError(err, errors.ErrCodeInvalidInput))
+
+		client.On("GetNonce", mock.Anything, wallet.Address).Return(uint64(7), nil)
+		client.On("GetGasPrice", mock.Anything).Return(big.NewInt(20), nil)
+		opts := contract.ExecutionOptions{Nonce: 7, GasPrice: big.NewInt(20), Value: big.NewInt(0)}
+		approveArgs := []any{common.HexToAddress(testSpenderAddress), big.NewInt(0)}
+		manager.On("ExecuteMethod", testTokenAddress, "approve", opts, approveArgs).Return("0xrevoke", nil).Once()
+
+		txHash, err := service.RevokeAllowance(ctx, types.ChainTypeEthereum, wallet.Address, testTokenAddress, testSpenderAddress)
+		require.NoError(t, err)
+		assert.Equal(t, "0xrevoke", txHash)
+
+		manager.AssertExpectations(t)
+		client.AssertExpectations(t)
+	})
+}
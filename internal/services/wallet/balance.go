@@ -4,10 +4,18 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
+
+	coreAbi "vault0/internal/core/abi"
+	"vault0/internal/core/contract"
 	"vault0/internal/core/tokenstore"
+	coreWallet "vault0/internal/core/wallet"
+	"vault0/internal/db"
 	"vault0/internal/errors"
 	"vault0/internal/logger"
+	"vault0/internal/services/stream"
 	"vault0/internal/types"
 )
 
@@ -25,6 +33,9 @@ type BalanceService interface {
 	// UpdateTokenBalance updates the token balance for a wallet based on an ERC20 transfer.
 	// It identifies the involved wallet (sender or receiver) and updates the specific
 	// token balance. For senders, it also deducts the native currency gas cost.
+	// Balances of fee-on-transfer tokens change by the amounts of the receipt Transfer
+	// logs rather than the call amount, and balances of rebasing tokens are read from
+	// the token contract.
 	// Parameters:
 	//   - ctx: Context for the operation
 	//   - transfer: The parsed ERC20 transfer details
@@ -32,6 +43,38 @@ type BalanceService interface {
 	//   - error: ErrWalletNotFound, ErrTokenNotFound, or other processing errors
 	UpdateTokenBalance(ctx context.Context, transfer *types.ERC20Transfer) error
 
+	// RefreshTokenBalance reads a wallet's balance from the token contract and stores it.
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - chainType: The blockchain network type
+	//   - address: The wallet's address
+	//   - tokenAddress: The ERC20 token contract address
+	// Returns:
+	//   - *big.Int: The refreshed balance
+	//   - error: ErrWalletNotFound if the wallet doesn't exist, or any contract call error
+	RefreshTokenBalance(ctx context.Context, chainType types.ChainType, address, tokenAddress string) (*big.Int, error)
+
+	// SyncRebasingBalances refreshes the stored balances of all rebasing tokens from
+	// the token contracts, as they change without emitting transfers
+	// Returns:
+	//   - error: The first error that occurred while refreshing a balance
+	SyncRebasingBalances(ctx context.Context) error
+
+	// UpdateNFTHoldings applies NFT transfers to the holdings of the monitored wallets
+	// involved as sender or recipient. Each transfer is applied once, keyed by the
+	// transaction hash and log index, so processing a transaction again has no effect.
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - chainType: The blockchain network the transfers happened on
+	//   - txHash: The hash of the transaction moving the tokens
+	//   - transfers: The NFT transfers, in log order
+	// Returns:
+	//   - error: The first error that occurred while updating a holding
+	UpdateNFTHoldings(ctx context.Context, chainType types.ChainType, txHash string, transfers []types.NFTTransfer) error
+
+	// ListNFTHoldings retrieves the NFTs held by a wallet with token-based pagination
+	ListNFTHoldings(ctx context.Context, chainType types.ChainType, address string, limit int, nextToken string) (*types.Page[*NFTHolding], error)
+
 	// GetWalletBalances retrieves the native and token balances for a wallet
 	GetWalletBalances(ctx context.Context, id int64) ([]*TokenBalanceData, error)
 
@@ -40,17 +83,27 @@ type BalanceService interface {
 }
 
 type balanceService struct {
-	repository Repository
-	log        logger.Logger
-	tokenStore tokenstore.TokenStore
+	repository      Repository
+	log             logger.Logger
+	tokenStore      tokenstore.TokenStore
+	walletFactory   coreWallet.Factory
+	contractFactory contract.Factory
+	abiFactory      coreAbi.Factory
+	transactor      db.Transactor
+	streamService   stream.Service
 }
 
 func NewBalanceService(
 	repository Repository,
 	log logger.Logger,
 	tokenStore tokenstore.TokenStore,
+	walletFactory coreWallet.Factory,
+	contractFactory contract.Factory,
+	abiFactory coreAbi.Factory,
+	transactor db.Transactor,
+	streamService stream.Service,
 ) BalanceService {
-	return &balanceService{repository, log, tokenStore}
+	return &balanceService{repository, log, tokenStore, walletFactory, contractFactory, abiFactory, transactor, streamService}
 }
 
 // isOutgoingTransaction returns (isOutgoingTransaction, error)
@@ -115,7 +168,12 @@ func (s *balanceService) UpdateWalletBalance(ctx context.Context, tx *types.Tran
 		newBalance = new(big.Int).Add(currentBalance, tx.Value)
 	}
 
-	return s.repository.UpdateBalance(ctx, wallet, newBalance)
+	if err := s.repository.UpdateBalance(ctx, wallet, newBalance); err != nil {
+		return err
+	}
+
+	s.publishBalance(ctx, wallet, "", newBalance)
+	return nil
 }
 
 // UpdateTokenBalance updates the token balance for a wallet based on an ERC20 transfer
@@ -192,53 +250,411 @@ func (s *balanceService) UpdateTokenBalance(ctx context.Context, transfer *types
 	}
 	normalizedTokenAddress := tokenAddress.ToChecksum()
 
-	// Get current balance or default to zero using the new repository method
-	tb, err := s.repository.GetTokenBalance(ctx, involvedWallet.ID, normalizedTokenAddress)
+	// Balances read from the token contract are read before the transaction, so that
+	// the RPC call doesn't hold the database write lock
+	token, contractBalance, err := s.contractBalanceAfterTransfer(ctx, involvedWallet, normalizedTokenAddress, transfer)
 	if err != nil {
 		return err
 	}
 
+	// Read the current balances and store the updated token balance and the gas
+	// deducted from the native balance within one transaction
+	return s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		newTokenBalance := contractBalance
+		if newTokenBalance == nil {
+			newTokenBalance, err = s.storedBalanceAfterTransfer(ctx, involvedWallet, token, normalizedTokenAddress, transfer, isOutgoing)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := s.repository.UpdateTokenBalance(ctx, involvedWallet, normalizedTokenAddress, newTokenBalance); err != nil {
+			s.log.Error("Failed to update token balance in repository",
+				logger.Int64("wallet_id", involvedWallet.ID),
+				logger.String("token_address", normalizedTokenAddress),
+				logger.Error(err))
+			return err
+		}
+		if err := s.publishBalance(ctx, involvedWallet, normalizedTokenAddress, newTokenBalance); err != nil {
+			return err
+		}
+
+		// Deduct native gas cost ONLY if the wallet was the sender
+		if isOutgoing {
+			// Use GasUsed and GasPrice from the embedded BaseTransaction
+			if transfer.GasUsed > 0 && transfer.GasPrice != nil && transfer.GasPrice.Sign() > 0 {
+				current, err := s.repository.GetByID(ctx, involvedWallet.ID)
+				if err != nil {
+					return err
+				}
+				currentNativeBalance := current.Balance.ToBigInt()
+				newNativeBalance := s.calculateNewBalanceAfterGas(currentNativeBalance, transfer.GasUsed, transfer.GasPrice)
+				if err := s.repository.UpdateBalance(ctx, involvedWallet, newNativeBalance); err != nil {
+					s.log.Error("Failed to update sender native balance for gas during token transfer",
+						logger.Int64("wallet_id", involvedWallet.ID),
+						logger.String("tx_hash", transfer.Hash),
+						logger.Error(err))
+					return err
+				}
+				if err := s.publishBalance(ctx, involvedWallet, "", newNativeBalance); err != nil {
+					return err
+				}
+			} else {
+				s.log.Warn("Missing gas details in ERC20Transfer, cannot deduct native gas cost",
+					logger.String("tx_hash", transfer.Hash),
+					logger.Int64("wallet_id", involvedWallet.ID))
+			}
+		}
+
+		return nil
+	})
+}
+
+// tokenBalanceAfterTransfer computes a wallet's token balance after an ERC20 transfer.
+// The call amount is applied for regular tokens, the net amount of the receipt Transfer
+// logs for fee-on-transfer tokens, while rebasing tokens are read from the contract.
+func (s *balanceService) tokenBalanceAfterTransfer(ctx context.Context, wallet *Wallet, tokenAddress string, transfer *types.ERC20Transfer, isOutgoing bool) (*big.Int, error) {
+	token, balance, err := s.contractBalanceAfterTransfer(ctx, wallet, tokenAddress, transfer)
+	if err != nil || balance != nil {
+		return balance, err
+	}
+	return s.storedBalanceAfterTransfer(ctx, wallet, token, tokenAddress, transfer, isOutgoing)
+}
+
+// contractBalanceAfterTransfer reads a wallet's token balance from the contract when
+// the transfer can't be applied to the stored balance, that is for rebasing tokens and
+// fee-on-transfer tokens without receipt logs. It returns a nil balance otherwise,
+// along with the token when it is known.
+func (s *balanceService) contractBalanceAfterTransfer(ctx context.Context, wallet *Wallet, tokenAddress string, transfer *types.ERC20Transfer) (*types.Token, *big.Int, error) {
+	token, err := s.tokenStore.GetToken(ctx, tokenAddress)
+	if err != nil && !errors.IsError(err, errors.ErrCodeResourceNotFound) {
+		return nil, nil, err
+	}
+	if token == nil {
+		return nil, nil, nil
+	}
+
+	if token.Rebasing {
+		balance, err := s.readTokenBalance(ctx, wallet, tokenAddress)
+		return token, balance, err
+	}
+
+	if token.FeeOnTransfer {
+		if _, ok := transfer.Metadata.GetTokenTransfers(); !ok {
+			// Without the receipt logs the received amount is unknown
+			s.log.Warn("Missing receipt transfers of fee-on-transfer token, reading balance from contract",
+				logger.String("tx_hash", transfer.Hash),
+				logger.String("token_address", tokenAddress))
+			balance, err := s.readTokenBalance(ctx, wallet, tokenAddress)
+			return token, balance, err
+		}
+	}
+
+	return token, nil, nil
+}
+
+// storedBalanceAfterTransfer applies a transfer to a wallet's stored token balance
+func (s *balanceService) storedBalanceAfterTransfer(ctx context.Context, wallet *Wallet, token *types.Token, tokenAddress string, transfer *types.ERC20Transfer, isOutgoing bool) (*big.Int, error) {
+	// Get current balance or default to zero using the new repository method
+	tb, err := s.repository.GetTokenBalance(ctx, wallet.ID, tokenAddress)
+	if err != nil {
+		return nil, err
+	}
+
 	// tb.Balance will be zero if the balance didn't exist in the DB
 	currentTokenBalance := tb.Balance.ToBigInt()
 
-	var newTokenBalance *big.Int
-	if isOutgoing {
-		newTokenBalance = new(big.Int).Sub(currentTokenBalance, transfer.Amount) // Use Amount from transfer
-		if newTokenBalance.Sign() < 0 {
-			newTokenBalance = big.NewInt(0)
-		}
+	var delta *big.Int
+	if token != nil && token.FeeOnTransfer {
+		logged, _ := transfer.Metadata.GetTokenTransfers()
+		delta = netTransferAmount(logged, tokenAddress, wallet.Address)
+	} else if isOutgoing {
+		delta = new(big.Int).Neg(transfer.Amount) // Use Amount from transfer
 	} else {
-		newTokenBalance = new(big.Int).Add(currentTokenBalance, transfer.Amount) // Use Amount from transfer
+		delta = transfer.Amount // Use Amount from transfer
+	}
+
+	newTokenBalance := new(big.Int).Add(currentTokenBalance, delta)
+	if newTokenBalance.Sign() < 0 {
+		newTokenBalance = big.NewInt(0)
+	}
+
+	return newTokenBalance, nil
+}
+
+// netTransferAmount sums the amounts of a token received by an address minus the
+// amounts it sent, as reported by the Transfer logs of a transaction
+func netTransferAmount(transfers []types.TokenTransfer, tokenAddress, address string) *big.Int {
+	net := new(big.Int)
+	for _, transfer := range transfers {
+		if transfer.Amount == nil || !strings.EqualFold(transfer.TokenAddress, tokenAddress) {
+			continue
+		}
+		if strings.EqualFold(transfer.To, address) {
+			net.Add(net, transfer.Amount)
+		}
+		if strings.EqualFold(transfer.From, address) {
+			net.Sub(net, transfer.Amount)
+		}
+	}
+	return net
+}
+
+// readTokenBalance reads a wallet's balance from the token contract
+func (s *balanceService) readTokenBalance(ctx context.Context, wallet *Wallet, tokenAddress string) (*big.Int, error) {
+	abiLoader, err := s.abiFactory.NewABILoader(wallet.ChainType)
+	if err != nil {
+		return nil, err
+	}
+	erc20ABI, err := abiLoader.LoadABIByType(ctx, coreAbi.ABITypeERC20)
+	if err != nil {
+		return nil, err
+	}
+
+	walletManager, err := s.walletFactory.NewManager(ctx, wallet.ChainType, wallet.KeyID)
+	if err != nil {
+		return nil, err
+	}
+	contractManager, err := s.contractFactory.NewManager(ctx, walletManager)
+	if err != nil {
+		return nil, err
+	}
+
+	outputs, err := contractManager.CallMethod(ctx, tokenAddress, erc20ABI, "balanceOf", common.HexToAddress(wallet.Address))
+	if err != nil {
+		return nil, err
+	}
+	if len(outputs) != 1 {
+		return nil, errors.NewInvalidContractCallError(tokenAddress, fmt.Errorf("unexpected balanceOf result %v", outputs))
+	}
+	balance, ok := outputs[0].(*big.Int)
+	if !ok {
+		return nil, errors.NewInvalidContractCallError(tokenAddress, fmt.Errorf("unexpected balanceOf result type %T", outputs[0]))
+	}
+
+	return balance, nil
+}
+
+// RefreshTokenBalance reads a wallet's balance from the token contract and stores it
+func (s *balanceService) RefreshTokenBalance(ctx context.Context, chainType types.ChainType, address, tokenAddress string) (*big.Int, error) {
+	wallet, err := s.repository.GetByAddress(ctx, chainType, address)
+	if err != nil {
+		return nil, err
+	}
+	return s.refreshTokenBalance(ctx, wallet, tokenAddress)
+}
+
+func (s *balanceService) refreshTokenBalance(ctx context.Context, wallet *Wallet, tokenAddress string) (*big.Int, error) {
+	normalized, err := types.NewAddress(wallet.ChainType, tokenAddress)
+	if err != nil {
+		return nil, err
+	}
+	normalizedTokenAddress := normalized.ToChecksum()
+
+	balance, err := s.readTokenBalance(ctx, wallet, normalizedTokenAddress)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.repository.UpdateTokenBalance(ctx, involvedWallet, normalizedTokenAddress, newTokenBalance); err != nil {
-		s.log.Error("Failed to update token balance in repository",
-			logger.Int64("wallet_id", involvedWallet.ID),
-			logger.String("token_address", normalizedTokenAddress),
-			logger.Error(err))
+	if err := s.repository.UpdateTokenBalance(ctx, wallet, normalizedTokenAddress, balance); err != nil {
+		return nil, err
+	}
+
+	s.publishBalance(ctx, wallet, normalizedTokenAddress, balance)
+	return balance, nil
+}
+
+// publishBalance streams a balance change of a wallet, native when tokenAddress is empty.
+// Failures are logged and returned, so that callers running in a transaction can roll
+// back the change they notify.
+func (s *balanceService) publishBalance(ctx context.Context, wallet *Wallet, tokenAddress string, balance *big.Int) error {
+	data := stream.BalanceData{
+		WalletID:     wallet.ID,
+		Address:      wallet.Address,
+		TokenAddress: tokenAddress,
+		Balance:      balance.String(),
+	}
+
+	scope := stream.Scope{ChainType: wallet.ChainType, WalletID: wallet.ID}
+	if err := s.streamService.Publish(ctx, stream.EventTypeBalance, scope, data); err != nil {
+		s.log.Error("Failed to publish balance event",
+			logger.Error(err),
+			logger.Int64("wallet_id", wallet.ID),
+			logger.String("token_address", tokenAddress))
 		return err
 	}
+	return nil
+}
 
-	// Deduct native gas cost ONLY if the wallet was the sender
-	if isOutgoing {
-		// Use GasUsed and GasPrice from the embedded BaseTransaction
-		if transfer.GasUsed > 0 && transfer.GasPrice != nil && transfer.GasPrice.Sign() > 0 {
-			currentNativeBalance := involvedWallet.Balance.ToBigInt()
-			newNativeBalance := s.calculateNewBalanceAfterGas(currentNativeBalance, transfer.GasUsed, transfer.GasPrice)
-			if err := s.repository.UpdateBalance(ctx, involvedWallet, newNativeBalance); err != nil {
-				s.log.Error("Failed to update sender native balance for gas during token transfer",
-					logger.Int64("wallet_id", involvedWallet.ID),
-					logger.String("tx_hash", transfer.Hash),
+// SyncRebasingBalances refreshes the stored balances of all rebasing tokens
+func (s *balanceService) SyncRebasingBalances(ctx context.Context) error {
+	rebasing := true
+	page, err := s.tokenStore.ListTokens(ctx, &tokenstore.TokenFilter{Rebasing: &rebasing}, 0, "")
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	refreshed := 0
+	for _, token := range page.Items {
+		balances, err := s.repository.ListTokenBalancesByToken(ctx, token.Address)
+		if err != nil {
+			return err
+		}
+
+		for _, tb := range balances {
+			wallet, err := s.repository.GetByID(ctx, tb.WalletID)
+			if err != nil {
+				if errors.IsError(err, errors.ErrCodeWalletNotFound) {
+					continue // Deleted wallet
+				}
+				return err
+			}
+
+			if _, err := s.refreshTokenBalance(ctx, wallet, token.Address); err != nil {
+				s.log.Warn("Failed to refresh rebasing token balance",
+					logger.Int64("wallet_id", wallet.ID),
+					logger.String("token_address", token.Address),
 					logger.Error(err))
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			refreshed++
+		}
+	}
+
+	s.log.Debug("Synchronized rebasing token balances",
+		logger.Int("tokens", len(page.Items)),
+		logger.Int("balances", refreshed))
+
+	return firstErr
+}
+
+// UpdateNFTHoldings applies NFT transfers to the holdings of the monitored wallets.
+// The transfers are applied atomically, none of them is stored if one fails.
+func (s *balanceService) UpdateNFTHoldings(ctx context.Context, chainType types.ChainType, txHash string, transfers []types.NFTTransfer) error {
+	return s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		for _, transfer := range mergeNFTTransfers(transfers) {
+			if err := s.applyNFTTransfer(ctx, chainType, txHash, transfer, transfer.From, false); err != nil {
+				return err
+			}
+			if err := s.applyNFTTransfer(ctx, chainType, txHash, transfer, transfer.To, true); err != nil {
+				return err
 			}
+		}
+
+		return nil
+	})
+}
+
+// mergeNFTTransfers sums the amounts of the transfers of the same token by the same
+// log, as a TransferBatch may list a token ID more than once, so that each transfer
+// is identified by its log index and token. Transfers without a token ID or amount
+// are dropped.
+func mergeNFTTransfers(transfers []types.NFTTransfer) []types.NFTTransfer {
+	type transferKey struct {
+		logIndex     uint
+		tokenAddress string
+		tokenID      string
+	}
+
+	merged := make([]types.NFTTransfer, 0, len(transfers))
+	positions := make(map[transferKey]int, len(transfers))
+	for _, transfer := range transfers {
+		if transfer.TokenID == nil || transfer.Amount == nil {
+			continue
+		}
+
+		key := transferKey{transfer.LogIndex, strings.ToLower(transfer.TokenAddress), transfer.TokenID.String()}
+		if i, found := positions[key]; found {
+			merged[i].Amount = new(big.Int).Add(merged[i].Amount, transfer.Amount)
+			continue
+		}
+		positions[key] = len(merged)
+		merged = append(merged, transfer)
+	}
+	return merged
+}
+
+// applyNFTTransfer updates the holding of one side of an NFT transfer if the
+// address belongs to a monitored wallet and the transfer wasn't applied yet
+func (s *balanceService) applyNFTTransfer(ctx context.Context, chainType types.ChainType, txHash string, transfer types.NFTTransfer, address string, incoming bool) error {
+	if address == "" || types.IsZeroAddress(address) {
+		return nil // Mint or burn side
+	}
+
+	wallet, err := s.repository.GetByAddress(ctx, chainType, address)
+	if err != nil {
+		if errors.IsError(err, errors.ErrCodeWalletNotFound) {
+			return nil // Not one of our wallets
+		}
+		return err
+	}
+
+	applied, err := s.repository.MarkNFTTransferApplied(ctx, wallet.ID, txHash, transfer, incoming)
+	if err != nil {
+		return err
+	}
+	if !applied {
+		s.log.Debug("NFT transfer already applied",
+			logger.Int64("wallet_id", wallet.ID),
+			logger.String("tx_hash", txHash),
+			logger.Int("log_index", int(transfer.LogIndex)))
+		return nil
+	}
+
+	var newAmount *big.Int
+	if transfer.Standard == types.TokenTypeERC721 {
+		// ERC721 tokens are unique, the recipient becomes the sole owner
+		newAmount = big.NewInt(0)
+		if incoming {
+			newAmount = big.NewInt(1)
+		}
+	} else {
+		holding, err := s.repository.GetNFTHolding(ctx, wallet.ID, transfer.TokenAddress, transfer.TokenID)
+		if err != nil {
+			return err
+		}
+		current := holding.Amount.ToBigInt()
+		if incoming {
+			newAmount = new(big.Int).Add(current, transfer.Amount)
 		} else {
-			s.log.Warn("Missing gas details in ERC20Transfer, cannot deduct native gas cost",
-				logger.String("tx_hash", transfer.Hash),
-				logger.Int64("wallet_id", involvedWallet.ID))
+			newAmount = new(big.Int).Sub(current, transfer.Amount)
+			if newAmount.Sign() < 0 {
+				newAmount = big.NewInt(0)
+			}
 		}
 	}
 
-	return nil
+	s.log.Info("Updating NFT holding",
+		logger.Int64("wallet_id", wallet.ID),
+		logger.String("token_address", transfer.TokenAddress),
+		logger.String("token_id", transfer.TokenID.String()),
+		logger.Bool("is_incoming", incoming))
+
+	return s.repository.UpdateNFTHolding(ctx, wallet, transfer.Standard, transfer.TokenAddress, transfer.TokenID, newAmount)
+}
+
+// ListNFTHoldings retrieves the NFTs held by a wallet with token-based pagination
+func (s *balanceService) ListNFTHoldings(ctx context.Context, chainType types.ChainType, address string, limit int, nextToken string) (*types.Page[*NFTHolding], error) {
+	if chainType == "" {
+		return nil, errors.NewInvalidInputError("Chain type is required", "chain_type", "")
+	}
+	if address == "" {
+		return nil, errors.NewInvalidInputError("Address is required", "address", "")
+	}
+	if limit < 0 {
+		limit = 10
+	}
+
+	wallet, err := s.repository.GetByAddress(ctx, chainType, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repository.ListNFTHoldings(ctx, wallet.ID, limit, nextToken)
 }
 
 // calculateNewBalanceAfterGas calculates the new balance after deducting gas costs.
@@ -0,0 +1,362 @@
+package wallet
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/core/tokenstore"
+	"vault0/internal/db"
+	"vault0/internal/logger"
+	"vault0/internal/services/stream"
+	"vault0/internal/testing/dbtest"
+	"vault0/internal/types"
+)
+
+const (
+	testOtherWalletAddress = "0x8ba1f109551bD432803012645Ac136ddd64DBA72"
+	testNFTTokenAddress    = "0x5555555555555555555555555555555555555555"
+	testTxHash             = "0xabababababababababababababababababababababababababababababababab"
+	testFeeTokenAddress    = "0x6666666666666666666666666666666666666666"
+	testRebasingAddress    = "0x7777777777777777777777777777777777777777"
+	testFeeCollector       = "0x9999999999999999999999999999999999999999"
+)
+
+// testStreamService records the published events
+type testStreamService struct {
+	stream.Service
+	events    []stream.EventType
+	published []any
+}
+
+func (s *testStreamService) Publish(ctx context.Context, eventType stream.EventType, scope stream.Scope, data any) error {
+	s.events = append(s.events, eventType)
+	s.published = append(s.published, data)
+	return nil
+}
+
+// newTestBalanceService creates a balance service backed by a test database, reading
+// token balances through the given contract manager
+func newTestBalanceService(database *db.DB, manager *testContractManager) *balanceService {
+	return &balanceService{
+		repository:      NewRepository(database),
+		log:             logger.NewNopLogger(),
+		tokenStore:      tokenstore.NewTokenStore(database, &types.Chains{}, logger.NewNopLogger()),
+		walletFactory:   &testWalletFactory{},
+		contractFactory: &testContractFactory{manager: manager},
+		abiFactory:      &testABIFactory{},
+		transactor:      database,
+		streamService:   &testStreamService{},
+	}
+}
+
+// addBehaviorTokens registers a fee-on-transfer and a rebasing token
+func addBehaviorTokens(t *testing.T, store tokenstore.TokenStore) {
+	t.Helper()
+
+	ctx := context.Background()
+	require.NoError(t, store.AddToken(ctx, &types.Token{
+		Address: testFeeTokenAddress, ChainType: types.ChainTypeEthereum, Symbol: "FEE", Decimals: 18,
+		Type: types.TokenTypeERC20, Verified: true, FeeOnTransfer: true,
+	}))
+	require.NoError(t, store.AddToken(ctx, &types.Token{
+		Address: testRebasingAddress, ChainType: types.ChainTypeEthereum, Symbol: "REB", Decimals: 18,
+		Type: types.TokenTypeERC20, Verified: true, Rebasing: true,
+	}))
+}
+
+// tokenBalance returns the stored token balance of a wallet
+func tokenBalance(t *testing.T, repo Repository, wallet *Wallet, tokenAddress string) string {
+	t.Helper()
+
+	tb, err := repo.GetTokenBalance(context.Background(), wallet.ID, tokenAddress)
+	require.NoError(t, err)
+	return tb.Balance.ToBigInt().String()
+}
+
+// newTestTokenTransfer creates an ERC20 transfer of a token between two addresses
+func newTestTokenTransfer(tokenAddress, from, to string, amount int64) *types.ERC20Transfer {
+	return &types.ERC20Transfer{
+		Transaction: types.Transaction{
+			BaseTransaction: types.BaseTransaction{
+				ChainType: types.ChainTypeEthereum,
+				Hash:      testTxHash,
+				From:      from,
+				To:        tokenAddress,
+				GasPrice:  big.NewInt(0),
+			},
+			Metadata: types.TxMetadata{},
+		},
+		TokenAddress: tokenAddress,
+		Recipient:    to,
+		Amount:       big.NewInt(amount),
+	}
+}
+
+// nftAmount returns the amount of an NFT held by a wallet
+func nftAmount(t *testing.T, repo Repository, wallet *Wallet, tokenID int64) string {
+	t.Helper()
+
+	holding, err := repo.GetNFTHolding(context.Background(), wallet.ID, testNFTTokenAddress, big.NewInt(tokenID))
+	require.NoError(t, err)
+	return holding.Amount.String()
+}
+
+func TestBalanceService_UpdateNFTHoldings(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		service := newTestBalanceService(database, &testContractManager{})
+		repo := service.repository
+		ctx := context.Background()
+
+		sender := createTestWallet(t, repo)
+		recipient := &Wallet{KeyID: "2", ChainType: types.ChainTypeEthereum, Address: testOtherWalletAddress, Name: "Operations"}
+		require.NoError(t, repo.Create(ctx, recipient))
+
+		erc1155 := func(from, to string, tokenID, amount int64, logIndex uint) types.NFTTransfer {
+			return types.NFTTransfer{
+				TokenAddress: testNFTTokenAddress,
+				Standard:     types.TokenTypeERC1155,
+				From:         from,
+				To:           to,
+				TokenID:      big.NewInt(tokenID),
+				Amount:       big.NewInt(amount),
+				LogIndex:     logIndex,
+			}
+		}
+
+		// A mint, with a batch listing the same token twice
+		mint := []types.NFTTransfer{
+			erc1155(types.ZeroAddress, sender.Address, 1, 6, 0),
+			erc1155(types.ZeroAddress, sender.Address, 1, 4, 0),
+			erc1155(types.ZeroAddress, sender.Address, 2, 1, 0),
+		}
+		require.NoError(t, service.UpdateNFTHoldings(ctx, types.ChainTypeEthereum, "0x01", mint))
+		assert.Equal(t, "10", nftAmount(t, repo, sender, 1))
+		assert.Equal(t, "1", nftAmount(t, repo, sender, 2))
+
+		// A transfer between monitored wallets updates both sides
+		transfer := []types.NFTTransfer{erc1155(sender.Address, recipient.Address, 1, 3, 1)}
+		require.NoError(t, service.UpdateNFTHoldings(ctx, types.ChainTypeEthereum, testTxHash, transfer))
+		assert.Equal(t, "7", nftAmount(t, repo, sender, 1))
+		assert.Equal(t, "3", nftAmount(t, repo, recipient, 1))
+
+		// Processing the same transaction again has no effect
+		require.NoError(t, service.UpdateNFTHoldings(ctx, types.ChainTypeEthereum, testTxHash, transfer))
+		assert.Equal(t, "7", nftAmount(t, repo, sender, 1))
+		assert.Equal(t, "3", nftAmount(t, repo, recipient, 1))
+
+		// Sending more than held empties the holding rather than going negative
+		overdraw := []types.NFTTransfer{erc1155(sender.Address, types.ZeroAddress, 2, 5, 2)}
+		require.NoError(t, service.UpdateNFTHoldings(ctx, types.ChainTypeEthereum, testTxHash, overdraw))
+		assert.Equal(t, "0", nftAmount(t, repo, sender, 2))
+
+		// ERC721 ownership moves to the recipient
+		nft := types.NFTTransfer{
+			TokenAddress: testNFTTokenAddress,
+			Standard:     types.TokenTypeERC721,
+			From:         sender.Address,
+			To:           recipient.Address,
+			TokenID:      big.NewInt(9),
+			Amount:       big.NewInt(1),
+			LogIndex:     3,
+		}
+		require.NoError(t, service.UpdateNFTHoldings(ctx, types.ChainTypeEthereum, testTxHash, []types.NFTTransfer{nft}))
+		assert.Equal(t, "0", nftAmount(t, repo, sender, 9))
+		assert.Equal(t, "1", nftAmount(t, repo, recipient, 9))
+
+		page, err := repo.ListNFTHoldings(ctx, recipient.ID, 10, "")
+		require.NoError(t, err)
+		assert.Len(t, page.Items, 2)
+	})
+}
+
+func TestMergeNFTTransfers(t *testing.T) {
+	transfers := []types.NFTTransfer{
+		{TokenAddress: testNFTTokenAddress, TokenID: big.NewInt(1), Amount: big.NewInt(2), LogIndex: 1},
+		{TokenAddress: testNFTTokenAddress, TokenID: big.NewInt(1), Amount: big.NewInt(3), LogIndex: 1},
+		{TokenAddress: testNFTTokenAddress, TokenID: big.NewInt(1), Amount: big.NewInt(4), LogIndex: 2},
+		{TokenAddress: testNFTTokenAddress, TokenID: nil, Amount: big.NewInt(1), LogIndex: 3},
+	}
+
+	merged := mergeNFTTransfers(transfers)
+	require.Len(t, merged, 2)
+	assert.Equal(t, "5", merged[0].Amount.String())
+	assert.Equal(t, "4", merged[1].Amount.String())
+	assert.Equal(t, "2", transfers[0].Amount.String(), "input transfers must not be modified")
+}
+
+func TestBalanceService_TokenBalanceAfterTransfer(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		manager := &testContractManager{}
+		service := newTestBalanceService(database, manager)
+		repo := service.repository
+		ctx := context.Background()
+		wallet := createTestWallet(t, repo)
+		addBehaviorTokens(t, service.tokenStore)
+		require.NoError(t, repo.UpdateTokenBalance(ctx, wallet, testTokenAddress, big.NewInt(100)))
+		require.NoError(t, repo.UpdateTokenBalance(ctx, wallet, testFeeTokenAddress, big.NewInt(100)))
+
+		// Regular tokens apply the call amount
+		incoming := newTestTokenTransfer(testTokenAddress, testOtherWalletAddress, wallet.Address, 40)
+		balance, err := service.tokenBalanceAfterTransfer(ctx, wallet, testTokenAddress, incoming, false)
+		require.NoError(t, err)
+		assert.Equal(t, "140", balance.String())
+
+		outgoing := newTestTokenTransfer(testTokenAddress, wallet.Address, testOtherWalletAddress, 150)
+		balance, err = service.tokenBalanceAfterTransfer(ctx, wallet, testTokenAddress, outgoing, true)
+		require.NoError(t, err)
+		assert.Equal(t, "0", balance.String(), "balances must not go negative")
+
+		// Fee-on-transfer tokens apply the amounts of the receipt logs
+		feeTransfer := newTestTokenTransfer(testFeeTokenAddress, testOtherWalletAddress, wallet.Address, 40)
+		require.NoError(t, feeTransfer.Metadata.SetJSON(types.TokenTransfersMetadataKey, []types.TokenTransfer{
+			{TokenAddress: testFeeTokenAddress, From: testOtherWalletAddress, To: wallet.Address, Amount: big.NewInt(38)},
+			{TokenAddress: testFeeTokenAddress, From: testOtherWalletAddress, To: testFeeCollector, Amount: big.NewInt(2)},
+		}))
+		balance, err = service.tokenBalanceAfterTransfer(ctx, wallet, testFeeTokenAddress, feeTransfer, false)
+		require.NoError(t, err)
+		assert.Equal(t, "138", balance.String())
+
+		// Without the receipt logs, the balance is read from the contract
+		balanceOfArgs := []any{common.HexToAddress(wallet.Address)}
+		manager.On("CallMethod", testFeeTokenAddress, "balanceOf", balanceOfArgs).Return([]any{big.NewInt(137)}, nil).Once()
+		noReceipt := newTestTokenTransfer(testFeeTokenAddress, testOtherWalletAddress, wallet.Address, 40)
+		balance, err = service.tokenBalanceAfterTransfer(ctx, wallet, testFeeTokenAddress, noReceipt, false)
+		require.NoError(t, err)
+		assert.Equal(t, "137", balance.String())
+
+		// Rebasing tokens are always read from the contract
+		manager.On("CallMethod", testRebasingAddress, "balanceOf", balanceOfArgs).Return([]any{big.NewInt(1001)}, nil).Once()
+		rebasing := newTestTokenTransfer(testRebasingAddress, testOtherWalletAddress, wallet.Address, 1000)
+		balance, err = service.tokenBalanceAfterTransfer(ctx, wallet, testRebasingAddress, rebasing, false)
+		require.NoError(t, err)
+		assert.Equal(t, "1001", balance.String())
+
+		manager.AssertExpectations(t)
+	})
+}
+
+func TestBalanceService_UpdateTokenBalance(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		manager := &testContractManager{}
+		service := newTestBalanceService(database, manager)
+		repo := service.repository
+		ctx := context.Background()
+		wallet := createTestWallet(t, repo)
+		require.NoError(t, repo.UpdateBalance(ctx, wallet, big.NewInt(1000000)))
+		require.NoError(t, repo.UpdateTokenBalance(ctx, wallet, testTokenAddress, big.NewInt(100)))
+
+		transfer := newTestTokenTransfer(testTokenAddress, wallet.Address, testOtherWalletAddress, 30)
+		transfer.GasUsed = 21000
+		transfer.GasPrice = big.NewInt(10)
+		require.NoError(t, service.UpdateTokenBalance(ctx, transfer))
+		assert.Equal(t, "70", tokenBalance(t, repo, wallet, testTokenAddress))
+
+		// The sender pays the gas of the transfer
+		updated, err := repo.GetByID(ctx, wallet.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "790000", updated.Balance.ToBigInt().String())
+		assert.Len(t, service.streamService.(*testStreamService).published, 2)
+
+		// Transfers between unmonitored addresses are ignored
+		unrelated := newTestTokenTransfer(testTokenAddress, testFeeCollector, testNFTTokenAddress, 30)
+		require.NoError(t, service.UpdateTokenBalance(ctx, unrelated))
+		assert.Equal(t, "70", tokenBalance(t, repo, wallet, testTokenAddress))
+
+		// Balances read from the contract are read before the database transaction
+		addBehaviorTokens(t, service.tokenStore)
+		manager.On("CallMethod", testRebasingAddress, "balanceOf", mock.Anything).Return([]any{big.NewInt(1010)}, nil).Once()
+		rebasing := newTestTokenTransfer(testRebasingAddress, testOtherWalletAddress, wallet.Address, 10)
+		require.NoError(t, service.UpdateTokenBalance(ctx, rebasing))
+		assert.Equal(t, "1010", tokenBalance(t, repo, wallet, testRebasingAddress))
+		assert.False(t, manager.calledInTransaction)
+		manager.AssertExpectations(t)
+	})
+}
+
+func TestBalanceService_SyncRebasingBalances(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		manager := &testContractManager{}
+		service := newTestBalanceService(database, manager)
+		repo := service.repository
+		ctx := context.Background()
+		wallet := createTestWallet(t, repo)
+		addBehaviorTokens(t, service.tokenStore)
+		require.NoError(t, repo.UpdateTokenBalance(ctx, wallet, testRebasingAddress, big.NewInt(1000)))
+		require.NoError(t, repo.UpdateTokenBalance(ctx, wallet, testFeeTokenAddress, big.NewInt(500)))
+
+		manager.On("CallMethod", testRebasingAddress, "balanceOf", mock.Anything).Return([]any{big.NewInt(1010)}, nil).Once()
+		require.NoError(t, service.SyncRebasingBalances(ctx))
+		assert.Equal(t, "1010", tokenBalance(t, repo, wallet, testRebasingAddress))
+		// Only the rebasing tokens are read from the contracts
+		assert.Equal(t, "500", tokenBalance(t, repo, wallet, testFeeTokenAddress))
+
+		// Failed reads are reported and leave the stored balance unchanged
+		manager.On("CallMethod", testRebasingAddress, "balanceOf", mock.Anything).Return([]any{}, nil).Once()
+		assert.Error(t, service.SyncRebasingBalances(ctx))
+		assert.Equal(t, "1010", tokenBalance(t, repo, wallet, testRebasingAddress))
+
+		manager.AssertExpectations(t)
+	})
+}
+
+func TestNetTransferAmount(t *testing.T) {
+	const token = "0x6666666666666666666666666666666666666666"
+	const wallet = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+
+	tests := []struct {
+		name      string
+		transfers []types.TokenTransfer
+		expected  string
+	}{
+		{
+			name:     "no transfers",
+			expected: "0",
+		},
+		{
+			name: "received amount net of the fee",
+			transfers: []types.TokenTransfer{
+				{TokenAddress: token, From: testOtherWalletAddress, To: wallet, Amount: big.NewInt(95)},
+				{TokenAddress: token, From: testOtherWalletAddress, To: testFeeCollector, Amount: big.NewInt(5)},
+			},
+			expected: "95",
+		},
+		{
+			name: "sent amount including the fee",
+			transfers: []types.TokenTransfer{
+				{TokenAddress: token, From: wallet, To: testOtherWalletAddress, Amount: big.NewInt(95)},
+				{TokenAddress: token, From: wallet, To: testFeeCollector, Amount: big.NewInt(5)},
+			},
+			expected: "-100",
+		},
+		{
+			name: "addresses compared case-insensitively, other tokens ignored",
+			transfers: []types.TokenTransfer{
+				{TokenAddress: "0x" + strings.ToUpper(token[2:]), From: testOtherWalletAddress, To: wallet, Amount: big.NewInt(1)},
+				{TokenAddress: token, From: testOtherWalletAddress, To: strings.ToLower(wallet), Amount: big.NewInt(10)},
+				{TokenAddress: testTokenAddress, From: testOtherWalletAddress, To: wallet, Amount: big.NewInt(7)},
+				{TokenAddress: token, From: testOtherWalletAddress, To: wallet},
+			},
+			expected: "11",
+		},
+		{
+			name: "self transfer",
+			transfers: []types.TokenTransfer{
+				{TokenAddress: token, From: wallet, To: wallet, Amount: big.NewInt(3)},
+			},
+			expected: "0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, netTransferAmount(tc.transfers, token, wallet).String())
+		})
+	}
+}
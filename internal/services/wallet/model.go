@@ -8,6 +8,16 @@ import (
 	"vault0/internal/types"
 )
 
+// SignatureFormat identifies how a payload is hashed before it is signed
+type SignatureFormat string
+
+const (
+	// SignatureFormatPersonal hashes the payload as an EIP-191 personal_sign message
+	SignatureFormatPersonal SignatureFormat = "personal_sign"
+	// SignatureFormatTypedData hashes the payload as an EIP-712 typed data JSON document
+	SignatureFormatTypedData SignatureFormat = "typed_data"
+)
+
 // Wallet represents a wallet entity stored in the database
 type Wallet struct {
 	ID              int64           `db:"id"`
@@ -38,6 +48,27 @@ type TokenBalanceData struct {
 	UpdatedAt time.Time
 }
 
+// NFTHolding represents an ERC721 or ERC1155 token held by a wallet
+type NFTHolding struct {
+	ID           int64           `db:"id"`
+	WalletID     int64           `db:"wallet_id"`
+	TokenAddress string          `db:"token_address"`
+	TokenID      types.BigInt    `db:"token_id"`
+	Standard     types.TokenType `db:"standard"`
+	Amount       types.BigInt    `db:"amount"`
+	UpdatedAt    time.Time       `db:"updated_at"`
+}
+
+// TokenAllowance represents an ERC20 allowance granted by a wallet to a spender
+type TokenAllowance struct {
+	ID           int64        `db:"id"`
+	WalletID     int64        `db:"wallet_id"`
+	TokenAddress string       `db:"token_address"`
+	Spender      string       `db:"spender"`
+	Amount       types.BigInt `db:"amount"`
+	UpdatedAt    time.Time    `db:"updated_at"`
+}
+
 // ScanWallet scans a database row into a Wallet struct
 func ScanWallet(row interface {
 	Scan(dest ...any) error
@@ -92,6 +123,49 @@ func ScanTokenBalance(row interface {
 	return tokenBalance, nil
 }
 
+// ScanNFTHolding scans a database row into an NFTHolding struct
+func ScanNFTHolding(row interface {
+	Scan(dest ...any) error
+}) (*NFTHolding, error) {
+	holding := &NFTHolding{}
+
+	err := row.Scan(
+		&holding.ID,
+		&holding.WalletID,
+		&holding.TokenAddress,
+		&holding.TokenID,
+		&holding.Standard,
+		&holding.Amount,
+		&holding.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return holding, nil
+}
+
+// ScanTokenAllowance scans a database row into a TokenAllowance struct
+func ScanTokenAllowance(row interface {
+	Scan(dest ...any) error
+}) (*TokenAllowance, error) {
+	allowance := &TokenAllowance{}
+
+	err := row.Scan(
+		&allowance.ID,
+		&allowance.WalletID,
+		&allowance.TokenAddress,
+		&allowance.Spender,
+		&allowance.Amount,
+		&allowance.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return allowance, nil
+}
+
 // GetToken returns the native token for the wallet's blockchain
 func (w *Wallet) GetToken() (*types.Token, error) {
 	return types.NewNativeToken(w.ChainType)
@@ -3,11 +3,16 @@ package wallet
 import (
 	"context"
 	"math/big"
+	"time"
+	"vault0/internal/config"
 	"vault0/internal/core/blockchain"
 	"vault0/internal/core/transaction"
 	"vault0/internal/errors"
 	"vault0/internal/logger"
+	"vault0/internal/services/stream"
+	tokenService "vault0/internal/services/token"
 	txService "vault0/internal/services/transaction"
+	"vault0/internal/services/webhook"
 	"vault0/internal/types"
 )
 
@@ -41,9 +46,14 @@ type walletMonitorService struct {
 	repository        Repository
 	blockchainFactory blockchain.Factory
 	balanceService    BalanceService
+	allowanceService  AllowanceService
+	tokenService      tokenService.Service
 	txMonitor         txService.MonitorService
 	txHistory         txService.HistoryService
 	txFactory         transaction.Factory
+	webhookService    webhook.Service
+	streamService     stream.Service
+	config            *config.Config
 }
 
 func NewWalletMonitorService(
@@ -51,11 +61,16 @@ func NewWalletMonitorService(
 	repository Repository,
 	blockchainFactory blockchain.Factory,
 	balanceService BalanceService,
+	allowanceService AllowanceService,
+	tokenService tokenService.Service,
 	txMonitor txService.MonitorService,
 	txHistory txService.HistoryService,
 	txFactory transaction.Factory,
+	webhookService webhook.Service,
+	streamService stream.Service,
+	cfg *config.Config,
 ) WalletMonitor {
-	return &walletMonitorService{log, repository, blockchainFactory, balanceService, txMonitor, txHistory, txFactory}
+	return &walletMonitorService{log, repository, blockchainFactory, balanceService, allowanceService, tokenService, txMonitor, txHistory, txFactory, webhookService, streamService, cfg}
 }
 
 // StartWalletMonitoring initializes monitoring for all non-deleted wallets
@@ -139,6 +154,9 @@ func (s *walletMonitorService) StartWalletMonitoring(ctx context.Context) error
 	// Start subscription to history events
 	go s.subscribeToHistoryEvents(ctx)
 
+	// Start refreshing the balances of rebasing tokens
+	go s.synchRebasingBalances(ctx)
+
 	return nil
 }
 
@@ -196,6 +214,9 @@ func (s *walletMonitorService) processTransactionEvent(ctx context.Context, even
 			return
 		}
 
+		// Approvals decoded from the call, used when the receipt logs are unavailable
+		var approvals []types.TokenApproval
+
 		// Update balances based on transaction type
 		switch decodedTx.GetType() {
 		case types.TransactionTypeERC20Transfer:
@@ -205,6 +226,7 @@ func (s *walletMonitorService) processTransactionEvent(ctx context.Context, even
 					logger.String("tx_hash", tx.Hash))
 				return
 			}
+			s.registerTokens(ctx, tx, erc20Transfer.TokenAddress)
 
 			// Update token balance
 			err := s.balanceService.UpdateTokenBalance(ctx, erc20Transfer)
@@ -216,6 +238,38 @@ func (s *walletMonitorService) processTransactionEvent(ctx context.Context, even
 					logger.String("token_address", erc20Transfer.TokenAddress))
 			}
 
+		case types.TransactionTypeERC20Approval:
+			erc20Approval, ok := decodedTx.(*types.ERC20Approval)
+			if !ok {
+				s.log.Error("Failed to convert transaction to ERC20Approval",
+					logger.String("tx_hash", tx.Hash))
+				return
+			}
+			approvals = append(approvals, types.TokenApproval{
+				TokenAddress: erc20Approval.TokenAddress,
+				Owner:        erc20Approval.Owner,
+				Spender:      erc20Approval.Spender,
+				Amount:       erc20Approval.Amount,
+			})
+
+		case types.TransactionTypeERC721Transfer:
+			erc721Transfer, ok := decodedTx.(*types.ERC721Transfer)
+			if !ok {
+				s.log.Error("Failed to convert transaction to ERC721Transfer",
+					logger.String("tx_hash", tx.Hash))
+				return
+			}
+			s.updateNFTHoldings(ctx, walletID, tx, erc721Transfer.NFTTransfers())
+
+		case types.TransactionTypeERC1155Transfer:
+			erc1155Transfer, ok := decodedTx.(*types.ERC1155Transfer)
+			if !ok {
+				s.log.Error("Failed to convert transaction to ERC1155Transfer",
+					logger.String("tx_hash", tx.Hash))
+				return
+			}
+			s.updateNFTHoldings(ctx, walletID, tx, erc1155Transfer.NFTTransfers())
+
 		default:
 			// Update native balance
 			err := s.balanceService.UpdateWalletBalance(ctx, tx)
@@ -225,7 +279,15 @@ func (s *walletMonitorService) processTransactionEvent(ctx context.Context, even
 					logger.Int64("wallet_id", walletID),
 					logger.String("tx_hash", tx.Hash))
 			}
+
+			// Contract calls such as marketplace sales may move NFTs as well
+			s.updateNFTHoldings(ctx, walletID, tx, nil)
 		}
+
+		// Any contract call may change allowances, e.g. swaps spending them
+		s.updateAllowances(ctx, walletID, tx, approvals)
+
+		s.publishTransactionEvent(ctx, walletID, tx, decodedTx.GetType())
 	}
 
 	// Update LastBlockNumber if requested and the transaction has a block number
@@ -257,6 +319,141 @@ func (s *walletMonitorService) processTransactionEvent(ctx context.Context, even
 	}
 }
 
+// publishTransactionEvent notifies the webhooks and the live event stream of a new deposit
+// to or withdrawal from a wallet
+func (s *walletMonitorService) publishTransactionEvent(ctx context.Context, walletID int64, tx *types.Transaction, txType types.TransactionType) {
+	// Approvals move no funds, and spam flagged transfers must not be reported as deposits
+	if txType == types.TransactionTypeERC20Approval {
+		return
+	}
+	if _, flagged := tx.Metadata.GetRiskFlags(); flagged {
+		s.log.Debug("Skipping events of a spam flagged transaction",
+			logger.Int64("wallet_id", walletID),
+			logger.String("tx_hash", tx.Hash))
+		return
+	}
+
+	wallet, err := s.repository.GetByID(ctx, walletID)
+	if err != nil {
+		s.log.Error("Failed to get wallet for webhook event",
+			logger.Error(err),
+			logger.Int64("wallet_id", walletID),
+			logger.String("tx_hash", tx.Hash))
+		return
+	}
+
+	eventType, streamEventType := webhook.EventTypeDeposit, stream.EventTypeDeposit
+	if types.NormalizeAddress(tx.ChainType, tx.From) == types.NormalizeAddress(wallet.ChainType, wallet.Address) {
+		eventType, streamEventType = webhook.EventTypeWithdrawal, stream.EventTypeWithdrawal
+	}
+
+	data := webhook.TransactionData{
+		WalletID:  walletID,
+		Hash:      tx.Hash,
+		From:      tx.From,
+		To:        tx.To,
+		Value:     "0",
+		Type:      tx.Type,
+		Status:    tx.Status,
+		Timestamp: tx.Timestamp,
+		Metadata:  tx.Metadata,
+	}
+	if tx.Value != nil {
+		data.Value = tx.Value.String()
+	}
+	if tx.BlockNumber != nil {
+		data.BlockNumber = tx.BlockNumber.String()
+	}
+
+	event := &webhook.Event{
+		Type:      eventType,
+		ChainType: wallet.ChainType,
+		Address:   wallet.Address,
+		Data:      data,
+	}
+	if err := s.webhookService.Publish(ctx, event); err != nil {
+		s.log.Error("Failed to publish webhook event",
+			logger.Error(err),
+			logger.String("event_type", string(eventType)),
+			logger.Int64("wallet_id", walletID),
+			logger.String("tx_hash", tx.Hash))
+	}
+
+	scope := stream.Scope{ChainType: wallet.ChainType, WalletID: walletID}
+	if err := s.streamService.Publish(ctx, streamEventType, scope, data); err != nil {
+		s.log.Error("Failed to publish stream event",
+			logger.Error(err),
+			logger.String("event_type", string(streamEventType)),
+			logger.Int64("wallet_id", walletID),
+			logger.String("tx_hash", tx.Hash))
+	}
+}
+
+// updateNFTHoldings applies the NFT transfers of a transaction to the wallet holdings.
+// Transfers extracted from the receipt logs take precedence over the decoded call, as
+// they also cover tokens moved by other contracts and operator transfers.
+func (s *walletMonitorService) updateNFTHoldings(ctx context.Context, walletID int64, tx *types.Transaction, decoded []types.NFTTransfer) {
+	transfers, ok := tx.Metadata.GetNFTTransfers()
+	if !ok {
+		transfers = decoded
+	}
+	if len(transfers) == 0 {
+		return
+	}
+
+	tokenAddresses := make([]string, 0, len(transfers))
+	for _, transfer := range transfers {
+		tokenAddresses = append(tokenAddresses, transfer.TokenAddress)
+	}
+	s.registerTokens(ctx, tx, tokenAddresses...)
+
+	if err := s.balanceService.UpdateNFTHoldings(ctx, tx.ChainType, tx.Hash, transfers); err != nil {
+		s.log.Error("Failed to update NFT holdings",
+			logger.Error(err),
+			logger.Int64("wallet_id", walletID),
+			logger.String("tx_hash", tx.Hash))
+	}
+}
+
+// registerTokens registers the unknown tokens moved by a transaction as unverified,
+// discovering their details from the token contracts
+func (s *walletMonitorService) registerTokens(ctx context.Context, tx *types.Transaction, tokenAddresses ...string) {
+	seen := make(map[string]bool, len(tokenAddresses))
+	for _, tokenAddress := range tokenAddresses {
+		if tokenAddress == "" || seen[tokenAddress] {
+			continue
+		}
+		seen[tokenAddress] = true
+
+		if _, err := s.tokenService.RegisterToken(ctx, tx.ChainType, tokenAddress); err != nil {
+			s.log.Warn("Failed to register token seen in wallet transfer",
+				logger.Error(err),
+				logger.String("tx_hash", tx.Hash),
+				logger.String("token_address", tokenAddress))
+		}
+	}
+}
+
+// updateAllowances refreshes the allowances changed by a transaction. Approvals
+// extracted from the receipt logs take precedence over the decoded call, as they
+// also cover permits and allowances spent by other contracts.
+func (s *walletMonitorService) updateAllowances(ctx context.Context, walletID int64, tx *types.Transaction, decoded []types.TokenApproval) {
+	approvals, ok := tx.Metadata.GetTokenApprovals()
+	if !ok {
+		approvals = decoded
+	}
+	if len(approvals) == 0 {
+		return
+	}
+
+	if err := s.allowanceService.ProcessApprovals(ctx, tx.ChainType, approvals); err != nil {
+		s.log.Error("Failed to update token allowances",
+			logger.Error(err),
+			logger.Int64("wallet_id", walletID),
+			logger.String("tx_hash", tx.Hash))
+	}
+}
+
 // subscribeToTransactionEvents subscribes to real-time transaction events and updates wallet balances
 func (s *walletMonitorService) subscribeToTransactionEvents(ctx context.Context) {
 	s.log.Info("Starting subscription to transaction events")
@@ -314,6 +511,33 @@ func (s *walletMonitorService) subscribeToHistoryEvents(ctx context.Context) {
 	}
 }
 
+// synchRebasingBalances periodically reads the balances of rebasing tokens from the
+// token contracts, as they change without transfers to apply
+func (s *walletMonitorService) synchRebasingBalances(ctx context.Context) {
+	interval := 300 // Default to 5 minutes if not specified
+	if s.config != nil && s.config.Wallet.RebasingBalanceSynchInterval > 0 {
+		interval = s.config.Wallet.RebasingBalanceSynchInterval
+	}
+
+	s.log.Info("Starting rebasing token balance synchronization",
+		logger.Int("interval_seconds", interval))
+
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.Info("Stopping rebasing token balance synchronization due to context cancellation")
+			return
+		case <-ticker.C:
+			if err := s.balanceService.SyncRebasingBalances(ctx); err != nil {
+				s.log.Error("Failed to synchronize rebasing token balances", logger.Error(err))
+			}
+		}
+	}
+}
+
 // StopWalletMonitoring stops monitoring for all wallets
 func (s *walletMonitorService) StopWalletMonitoring(ctx context.Context) error {
 	s.log.Info("Stopping wallet monitoring...")
@@ -0,0 +1,102 @@
+package wallet
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/db"
+	"vault0/internal/logger"
+	"vault0/internal/services/stream"
+	"vault0/internal/services/webhook"
+	"vault0/internal/testing/dbtest"
+	"vault0/internal/types"
+)
+
+// testWebhookService records the webhook events published
+type testWebhookService struct {
+	webhook.Service
+	events []webhook.EventType
+}
+
+func (s *testWebhookService) Publish(ctx context.Context, event *webhook.Event) error {
+	s.events = append(s.events, event.Type)
+	return nil
+}
+
+func TestWalletMonitorService_PublishTransactionEvent(t *testing.T) {
+	const otherAddress = "0x8ba1f109551bD432803012645Ac136ddd64DBA72"
+
+	spam := types.TxMetadata{}
+	require.NoError(t, spam.SetJSON(types.RiskFlagsMetadataKey, []types.RiskFlag{types.RiskFlagZeroValueTransfer}))
+
+	tests := []struct {
+		name          string
+		from          string
+		txType        types.TransactionType
+		metadata      types.TxMetadata
+		webhookEvents []webhook.EventType
+		streamEvents  []stream.EventType
+	}{
+		{
+			name:          "deposit",
+			from:          otherAddress,
+			txType:        types.TransactionTypeNative,
+			webhookEvents: []webhook.EventType{webhook.EventTypeDeposit},
+			streamEvents:  []stream.EventType{stream.EventTypeDeposit},
+		},
+		{
+			name:          "withdrawal",
+			from:          testWalletAddress,
+			txType:        types.TransactionTypeERC20Transfer,
+			webhookEvents: []webhook.EventType{webhook.EventTypeWithdrawal},
+			streamEvents:  []stream.EventType{stream.EventTypeWithdrawal},
+		},
+		{
+			name:   "approval",
+			from:   testWalletAddress,
+			txType: types.TransactionTypeERC20Approval,
+		},
+		{
+			name:     "spam flagged transfer",
+			from:     otherAddress,
+			txType:   types.TransactionTypeERC20Transfer,
+			metadata: spam,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := dbtest.New(t, db.DialectSQLite)
+			repo := NewRepository(database)
+			wallet := createTestWallet(t, repo)
+
+			webhooks, streams := &testWebhookService{}, &testStreamService{}
+			s := &walletMonitorService{
+				log:            logger.NewNopLogger(),
+				repository:     repo,
+				webhookService: webhooks,
+				streamService:  streams,
+			}
+
+			tx := &types.Transaction{
+				BaseTransaction: types.BaseTransaction{
+					ChainType: types.ChainTypeEthereum,
+					Hash:      "0xabc",
+					From:      tt.from,
+					To:        testTokenAddress,
+					Value:     big.NewInt(0),
+					Type:      tt.txType,
+				},
+				Metadata: tt.metadata,
+			}
+			s.publishTransactionEvent(context.Background(), wallet.ID, tx, tt.txType)
+
+			assert.Equal(t, tt.webhookEvents, webhooks.events)
+			assert.Equal(t, tt.streamEvents, streams.events)
+		})
+	}
+}
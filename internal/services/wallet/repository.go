@@ -51,6 +51,9 @@ type Repository interface {
 	// GetTokenBalance retrieves a specific token balance for a wallet and token address
 	GetTokenBalance(ctx context.Context, walletID int64, tokenAddress string) (*TokenBalance, error)
 
+	// ListTokenBalancesByToken retrieves the balances of a token across all wallets
+	ListTokenBalancesByToken(ctx context.Context, tokenAddress string) ([]*TokenBalance, error)
+
 	// UpdateBlockNumber updates only the last_block_number for a given wallet ID
 	UpdateBlockNumber(ctx context.Context, walletID int64, blockNumber int64) error
 
@@ -59,6 +62,30 @@ type Repository interface {
 
 	// GetWalletsByKeyID retrieves all non-deleted wallets associated with a specific keystore key ID.
 	GetWalletsByKeyID(ctx context.Context, keyID string) ([]*Wallet, error)
+
+	// GetNFTHolding retrieves the amount of an NFT held by a wallet
+	GetNFTHolding(ctx context.Context, walletID int64, tokenAddress string, tokenID *big.Int) (*NFTHolding, error)
+
+	// UpdateNFTHolding updates or creates the amount of an NFT held by a wallet,
+	// removing the holding when the amount drops to zero
+	UpdateNFTHolding(ctx context.Context, wallet *Wallet, standard types.TokenType, tokenAddress string, tokenID *big.Int, amount *big.Int) error
+
+	// ListNFTHoldings retrieves the NFTs held by a wallet with token-based pagination
+	ListNFTHoldings(ctx context.Context, walletID int64, limit int, nextToken string) (*types.Page[*NFTHolding], error)
+
+	// MarkNFTTransferApplied records that one side of an NFT transfer of a transaction was
+	// applied to the holdings of a wallet. It returns false if it was already recorded.
+	MarkNFTTransferApplied(ctx context.Context, walletID int64, txHash string, transfer types.NFTTransfer, incoming bool) (bool, error)
+
+	// GetTokenAllowance retrieves the allowance a wallet granted to a spender for a token
+	GetTokenAllowance(ctx context.Context, walletID int64, tokenAddress, spender string) (*TokenAllowance, error)
+
+	// UpdateTokenAllowance updates or creates the allowance a wallet granted to a spender,
+	// removing the entry when the allowance drops to zero
+	UpdateTokenAllowance(ctx context.Context, wallet *Wallet, tokenAddress, spender string, amount *big.Int) error
+
+	// ListTokenAllowances retrieves the outstanding allowances of a wallet with token-based pagination
+	ListTokenAllowances(ctx context.Context, walletID int64, limit int, nextToken string) (*types.Page[*TokenAllowance], error)
 }
 
 // repository implements Repository interface for SQLite
@@ -66,17 +93,23 @@ type repository struct {
 	db                    *db.DB
 	walletStructMap       *sqlbuilder.Struct
 	tokenBalanceStructMap *sqlbuilder.Struct
+	nftHoldingStructMap   *sqlbuilder.Struct
+	allowanceStructMap    *sqlbuilder.Struct
 }
 
 // NewRepository creates a new SQLite repository for wallets
 func NewRepository(db *db.DB) Repository {
 	walletStructMap := sqlbuilder.NewStruct(new(Wallet))
 	tokenBalanceStructMap := sqlbuilder.NewStruct(new(TokenBalance))
+	nftHoldingStructMap := sqlbuilder.NewStruct(new(NFTHolding))
+	allowanceStructMap := sqlbuilder.NewStruct(new(TokenAllowance))
 
 	return &repository{
 		db:                    db,
 		walletStructMap:       walletStructMap,
 		tokenBalanceStructMap: tokenBalanceStructMap,
+		nftHoldingStructMap:   nftHoldingStructMap,
+		allowanceStructMap:    allowanceStructMap,
 	}
 }
 
@@ -128,6 +161,54 @@ func (r *repository) executeTokenBalanceQuery(ctx context.Context, sql string, a
 	return tokenBalances, nil
 }
 
+// executeNFTHoldingQuery executes a query and scans the results into NFTHolding objects
+func (r *repository) executeNFTHoldingQuery(ctx context.Context, sql string, args ...any) ([]*NFTHolding, error) {
+	rows, err := r.db.ExecuteQueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var holdings []*NFTHolding
+	for rows.Next() {
+		holding, err := ScanNFTHolding(rows)
+		if err != nil {
+			return nil, err
+		}
+		holdings = append(holdings, holding)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return holdings, nil
+}
+
+// executeTokenAllowanceQuery executes a query and scans the results into TokenAllowance objects
+func (r *repository) executeTokenAllowanceQuery(ctx context.Context, sql string, args ...any) ([]*TokenAllowance, error) {
+	rows, err := r.db.ExecuteQueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var allowances []*TokenAllowance
+	for rows.Next() {
+		allowance, err := ScanTokenAllowance(rows)
+		if err != nil {
+			return nil, err
+		}
+		allowances = append(allowances, allowance)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return allowances, nil
+}
+
 // Create inserts a new wallet into the database
 func (r *repository) Create(ctx context.Context, wallet *Wallet) error {
 	// Generate a new Snowflake ID if not provided
@@ -517,6 +598,16 @@ func (r *repository) GetTokenBalance(ctx context.Context, walletID int64, tokenA
 	return tokenBalances[0], nil
 }
 
+// ListTokenBalancesByToken retrieves the balances of a token across all wallets
+func (r *repository) ListTokenBalancesByToken(ctx context.Context, tokenAddress string) ([]*TokenBalance, error) {
+	sb := r.tokenBalanceStructMap.SelectFrom("wallet_balances")
+	sb.Where(sb.Equal("lower(token_address)", strings.ToLower(tokenAddress)))
+
+	sqlQuery, args := sb.Build()
+
+	return r.executeTokenBalanceQuery(ctx, sqlQuery, args...)
+}
+
 // UpdateBlockNumber updates only the last_block_number for a given wallet ID
 func (r *repository) UpdateBlockNumber(ctx context.Context, walletID int64, blockNumber int64) error {
 	query := `UPDATE wallets SET last_block_number = ? WHERE id = ?`
@@ -578,3 +669,301 @@ func (r *repository) GetWalletsByKeyID(ctx context.Context, keyID string) ([]*Wa
 
 	return wallets, nil
 }
+
+// GetNFTHolding retrieves the amount of an NFT held by a wallet.
+// If the wallet doesn't hold the token, it returns a holding with a zero amount and nil error.
+func (r *repository) GetNFTHolding(ctx context.Context, walletID int64, tokenAddress string, tokenID *big.Int) (*NFTHolding, error) {
+	sb := r.nftHoldingStructMap.SelectFrom("nft_holdings")
+	sb.Where(sb.Equal("wallet_id", walletID))
+	sb.Where(sb.Equal("lower(token_address)", strings.ToLower(tokenAddress)))
+	sb.Where(sb.Equal("token_id", tokenID.String()))
+
+	sqlQuery, args := sb.Build()
+
+	holdings, err := r.executeNFTHoldingQuery(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	if len(holdings) == 0 {
+		return &NFTHolding{
+			WalletID:     walletID,
+			TokenAddress: tokenAddress,
+			TokenID:      types.NewBigInt(tokenID),
+			Amount:       types.ZeroBigInt(),
+		}, nil
+	}
+
+	return holdings[0], nil
+}
+
+// UpdateNFTHolding updates or creates the amount of an NFT held by a wallet,
+// removing the holding when the amount drops to zero
+func (r *repository) UpdateNFTHolding(ctx context.Context, wallet *Wallet, standard types.TokenType, tokenAddress string, tokenID *big.Int, amount *big.Int) error {
+	normalizedAddr, err := types.NewAddress(wallet.ChainType, tokenAddress)
+	if err != nil {
+		return err
+	}
+	normalizedTokenAddress := normalizedAddr.ToChecksum()
+
+	existing, err := r.GetNFTHolding(ctx, wallet.ID, normalizedTokenAddress, tokenID)
+	if err != nil {
+		return err
+	}
+
+	// The token is no longer held
+	if amount == nil || amount.Sign() <= 0 {
+		if existing.ID == 0 {
+			return nil
+		}
+
+		deleteBuilder := sqlbuilder.NewDeleteBuilder()
+		deleteBuilder.DeleteFrom("nft_holdings")
+		deleteBuilder.Where(deleteBuilder.Equal("id", existing.ID))
+		deleteSQL, deleteArgs := deleteBuilder.Build()
+
+		if _, err := r.db.ExecuteStatementContext(ctx, deleteSQL, deleteArgs...); err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		return nil
+	}
+
+	holding := existing
+	holding.TokenAddress = normalizedTokenAddress
+	holding.Standard = standard
+	holding.Amount = types.NewBigInt(amount)
+	holding.UpdatedAt = time.Now()
+
+	if holding.ID != 0 {
+		ub := r.nftHoldingStructMap.Update("nft_holdings", holding)
+		ub.Where(ub.Equal("id", holding.ID))
+		updateSQL, updateArgs := ub.Build()
+
+		if _, err := r.db.ExecuteStatementContext(ctx, updateSQL, updateArgs...); err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		return nil
+	}
+
+	holding.ID, err = r.db.GenerateID()
+	if err != nil {
+		return err
+	}
+
+	ib := r.nftHoldingStructMap.InsertInto("nft_holdings", holding)
+	insertSQL, insertArgs := ib.Build()
+
+	if _, err := r.db.ExecuteStatementContext(ctx, insertSQL, insertArgs...); err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+// MarkNFTTransferApplied records that one side of an NFT transfer of a transaction was
+// applied to the holdings of a wallet. It returns false if it was already recorded.
+func (r *repository) MarkNFTTransferApplied(ctx context.Context, walletID int64, txHash string, transfer types.NFTTransfer, incoming bool) (bool, error) {
+	result, err := r.db.ExecuteStatementContext(
+		ctx,
+		`INSERT INTO nft_holding_transfers (wallet_id, tx_hash, log_index, token_address, token_id, incoming, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT DO NOTHING`,
+		walletID,
+		strings.ToLower(txHash),
+		int64(transfer.LogIndex),
+		strings.ToLower(transfer.TokenAddress),
+		transfer.TokenID.String(),
+		incoming,
+		time.Now(),
+	)
+	if err != nil {
+		return false, errors.NewDatabaseError(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.NewDatabaseError(err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// ListNFTHoldings retrieves the NFTs held by a wallet with token-based pagination
+func (r *repository) ListNFTHoldings(ctx context.Context, walletID int64, limit int, nextToken string) (*types.Page[*NFTHolding], error) {
+	sb := r.nftHoldingStructMap.SelectFrom("nft_holdings")
+	sb.Where(sb.Equal("wallet_id", walletID))
+
+	paginationColumn := "id"
+
+	token, err := types.DecodeNextPageToken(nextToken, paginationColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	if token != nil {
+		idVal, ok := token.GetValueInt64()
+		if !ok {
+			return nil, errors.NewInvalidPaginationTokenError(nextToken,
+				fmt.Errorf("expected integer ID in token, got %T", token.Value))
+		}
+		sb.Where(sb.GreaterThan(paginationColumn, idVal))
+	}
+
+	sb.OrderBy(paginationColumn + " ASC")
+
+	// Fetch one extra to determine if more exist
+	if limit > 0 {
+		sb.Limit(limit + 1)
+	}
+
+	sqlQuery, args := sb.Build()
+
+	holdings, err := r.executeNFTHoldingQuery(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	generateToken := func(holding *NFTHolding) *types.NextPageToken {
+		return &types.NextPageToken{
+			Column: paginationColumn,
+			Value:  strconv.FormatInt(holding.ID, 10), // Snowflake IDs exceed float64 precision
+		}
+	}
+
+	return types.NewPage(holdings, limit, generateToken), nil
+}
+
+// GetTokenAllowance retrieves the allowance a wallet granted to a spender for a token.
+// If no allowance is recorded, it returns an allowance with a zero amount and nil error.
+func (r *repository) GetTokenAllowance(ctx context.Context, walletID int64, tokenAddress, spender string) (*TokenAllowance, error) {
+	sb := r.allowanceStructMap.SelectFrom("token_allowances")
+	sb.Where(sb.Equal("wallet_id", walletID))
+	sb.Where(sb.Equal("lower(token_address)", strings.ToLower(tokenAddress)))
+	sb.Where(sb.Equal("lower(spender)", strings.ToLower(spender)))
+
+	sqlQuery, args := sb.Build()
+
+	allowances, err := r.executeTokenAllowanceQuery(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	if len(allowances) == 0 {
+		return &TokenAllowance{
+			WalletID:     walletID,
+			TokenAddress: tokenAddress,
+			Spender:      spender,
+			Amount:       types.ZeroBigInt(),
+		}, nil
+	}
+
+	return allowances[0], nil
+}
+
+// UpdateTokenAllowance updates or creates the allowance a wallet granted to a spender,
+// removing the entry when the allowance drops to zero
+func (r *repository) UpdateTokenAllowance(ctx context.Context, wallet *Wallet, tokenAddress, spender string, amount *big.Int) error {
+	normalizedToken, err := types.NewAddress(wallet.ChainType, tokenAddress)
+	if err != nil {
+		return err
+	}
+	normalizedSpender, err := types.NewAddress(wallet.ChainType, spender)
+	if err != nil {
+		return err
+	}
+
+	existing, err := r.GetTokenAllowance(ctx, wallet.ID, normalizedToken.ToChecksum(), normalizedSpender.ToChecksum())
+	if err != nil {
+		return err
+	}
+
+	// The allowance was revoked or fully spent
+	if amount == nil || amount.Sign() <= 0 {
+		if existing.ID == 0 {
+			return nil
+		}
+
+		deleteBuilder := sqlbuilder.NewDeleteBuilder()
+		deleteBuilder.DeleteFrom("token_allowances")
+		deleteBuilder.Where(deleteBuilder.Equal("id", existing.ID))
+		deleteSQL, deleteArgs := deleteBuilder.Build()
+
+		if _, err := r.db.ExecuteStatementContext(ctx, deleteSQL, deleteArgs...); err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		return nil
+	}
+
+	allowance := existing
+	allowance.TokenAddress = normalizedToken.ToChecksum()
+	allowance.Spender = normalizedSpender.ToChecksum()
+	allowance.Amount = types.NewBigInt(amount)
+	allowance.UpdatedAt = time.Now()
+
+	if allowance.ID != 0 {
+		ub := r.allowanceStructMap.Update("token_allowances", allowance)
+		ub.Where(ub.Equal("id", allowance.ID))
+		updateSQL, updateArgs := ub.Build()
+
+		if _, err := r.db.ExecuteStatementContext(ctx, updateSQL, updateArgs...); err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		return nil
+	}
+
+	allowance.ID, err = r.db.GenerateID()
+	if err != nil {
+		return err
+	}
+
+	ib := r.allowanceStructMap.InsertInto("token_allowances", allowance)
+	insertSQL, insertArgs := ib.Build()
+
+	if _, err := r.db.ExecuteStatementContext(ctx, insertSQL, insertArgs...); err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	return nil
+}
+
+// ListTokenAllowances retrieves the outstanding allowances of a wallet with token-based pagination
+func (r *repository) ListTokenAllowances(ctx context.Context, walletID int64, limit int, nextToken string) (*types.Page[*TokenAllowance], error) {
+	sb := r.allowanceStructMap.SelectFrom("token_allowances")
+	sb.Where(sb.Equal("wallet_id", walletID))
+
+	paginationColumn := "id"
+
+	token, err := types.DecodeNextPageToken(nextToken, paginationColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	if token != nil {
+		idVal, ok := token.GetValueInt64()
+		if !ok {
+			return nil, errors.NewInvalidPaginationTokenError(nextToken,
+				fmt.Errorf("expected integer ID in token, got %T", token.Value))
+		}
+		sb.Where(sb.GreaterThan(paginationColumn, idVal))
+	}
+
+	sb.OrderBy(paginationColumn + " ASC")
+
+	// Fetch one extra to determine if more exist
+	if limit > 0 {
+		sb.Limit(limit + 1)
+	}
+
+	sqlQuery, args := sb.Build()
+
+	allowances, err := r.executeTokenAllowanceQuery(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	generateToken := func(allowance *TokenAllowance) *types.NextPageToken {
+		return &types.NextPageToken{
+			Column: paginationColumn,
+			Value:  strconv.FormatInt(allowance.ID, 10), // Snowflake IDs exceed float64 precision
+		}
+	}
+
+	return types.NewPage(allowances, limit, generateToken), nil
+}
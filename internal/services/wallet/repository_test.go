@@ -0,0 +1,242 @@
+package wallet
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/db"
+	"vault0/internal/testing/dbtest"
+	"vault0/internal/types"
+)
+
+const (
+	testWalletAddress = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+	testTokenAddress  = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+)
+
+func createTestWallet(t *testing.T, repo Repository) *Wallet {
+	t.Helper()
+
+	wallet := &Wallet{
+		KeyID:     "1",
+		ChainType: types.ChainTypeEthereum,
+		Address:   testWalletAddress,
+		Name:      "Treasury",
+		Tags:      types.JSONMap{"team": "ops"},
+	}
+	require.NoError(t, repo.Create(context.Background(), wallet))
+	return wallet
+}
+
+func TestRepository_CreateAndGetWallet(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database)
+		ctx := context.Background()
+		wallet := createTestWallet(t, repo)
+
+		got, err := repo.GetByAddress(ctx, types.ChainTypeEthereum, testWalletAddress)
+		require.NoError(t, err)
+		assert.Equal(t, wallet.ID, got.ID)
+		assert.Equal(t, "Treasury", got.Name)
+		assert.Equal(t, "ops", got.Tags["team"])
+		assert.True(t, got.Balance.IsZero())
+		assert.False(t, got.DeletedAt.Valid)
+
+		exists, err := repo.Exists(ctx, types.ChainTypeEthereum, testWalletAddress)
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		byKey, err := repo.GetWalletsByKeyID(ctx, "1")
+		require.NoError(t, err)
+		assert.Len(t, byKey, 1)
+
+		require.NoError(t, repo.Delete(ctx, types.ChainTypeEthereum, testWalletAddress))
+		exists, err = repo.Exists(ctx, types.ChainTypeEthereum, testWalletAddress)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestRepository_Balances(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database)
+		ctx := context.Background()
+		wallet := createTestWallet(t, repo)
+
+		native, _ := new(big.Int).SetString("1500000000000000000", 10)
+		require.NoError(t, repo.UpdateBalance(ctx, wallet, native))
+
+		got, err := repo.GetByID(ctx, wallet.ID)
+		require.NoError(t, err)
+		assert.Equal(t, native.String(), got.Balance.String())
+
+		require.NoError(t, repo.UpdateTokenBalance(ctx, wallet, testTokenAddress, big.NewInt(250)))
+		require.NoError(t, repo.UpdateTokenBalance(ctx, wallet, testTokenAddress, big.NewInt(300)))
+
+		balance, err := repo.GetTokenBalance(ctx, wallet.ID, testTokenAddress)
+		require.NoError(t, err)
+		assert.Equal(t, "300", balance.Balance.String())
+
+		balances, err := repo.GetTokenBalances(ctx, wallet.ID)
+		require.NoError(t, err)
+		assert.Len(t, balances, 1)
+	})
+}
+
+func TestRepository_NFTHoldings(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database)
+		ctx := context.Background()
+		wallet := createTestWallet(t, repo)
+
+		tokenID, _ := new(big.Int).SetString("115792089237316195423570985008687907853269984665640564039457584007913129639935", 10)
+		require.NoError(t, repo.UpdateNFTHolding(ctx, wallet, types.TokenTypeERC721, testTokenAddress, tokenID, big.NewInt(1)))
+
+		page, err := repo.ListNFTHoldings(ctx, wallet.ID, 10, "")
+		require.NoError(t, err)
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, tokenID.String(), page.Items[0].TokenID.String())
+
+		// A zero amount removes the holding
+		require.NoError(t, repo.UpdateNFTHolding(ctx, wallet, types.TokenTypeERC721, testTokenAddress, tokenID, big.NewInt(0)))
+		page, err = repo.ListNFTHoldings(ctx, wallet.ID, 10, "")
+		require.NoError(t, err)
+		assert.Empty(t, page.Items)
+	})
+}
+
+func TestRepository_NFTHoldingsPaginationBeyondFloat64(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database)
+		ctx := context.Background()
+		wallet := createTestWallet(t, repo)
+
+		// Consecutive IDs above 2^53 can't be told apart as float64
+		const firstID = int64(1)<<60 + 1
+		for i := int64(0); i < 3; i++ {
+			require.NoError(t, repo.UpdateNFTHolding(ctx, wallet, types.TokenTypeERC721, testTokenAddress, big.NewInt(i), big.NewInt(1)))
+			_, err := database.ExecuteStatementContext(ctx, "UPDATE nft_holdings SET id = ? WHERE token_id = ?", firstID+i, big.NewInt(i).String())
+			require.NoError(t, err)
+		}
+
+		// A token losing precision may page forever, so the pages read are bounded
+		var ids []int64
+		nextToken := ""
+		for pages := 0; pages < 5; pages++ {
+			page, err := repo.ListNFTHoldings(ctx, wallet.ID, 1, nextToken)
+			require.NoError(t, err)
+			for _, holding := range page.Items {
+				ids = append(ids, holding.ID)
+			}
+			if page.NextToken == "" {
+				break
+			}
+			nextToken = page.NextToken
+		}
+		assert.Equal(t, []int64{firstID, firstID + 1, firstID + 2}, ids)
+	})
+}
+
+func TestRepository_NFTTransferApplied(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database)
+		ctx := context.Background()
+		wallet := createTestWallet(t, repo)
+
+		transfer := types.NFTTransfer{TokenAddress: testTokenAddress, TokenID: big.NewInt(7), Amount: big.NewInt(1), LogIndex: 4}
+		applied, err := repo.MarkNFTTransferApplied(ctx, wallet.ID, testTxHash, transfer, true)
+		require.NoError(t, err)
+		assert.True(t, applied)
+
+		applied, err = repo.MarkNFTTransferApplied(ctx, wallet.ID, testTxHash, transfer, true)
+		require.NoError(t, err)
+		assert.False(t, applied, "a transfer is applied once per direction")
+
+		// The outgoing side of a self-transfer is recorded separately
+		applied, err = repo.MarkNFTTransferApplied(ctx, wallet.ID, testTxHash, transfer, false)
+		require.NoError(t, err)
+		assert.True(t, applied)
+	})
+}
+
+func TestRepository_TokenAllowances(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database)
+		ctx := context.Background()
+		wallet := createTestWallet(t, repo)
+
+		spenders := []string{
+			"0x1111111111111111111111111111111111111111",
+			"0x2222222222222222222222222222222222222222",
+		}
+		unlimited := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+		require.NoError(t, repo.UpdateTokenAllowance(ctx, wallet, testTokenAddress, spenders[0], unlimited))
+		require.NoError(t, repo.UpdateTokenAllowance(ctx, wallet, testTokenAddress, spenders[1], big.NewInt(100)))
+		require.NoError(t, repo.UpdateTokenAllowance(ctx, wallet, testTokenAddress, spenders[1], big.NewInt(50)))
+
+		allowance, err := repo.GetTokenAllowance(ctx, wallet.ID, testTokenAddress, spenders[0])
+		require.NoError(t, err)
+		assert.Equal(t, unlimited.String(), allowance.Amount.String())
+
+		page, err := repo.ListTokenAllowances(ctx, wallet.ID, 1, "")
+		require.NoError(t, err)
+		require.Len(t, page.Items, 1)
+		require.NotEmpty(t, page.NextToken)
+
+		next, err := repo.ListTokenAllowances(ctx, wallet.ID, 1, page.NextToken)
+		require.NoError(t, err)
+		require.Len(t, next.Items, 1)
+		assert.NotEqual(t, page.Items[0].Spender, next.Items[0].Spender)
+
+		// A zero amount removes the allowance
+		require.NoError(t, repo.UpdateTokenAllowance(ctx, wallet, testTokenAddress, spenders[1], big.NewInt(0)))
+		allowance, err = repo.GetTokenAllowance(ctx, wallet.ID, testTokenAddress, spenders[1])
+		require.NoError(t, err)
+		assert.Equal(t, 0, allowance.Amount.ToBigInt().Sign())
+
+		page, err = repo.ListTokenAllowances(ctx, wallet.ID, 10, "")
+		require.NoError(t, err)
+		assert.Len(t, page.Items, 1)
+	})
+}
+
+func TestRepository_TokenAllowancesPaginationBeyondFloat64(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database)
+		ctx := context.Background()
+		wallet := createTestWallet(t, repo)
+
+		// Consecutive IDs above 2^53 can't be told apart as float64
+		const firstID = int64(1)<<60 + 1
+		spenders := []string{
+			"0x1111111111111111111111111111111111111111",
+			"0x2222222222222222222222222222222222222222",
+			"0x3333333333333333333333333333333333333333",
+		}
+		for i, spender := range spenders {
+			require.NoError(t, repo.UpdateTokenAllowance(ctx, wallet, testTokenAddress, spender, big.NewInt(100)))
+			_, err := database.ExecuteStatementContext(ctx, "UPDATE token_allowances SET id = ? WHERE spender = ?", firstID+int64(i), spender)
+			require.NoError(t, err)
+		}
+
+		// A token losing precision may page forever, so the pages read are bounded
+		var ids []int64
+		nextToken := ""
+		for pages := 0; pages < 5; pages++ {
+			page, err := repo.ListTokenAllowances(ctx, wallet.ID, 1, nextToken)
+			require.NoError(t, err)
+			for _, allowance := range page.Items {
+				ids = append(ids, allowance.ID)
+			}
+			if page.NextToken == "" {
+				break
+			}
+			nextToken = page.NextToken
+		}
+		assert.Equal(t, []int64{firstID, firstID + 1, firstID + 2}, ids)
+	})
+}
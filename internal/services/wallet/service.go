@@ -4,9 +4,12 @@ import (
 	"context"
 	"math/big"
 
+	"vault0/internal/core/blockchain"
+	"vault0/internal/core/crypto"
 	"vault0/internal/core/keystore"
 	"vault0/internal/core/tokenstore"
 	coreWallet "vault0/internal/core/wallet"
+	"vault0/internal/db"
 	"vault0/internal/errors"
 	"vault0/internal/logger"
 	"vault0/internal/types"
@@ -140,6 +143,66 @@ type Service interface {
 	// TransformTransaction implements transaction.TransactionTransformer interface.
 	// It adds wallet information to transaction metadata if the transaction is associated with a wallet.
 	TransformTransaction(ctx context.Context, tx *types.Transaction) error
+
+	// SignMessage signs a message with a managed wallet following EIP-191 (personal_sign).
+	//
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - chainType: The blockchain network type
+	//   - address: The wallet's address
+	//   - message: The raw message bytes to sign
+	//
+	// Returns:
+	//   - []byte: The 65-byte r||s||v signature
+	//   - error: ErrWalletNotFound if the wallet doesn't exist, or any signing error
+	SignMessage(ctx context.Context, chainType types.ChainType, address string, message []byte) ([]byte, error)
+
+	// SignTypedData signs an EIP-712 typed data JSON document with a managed wallet.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - chainType: The blockchain network type
+	//   - address: The wallet's address
+	//   - typedData: The typed data JSON document (types, primaryType, domain, message)
+	//
+	// Returns:
+	//   - []byte: The 65-byte r||s||v signature
+	//   - error: ErrInvalidTypedData if the document is malformed, or any other error
+	SignTypedData(ctx context.Context, chainType types.ChainType, address string, typedData []byte) ([]byte, error)
+
+	// RecoverSigner recovers the address that produced a signature over a payload.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - chainType: The blockchain network type
+	//   - format: How the payload was hashed before signing
+	//   - payload: The message bytes or typed data JSON document
+	//   - signature: The 65-byte r||s||v signature
+	//
+	// Returns:
+	//   - string: The recovered signer address
+	//   - error: ErrInvalidSignature or ErrSignatureRecovery if recovery fails
+	RecoverSigner(ctx context.Context, chainType types.ChainType, format SignatureFormat, payload, signature []byte) (string, error)
+
+	// SendNFT transfers an ERC721 token or an ERC1155 balance from a managed wallet.
+	// The token standard is taken from the token store when the collection is
+	// registered, otherwise the provided standard is used.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation
+	//   - chainType: The blockchain network type
+	//   - address: The sending wallet's address
+	//   - standard: The token standard (erc721 or erc1155), optional for registered tokens
+	//   - tokenAddress: The NFT contract address
+	//   - toAddress: The recipient's address
+	//   - tokenID: The identifier of the token within the contract
+	//   - amount: Number of tokens to send; must be 1 for ERC721
+	//
+	// Returns:
+	//   - string: The hash of the broadcast transaction
+	//   - error: ErrWalletNotFound if the wallet doesn't exist, ErrInvalidInput for invalid parameters,
+	//     or any error while creating, signing or broadcasting the transaction
+	SendNFT(ctx context.Context, chainType types.ChainType, address string, standard types.TokenType, tokenAddress, toAddress string, tokenID, amount *big.Int) (string, error)
 }
 
 // walletService implements the Service interface
@@ -149,7 +212,9 @@ type walletService struct {
 	keystore      keystore.KeyStore
 	tokenStore    tokenstore.TokenStore
 	walletFactory coreWallet.Factory
+	blockchains   blockchain.Factory
 	chains        *types.Chains
+	transactor    db.Transactor
 }
 
 // NewService creates a new wallet service
@@ -159,7 +224,9 @@ func NewService(
 	keyStore keystore.KeyStore,
 	tokenStore tokenstore.TokenStore,
 	walletFactory coreWallet.Factory,
+	blockchains blockchain.Factory,
 	chains *types.Chains,
+	transactor db.Transactor,
 ) Service {
 	return &walletService{
 		log:           log,
@@ -167,7 +234,9 @@ func NewService(
 		keystore:      keyStore,
 		tokenStore:    tokenStore,
 		walletFactory: walletFactory,
+		blockchains:   blockchains,
 		chains:        chains,
+		transactor:    transactor,
 	}
 }
 
@@ -182,30 +251,35 @@ func (s *walletService) CreateWallet(ctx context.Context, chainType types.ChainT
 		return nil, err
 	}
 
-	key, err := s.keystore.Create(ctx, name, chain.KeyType, chain.Curve, tags)
-	if err != nil {
-		return nil, err
-	}
+	// Create the key and the wallet together so that no orphan key is left behind
+	var wallet *Wallet
+	err = s.transactor.WithTransaction(ctx, func(ctx context.Context) error {
+		key, err := s.keystore.Create(ctx, name, chain.KeyType, chain.Curve, tags)
+		if err != nil {
+			return err
+		}
 
-	w, err := s.walletFactory.NewManager(ctx, chainType, key.ID)
-	if err != nil {
-		return nil, err
-	}
+		w, err := s.walletFactory.NewManager(ctx, chainType, key.ID)
+		if err != nil {
+			return err
+		}
 
-	address, err := w.DeriveAddress(ctx)
-	if err != nil {
-		return nil, err
-	}
+		address, err := w.DeriveAddress(ctx)
+		if err != nil {
+			return err
+		}
 
-	wallet := &Wallet{
-		KeyID:     key.ID,
-		ChainType: chain.Type,
-		Address:   address,
-		Name:      name,
-		Tags:      tags,
-	}
+		wallet = &Wallet{
+			KeyID:     key.ID,
+			ChainType: chain.Type,
+			Address:   address,
+			Name:      name,
+			Tags:      tags,
+		}
 
-	if err := s.repository.Create(ctx, wallet); err != nil {
+		return s.repository.Create(ctx, wallet)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -485,5 +559,194 @@ func (s *walletService) TransformTransaction(ctx context.Context, tx *types.Tran
 		}
 	}
 
+	// NFTs may be moved to or from our wallets by other contracts, e.g. marketplace sales
+	if _, exists := tx.Metadata.GetString(types.WalletIDMetadaKey); !exists {
+		s.setWalletIDFromNFTTransfers(ctx, tx)
+	}
+
 	return nil
 }
+
+// setWalletIDFromNFTTransfers associates a transaction with the first wallet
+// found among the senders and recipients of its NFT transfers
+func (s *walletService) setWalletIDFromNFTTransfers(ctx context.Context, tx *types.Transaction) {
+	transfers, ok := tx.Metadata.GetNFTTransfers()
+	if !ok {
+		return
+	}
+
+	for _, transfer := range transfers {
+		for _, address := range []string{transfer.To, transfer.From} {
+			if address == "" || types.IsZeroAddress(address) {
+				continue
+			}
+			wallet, err := s.repository.GetByAddress(ctx, tx.ChainType, address)
+			if wallet == nil || err != nil {
+				continue
+			}
+			if err := tx.Metadata.Set(types.WalletIDMetadaKey, wallet.ID); err != nil {
+				s.log.Warn("Failed to set wallet ID in metadata for NFT transfer address",
+					logger.Error(err),
+					logger.String("tx_hash", tx.Hash),
+					logger.Int64("wallet_id", wallet.ID))
+			}
+			return
+		}
+	}
+}
+
+// walletManager returns the core wallet manager for a stored wallet
+func (s *walletService) walletManager(ctx context.Context, chainType types.ChainType, address string) (coreWallet.WalletManager, error) {
+	wallet, err := s.GetWalletByAddress(ctx, chainType, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.walletFactory.NewManager(ctx, chainType, wallet.KeyID)
+}
+
+// SignMessage signs a message with a managed wallet following EIP-191
+func (s *walletService) SignMessage(ctx context.Context, chainType types.ChainType, address string, message []byte) ([]byte, error) {
+	if len(message) == 0 {
+		return nil, errors.NewInvalidInputError("Message is required", "message", "")
+	}
+
+	w, err := s.walletManager(ctx, chainType, address)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := w.SignMessage(ctx, message)
+	if err != nil {
+		s.log.Error("Failed to sign message",
+			logger.Error(err),
+			logger.String("chain_type", string(chainType)),
+			logger.String("address", address))
+		return nil, err
+	}
+
+	return signature, nil
+}
+
+// SignTypedData signs an EIP-712 typed data document with a managed wallet
+func (s *walletService) SignTypedData(ctx context.Context, chainType types.ChainType, address string, typedData []byte) ([]byte, error) {
+	if len(typedData) == 0 {
+		return nil, errors.NewInvalidInputError("Typed data is required", "typed_data", "")
+	}
+
+	w, err := s.walletManager(ctx, chainType, address)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := w.SignTypedData(ctx, typedData)
+	if err != nil {
+		s.log.Error("Failed to sign typed data",
+			logger.Error(err),
+			logger.String("chain_type", string(chainType)),
+			logger.String("address", address))
+		return nil, err
+	}
+
+	return signature, nil
+}
+
+// RecoverSigner recovers the address that produced a signature over a payload
+func (s *walletService) RecoverSigner(ctx context.Context, chainType types.ChainType, format SignatureFormat, payload, signature []byte) (string, error) {
+	if _, err := s.chains.Get(chainType); err != nil {
+		return "", err
+	}
+
+	switch format {
+	case SignatureFormatPersonal, SignatureFormatTypedData:
+	default:
+		return "", errors.NewInvalidInputError("Unsupported signature format", "format", format)
+	}
+
+	digest, err := crypto.HashEthMessage(crypto.EthMessageFormat(format), payload)
+	if err != nil {
+		return "", err
+	}
+
+	return crypto.RecoverEthAddress(digest, signature)
+}
+
+// SendNFT creates, signs and broadcasts an NFT transfer from a managed wallet
+func (s *walletService) SendNFT(ctx context.Context, chainType types.ChainType, address string, standard types.TokenType, tokenAddress, toAddress string, tokenID, amount *big.Int) (string, error) {
+	if tokenAddress == "" {
+		return "", errors.NewInvalidInputError("Token address is required", "token_address", "")
+	}
+	if toAddress == "" {
+		return "", errors.NewInvalidInputError("Recipient address is required", "to", "")
+	}
+	if tokenID == nil {
+		return "", errors.NewInvalidInputError("Token ID is required", "token_id", "")
+	}
+	if amount == nil {
+		amount = big.NewInt(1)
+	}
+
+	// A registered token's standard takes precedence over the caller's hint
+	token, err := s.tokenStore.GetToken(ctx, tokenAddress)
+	if err != nil && !errors.IsError(err, errors.ErrCodeResourceNotFound) {
+		return "", err
+	}
+	if token != nil {
+		standard = token.Type
+	}
+	if standard != types.TokenTypeERC721 && standard != types.TokenTypeERC1155 {
+		return "", errors.NewInvalidInputError("Token is not an ERC721 or ERC1155 contract", "standard", standard)
+	}
+
+	w, err := s.walletManager(ctx, chainType, address)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := s.blockchains.NewClient(chainType)
+	if err != nil {
+		return "", err
+	}
+
+	nonce, err := client.GetNonce(ctx, address)
+	if err != nil {
+		return "", err
+	}
+
+	gasPrice, err := client.GetGasPrice(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := w.CreateNFTTransaction(ctx, standard, tokenAddress, toAddress, tokenID, amount, types.TransactionOptions{
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signedTx, err := w.SignTransaction(ctx, tx)
+	if err != nil {
+		return "", errors.NewTransactionSigningError(err)
+	}
+
+	txHash, err := client.BroadcastTransaction(ctx, signedTx)
+	if err != nil {
+		s.log.Error("Failed to broadcast NFT transfer",
+			logger.Error(err),
+			logger.String("chain_type", string(chainType)),
+			logger.String("address", address),
+			logger.String("token_address", tokenAddress),
+			logger.String("token_id", tokenID.String()))
+		return "", errors.NewTransactionBroadcastError(err)
+	}
+
+	s.log.Info("NFT transfer submitted",
+		logger.String("tx_hash", txHash),
+		logger.String("chain_type", string(chainType)),
+		logger.String("token_address", tokenAddress),
+		logger.String("token_id", tokenID.String()))
+
+	return txHash, nil
+}
@@ -0,0 +1,304 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"vault0/internal/config"
+	"vault0/internal/core/crypto"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+)
+
+const (
+	// Headers of the delivery requests
+	HeaderEvent     = "X-Vault0-Event"
+	HeaderDelivery  = "X-Vault0-Delivery"
+	HeaderTimestamp = "X-Vault0-Timestamp"
+	HeaderSignature = "X-Vault0-Signature"
+
+	// deliveryBatchSize is the maximum number of due deliveries attempted per poll
+	deliveryBatchSize = 100
+	// deliveryConcurrency is the maximum number of concurrent delivery requests
+	deliveryConcurrency = 10
+	// maxErrorLength truncates the errors and response bodies recorded in the delivery log
+	maxErrorLength = 512
+)
+
+// DeliveryService defines the interface for sending the recorded deliveries to the webhooks
+type DeliveryService interface {
+	// DeliverDue attempts the pending deliveries whose next attempt is due. Failed
+	// deliveries are retried with exponential backoff and dead-lettered after the
+	// configured number of attempts.
+	//
+	// Returns:
+	//   - The number of deliveries attempted
+	//   - An error if the due deliveries cannot be retrieved
+	DeliverDue(ctx context.Context) (int, error)
+
+	// StartDelivery starts a background scheduler that periodically attempts the due
+	// deliveries at an interval specified in the configuration.
+	//
+	// Parameters:
+	//   - ctx: Context for the operation, used to cancel the job
+	StartDelivery(ctx context.Context)
+
+	// StopDelivery stops the delivery scheduler
+	StopDelivery()
+}
+
+// deliveryService implements the DeliveryService interface
+type deliveryService struct {
+	repository Repository
+	encryptor  crypto.Encryptor
+	client     *http.Client
+	log        logger.Logger
+	config     *config.Config
+
+	jobCtx    context.Context
+	jobCancel context.CancelFunc
+}
+
+// NewDeliveryService creates a new webhook delivery service instance
+func NewDeliveryService(repository Repository, cfg *config.Config, log logger.Logger) (DeliveryService, error) {
+	encryptor, err := newEncryptor(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := 10
+	if cfg.Webhooks.Timeout > 0 {
+		timeout = cfg.Webhooks.Timeout
+	}
+
+	return &deliveryService{
+		repository: repository,
+		encryptor:  encryptor,
+		client:     &http.Client{Timeout: time.Duration(timeout) * time.Second},
+		log:        log.With(logger.String("service", "webhook_delivery")),
+		config:     cfg,
+	}, nil
+}
+
+// StartDelivery starts a background scheduler that periodically attempts the due deliveries
+func (s *deliveryService) StartDelivery(ctx context.Context) {
+	interval := 5
+	if s.config.Webhooks.PollInterval > 0 {
+		interval = s.config.Webhooks.PollInterval
+	}
+
+	s.jobCtx, s.jobCancel = context.WithCancel(ctx)
+
+	s.log.Info("Starting webhook delivery scheduler",
+		logger.Int("interval_seconds", interval))
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(interval) * time.Second)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.jobCtx.Done():
+				s.log.Info("Webhook delivery scheduler stopped")
+				return
+			case <-ticker.C:
+				if _, err := s.DeliverDue(s.jobCtx); err != nil {
+					s.log.Error("Scheduled webhook delivery failed", logger.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// StopDelivery stops the delivery scheduler
+func (s *deliveryService) StopDelivery() {
+	if s.jobCancel != nil {
+		s.jobCancel()
+		s.jobCancel = nil
+		s.log.Info("Webhook delivery scheduler stopped")
+	}
+}
+
+// DeliverDue implements the DeliveryService interface
+func (s *deliveryService) DeliverDue(ctx context.Context) (int, error) {
+	deliveries, err := s.repository.ListDueDeliveries(ctx, time.Now(), deliveryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+
+	// Webhooks are looked up once per batch, as most deliveries share a few webhooks
+	webhooks := make(map[int64]*Webhook)
+	for _, delivery := range deliveries {
+		if _, ok := webhooks[delivery.WebhookID]; ok {
+			continue
+		}
+		webhook, err := s.repository.GetByID(ctx, delivery.WebhookID)
+		if err != nil && !errors.IsError(err, errors.ErrCodeWebhookNotFound) {
+			return 0, err
+		}
+		webhooks[delivery.WebhookID] = webhook
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, deliveryConcurrency)
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(delivery *Delivery) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			s.deliver(ctx, webhooks[delivery.WebhookID], delivery)
+		}(delivery)
+	}
+	wg.Wait()
+
+	s.log.Debug("Attempted webhook deliveries", logger.Int("count", len(deliveries)))
+	return len(deliveries), nil
+}
+
+// deliver attempts a delivery and records its outcome
+func (s *deliveryService) deliver(ctx context.Context, webhook *Webhook, delivery *Delivery) {
+	now := time.Now()
+	delivery.LastAttemptAt = &now
+	delivery.ResponseStatus = nil
+	delivery.LastError = nil
+
+	if webhook == nil || !webhook.Enabled {
+		// Deliveries of removed or disabled webhooks are dead-lettered right away, and
+		// can be retried once the webhook is enabled again
+		reason := "webhook disabled"
+		delivery.Status = DeliveryStatusDead
+		delivery.LastError = &reason
+	} else {
+		delivery.Attempts++
+		status, err := s.send(ctx, webhook, delivery, now)
+		if status != 0 {
+			delivery.ResponseStatus = &status
+		}
+
+		switch {
+		case err == nil:
+			delivery.Status = DeliveryStatusSucceeded
+		case ctx.Err() != nil:
+			// Interrupted by shutdown: the attempt does not count against the delivery
+			return
+		default:
+			reason := truncate(err.Error())
+			delivery.LastError = &reason
+			if delivery.Attempts >= s.maxAttempts() {
+				delivery.Status = DeliveryStatusDead
+				s.log.Warn("Webhook delivery dead-lettered",
+					logger.Int64("webhook_id", delivery.WebhookID),
+					logger.Int64("delivery_id", delivery.ID),
+					logger.Int("attempts", delivery.Attempts),
+					logger.Error(err))
+			} else {
+				delivery.NextAttemptAt = now.Add(s.retryDelay(delivery.Attempts))
+				s.log.Debug("Webhook delivery failed, will retry",
+					logger.Int64("webhook_id", delivery.WebhookID),
+					logger.Int64("delivery_id", delivery.ID),
+					logger.Int("attempts", delivery.Attempts),
+					logger.Error(err))
+			}
+		}
+	}
+
+	if err := s.repository.UpdateDelivery(ctx, delivery); err != nil {
+		s.log.Error("Failed to record webhook delivery attempt",
+			logger.Int64("delivery_id", delivery.ID),
+			logger.Error(err))
+	}
+}
+
+// send posts the payload of a delivery to the webhook URL and returns the response status.
+// Any response status other than 2xx is an error.
+func (s *deliveryService) send(ctx context.Context, webhook *Webhook, delivery *Delivery, now time.Time) (int, error) {
+	secret, err := s.encryptor.Decrypt(webhook.Secret)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decrypt webhook secret: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewBufferString(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Vault0-Webhooks")
+	req.Header.Set(HeaderEvent, string(delivery.EventType))
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(secret, timestamp, []byte(delivery.Payload)))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorLength))
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d: %s", resp.StatusCode, body)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+// maxAttempts returns the number of failed attempts after which a delivery is dead-lettered
+func (s *deliveryService) maxAttempts() int {
+	if s.config.Webhooks.MaxAttempts > 0 {
+		return s.config.Webhooks.MaxAttempts
+	}
+	return 10
+}
+
+// retryDelay returns the delay before the next attempt of a delivery that failed attempts times,
+// doubling from the base delay up to the maximum delay
+func (s *deliveryService) retryDelay(attempts int) time.Duration {
+	base := 30 * time.Second
+	if s.config.Webhooks.RetryBaseDelay > 0 {
+		base = time.Duration(s.config.Webhooks.RetryBaseDelay) * time.Second
+	}
+	maxDelay := 6 * time.Hour
+	if s.config.Webhooks.RetryMaxDelay > 0 {
+		maxDelay = time.Duration(s.config.Webhooks.RetryMaxDelay) * time.Second
+	}
+
+	delay := base
+	for i := 1; i < attempts && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
+
+// Sign computes the signature header of a delivery: the hex encoded HMAC-SHA256, keyed
+// with the webhook secret, of the timestamp header, a dot and the request body.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// truncate shortens a message recorded in the delivery log
+func truncate(message string) string {
+	if len(message) > maxErrorLength {
+		return message[:maxErrorLength]
+	}
+	return message
+}
@@ -0,0 +1,137 @@
+package webhook
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"vault0/internal/types"
+)
+
+// EventType represents the type of an event notified to webhooks
+type EventType string
+
+const (
+	// EventTypeDeposit is published when a wallet receives a new transaction
+	EventTypeDeposit EventType = "transaction.deposit"
+	// EventTypeWithdrawal is published when a wallet sends a new transaction
+	EventTypeWithdrawal EventType = "transaction.withdrawal"
+	// EventTypeVaultStatusChanged is published when a vault changes status
+	EventTypeVaultStatusChanged EventType = "vault.status_changed"
+	// EventTypeVaultRecoveryRequested is published when the recovery of a vault is requested
+	EventTypeVaultRecoveryRequested EventType = "vault.recovery_requested"
+)
+
+// EventTypes lists the event types webhooks can subscribe to
+var EventTypes = []EventType{
+	EventTypeDeposit,
+	EventTypeWithdrawal,
+	EventTypeVaultStatusChanged,
+	EventTypeVaultRecoveryRequested,
+}
+
+// IsValid returns whether the event type is one webhooks can subscribe to
+func (t EventType) IsValid() bool {
+	return slices.Contains(EventTypes, t)
+}
+
+// Event is an occurrence notified to the webhooks subscribed to it. It is the JSON
+// body of the delivery requests.
+type Event struct {
+	ID        int64           `json:"id,string"`            // Unique ID of the event, shared by all its deliveries
+	Type      EventType       `json:"type"`                 // Type of the event
+	CreatedAt time.Time       `json:"created_at"`           // Time the event occurred
+	ChainType types.ChainType `json:"chain_type,omitempty"` // Chain the event occurred on
+	Address   string          `json:"address,omitempty"`    // Wallet or vault address the event relates to
+	Data      any             `json:"data"`                 // Event details, TransactionData or VaultData
+}
+
+// TransactionData holds the details of deposit and withdrawal events
+type TransactionData struct {
+	WalletID    int64                   `json:"wallet_id,string"`
+	Hash        string                  `json:"hash"`
+	From        string                  `json:"from"`
+	To          string                  `json:"to"`
+	Value       string                  `json:"value"`
+	Type        types.TransactionType   `json:"type"`
+	Status      types.TransactionStatus `json:"status"`
+	BlockNumber string                  `json:"block_number,omitempty"`
+	Timestamp   int64                   `json:"timestamp,omitempty"`
+	Metadata    types.TxMetadata        `json:"metadata,omitempty"`
+}
+
+// VaultData holds the details of vault events
+type VaultData struct {
+	VaultID             int64      `json:"vault_id,string"`
+	Name                string     `json:"name"`
+	WalletID            int64      `json:"wallet_id,string"`
+	PreviousStatus      string     `json:"previous_status"`
+	Status              string     `json:"status"`
+	RecoveryAddress     string     `json:"recovery_address"`
+	RecoveryRequestedAt *time.Time `json:"recovery_requested_at,omitempty"`
+	FailureReason       *string    `json:"failure_reason,omitempty"`
+	TxHash              string     `json:"tx_hash,omitempty"`
+}
+
+// Webhook represents a subscription of a URL to events
+type Webhook struct {
+	ID          int64           `db:"id"`
+	URL         string          `db:"url"`
+	Description string          `db:"description"`
+	EventTypes  types.JSONArray `db:"event_types"` // Event types notified, all when empty
+	ChainTypes  types.JSONArray `db:"chain_types"` // Chains of the events notified, all when empty
+	Addresses   types.JSONArray `db:"addresses"`   // Addresses of the events notified, all when empty
+	Secret      []byte          `db:"secret"`      // HMAC signing secret, encrypted with the DB encryption key
+	Enabled     bool            `db:"enabled"`
+	CreatedAt   time.Time       `db:"created_at"`
+	UpdatedAt   time.Time       `db:"updated_at"`
+}
+
+// Matches returns whether the webhook is notified of an event
+func (w *Webhook) Matches(event *Event) bool {
+	if !w.Enabled {
+		return false
+	}
+	if len(w.EventTypes) > 0 && !slices.Contains(w.EventTypes, string(event.Type)) {
+		return false
+	}
+	if len(w.ChainTypes) > 0 && !slices.Contains(w.ChainTypes, string(event.ChainType)) {
+		return false
+	}
+	if len(w.Addresses) > 0 && !slices.ContainsFunc(w.Addresses, func(address string) bool {
+		return strings.EqualFold(address, event.Address)
+	}) {
+		return false
+	}
+	return true
+}
+
+// DeliveryStatus represents the status of the delivery of an event to a webhook
+type DeliveryStatus string
+
+const (
+	// DeliveryStatusPending is the status of deliveries waiting for their next attempt
+	DeliveryStatusPending DeliveryStatus = "pending"
+	// DeliveryStatusSucceeded is the status of deliveries acknowledged with a 2xx response
+	DeliveryStatusSucceeded DeliveryStatus = "succeeded"
+	// DeliveryStatusDead is the status of deliveries that failed every attempt, which
+	// are only retried on request
+	DeliveryStatusDead DeliveryStatus = "dead"
+)
+
+// Delivery represents the delivery of an event to a webhook
+type Delivery struct {
+	ID             int64          `db:"id"`
+	WebhookID      int64          `db:"webhook_id"`
+	EventID        int64          `db:"event_id"`
+	EventType      EventType      `db:"event_type"`
+	Payload        string         `db:"payload"` // JSON encoded Event
+	Status         DeliveryStatus `db:"status"`
+	Attempts       int            `db:"attempts"`
+	NextAttemptAt  time.Time      `db:"next_attempt_at"`
+	LastAttemptAt  *time.Time     `db:"last_attempt_at"`
+	ResponseStatus *int           `db:"response_status"` // HTTP status of the last attempt, if any
+	LastError      *string        `db:"last_error"`
+	CreatedAt      time.Time      `db:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at"`
+}
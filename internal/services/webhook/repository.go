@@ -0,0 +1,423 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+
+	"vault0/internal/db"
+	"vault0/internal/errors"
+	"vault0/internal/types"
+)
+
+// Repository defines the webhook data access interface
+type Repository interface {
+	// Create adds a new webhook to the database
+	Create(ctx context.Context, webhook *Webhook) error
+
+	// Update modifies an existing webhook
+	Update(ctx context.Context, webhook *Webhook) error
+
+	// Delete removes a webhook and its deliveries from the database
+	Delete(ctx context.Context, id int64) error
+
+	// GetByID retrieves a webhook by its ID
+	GetByID(ctx context.Context, id int64) (*Webhook, error)
+
+	// List retrieves a paginated collection of webhooks
+	// When limit=0, returns all webhooks without pagination
+	List(ctx context.Context, limit int, nextToken string) (*types.Page[*Webhook], error)
+
+	// ListEnabled retrieves all the enabled webhooks
+	ListEnabled(ctx context.Context) ([]*Webhook, error)
+
+	// CreateDelivery adds a new delivery to the database
+	CreateDelivery(ctx context.Context, delivery *Delivery) error
+
+	// UpdateDelivery stores the outcome of a delivery attempt
+	UpdateDelivery(ctx context.Context, delivery *Delivery) error
+
+	// GetDelivery retrieves a delivery by its ID
+	GetDelivery(ctx context.Context, id int64) (*Delivery, error)
+
+	// ListDeliveries retrieves the deliveries of a webhook, newest first, optionally
+	// filtered by status
+	ListDeliveries(ctx context.Context, webhookID int64, status *DeliveryStatus, limit int, nextToken string) (*types.Page[*Delivery], error)
+
+	// ListDueDeliveries retrieves up to limit pending deliveries whose next attempt is due at now,
+	// oldest first
+	ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*Delivery, error)
+}
+
+// repository implements Repository using the database
+type repository struct {
+	db                *db.DB
+	webhookStructMap  *sqlbuilder.Struct
+	deliveryStructMap *sqlbuilder.Struct
+}
+
+// NewRepository creates a new webhook repository
+func NewRepository(db *db.DB) Repository {
+	return &repository{
+		db:                db,
+		webhookStructMap:  sqlbuilder.NewStruct(new(Webhook)),
+		deliveryStructMap: sqlbuilder.NewStruct(new(Delivery)),
+	}
+}
+
+// executeWebhookQuery executes a query and scans the results into Webhook objects
+func (r *repository) executeWebhookQuery(ctx context.Context, query string, args ...any) ([]*Webhook, error) {
+	rows, err := r.db.ExecuteQueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	webhooks := []*Webhook{}
+	for rows.Next() {
+		var webhook Webhook
+		if err := rows.Scan(r.webhookStructMap.Addr(&webhook)...); err != nil {
+			return nil, errors.NewDatabaseError(err)
+		}
+		webhooks = append(webhooks, &webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	return webhooks, nil
+}
+
+// executeDeliveryQuery executes a query and scans the results into Delivery objects
+func (r *repository) executeDeliveryQuery(ctx context.Context, query string, args ...any) ([]*Delivery, error) {
+	rows, err := r.db.ExecuteQueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := []*Delivery{}
+	for rows.Next() {
+		var delivery Delivery
+		var lastAttemptAt sql.NullTime
+		var responseStatus sql.NullInt64
+		var lastError sql.NullString
+
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.EventID,
+			&delivery.EventType,
+			&delivery.Payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&lastAttemptAt,
+			&responseStatus,
+			&lastError,
+			&delivery.CreatedAt,
+			&delivery.UpdatedAt,
+		)
+		if err != nil {
+			return nil, errors.NewDatabaseError(err)
+		}
+
+		// Handle nullable fields
+		if lastAttemptAt.Valid {
+			delivery.LastAttemptAt = &lastAttemptAt.Time
+		}
+		if responseStatus.Valid {
+			status := int(responseStatus.Int64)
+			delivery.ResponseStatus = &status
+		}
+		if lastError.Valid {
+			delivery.LastError = &lastError.String
+		}
+
+		deliveries = append(deliveries, &delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.NewDatabaseError(err)
+	}
+
+	return deliveries, nil
+}
+
+// Create adds a new webhook to the database
+func (r *repository) Create(ctx context.Context, webhook *Webhook) error {
+	if webhook.ID == 0 {
+		var err error
+		webhook.ID, err = r.db.GenerateID()
+		if err != nil {
+			return err
+		}
+	}
+
+	now := time.Now().UTC()
+	webhook.CreatedAt = now
+	webhook.UpdatedAt = now
+
+	sql, args := r.webhookStructMap.InsertInto("webhooks", webhook).Build()
+	_, err := r.db.ExecuteStatementContext(ctx, sql, args...)
+	return err
+}
+
+// Update modifies an existing webhook
+func (r *repository) Update(ctx context.Context, webhook *Webhook) error {
+	webhook.UpdatedAt = time.Now().UTC()
+
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update("webhooks")
+	ub.Set(
+		ub.Assign("url", webhook.URL),
+		ub.Assign("description", webhook.Description),
+		ub.Assign("event_types", webhook.EventTypes),
+		ub.Assign("chain_types", webhook.ChainTypes),
+		ub.Assign("addresses", webhook.Addresses),
+		ub.Assign("secret", webhook.Secret),
+		ub.Assign("enabled", webhook.Enabled),
+		ub.Assign("updated_at", webhook.UpdatedAt),
+	)
+	ub.Where(ub.Equal("id", webhook.ID))
+
+	sql, args := ub.Build()
+	result, err := r.db.ExecuteStatementContext(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewWebhookNotFoundError(webhook.ID)
+	}
+
+	return nil
+}
+
+// Delete removes a webhook and its deliveries from the database
+func (r *repository) Delete(ctx context.Context, id int64) error {
+	return r.db.WithTransaction(ctx, func(ctx context.Context) error {
+		result, err := r.db.ExecuteStatementContext(ctx, "DELETE FROM webhooks WHERE id = ?", id)
+		if err != nil {
+			return err
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return errors.NewDatabaseError(err)
+		}
+		if rowsAffected == 0 {
+			return errors.NewWebhookNotFoundError(id)
+		}
+
+		_, err = r.db.ExecuteStatementContext(ctx, "DELETE FROM webhook_deliveries WHERE webhook_id = ?", id)
+		return err
+	})
+}
+
+// GetByID retrieves a webhook by its ID
+func (r *repository) GetByID(ctx context.Context, id int64) (*Webhook, error) {
+	sb := r.webhookStructMap.SelectFrom("webhooks")
+	sb.Where(sb.Equal("id", id))
+
+	sql, args := sb.Build()
+	webhooks, err := r.executeWebhookQuery(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(webhooks) == 0 {
+		return nil, errors.NewWebhookNotFoundError(id)
+	}
+
+	return webhooks[0], nil
+}
+
+// List retrieves a paginated collection of webhooks
+func (r *repository) List(ctx context.Context, limit int, nextToken string) (*types.Page[*Webhook], error) {
+	sb := r.webhookStructMap.SelectFrom("webhooks")
+
+	paginationColumn := "id"
+	token, err := types.DecodeNextPageToken(nextToken, paginationColumn)
+	if err != nil {
+		return nil, err
+	}
+	if token != nil {
+		id, ok := token.GetValueInt64()
+		if !ok {
+			return nil, errors.NewInvalidPaginationTokenError(nextToken,
+				fmt.Errorf("id value must be an integer, got %T", token.Value))
+		}
+		sb.Where(sb.GreaterThan(paginationColumn, id))
+	}
+
+	sb.OrderBy(paginationColumn + " ASC")
+	if limit > 0 {
+		sb.Limit(limit + 1)
+	}
+
+	sql, args := sb.Build()
+	webhooks, err := r.executeWebhookQuery(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	generateToken := func(webhook *Webhook) *types.NextPageToken {
+		return &types.NextPageToken{
+			Column: paginationColumn,
+			Value:  strconv.FormatInt(webhook.ID, 10), // Snowflake IDs exceed float64 precision
+		}
+	}
+
+	return types.NewPage(webhooks, limit, generateToken), nil
+}
+
+// ListEnabled retrieves all the enabled webhooks
+func (r *repository) ListEnabled(ctx context.Context) ([]*Webhook, error) {
+	sb := r.webhookStructMap.SelectFrom("webhooks")
+	sb.Where(sb.Equal("enabled", true))
+	sb.OrderBy("id ASC")
+
+	sql, args := sb.Build()
+	return r.executeWebhookQuery(ctx, sql, args...)
+}
+
+// CreateDelivery adds a new delivery to the database
+func (r *repository) CreateDelivery(ctx context.Context, delivery *Delivery) error {
+	if delivery.ID == 0 {
+		var err error
+		delivery.ID, err = r.db.GenerateID()
+		if err != nil {
+			return err
+		}
+	}
+
+	now := time.Now().UTC()
+	delivery.CreatedAt = now
+	delivery.UpdatedAt = now
+	if delivery.NextAttemptAt.IsZero() {
+		delivery.NextAttemptAt = now
+	}
+	// Due deliveries are found by comparing attempt times, which are stored in UTC
+	delivery.NextAttemptAt = delivery.NextAttemptAt.UTC()
+
+	sql, args := r.deliveryStructMap.InsertInto("webhook_deliveries", delivery).Build()
+	_, err := r.db.ExecuteStatementContext(ctx, sql, args...)
+	return err
+}
+
+// UpdateDelivery stores the outcome of a delivery attempt
+func (r *repository) UpdateDelivery(ctx context.Context, delivery *Delivery) error {
+	delivery.UpdatedAt = time.Now().UTC()
+
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update("webhook_deliveries")
+	ub.Set(
+		ub.Assign("status", delivery.Status),
+		ub.Assign("attempts", delivery.Attempts),
+		ub.Assign("next_attempt_at", delivery.NextAttemptAt.UTC()),
+		ub.Assign("last_attempt_at", delivery.LastAttemptAt),
+		ub.Assign("response_status", delivery.ResponseStatus),
+		ub.Assign("last_error", delivery.LastError),
+		ub.Assign("updated_at", delivery.UpdatedAt),
+	)
+	ub.Where(ub.Equal("id", delivery.ID))
+
+	sql, args := ub.Build()
+	result, err := r.db.ExecuteStatementContext(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.NewDatabaseError(err)
+	}
+	if rowsAffected == 0 {
+		return errors.NewWebhookDeliveryNotFoundError(delivery.ID)
+	}
+
+	return nil
+}
+
+// GetDelivery retrieves a delivery by its ID
+func (r *repository) GetDelivery(ctx context.Context, id int64) (*Delivery, error) {
+	sb := r.deliveryStructMap.SelectFrom("webhook_deliveries")
+	sb.Where(sb.Equal("id", id))
+
+	sql, args := sb.Build()
+	deliveries, err := r.executeDeliveryQuery(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return nil, errors.NewWebhookDeliveryNotFoundError(id)
+	}
+
+	return deliveries[0], nil
+}
+
+// ListDeliveries retrieves the deliveries of a webhook, newest first
+func (r *repository) ListDeliveries(ctx context.Context, webhookID int64, status *DeliveryStatus, limit int, nextToken string) (*types.Page[*Delivery], error) {
+	sb := r.deliveryStructMap.SelectFrom("webhook_deliveries")
+	sb.Where(sb.Equal("webhook_id", webhookID))
+	if status != nil {
+		sb.Where(sb.Equal("status", *status))
+	}
+
+	paginationColumn := "id"
+	token, err := types.DecodeNextPageToken(nextToken, paginationColumn)
+	if err != nil {
+		return nil, err
+	}
+	if token != nil {
+		id, ok := token.GetValueInt64()
+		if !ok {
+			return nil, errors.NewInvalidPaginationTokenError(nextToken,
+				fmt.Errorf("id value must be an integer, got %T", token.Value))
+		}
+		sb.Where(sb.LessThan(paginationColumn, id))
+	}
+
+	sb.OrderBy(paginationColumn + " DESC")
+	if limit > 0 {
+		sb.Limit(limit + 1)
+	}
+
+	sql, args := sb.Build()
+	deliveries, err := r.executeDeliveryQuery(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	generateToken := func(delivery *Delivery) *types.NextPageToken {
+		return &types.NextPageToken{
+			Column: paginationColumn,
+			Value:  strconv.FormatInt(delivery.ID, 10), // Snowflake IDs exceed float64 precision
+		}
+	}
+
+	return types.NewPage(deliveries, limit, generateToken), nil
+}
+
+// ListDueDeliveries retrieves the pending deliveries whose next attempt is due
+func (r *repository) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*Delivery, error) {
+	sb := r.deliveryStructMap.SelectFrom("webhook_deliveries")
+	sb.Where(
+		sb.Equal("status", DeliveryStatusPending),
+		sb.LessEqualThan("next_attempt_at", now.UTC()),
+	)
+	sb.OrderBy("next_attempt_at ASC")
+	sb.Limit(limit)
+
+	sql, args := sb.Build()
+	return r.executeDeliveryQuery(ctx, sql, args...)
+}
@@ -0,0 +1,148 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"vault0/internal/db"
+	"vault0/internal/errors"
+	"vault0/internal/testing/dbtest"
+	"vault0/internal/types"
+)
+
+func TestRepository_Webhooks(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database)
+		ctx := context.Background()
+
+		webhook := &Webhook{
+			URL:        "https://example.com/hooks",
+			EventTypes: types.NewJSONArray([]string{string(EventTypeDeposit)}),
+			Secret:     []byte("encrypted"),
+			Enabled:    true,
+		}
+		require.NoError(t, repo.Create(ctx, webhook))
+		require.NotZero(t, webhook.ID)
+
+		disabled := &Webhook{URL: "https://example.com/disabled", Secret: []byte("encrypted")}
+		require.NoError(t, repo.Create(ctx, disabled))
+
+		found, err := repo.GetByID(ctx, webhook.ID)
+		require.NoError(t, err)
+		assert.Equal(t, webhook.URL, found.URL)
+		assert.Equal(t, []string{string(EventTypeDeposit)}, []string(found.EventTypes))
+		assert.Empty(t, found.ChainTypes)
+		assert.Equal(t, []byte("encrypted"), found.Secret)
+		assert.True(t, found.Enabled)
+
+		enabled, err := repo.ListEnabled(ctx)
+		require.NoError(t, err)
+		require.Len(t, enabled, 1)
+		assert.Equal(t, webhook.ID, enabled[0].ID)
+
+		found.Addresses = types.NewJSONArray([]string{"0xabc"})
+		found.Enabled = false
+		require.NoError(t, repo.Update(ctx, found))
+		found, err = repo.GetByID(ctx, webhook.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"0xabc"}, []string(found.Addresses))
+		assert.False(t, found.Enabled)
+
+		page, err := repo.List(ctx, 1, "")
+		require.NoError(t, err)
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, webhook.ID, page.Items[0].ID)
+		require.NotEmpty(t, page.NextToken)
+		page, err = repo.List(ctx, 1, page.NextToken)
+		require.NoError(t, err)
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, disabled.ID, page.Items[0].ID)
+
+		delivery := &Delivery{WebhookID: webhook.ID, EventID: 1, EventType: EventTypeDeposit, Payload: "{}", Status: DeliveryStatusPending}
+		require.NoError(t, repo.CreateDelivery(ctx, delivery))
+
+		require.NoError(t, repo.Delete(ctx, webhook.ID))
+		_, err = repo.GetByID(ctx, webhook.ID)
+		assert.True(t, errors.IsError(err, errors.ErrCodeWebhookNotFound))
+		_, err = repo.GetDelivery(ctx, delivery.ID)
+		assert.True(t, errors.IsError(err, errors.ErrCodeWebhookDeliveryNotFound))
+
+		err = repo.Delete(ctx, webhook.ID)
+		assert.True(t, errors.IsError(err, errors.ErrCodeWebhookNotFound))
+	})
+}
+
+func TestRepository_Deliveries(t *testing.T) {
+	dbtest.Run(t, func(t *testing.T, database *db.DB) {
+		repo := NewRepository(database)
+		ctx := context.Background()
+
+		webhook := &Webhook{URL: "https://example.com/hooks", Secret: []byte("encrypted"), Enabled: true}
+		require.NoError(t, repo.Create(ctx, webhook))
+
+		now := time.Now()
+		due := &Delivery{WebhookID: webhook.ID, EventID: 1, EventType: EventTypeDeposit, Payload: `{"id":"1"}`, Status: DeliveryStatusPending}
+		require.NoError(t, repo.CreateDelivery(ctx, due))
+		later := &Delivery{WebhookID: webhook.ID, EventID: 2, EventType: EventTypeWithdrawal, Payload: `{"id":"2"}`,
+			Status: DeliveryStatusPending, NextAttemptAt: now.Add(time.Hour)}
+		require.NoError(t, repo.CreateDelivery(ctx, later))
+
+		// Only pending deliveries whose next attempt has come are due
+		dueDeliveries, err := repo.ListDueDeliveries(ctx, now.Add(time.Second), 10)
+		require.NoError(t, err)
+		require.Len(t, dueDeliveries, 1)
+		assert.Equal(t, due.ID, dueDeliveries[0].ID)
+		assert.Equal(t, `{"id":"1"}`, dueDeliveries[0].Payload)
+		assert.Nil(t, dueDeliveries[0].LastAttemptAt)
+
+		dueDeliveries, err = repo.ListDueDeliveries(ctx, now.Add(2*time.Hour), 10)
+		require.NoError(t, err)
+		assert.Len(t, dueDeliveries, 2)
+
+		attemptedAt := now
+		status := 503
+		reason := "unexpected response status 503"
+		due.Status = DeliveryStatusDead
+		due.Attempts = 3
+		due.LastAttemptAt = &attemptedAt
+		due.ResponseStatus = &status
+		due.LastError = &reason
+		require.NoError(t, repo.UpdateDelivery(ctx, due))
+
+		found, err := repo.GetDelivery(ctx, due.ID)
+		require.NoError(t, err)
+		assert.Equal(t, DeliveryStatusDead, found.Status)
+		assert.Equal(t, 3, found.Attempts)
+		require.NotNil(t, found.LastAttemptAt)
+		assert.WithinDuration(t, attemptedAt, *found.LastAttemptAt, time.Millisecond)
+		require.NotNil(t, found.ResponseStatus)
+		assert.Equal(t, 503, *found.ResponseStatus)
+		require.NotNil(t, found.LastError)
+		assert.Equal(t, reason, *found.LastError)
+
+		dueDeliveries, err = repo.ListDueDeliveries(ctx, now.Add(2*time.Hour), 10)
+		require.NoError(t, err)
+		require.Len(t, dueDeliveries, 1)
+		assert.Equal(t, later.ID, dueDeliveries[0].ID)
+
+		// The delivery log is listed newest first, optionally filtered by status
+		page, err := repo.ListDeliveries(ctx, webhook.ID, nil, 1, "")
+		require.NoError(t, err)
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, later.ID, page.Items[0].ID)
+		page, err = repo.ListDeliveries(ctx, webhook.ID, nil, 1, page.NextToken)
+		require.NoError(t, err)
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, due.ID, page.Items[0].ID)
+
+		dead := DeliveryStatusDead
+		page, err = repo.ListDeliveries(ctx, webhook.ID, &dead, 10, "")
+		require.NoError(t, err)
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, due.ID, page.Items[0].ID)
+	})
+}
@@ -0,0 +1,346 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"vault0/internal/config"
+	"vault0/internal/core/crypto"
+	"vault0/internal/db"
+	"vault0/internal/errors"
+	"vault0/internal/logger"
+	"vault0/internal/types"
+)
+
+// secretSize is the size in bytes of the generated signing secrets
+const secretSize = 32
+
+// Filter restricts the events notified to a webhook. Empty lists match everything.
+type Filter struct {
+	EventTypes []EventType
+	ChainTypes []types.ChainType
+	Addresses  []string
+}
+
+// Service defines the interface for webhook subscriptions and the publication of events
+type Service interface {
+	// CreateWebhook subscribes a URL to the events matching the filter. It returns the
+	// webhook and its signing secret, which is not retrievable afterwards.
+	//
+	// Returns:
+	//   - ErrInvalidInput if the URL or the filter are invalid
+	CreateWebhook(ctx context.Context, url, description string, filter Filter) (*Webhook, string, error)
+
+	// UpdateWebhook replaces the URL, description, filter and enabled state of a webhook
+	//
+	// Returns:
+	//   - ErrWebhookNotFound if the webhook does not exist
+	//   - ErrInvalidInput if the URL or the filter are invalid
+	UpdateWebhook(ctx context.Context, id int64, url, description string, filter Filter, enabled bool) (*Webhook, error)
+
+	// RotateSecret replaces the signing secret of a webhook and returns the new secret
+	//
+	// Returns:
+	//   - ErrWebhookNotFound if the webhook does not exist
+	RotateSecret(ctx context.Context, id int64) (string, error)
+
+	// DeleteWebhook removes a webhook and its deliveries
+	//
+	// Returns:
+	//   - ErrWebhookNotFound if the webhook does not exist
+	DeleteWebhook(ctx context.Context, id int64) error
+
+	// GetWebhook retrieves a webhook by ID
+	//
+	// Returns:
+	//   - ErrWebhookNotFound if the webhook does not exist
+	GetWebhook(ctx context.Context, id int64) (*Webhook, error)
+
+	// ListWebhooks retrieves webhooks with pagination
+	ListWebhooks(ctx context.Context, limit int, nextToken string) (*types.Page[*Webhook], error)
+
+	// ListDeliveries retrieves the delivery log of a webhook, newest first, optionally
+	// filtered by status
+	//
+	// Returns:
+	//   - ErrWebhookNotFound if the webhook does not exist
+	ListDeliveries(ctx context.Context, webhookID int64, status *DeliveryStatus, limit int, nextToken string) (*types.Page[*Delivery], error)
+
+	// RetryDelivery schedules a delivery of a webhook for immediate redelivery with a
+	// new series of attempts, e.g. once a dead-lettered delivery's endpoint is fixed
+	//
+	// Returns:
+	//   - ErrWebhookDeliveryNotFound if the webhook has no such delivery
+	RetryDelivery(ctx context.Context, webhookID, deliveryID int64) (*Delivery, error)
+
+	// Publish records a delivery of the event to each enabled webhook it matches. The
+	// deliveries are sent by the DeliveryService, and are stored in the transaction
+	// carried by ctx if any, so that events are only notified once they are committed.
+	Publish(ctx context.Context, event *Event) error
+}
+
+// service implements the Service interface
+type service struct {
+	repository Repository
+	db         *db.DB
+	chains     *types.Chains
+	encryptor  crypto.Encryptor
+	log        logger.Logger
+}
+
+// NewService creates a new webhook service instance
+func NewService(repository Repository, database *db.DB, chains *types.Chains, cfg *config.Config, log logger.Logger) (Service, error) {
+	encryptor, err := newEncryptor(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &service{
+		repository: repository,
+		db:         database,
+		chains:     chains,
+		encryptor:  encryptor,
+		log:        log.With(logger.String("service", "webhook")),
+	}, nil
+}
+
+// CreateWebhook implements the Service interface
+func (s *service) CreateWebhook(ctx context.Context, url, description string, filter Filter) (*Webhook, string, error) {
+	webhook := &Webhook{Description: description, Enabled: true}
+	if err := s.apply(webhook, url, filter); err != nil {
+		return nil, "", err
+	}
+
+	secret, err := s.generateSecret(webhook)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := s.repository.Create(ctx, webhook); err != nil {
+		s.log.Error("Failed to create webhook", logger.Error(err), logger.String("url", webhook.URL))
+		return nil, "", err
+	}
+
+	s.log.Info("Created webhook", logger.Int64("webhook_id", webhook.ID), logger.String("url", webhook.URL))
+	return webhook, secret, nil
+}
+
+// UpdateWebhook implements the Service interface
+func (s *service) UpdateWebhook(ctx context.Context, id int64, url, description string, filter Filter, enabled bool) (*Webhook, error) {
+	webhook, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.Description = description
+	webhook.Enabled = enabled
+	if err := s.apply(webhook, url, filter); err != nil {
+		return nil, err
+	}
+
+	if err := s.repository.Update(ctx, webhook); err != nil {
+		s.log.Error("Failed to update webhook", logger.Error(err), logger.Int64("webhook_id", id))
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// RotateSecret implements the Service interface
+func (s *service) RotateSecret(ctx context.Context, id int64) (string, error) {
+	webhook, err := s.repository.GetByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	secret, err := s.generateSecret(webhook)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.repository.Update(ctx, webhook); err != nil {
+		s.log.Error("Failed to rotate webhook secret", logger.Error(err), logger.Int64("webhook_id", id))
+		return "", err
+	}
+
+	s.log.Info("Rotated webhook secret", logger.Int64("webhook_id", id))
+	return secret, nil
+}
+
+// DeleteWebhook implements the Service interface
+func (s *service) DeleteWebhook(ctx context.Context, id int64) error {
+	if err := s.repository.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.log.Info("Deleted webhook", logger.Int64("webhook_id", id))
+	return nil
+}
+
+// GetWebhook implements the Service interface
+func (s *service) GetWebhook(ctx context.Context, id int64) (*Webhook, error) {
+	return s.repository.GetByID(ctx, id)
+}
+
+// ListWebhooks implements the Service interface
+func (s *service) ListWebhooks(ctx context.Context, limit int, nextToken string) (*types.Page[*Webhook], error) {
+	return s.repository.List(ctx, limit, nextToken)
+}
+
+// ListDeliveries implements the Service interface
+func (s *service) ListDeliveries(ctx context.Context, webhookID int64, status *DeliveryStatus, limit int, nextToken string) (*types.Page[*Delivery], error) {
+	if _, err := s.repository.GetByID(ctx, webhookID); err != nil {
+		return nil, err
+	}
+	return s.repository.ListDeliveries(ctx, webhookID, status, limit, nextToken)
+}
+
+// RetryDelivery implements the Service interface
+func (s *service) RetryDelivery(ctx context.Context, webhookID, deliveryID int64) (*Delivery, error) {
+	delivery, err := s.repository.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.WebhookID != webhookID {
+		return nil, errors.NewWebhookDeliveryNotFoundError(deliveryID)
+	}
+
+	delivery.Status = DeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+	if err := s.repository.UpdateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	s.log.Info("Scheduled webhook delivery for redelivery",
+		logger.Int64("webhook_id", webhookID),
+		logger.Int64("delivery_id", deliveryID))
+	return delivery, nil
+}
+
+// Publish implements the Service interface
+func (s *service) Publish(ctx context.Context, event *Event) error {
+	if event.ID == 0 {
+		id, err := s.db.GenerateID()
+		if err != nil {
+			return err
+		}
+		event.ID = id
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now().UTC()
+	}
+
+	return s.db.WithTransaction(ctx, func(ctx context.Context) error {
+		webhooks, err := s.repository.ListEnabled(ctx)
+		if err != nil {
+			return err
+		}
+
+		var payload []byte
+		for _, webhook := range webhooks {
+			if !webhook.Matches(event) {
+				continue
+			}
+
+			if payload == nil {
+				if payload, err = json.Marshal(event); err != nil {
+					return errors.NewOperationFailedError("encode webhook event", err)
+				}
+			}
+
+			delivery := &Delivery{
+				WebhookID: webhook.ID,
+				EventID:   event.ID,
+				EventType: event.Type,
+				Payload:   string(payload),
+				Status:    DeliveryStatusPending,
+			}
+			if err := s.repository.CreateDelivery(ctx, delivery); err != nil {
+				s.log.Error("Failed to record webhook delivery",
+					logger.Error(err),
+					logger.Int64("webhook_id", webhook.ID),
+					logger.String("event_type", string(event.Type)))
+				return err
+			}
+
+			s.log.Debug("Recorded webhook delivery",
+				logger.Int64("webhook_id", webhook.ID),
+				logger.Int64("delivery_id", delivery.ID),
+				logger.String("event_type", string(event.Type)))
+		}
+		return nil
+	})
+}
+
+// apply validates the URL and filter of a webhook and sets them
+func (s *service) apply(webhook *Webhook, rawURL string, filter Filter) error {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.NewInvalidInputError("URL must be an absolute http or https URL", "url", rawURL)
+	}
+
+	eventTypes := make([]string, 0, len(filter.EventTypes))
+	for _, eventType := range filter.EventTypes {
+		if !eventType.IsValid() {
+			return errors.NewInvalidInputError("Unsupported event type", "event_types", eventType)
+		}
+		eventTypes = append(eventTypes, string(eventType))
+	}
+
+	chainTypes := make([]string, 0, len(filter.ChainTypes))
+	for _, chainType := range filter.ChainTypes {
+		if _, err := s.chains.Get(chainType); err != nil {
+			return errors.NewInvalidInputError("Unsupported chain type", "chain_types", chainType)
+		}
+		chainTypes = append(chainTypes, string(chainType))
+	}
+
+	addresses := make([]string, 0, len(filter.Addresses))
+	for _, address := range filter.Addresses {
+		if address = strings.TrimSpace(address); address == "" {
+			return errors.NewInvalidInputError("Addresses cannot be empty", "addresses", address)
+		}
+		addresses = append(addresses, address)
+	}
+
+	webhook.URL = parsed.String()
+	webhook.EventTypes = types.NewJSONArray(eventTypes)
+	webhook.ChainTypes = types.NewJSONArray(chainTypes)
+	webhook.Addresses = types.NewJSONArray(addresses)
+	return nil
+}
+
+// generateSecret generates a new signing secret for a webhook, stores it encrypted
+// in the webhook and returns it
+func (s *service) generateSecret(webhook *Webhook) (string, error) {
+	raw := make([]byte, secretSize)
+	if _, err := rand.Read(raw); err != nil {
+		return "", errors.NewOperationFailedError("generate webhook secret", err)
+	}
+	secret := hex.EncodeToString(raw)
+
+	encrypted, err := s.encryptor.Encrypt([]byte(secret))
+	if err != nil {
+		return "", errors.NewEncryptionError(err)
+	}
+	webhook.Secret = encrypted
+	return secret, nil
+}
+
+// newEncryptor creates the encryptor of the webhook signing secrets from the DB encryption key
+func newEncryptor(cfg *config.Config) (crypto.Encryptor, error) {
+	if cfg.DBEncryptionKey == "" {
+		return nil, errors.NewInvalidEncryptionKeyError("DB_ENCRYPTION_KEY environment variable is required")
+	}
+
+	encryptor, err := crypto.NewAESEncryptorFromBase64(cfg.DBEncryptionKey)
+	if err != nil {
+		return nil, errors.NewEncryptionError(err)
+	}
+	return encryptor, nil
+}
@@ -37,6 +37,38 @@ func (m *MockABIUtils) GetAddressFromArgs(args map[string]any, key string) (type
 	return mockArgs.Get(0).(types.Address), mockArgs.Error(1)
 }
 
+func (m *MockABIUtils) DecodeCall(contractABI string, inputData []byte) (*types.DecodedCall, error) {
+	args := m.Called(contractABI, inputData)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.DecodedCall), args.Error(1)
+}
+
+func (m *MockABIUtils) DecodeLog(contractABI string, log types.Log) (*types.DecodedEvent, error) {
+	args := m.Called(contractABI, log)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.DecodedEvent), args.Error(1)
+}
+
+func (m *MockABIUtils) DecodeCallHeuristic(inputData []byte) (*types.DecodedCall, error) {
+	args := m.Called(inputData)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.DecodedCall), args.Error(1)
+}
+
+func (m *MockABIUtils) DecodeLogHeuristic(log types.Log) (*types.DecodedEvent, error) {
+	args := m.Called(log)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.DecodedEvent), args.Error(1)
+}
+
 func (m *MockABIUtils) GetBytes32FromArgs(args map[string]any, key string) ([32]byte, error) {
 	mockArgs := m.Called(args, key)
 	return mockArgs.Get(0).([32]byte), mockArgs.Error(1)
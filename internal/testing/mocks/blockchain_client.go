@@ -76,6 +76,22 @@ func (m *MockBlockchainClient) CallContract(ctx context.Context, from string, to
 	return args.Get(0).([]byte), err
 }
 
+func (m *MockBlockchainClient) GetStorageAt(ctx context.Context, address string, slot string) ([]byte, error) {
+	args := m.Called(ctx, address, slot)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockBlockchainClient) GetCode(ctx context.Context, address string) ([]byte, error) {
+	args := m.Called(ctx, address)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
 func (m *MockBlockchainClient) FilterContractLogs(ctx context.Context, addresses []string, eventSignature string, eventArgs []any, fromBlock, toBlock int64) ([]types.Log, error) {
 	args := m.Called(ctx, addresses, eventSignature, eventArgs, fromBlock, toBlock)
 	return args.Get(0).([]types.Log), args.Error(1)
@@ -123,3 +139,11 @@ func NewMockBlockchainClient() *MockBlockchainClient {
 
 	return mockClient
 }
+
+// WithNoProxyStorage sets up expectations for contracts that don't follow any
+// storage-based proxy standard: empty storage slots and regular bytecode
+func (m *MockBlockchainClient) WithNoProxyStorage() *MockBlockchainClient {
+	m.On("GetStorageAt", mock.Anything, mock.Anything, mock.Anything).Return(make([]byte, 32), nil).Maybe()
+	m.On("GetCode", mock.Anything, mock.Anything).Return([]byte{0x60, 0x80, 0x60, 0x40}, nil).Maybe()
+	return m
+}
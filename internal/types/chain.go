@@ -2,6 +2,7 @@ package types
 
 import (
 	"crypto/elliptic"
+	"fmt"
 
 	"vault0/internal/config"
 	"vault0/internal/core/crypto"
@@ -79,6 +80,16 @@ func (c *Chains) Get(chainType ChainType) (Chain, error) {
 	return chain, nil
 }
 
+// GetByID returns the Chain configuration for the specified network identifier.
+func (c *Chains) GetByID(id int64) (Chain, error) {
+	for _, chain := range c.Chains {
+		if chain.ID == id {
+			return chain, nil
+		}
+	}
+	return Chain{}, errors.NewChainNotSupportedError(fmt.Sprintf("chain id %d", id))
+}
+
 // List returns a slice of all Chain configurations.
 func (c *Chains) List() []Chain {
 	chains := make([]Chain, 0, len(c.Chains))
@@ -0,0 +1,50 @@
+package types
+
+// DecodedArgument is a named, typed argument of a decoded contract call or event.
+// Values are normalized for JSON: integers and addresses are strings, byte
+// values are 0x-prefixed hex, arrays are lists and tuples are objects.
+type DecodedArgument struct {
+	// Name is the argument name from the ABI (may be empty)
+	Name string `json:"name"`
+	// Type is the canonical ABI type (e.g., "address", "uint256", "bytes32[]")
+	Type string `json:"type"`
+	// Value is the decoded argument value
+	Value any `json:"value"`
+	// Indexed reports whether an event argument was an indexed topic. Indexed
+	// dynamic values (strings, bytes, arrays) only carry their keccak256 hash.
+	Indexed bool `json:"indexed,omitempty"`
+}
+
+// DecodedCall is a contract call decoded using the contract's ABI.
+type DecodedCall struct {
+	// Method is the name of the called method
+	Method string `json:"method"`
+	// Signature is the canonical method signature (e.g., "transfer(address,uint256)")
+	Signature string `json:"signature"`
+	// Selector is the 0x-prefixed 4-byte method selector
+	Selector string `json:"selector"`
+	// Arguments holds the decoded call arguments in ABI order
+	Arguments []DecodedArgument `json:"arguments"`
+	// Heuristic reports whether the call was decoded without the contract ABI,
+	// using a signature looked up by selector. Argument names are unknown and
+	// colliding selectors may yield the wrong method.
+	Heuristic bool `json:"heuristic,omitempty"`
+}
+
+// DecodedEvent is a receipt log decoded using the emitting contract's ABI.
+type DecodedEvent struct {
+	// Address is the contract that emitted the log
+	Address string `json:"address"`
+	// LogIndex is the index of the log in the block
+	LogIndex uint `json:"log_index"`
+	// Name is the event name
+	Name string `json:"name"`
+	// Signature is the canonical event signature (e.g., "Transfer(address,address,uint256)")
+	Signature string `json:"signature"`
+	// Arguments holds the decoded event arguments in ABI order
+	Arguments []DecodedArgument `json:"arguments"`
+	// Heuristic reports whether the event was decoded without the contract ABI,
+	// using a signature looked up by topic. Argument names are unknown and the
+	// leading arguments are assumed to be the indexed ones.
+	Heuristic bool `json:"heuristic,omitempty"`
+}
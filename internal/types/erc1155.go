@@ -0,0 +1,79 @@
+package types
+
+import "math/big"
+
+// ERC1155EventSignature defines the type for ERC1155 event signatures.
+type ERC1155EventSignature string
+
+// ERC1155 event signatures
+const (
+	// ERC1155TransferSingleEvent is the standard ERC1155 TransferSingle event signature
+	ERC1155TransferSingleEvent ERC1155EventSignature = "TransferSingle(address,address,address,uint256,uint256)"
+	// ERC1155TransferBatchEvent is the standard ERC1155 TransferBatch event signature
+	ERC1155TransferBatchEvent ERC1155EventSignature = "TransferBatch(address,address,address,uint256[],uint256[])"
+	// ERC1155ApprovalForAllEvent is the standard ERC1155 ApprovalForAll event signature
+	ERC1155ApprovalForAllEvent ERC1155EventSignature = "ApprovalForAll(address,address,bool)"
+	// ERC1155URIEvent is the standard ERC1155 URI event signature
+	ERC1155URIEvent ERC1155EventSignature = "URI(string,uint256)"
+)
+
+// ERC1155MethodSignature defines the type for ERC1155 method signatures.
+type ERC1155MethodSignature string
+
+// ERC1155 method signatures
+const (
+	ERC1155BalanceOfMethod             ERC1155MethodSignature = "balanceOf(address,uint256)"
+	ERC1155BalanceOfBatchMethod        ERC1155MethodSignature = "balanceOfBatch(address[],uint256[])"
+	ERC1155SafeTransferFromMethod      ERC1155MethodSignature = "safeTransferFrom(address,address,uint256,uint256,bytes)"
+	ERC1155SafeBatchTransferFromMethod ERC1155MethodSignature = "safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)"
+	ERC1155SetApprovalForAllMethod     ERC1155MethodSignature = "setApprovalForAll(address,bool)"
+	ERC1155IsApprovedForAllMethod      ERC1155MethodSignature = "isApprovedForAll(address,address)"
+	ERC1155URIMethod                   ERC1155MethodSignature = "uri(uint256)"
+)
+
+// ERC1155 transaction type constants
+const (
+	// TransactionTypeERC1155Transfer indicates a transaction is an ERC1155 token transfer
+	TransactionTypeERC1155Transfer TransactionType = "erc1155_transfer"
+)
+
+// ERC1155Transfer represents an ERC1155 single or batch token transfer transaction.
+// It embeds Transaction for the core details and adds token-specific fields.
+type ERC1155Transfer struct {
+	// Embeds the core transaction details
+	Transaction
+	// TokenAddress is the address of the ERC1155 token contract
+	TokenAddress string
+	// TokenSymbol is the symbol of the ERC1155 token (optional)
+	TokenSymbol string
+	// TokenName is the name of the ERC1155 token (optional)
+	TokenName string
+	// Sender is the address the tokens are transferred from, which differs
+	// from the transaction sender when an approved operator moves them
+	Sender string
+	// Recipient is the address receiving the tokens
+	Recipient string
+	// TokenIDs are the identifiers of the transferred tokens
+	TokenIDs []*big.Int
+	// Amounts are the transferred amounts, one per token ID
+	Amounts []*big.Int
+}
+
+// NFTTransfers returns one token movement per transferred token ID
+func (t *ERC1155Transfer) NFTTransfers() []NFTTransfer {
+	transfers := make([]NFTTransfer, 0, len(t.TokenIDs))
+	for i, tokenID := range t.TokenIDs {
+		if i >= len(t.Amounts) {
+			break
+		}
+		transfers = append(transfers, NFTTransfer{
+			TokenAddress: t.TokenAddress,
+			Standard:     TokenTypeERC1155,
+			From:         t.Sender,
+			To:           t.Recipient,
+			TokenID:      tokenID,
+			Amount:       t.Amounts[i],
+		})
+	}
+	return transfers
+}
@@ -29,6 +29,15 @@ const (
 	// ERC20AllowanceMethod is the standard ERC20 allowance method signature
 	ERC20AllowanceMethod ERC20MethodSignature = "allowance(address,address)"
 
+	// ERC20IncreaseAllowanceMethod is the OpenZeppelin increaseAllowance method signature
+	ERC20IncreaseAllowanceMethod ERC20MethodSignature = "increaseAllowance(address,uint256)"
+
+	// ERC20DecreaseAllowanceMethod is the OpenZeppelin decreaseAllowance method signature
+	ERC20DecreaseAllowanceMethod ERC20MethodSignature = "decreaseAllowance(address,uint256)"
+
+	// ERC20PermitMethod is the EIP-2612 permit method signature
+	ERC20PermitMethod ERC20MethodSignature = "permit(address,address,uint256,uint256,uint8,bytes32,bytes32)"
+
 	// ERC20TransferFromMethod is the standard ERC20 transferFrom method signature
 	ERC20TransferFromMethod ERC20MethodSignature = "transferFrom(address,address,uint256)"
 
@@ -49,6 +58,9 @@ const (
 const (
 	// TransactionTypeERC20Transfer indicates a transaction is an ERC20 token transfer
 	TransactionTypeERC20Transfer TransactionType = "erc20_transfer"
+
+	// TransactionTypeERC20Approval indicates a transaction changes an ERC20 allowance
+	TransactionTypeERC20Approval TransactionType = "erc20_approval"
 )
 
 // ERC20Transfer represents an ERC20 token transfer transaction
@@ -67,3 +79,53 @@ type ERC20Transfer struct {
 	// Amount is the amount of tokens transferred
 	Amount *big.Int
 }
+
+// ERC20Approval represents a transaction that changes an ERC20 allowance through
+// approve, increaseAllowance, decreaseAllowance or an EIP-2612 permit
+type ERC20Approval struct {
+	// Embeds the core transaction details
+	Transaction
+	// TokenAddress is the address of the ERC20 token contract
+	TokenAddress string
+	// TokenSymbol is the symbol of the ERC20 token
+	TokenSymbol string
+	// TokenDecimals is the number of decimals of the ERC20 token
+	TokenDecimals uint8
+	// Owner is the address granting the allowance
+	Owner string
+	// Spender is the address allowed to spend the owner's tokens
+	Spender string
+	// Amount is the allowance for approve and permit, or the delta for
+	// increaseAllowance and decreaseAllowance
+	Amount *big.Int
+	// Method is the signature of the called method
+	Method ERC20MethodSignature
+}
+
+// TokenApproval is an allowance change emitted by an ERC20 Approval event
+type TokenApproval struct {
+	// TokenAddress is the address of the ERC20 token contract
+	TokenAddress string `json:"token_address"`
+	// Owner is the address granting the allowance
+	Owner string `json:"owner"`
+	// Spender is the address allowed to spend the owner's tokens
+	Spender string `json:"spender"`
+	// Amount is the new allowance
+	Amount *big.Int `json:"amount"`
+	// LogIndex is the index of the emitting log in the block
+	LogIndex uint `json:"log_index"`
+}
+
+// TokenTransfer is a token movement emitted by an ERC20 Transfer event
+type TokenTransfer struct {
+	// TokenAddress is the address of the ERC20 token contract
+	TokenAddress string `json:"token_address"`
+	// From is the address the tokens were moved from, the zero address for mints
+	From string `json:"from"`
+	// To is the address the tokens were moved to, the zero address for burns
+	To string `json:"to"`
+	// Amount is the amount of tokens moved
+	Amount *big.Int `json:"amount"`
+	// LogIndex is the index of the emitting log in the block
+	LogIndex uint `json:"log_index"`
+}
@@ -53,6 +53,21 @@ type ERC721Transfer struct {
 	TokenName string
 	// TokenID is the unique identifier of the transferred token
 	TokenID *big.Int
+	// Sender is the previous owner of the token, which differs from the
+	// transaction sender when an approved operator moves it
+	Sender string
 	// Recipient is the address receiving the token
 	Recipient string
 }
+
+// NFTTransfers returns the token movement of the transfer
+func (t *ERC721Transfer) NFTTransfers() []NFTTransfer {
+	return []NFTTransfer{{
+		TokenAddress: t.TokenAddress,
+		Standard:     TokenTypeERC721,
+		From:         t.Sender,
+		To:           t.Recipient,
+		TokenID:      t.TokenID,
+		Amount:       big.NewInt(1),
+	}}
+}
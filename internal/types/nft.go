@@ -0,0 +1,31 @@
+package types
+
+import (
+	"math/big"
+	"strings"
+)
+
+// NFTTransfer is a single non-fungible token movement, as emitted by an ERC721
+// Transfer or an ERC1155 TransferSingle/TransferBatch event. A transaction may
+// move several NFTs, e.g. a marketplace sale or a batch transfer.
+type NFTTransfer struct {
+	// TokenAddress is the address of the NFT contract
+	TokenAddress string `json:"token_address"`
+	// Standard is the token standard of the contract (erc721 or erc1155)
+	Standard TokenType `json:"standard"`
+	// From is the previous owner (zero address for mints)
+	From string `json:"from"`
+	// To is the new owner (zero address for burns)
+	To string `json:"to"`
+	// TokenID is the identifier of the token within the contract
+	TokenID *big.Int `json:"token_id"`
+	// Amount is the number of tokens moved; always 1 for ERC721
+	Amount *big.Int `json:"amount"`
+	// LogIndex is the index of the emitting log in the block
+	LogIndex uint `json:"log_index"`
+}
+
+// Involves reports whether the address is the sender or the recipient of the transfer
+func (t *NFTTransfer) Involves(address string) bool {
+	return strings.EqualFold(t.From, address) || strings.EqualFold(t.To, address)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"vault0/internal/errors"
 )
@@ -17,7 +18,8 @@ type NextPageToken struct {
 }
 
 // GetValueInt64 attempts to assert the Value field to an int64.
-// It handles cases where JSON unmarshalling might store numbers as float64.
+// It handles cases where JSON unmarshalling might store numbers as float64, and
+// decimal strings, which keep IDs beyond float64 precision exact.
 // It returns the int64 value and true if successful, otherwise zero and false.
 func (t *NextPageToken) GetValueInt64() (int64, bool) {
 	if t == nil || t.Value == nil {
@@ -29,6 +31,12 @@ func (t *NextPageToken) GetValueInt64() (int64, bool) {
 		return v, true
 	case float64:
 		return int64(v), true
+	case string:
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err == nil {
+			return i, true
+		}
+		return 0, false
 	case json.Number:
 		i, err := v.Int64()
 		if err == nil {
@@ -0,0 +1,37 @@
+package types
+
+// RiskFlag identifies a reason for considering a token or transaction as spam or a scam
+type RiskFlag string
+
+// Supported risk flags
+const (
+	// RiskFlagUnverifiedContract marks tokens whose contract source is not verified on the block explorer
+	RiskFlagUnverifiedContract RiskFlag = "unverified_contract"
+	// RiskFlagLookalikeSymbol marks tokens impersonating the symbol of a verified token
+	RiskFlagLookalikeSymbol RiskFlag = "lookalike_symbol"
+	// RiskFlagZeroValueTransfer marks token transfers of a zero amount, typically used for spam
+	RiskFlagZeroValueTransfer RiskFlag = "zero_value_transfer"
+	// RiskFlagAddressPoisoning marks transfers involving an address crafted to look like a
+	// known counterparty, so that it gets copied from the history by mistake
+	RiskFlagAddressPoisoning RiskFlag = "address_poisoning"
+)
+
+// HasRiskFlags returns true if the token has been flagged as spam or a scam
+func (t *Token) HasRiskFlags() bool {
+	return len(t.RiskFlags) > 0
+}
+
+// MergeRiskFlags returns the union of the given flags, preserving their order
+func MergeRiskFlags(flags ...[]RiskFlag) []RiskFlag {
+	var merged []RiskFlag
+	seen := make(map[RiskFlag]bool)
+	for _, set := range flags {
+		for _, flag := range set {
+			if !seen[flag] {
+				seen[flag] = true
+				merged = append(merged, flag)
+			}
+		}
+	}
+	return merged
+}
@@ -12,8 +12,10 @@ type TokenType string
 
 // Supported token types
 const (
-	TokenTypeNative TokenType = "native"
-	TokenTypeERC20  TokenType = "erc20"
+	TokenTypeNative  TokenType = "native"
+	TokenTypeERC20   TokenType = "erc20"
+	TokenTypeERC721  TokenType = "erc721"
+	TokenTypeERC1155 TokenType = "erc1155"
 )
 
 // Token represents a cryptocurrency token
@@ -28,11 +30,36 @@ type Token struct {
 	// Symbol is the token's ticker symbol (e.g., ETH, USDC)
 	Symbol string
 
+	// Name is the token's full name (e.g., USD Coin), empty if unknown
+	Name string
+
+	// LogoURI is the URI of the token's logo, empty if unknown
+	LogoURI string
+
+	// Tags are the token list tags attached to the token (e.g., stablecoin)
+	Tags []string
+
 	// Decimals is the number of decimal places the token supports
 	Decimals uint8
 
-	// Type indicates if the token is native to the chain or an ERC20 token
+	// Type indicates if the token is native to the chain, an ERC20 token
+	// or an ERC721/ERC1155 non-fungible token
 	Type TokenType
+
+	// Verified is false for tokens registered automatically from wallet
+	// transfers until they are confirmed by an operator
+	Verified bool
+
+	// RiskFlags lists the reasons the token is considered spam or a scam, empty if none
+	RiskFlags []RiskFlag
+
+	// FeeOnTransfer is true for tokens that deduct a fee from transferred amounts,
+	// so the recipient receives less than the amount of the transfer call
+	FeeOnTransfer bool
+
+	// Rebasing is true for tokens whose balances change without transfers,
+	// such as stETH and aTokens accruing yield
+	Rebasing bool
 }
 
 // IsNative returns true if the token is a native token of its blockchain
@@ -45,6 +72,17 @@ func (t *Token) IsERC20() bool {
 	return t.Type == TokenTypeERC20
 }
 
+// HasExactTransferAmounts returns true if balances change by exactly the transferred
+// amounts, which doesn't hold for fee-on-transfer and rebasing tokens
+func (t *Token) HasExactTransferAmounts() bool {
+	return !t.FeeOnTransfer && !t.Rebasing
+}
+
+// IsNFT returns true if the token is an ERC721 or ERC1155 non-fungible token
+func (t *Token) IsNFT() bool {
+	return t.Type == TokenTypeERC721 || t.Type == TokenTypeERC1155
+}
+
 // Validate checks if the token configuration is valid
 func (t *Token) Validate() error {
 	// Validate Symbol
@@ -53,7 +91,10 @@ func (t *Token) Validate() error {
 	}
 
 	// Validate Type
-	if t.Type != TokenTypeNative && t.Type != TokenTypeERC20 {
+	switch t.Type {
+	case TokenTypeNative, TokenTypeERC20, TokenTypeERC721, TokenTypeERC1155:
+		// These are valid token types
+	default:
 		return fmt.Errorf("invalid token type: %s", t.Type)
 	}
 
@@ -67,6 +108,11 @@ func (t *Token) Validate() error {
 		return fmt.Errorf("ERC20 token cannot have zero address")
 	}
 
+	// NFT collections are contracts as well
+	if t.IsNFT() && IsZeroAddress(t.Address) {
+		return fmt.Errorf("%s token cannot have zero address", strings.ToUpper(string(t.Type)))
+	}
+
 	// For native tokens, we typically expect the zero address
 	if t.Type == TokenTypeNative && !IsZeroAddress(t.Address) {
 		return fmt.Errorf("native token should use zero address, got: %s", t.Address)
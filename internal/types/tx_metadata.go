@@ -229,6 +229,84 @@ func (m TxMetadata) SetAll(data map[string]any) error {
 	return nil
 }
 
+// SetJSON stores a JSON-encoded value for the given key.
+// It is used for structured values such as decoded calls and events.
+func (m TxMetadata) SetJSON(key string, value any) error {
+	if m == nil {
+		return fmt.Errorf("cannot set value on nil TxMetadata")
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode metadata key '%s': %w", key, err)
+	}
+	m[key] = string(data)
+	return nil
+}
+
+// GetJSON decodes the JSON value stored for the given key into target.
+// Returns true if the key exists and the value was decoded successfully.
+func (m TxMetadata) GetJSON(key string, target any) bool {
+	val, ok := m[key]
+	if !ok {
+		return false
+	}
+	return json.Unmarshal([]byte(val), target) == nil
+}
+
+// GetDecodedCall retrieves the decoded contract call stored in the metadata.
+func (m TxMetadata) GetDecodedCall() (*DecodedCall, bool) {
+	var call DecodedCall
+	if !m.GetJSON(DecodedCallMetadataKey, &call) {
+		return nil, false
+	}
+	return &call, true
+}
+
+// GetDecodedEvents retrieves the decoded receipt events stored in the metadata.
+func (m TxMetadata) GetDecodedEvents() ([]DecodedEvent, bool) {
+	var events []DecodedEvent
+	if !m.GetJSON(DecodedEventsMetadataKey, &events) {
+		return nil, false
+	}
+	return events, true
+}
+
+// GetTokenApprovals retrieves the ERC20 approvals extracted from the receipt logs.
+func (m TxMetadata) GetTokenApprovals() ([]TokenApproval, bool) {
+	var approvals []TokenApproval
+	if !m.GetJSON(TokenApprovalsMetadataKey, &approvals) {
+		return nil, false
+	}
+	return approvals, true
+}
+
+// GetTokenTransfers retrieves the ERC20 transfers extracted from the receipt logs.
+func (m TxMetadata) GetTokenTransfers() ([]TokenTransfer, bool) {
+	var transfers []TokenTransfer
+	if !m.GetJSON(TokenTransfersMetadataKey, &transfers) {
+		return nil, false
+	}
+	return transfers, true
+}
+
+// GetNFTTransfers retrieves the NFT transfers extracted from the receipt logs.
+func (m TxMetadata) GetNFTTransfers() ([]NFTTransfer, bool) {
+	var transfers []NFTTransfer
+	if !m.GetJSON(NFTTransfersMetadataKey, &transfers) {
+		return nil, false
+	}
+	return transfers, true
+}
+
+// GetRiskFlags retrieves the risk flags of a spam or scam transaction.
+func (m TxMetadata) GetRiskFlags() ([]RiskFlag, bool) {
+	var flags []RiskFlag
+	if !m.GetJSON(RiskFlagsMetadataKey, &flags) {
+		return nil, false
+	}
+	return flags, true
+}
+
 // Copy returns a deep copy of the TxMetadata map.
 func (m TxMetadata) Copy() TxMetadata {
 	copy := make(TxMetadata)
@@ -244,21 +322,53 @@ const (
 	// VaultIDMetadaKey is the key for the transformer that extracts vault ID from metadata
 	VaultIDMetadaKey = "vault_id"
 
+	// DecodedCallMetadataKey holds the JSON-encoded DecodedCall of a contract call
+	DecodedCallMetadataKey = "decoded_call"
+
+	// DecodedEventsMetadataKey holds the JSON-encoded DecodedEvent list of the receipt logs
+	DecodedEventsMetadataKey = "decoded_events"
+
+	// NFTTransfersMetadataKey holds the JSON-encoded NFTTransfer list of the receipt logs
+	NFTTransfersMetadataKey = "nft_transfers"
+
+	// TokenApprovalsMetadataKey holds the JSON-encoded TokenApproval list of the receipt logs
+	TokenApprovalsMetadataKey = "token_approvals"
+
+	// TokenTransfersMetadataKey holds the JSON-encoded TokenTransfer list of the receipt logs
+	TokenTransfersMetadataKey = "token_transfers"
+
+	// RiskFlagsMetadataKey holds the JSON-encoded RiskFlag list of spam or scam transactions.
+	// It is absent for transactions that were not flagged.
+	RiskFlagsMetadataKey = "risk_flags"
+
 	// ERC20 specific metadata keys
-	ERC20TokenAddressMetadataKey  = "token_address"
-	ERC20TokenSymbolMetadataKey   = "token_symbol"
-	ERC20TokenDecimalsMetadataKey = "token_decimals"
-	ERC20RecipientMetadataKey     = "recipient"
-	ERC20AmountMetadataKey        = "amount"
+	ERC20TokenAddressMetadataKey   = "token_address"
+	ERC20TokenSymbolMetadataKey    = "token_symbol"
+	ERC20TokenDecimalsMetadataKey  = "token_decimals"
+	ERC20RecipientMetadataKey      = "recipient"
+	ERC20AmountMetadataKey         = "amount"
+	ERC20OwnerMetadataKey          = "owner"
+	ERC20SpenderMetadataKey        = "spender"
+	ERC20ApprovalMethodMetadataKey = "approval_method"
 
 	// ERC721 Specific Metadata Keys
 	ERC721TokenAddressMetadataKey = "token_address"
 	ERC721TokenSymbolMetadataKey  = "token_symbol"
 	ERC721TokenNameMetadataKey    = "token_name"
 	ERC721TokenIDMetadataKey      = "token_id"
+	ERC721SenderMetadataKey       = "sender"
 	ERC721RecipientMetadataKey    = "recipient"
 	ERC721TokenURIMetadataKey     = "token_uri"
 
+	// ERC1155 Specific Metadata Keys
+	ERC1155TokenAddressMetadataKey = "token_address"
+	ERC1155TokenSymbolMetadataKey  = "token_symbol"
+	ERC1155TokenNameMetadataKey    = "token_name"
+	ERC1155SenderMetadataKey       = "sender"
+	ERC1155RecipientMetadataKey    = "recipient"
+	ERC1155TokenIDsMetadataKey     = "token_ids" // JSON-encoded list of decimal strings
+	ERC1155AmountsMetadataKey      = "amounts"   // JSON-encoded list of decimal strings
+
 	// MultiSig specific metadata keys
 	MultiSigTokenAddressMetadataKey       = "token_address"
 	MultiSigTokenSymbolMetadataKey        = "token_symbol"
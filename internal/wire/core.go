@@ -1,6 +1,11 @@
 package wire
 
 import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+
 	"github.com/google/wire"
 
 	"vault0/internal/config"
@@ -8,34 +13,99 @@ import (
 	"vault0/internal/core/blockchain"
 	"vault0/internal/core/blockexplorer"
 	"vault0/internal/core/contract"
+	"vault0/internal/core/crypto"
+	"vault0/internal/core/fxfeed"
 	"vault0/internal/core/keystore"
+	"vault0/internal/core/leader"
 	"vault0/internal/core/pricefeed"
+	"vault0/internal/core/tokendiscovery"
+	"vault0/internal/core/tokenrisk"
 	"vault0/internal/core/tokenstore"
 	"vault0/internal/core/transaction"
 	"vault0/internal/core/wallet"
 	"vault0/internal/db"
+	"vault0/internal/errors"
 	"vault0/internal/logger"
 	"vault0/internal/types"
 )
 
+// NewConfig loads the application configuration and, when shares are configured,
+// reconstructs the DB encryption key from Shamir secret shares
+func NewConfig() (*config.Config, error) {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if !cfg.DBEncryptionKeyShares.Enabled() {
+		return cfg, nil
+	}
+	if cfg.DBEncryptionKey != "" {
+		return nil, errors.NewConfigurationError("db_encryption_key and db_encryption_key_shares are mutually exclusive")
+	}
+
+	key, err := combineKeyShares(cfg.DBEncryptionKeyShares, os.Stdin)
+	if err != nil {
+		return nil, err
+	}
+	cfg.DBEncryptionKey = base64.StdEncoding.EncodeToString(key)
+
+	return cfg, nil
+}
+
+// combineKeyShares collects shares from the configured files and, if enabled,
+// from stdin until the threshold is reached, then reconstructs the key
+func combineKeyShares(sharesCfg config.KeySharesConfig, stdin io.Reader) ([]byte, error) {
+	var shares []crypto.Share
+	for _, path := range sharesCfg.Files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.NewConfigurationError(fmt.Sprintf("failed to read key share file %s: %v", path, err))
+		}
+		share, err := crypto.ParseShare(string(data))
+		if err != nil {
+			return nil, err
+		}
+		shares = append(shares, share)
+	}
+
+	if sharesCfg.Stdin {
+		fmt.Fprintln(os.Stderr, "Enter DB encryption key shares, one per line:")
+		var err error
+		shares, err = crypto.ReadShares(stdin, shares)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return crypto.CombineShares(shares)
+}
+
 func NewSnowflake(config *config.Config) (*db.Snowflake, error) {
 	return db.NewSnowflake(config.Snowflake.DataCenterID, config.Snowflake.MachineID)
 }
 
 // CoreSet combines all core dependencies
 var CoreSet = wire.NewSet(
-	config.LoadConfig,
+	NewConfig,
 	NewSnowflake,
 	db.NewDatabase,
+	db.NewTransactor,
+	leader.NewElector,
 	logger.NewLogger,
 	keystore.NewKeyStore,
 	tokenstore.NewTokenStore,
+	tokendiscovery.NewDiscoverer,
+	tokenrisk.NewClassifier,
 	types.NewChains,
 	pricefeed.NewPriceFeed,
+	fxfeed.NewFXFeed,
 	blockchain.NewFactory,
 	wallet.NewFactory,
 	blockexplorer.NewFactory,
 	contract.NewFactory,
+	abi.NewABIRegistry,
+	abi.NewSignatureDB,
 	abi.NewFactory,
 	transaction.NewFactory,
 	NewCore,
@@ -48,14 +118,19 @@ type Core struct {
 	Logger                  logger.Logger
 	KeyStore                keystore.KeyStore
 	TokenStore              tokenstore.TokenStore
+	TokenDiscoverer         tokendiscovery.Discoverer
+	TokenRiskClassifier     tokenrisk.Classifier
 	Chains                  *types.Chains
 	WalletFactory           wallet.Factory
 	BlockchainClientFactory blockchain.Factory
 	ContractManagerFactory  contract.Factory
 	BlockExplorerFactory    blockexplorer.Factory
 	ABIFactory              abi.Factory
+	ABIRegistry             abi.ABIRegistry
 	PriceFeed               pricefeed.PriceFeed
+	FXFeed                  fxfeed.FXFeed
 	TransactionFactory      transaction.Factory
+	LeaderElector           leader.Elector
 }
 
 // NewCore creates a new Core instance with all core dependencies
@@ -65,14 +140,19 @@ func NewCore(
 	logger logger.Logger,
 	keyStore keystore.KeyStore,
 	tokenStore tokenstore.TokenStore,
+	tokenDiscoverer tokendiscovery.Discoverer,
+	tokenRiskClassifier tokenrisk.Classifier,
 	chains *types.Chains,
 	priceFeed pricefeed.PriceFeed,
+	fxFeed fxfeed.FXFeed,
 	walletFactory wallet.Factory,
 	blockchainClientFactory blockchain.Factory,
 	contractManagerFactory contract.Factory,
 	blockExplorerFactory blockexplorer.Factory,
 	abiFactory abi.Factory,
+	abiRegistry abi.ABIRegistry,
 	transactionFactory transaction.Factory,
+	leaderElector leader.Elector,
 ) *Core {
 	return &Core{
 		Config:                  config,
@@ -80,13 +160,18 @@ func NewCore(
 		Logger:                  logger,
 		KeyStore:                keyStore,
 		TokenStore:              tokenStore,
+		TokenDiscoverer:         tokenDiscoverer,
+		TokenRiskClassifier:     tokenRiskClassifier,
 		Chains:                  chains,
 		PriceFeed:               priceFeed,
+		FXFeed:                  fxFeed,
 		WalletFactory:           walletFactory,
 		BlockchainClientFactory: blockchainClientFactory,
 		ContractManagerFactory:  contractManagerFactory,
 		BlockExplorerFactory:    blockExplorerFactory,
 		ABIFactory:              abiFactory,
+		ABIRegistry:             abiRegistry,
 		TransactionFactory:      transactionFactory,
+		LeaderElector:           leaderElector,
 	}
 }
@@ -1,15 +1,20 @@
 package wire
 
 import (
+	"vault0/internal/api/handlers/abi"
+	"vault0/internal/api/handlers/backup"
+	"vault0/internal/api/handlers/fxrate"
 	"vault0/internal/api/handlers/keystore"
 	"vault0/internal/api/handlers/reference"
 	"vault0/internal/api/handlers/signer"
+	"vault0/internal/api/handlers/stream"
 	"vault0/internal/api/handlers/token"
 	"vault0/internal/api/handlers/tokenprice"
 	"vault0/internal/api/handlers/transaction"
 	"vault0/internal/api/handlers/user"
 	"vault0/internal/api/handlers/vault"
 	"vault0/internal/api/handlers/wallet"
+	"vault0/internal/api/handlers/webhook"
 
 	"vault0/internal/api"
 
@@ -23,8 +28,13 @@ var ServerSet = wire.NewSet(
 	token.NewHandler,
 	signer.NewHandler,
 	tokenprice.NewHandler,
+	fxrate.NewHandler,
 	reference.NewHandler,
 	keystore.NewHandler,
 	vault.NewHandler,
+	abi.NewHandler,
+	backup.NewHandler,
+	webhook.NewHandler,
+	stream.NewHandler,
 	api.NewServer,
 )
@@ -3,14 +3,19 @@ package wire
 import (
 	"github.com/google/wire"
 
+	"vault0/internal/services/abi"
+	"vault0/internal/services/backup"
+	"vault0/internal/services/fxrate"
 	"vault0/internal/services/keystore"
 	"vault0/internal/services/signer"
+	"vault0/internal/services/stream"
 	"vault0/internal/services/token"
 	"vault0/internal/services/tokenprice"
 	"vault0/internal/services/transaction"
 	"vault0/internal/services/user"
 	"vault0/internal/services/vault"
 	"vault0/internal/services/wallet"
+	"vault0/internal/services/webhook"
 )
 
 type Transaction struct {
@@ -21,6 +26,7 @@ type Transaction struct {
 	HistoryService        transaction.HistoryService
 	BlockchainTransformer transaction.BlockchainTransformer
 	TokenTransformer      transaction.TokenTransformer
+	RiskTransformer       transaction.RiskTransformer
 }
 
 // Services holds instances of all application services.
@@ -34,8 +40,15 @@ type Services struct {
 	SignerService            signer.Service
 	TokenPriceService        tokenprice.Service
 	TokenPricePollingService tokenprice.PricePoolingService
+	FXRateService            fxrate.Service
+	FXRatePollingService     fxrate.RatePollingService
 	KeystoreService          keystore.Service
 	VaultService             vault.Service
+	ABIService               abi.Service
+	BackupService            backup.Service
+	WebhookService           webhook.Service
+	WebhookDeliveryService   webhook.DeliveryService
+	StreamService            stream.Service
 }
 
 // Define Wire provider sets for each service
@@ -43,6 +56,7 @@ var WalletServiceSet = wire.NewSet(
 	wallet.NewRepository,
 	wallet.NewService,
 	wallet.NewBalanceService,
+	wallet.NewAllowanceService,
 	wallet.NewWalletMonitorService,
 )
 var UserServiceSet = wire.NewSet(user.NewRepository, user.NewService)
@@ -55,12 +69,19 @@ var TransactionServiceSet = wire.NewSet(
 	transaction.NewHistoryService,
 	transaction.NewBlockchainTransformer,
 	transaction.NewTokenTransformer,
+	transaction.NewRiskTransformer,
 )
 var TokenServiceSet = wire.NewSet(token.NewService, token.NewTokenMonitorService)
 var SignerServiceSet = wire.NewSet(signer.NewRepository, signer.NewService)
 var TokenPriceServiceSet = wire.NewSet(tokenprice.NewRepository, tokenprice.NewService, tokenprice.NewPollingService)
+
+var FXRateServiceSet = wire.NewSet(fxrate.NewRepository, fxrate.NewService, fxrate.NewPollingService)
 var KeystoreServiceSet = wire.NewSet(keystore.NewService)
 var VaultServiceSet = wire.NewSet(vault.NewRepository, vault.NewService)
+var ABIServiceSet = wire.NewSet(abi.NewService)
+var BackupServiceSet = wire.NewSet(backup.NewService)
+var WebhookServiceSet = wire.NewSet(webhook.NewRepository, webhook.NewService, webhook.NewDeliveryService)
+var StreamServiceSet = wire.NewSet(stream.NewRepository, stream.NewService)
 
 // Define the set for all services
 var ServicesSet = wire.NewSet(
@@ -70,8 +91,13 @@ var ServicesSet = wire.NewSet(
 	TokenServiceSet,
 	SignerServiceSet,
 	TokenPriceServiceSet,
+	FXRateServiceSet,
 	KeystoreServiceSet,
 	VaultServiceSet,
+	ABIServiceSet,
+	BackupServiceSet,
+	WebhookServiceSet,
+	StreamServiceSet,
 	NewServices,
 )
 
@@ -90,10 +116,18 @@ func NewServices(
 	signerSvc signer.Service,
 	tokenPriceSvc tokenprice.Service,
 	tokenPricePollingSvc tokenprice.PricePoolingService,
+	fxRateSvc fxrate.Service,
+	fxRatePollingSvc fxrate.RatePollingService,
 	keystoreSvc keystore.Service,
 	vaultSvc vault.Service,
+	abiSvc abi.Service,
+	backupSvc backup.Service,
+	webhookSvc webhook.Service,
+	webhookDeliverySvc webhook.DeliveryService,
+	streamSvc stream.Service,
 	blockchainTransformer transaction.BlockchainTransformer,
 	tokenTransformer transaction.TokenTransformer,
+	riskTransformer transaction.RiskTransformer,
 ) *Services {
 	return &Services{
 		Transaction: Transaction{
@@ -104,6 +138,7 @@ func NewServices(
 			HistoryService:        historySvc,
 			BlockchainTransformer: blockchainTransformer,
 			TokenTransformer:      tokenTransformer,
+			RiskTransformer:       riskTransformer,
 		},
 		WalletService:            walletSvc,
 		WalletMonitorService:     walletMonitorSvc,
@@ -113,7 +148,14 @@ func NewServices(
 		SignerService:            signerSvc,
 		TokenPriceService:        tokenPriceSvc,
 		TokenPricePollingService: tokenPricePollingSvc,
+		FXRateService:            fxRateSvc,
+		FXRatePollingService:     fxRatePollingSvc,
 		KeystoreService:          keystoreSvc,
 		VaultService:             vaultSvc,
+		ABIService:               abiSvc,
+		BackupService:            backupSvc,
+		WebhookService:           webhookSvc,
+		WebhookDeliveryService:   webhookDeliverySvc,
+		StreamService:            streamSvc,
 	}
 }
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS trg_signing_audit_log_no_delete;
+DROP TRIGGER IF EXISTS trg_signing_audit_log_no_update;
+DROP INDEX IF EXISTS idx_signing_audit_log_transaction_hash;
+DROP INDEX IF EXISTS idx_signing_audit_log_actor;
+DROP INDEX IF EXISTS idx_signing_audit_log_key_id;
+DROP TABLE IF EXISTS signing_audit_log;
+DROP TABLE IF EXISTS key_policies;
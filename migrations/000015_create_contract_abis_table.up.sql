@@ -0,0 +1,14 @@
+-- Registry of contract ABIs per chain and address
+CREATE TABLE IF NOT EXISTS contract_abis (
+    id BIGINT PRIMARY KEY,
+    chain_type TEXT NOT NULL,
+    address TEXT NOT NULL,
+    name TEXT,
+    abi TEXT NOT NULL, -- JSON ABI array
+    source TEXT NOT NULL, -- explorer, upload or artifact
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (chain_type, address)
+);
+
+CREATE INDEX IF NOT EXISTS idx_contract_abis_source ON contract_abis(source);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tokens_verified;
+ALTER TABLE tokens DROP COLUMN verified;
+ALTER TABLE tokens DROP COLUMN name;
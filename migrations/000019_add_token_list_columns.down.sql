@@ -0,0 +1,2 @@
+ALTER TABLE tokens DROP COLUMN tags;
+ALTER TABLE tokens DROP COLUMN logo_uri;
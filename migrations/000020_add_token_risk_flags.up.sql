@@ -0,0 +1,2 @@
+-- Add the spam and scam risk flags of tokens, stored comma-separated
+ALTER TABLE tokens ADD COLUMN risk_flags TEXT NOT NULL DEFAULT '';
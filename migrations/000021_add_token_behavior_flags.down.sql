@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_tokens_rebasing;
+ALTER TABLE tokens DROP COLUMN rebasing;
+ALTER TABLE tokens DROP COLUMN fee_on_transfer;
@@ -0,0 +1,5 @@
+-- Flag tokens whose balance changes differ from the transferred amounts
+ALTER TABLE tokens ADD COLUMN fee_on_transfer BOOLEAN NOT NULL DEFAULT 0;
+ALTER TABLE tokens ADD COLUMN rebasing BOOLEAN NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_tokens_rebasing ON tokens(rebasing);
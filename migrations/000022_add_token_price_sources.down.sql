@@ -0,0 +1 @@
+ALTER TABLE token_prices DROP COLUMN sources;
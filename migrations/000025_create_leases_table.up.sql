@@ -0,0 +1,8 @@
+-- Leases held by one replica at a time, e.g. to elect the replica that runs the background workers
+CREATE TABLE IF NOT EXISTS leases (
+    name TEXT PRIMARY KEY,
+    holder TEXT NOT NULL,
+    acquired_at INTEGER NOT NULL, -- Unix time in milliseconds
+    expires_at INTEGER NOT NULL,  -- Unix time in milliseconds
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
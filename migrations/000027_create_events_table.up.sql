@@ -0,0 +1,12 @@
+-- Live events streamed to the UI, kept for a retention period so that clients can resume
+CREATE TABLE IF NOT EXISTS events (
+    id BIGINT PRIMARY KEY,
+    type TEXT NOT NULL,
+    chain_type TEXT NOT NULL DEFAULT '', -- Empty for events spanning chains, such as prices
+    wallet_id BIGINT,
+    vault_id BIGINT,
+    data TEXT NOT NULL,                  -- JSON encoded event details
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_created_at ON events(created_at);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS nft_holding_transfers;
@@ -0,0 +1,12 @@
+-- NFT transfers applied to wallet holdings, so that processing a transaction
+-- again doesn't apply its ERC1155 transfers twice
+CREATE TABLE IF NOT EXISTS nft_holding_transfers (
+    wallet_id BIGINT NOT NULL,
+    tx_hash TEXT NOT NULL,
+    log_index BIGINT NOT NULL,
+    token_address TEXT NOT NULL,
+    token_id TEXT NOT NULL, -- uint256 token identifier as a decimal string
+    incoming BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (wallet_id, tx_hash, log_index, token_address, token_id, incoming)
+);
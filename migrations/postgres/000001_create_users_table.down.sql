@@ -0,0 +1,5 @@
+-- Drop the index first
+DROP INDEX IF EXISTS idx_users_email;
+
+-- Drop the users table
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,11 @@
+-- Create users table
+CREATE TABLE IF NOT EXISTS users (
+    id BIGINT PRIMARY KEY NOT NULL,
+    email TEXT UNIQUE NOT NULL,
+    password_hash TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Add index on email for faster lookups
+CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
@@ -0,0 +1,8 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_keys_name;
+DROP INDEX IF EXISTS idx_keys_type;
+DROP INDEX IF EXISTS idx_keys_created_at;
+DROP INDEX IF EXISTS idx_keys_curve;
+
+-- Drop keys table
+DROP TABLE IF EXISTS keys;
@@ -0,0 +1,3 @@
+-- Drop OAuth2 clients table
+DROP INDEX IF EXISTS idx_oauth_clients_client_id;
+DROP TABLE IF EXISTS oauth_clients;
\ No newline at end of file
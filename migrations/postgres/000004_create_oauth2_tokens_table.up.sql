@@ -0,0 +1,24 @@
+-- Create OAuth2 tokens table
+CREATE TABLE IF NOT EXISTS oauth_tokens (
+    id BIGINT PRIMARY KEY NOT NULL,
+    client_id TEXT,
+    user_id TEXT,
+    redirect_uri TEXT,
+    scope TEXT,
+    code TEXT UNIQUE,
+    code_created_at BIGINT,
+    code_expires_in BIGINT,
+    access TEXT UNIQUE,
+    access_created_at BIGINT,
+    access_expires_in BIGINT,
+    refresh TEXT UNIQUE,
+    refresh_created_at BIGINT,
+    refresh_expires_in BIGINT
+);
+
+-- Add indexes for faster lookups
+CREATE INDEX IF NOT EXISTS idx_oauth_tokens_client_id ON oauth_tokens(client_id);
+CREATE INDEX IF NOT EXISTS idx_oauth_tokens_user_id ON oauth_tokens(user_id);
+CREATE INDEX IF NOT EXISTS idx_oauth_tokens_code ON oauth_tokens(code);
+CREATE INDEX IF NOT EXISTS idx_oauth_tokens_access ON oauth_tokens(access);
+CREATE INDEX IF NOT EXISTS idx_oauth_tokens_refresh ON oauth_tokens(refresh);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_wallets_chain_address;
+DROP INDEX IF EXISTS idx_wallets_chain_type;
+DROP INDEX IF EXISTS idx_wallets_key_id;
+DROP INDEX IF EXISTS idx_wallets_deleted_at;
+DROP TABLE IF EXISTS wallets;
@@ -0,0 +1,21 @@
+-- Foreign keys are not declared on PostgreSQL: SQLite does not enforce them, and the
+-- repositories maintain the references between tables on both engines alike.
+CREATE TABLE IF NOT EXISTS wallets (
+    id BIGINT PRIMARY KEY NOT NULL,
+    key_id TEXT, -- Keystore key ID, keys are identified by text IDs
+    chain_type TEXT NOT NULL,
+    address TEXT NOT NULL,
+    name TEXT NOT NULL,
+    tags TEXT,
+    balance NUMERIC(78, 0) NOT NULL DEFAULT 0, -- Wide enough for any uint256 amount
+    last_block_number BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ,
+    UNIQUE(chain_type, address)
+);
+
+CREATE INDEX IF NOT EXISTS idx_wallets_key_id ON wallets(key_id);
+CREATE INDEX IF NOT EXISTS idx_wallets_chain_type ON wallets(chain_type);
+CREATE INDEX IF NOT EXISTS idx_wallets_deleted_at ON wallets(deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wallets_chain_address ON wallets(chain_type, address);
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_transactions_vault_id;
+DROP INDEX IF EXISTS idx_transactions_deleted_at;
+DROP INDEX IF EXISTS idx_transactions_to_address;
+DROP INDEX IF EXISTS idx_transactions_from_address;
+DROP INDEX IF EXISTS idx_transactions_type;
+DROP INDEX IF EXISTS idx_transactions_block_number;
+DROP INDEX IF EXISTS idx_transactions_wallet_id;
+DROP INDEX IF EXISTS idx_transactions_chain_type;
+DROP INDEX IF EXISTS idx_transactions_timestamp;
+DROP INDEX IF EXISTS idx_transactions_status;
+DROP TABLE IF EXISTS transactions;
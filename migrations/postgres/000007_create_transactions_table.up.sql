@@ -0,0 +1,41 @@
+CREATE TABLE transactions (
+    -- Service Layer Fields
+    id BIGINT PRIMARY KEY NOT NULL,
+    wallet_id BIGINT DEFAULT NULL,
+    vault_id BIGINT DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ DEFAULT NULL,
+
+    -- Mirrored BaseTransaction Fields
+    chain_type TEXT NOT NULL,
+    hash TEXT NOT NULL UNIQUE,
+    from_address TEXT NOT NULL,
+    to_address TEXT NOT NULL,
+    value NUMERIC(78, 0) NOT NULL,
+    data BYTEA,
+    nonce BIGINT NOT NULL,
+    gas_price NUMERIC(78, 0) NOT NULL,
+    gas_limit BIGINT NOT NULL,
+    type TEXT NOT NULL,
+
+    -- Mirrored Transaction Execution Fields
+    metadata JSONB DEFAULT NULL, -- Queried with the ->> operator
+    gas_used BIGINT DEFAULT NULL,
+    status TEXT NOT NULL,
+    timestamp BIGINT DEFAULT NULL,
+    block_number NUMERIC(78, 0) DEFAULT NULL
+);
+
+-- Recreate necessary indexes
+CREATE INDEX IF NOT EXISTS idx_transactions_wallet_id ON transactions(wallet_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_chain_type ON transactions(chain_type);
+-- UNIQUE index on hash is created via the table definition
+CREATE INDEX IF NOT EXISTS idx_transactions_timestamp ON transactions(timestamp);
+CREATE INDEX IF NOT EXISTS idx_transactions_status ON transactions(status);
+CREATE INDEX IF NOT EXISTS idx_transactions_block_number ON transactions(block_number);
+CREATE INDEX IF NOT EXISTS idx_transactions_type ON transactions(type);
+CREATE INDEX IF NOT EXISTS idx_transactions_from_address ON transactions(from_address);
+CREATE INDEX IF NOT EXISTS idx_transactions_to_address ON transactions(to_address);
+CREATE INDEX IF NOT EXISTS idx_transactions_deleted_at ON transactions(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_vault_id ON transactions(vault_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_wallet_balances_wallet_id;
+DROP INDEX IF EXISTS idx_wallet_balances_token_address;
+DROP TABLE IF EXISTS wallet_balances; 
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS wallet_balances (
+    wallet_id BIGINT NOT NULL,
+    token_address TEXT NOT NULL,
+    balance NUMERIC(78, 0) NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (wallet_id, token_address)
+);
+
+-- Create index for faster queries
+CREATE INDEX IF NOT EXISTS idx_wallet_balances_wallet_id ON wallet_balances(wallet_id);
+CREATE INDEX IF NOT EXISTS idx_wallet_balances_token_address ON wallet_balances(token_address);
@@ -0,0 +1,14 @@
+-- Prices are float values in the application, and market caps and supplies of some
+-- tokens exceed the range of a fixed-point column
+CREATE TABLE IF NOT EXISTS token_prices (
+    symbol TEXT PRIMARY KEY NOT NULL,
+    rank INTEGER NOT NULL,
+    price_usd DOUBLE PRECISION NOT NULL,
+    supply DOUBLE PRECISION NOT NULL,
+    market_cap_usd DOUBLE PRECISION NOT NULL,
+    volume_usd_24h DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_token_prices_rank ON token_prices(rank);
+CREATE INDEX IF NOT EXISTS idx_token_prices_updated_at ON token_prices(updated_at DESC);
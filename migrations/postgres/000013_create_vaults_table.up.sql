@@ -0,0 +1,24 @@
+-- Migration for creating the vaults table
+
+CREATE TABLE vaults (
+    id BIGINT PRIMARY KEY,
+    name TEXT NOT NULL,
+    contract_name TEXT NOT NULL,
+    wallet_id BIGINT NOT NULL,
+    chain_type TEXT NOT NULL,
+    tx_hash TEXT NOT NULL,
+    recovery_address TEXT NOT NULL,
+    signers TEXT NOT NULL, -- JSON array of signer addresses
+    status TEXT NOT NULL DEFAULT 'pending',
+    quorum INT NOT NULL,
+    address TEXT,
+    recovery_request_timestamp TIMESTAMPTZ,
+    failure_reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_vaults_wallet_id ON vaults (wallet_id);
+CREATE INDEX idx_vaults_status ON vaults (status);
+CREATE INDEX idx_vaults_address ON vaults (address);
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS trg_signing_audit_log_no_delete ON signing_audit_log;
+DROP TRIGGER IF EXISTS trg_signing_audit_log_no_update ON signing_audit_log;
+DROP FUNCTION IF EXISTS signing_audit_log_append_only();
+DROP INDEX IF EXISTS idx_signing_audit_log_transaction_hash;
+DROP INDEX IF EXISTS idx_signing_audit_log_actor;
+DROP INDEX IF EXISTS idx_signing_audit_log_key_id;
+DROP TABLE IF EXISTS signing_audit_log;
+DROP TABLE IF EXISTS key_policies;
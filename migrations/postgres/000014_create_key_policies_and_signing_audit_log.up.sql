@@ -0,0 +1,45 @@
+-- Per-key usage policies evaluated before every signing operation
+CREATE TABLE IF NOT EXISTS key_policies (
+    key_id TEXT PRIMARY KEY,
+    disabled BOOLEAN NOT NULL DEFAULT FALSE,
+    allowed_operations TEXT, -- JSON array of operations (empty means all)
+    allowed_data_types TEXT, -- JSON array of data types (empty means all)
+    rate_limit INT NOT NULL DEFAULT 0, -- Maximum signatures per window (0 means unlimited)
+    rate_limit_window INT NOT NULL DEFAULT 0, -- Window length in seconds
+    allowed_destinations TEXT, -- JSON array of destination addresses (empty means any)
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Append-only log of every signing attempt
+CREATE TABLE IF NOT EXISTS signing_audit_log (
+    id BIGINT PRIMARY KEY,
+    key_id TEXT NOT NULL,
+    operation TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    data_type TEXT NOT NULL,
+    digest TEXT NOT NULL, -- Hex-encoded digest that was signed
+    destination TEXT,
+    transaction_hash TEXT,
+    status TEXT NOT NULL, -- signed or denied
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_signing_audit_log_key_id ON signing_audit_log(key_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_signing_audit_log_actor ON signing_audit_log(actor);
+CREATE INDEX IF NOT EXISTS idx_signing_audit_log_transaction_hash ON signing_audit_log(transaction_hash);
+
+-- Reject any attempt to rewrite history
+CREATE OR REPLACE FUNCTION signing_audit_log_append_only() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'signing_audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_signing_audit_log_no_update
+BEFORE UPDATE ON signing_audit_log
+FOR EACH ROW EXECUTE FUNCTION signing_audit_log_append_only();
+
+CREATE TRIGGER trg_signing_audit_log_no_delete
+BEFORE DELETE ON signing_audit_log
+FOR EACH ROW EXECUTE FUNCTION signing_audit_log_append_only();
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_contract_abis_source;
+DROP TABLE IF EXISTS contract_abis;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_nft_holdings_wallet_id;
+DROP TABLE IF EXISTS nft_holdings;
@@ -0,0 +1,13 @@
+-- ERC721 and ERC1155 tokens held by wallets
+CREATE TABLE IF NOT EXISTS nft_holdings (
+    id BIGINT PRIMARY KEY,
+    wallet_id BIGINT NOT NULL,
+    token_address TEXT NOT NULL,
+    token_id TEXT NOT NULL, -- uint256 token identifier as a decimal string
+    standard TEXT NOT NULL, -- erc721 or erc1155
+    amount NUMERIC(78, 0) NOT NULL DEFAULT 1,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (wallet_id, token_address, token_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_nft_holdings_wallet_id ON nft_holdings(wallet_id);
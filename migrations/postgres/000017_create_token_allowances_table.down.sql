@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_token_allowances_wallet_id;
+DROP TABLE IF EXISTS token_allowances;
@@ -0,0 +1,12 @@
+-- Outstanding ERC20 allowances granted by wallets
+CREATE TABLE IF NOT EXISTS token_allowances (
+    id BIGINT PRIMARY KEY,
+    wallet_id BIGINT NOT NULL,
+    token_address TEXT NOT NULL,
+    spender TEXT NOT NULL,
+    amount TEXT NOT NULL, -- uint256 allowance as a decimal string, unlimited approvals exceed DECIMAL precision
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (wallet_id, token_address, spender)
+);
+
+CREATE INDEX IF NOT EXISTS idx_token_allowances_wallet_id ON token_allowances(wallet_id);
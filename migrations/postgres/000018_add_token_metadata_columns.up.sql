@@ -0,0 +1,6 @@
+-- Add the token name and a verification flag. Existing tokens were configured
+-- manually and are considered verified.
+ALTER TABLE tokens ADD COLUMN name TEXT NOT NULL DEFAULT '';
+ALTER TABLE tokens ADD COLUMN verified BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE INDEX IF NOT EXISTS idx_tokens_verified ON tokens(verified);
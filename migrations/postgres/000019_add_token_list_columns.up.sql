@@ -0,0 +1,3 @@
+-- Add the token list details. Tags are stored comma-separated.
+ALTER TABLE tokens ADD COLUMN logo_uri TEXT NOT NULL DEFAULT '';
+ALTER TABLE tokens ADD COLUMN tags TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+-- Record the price feed sources that contributed to each aggregated price, comma-separated
+ALTER TABLE token_prices ADD COLUMN sources TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,38 @@
+DROP INDEX IF EXISTS idx_token_price_mappings_asset_id;
+DROP TABLE IF EXISTS token_price_mappings;
+
+DROP INDEX IF EXISTS idx_token_prices_contract;
+DROP INDEX IF EXISTS idx_token_prices_symbol;
+DROP INDEX IF EXISTS idx_token_prices_rank;
+DROP INDEX IF EXISTS idx_token_prices_updated_at;
+ALTER TABLE token_prices RENAME TO token_prices_by_asset;
+
+CREATE TABLE IF NOT EXISTS token_prices (
+    symbol TEXT PRIMARY KEY NOT NULL,
+    rank INTEGER NOT NULL,
+    price_usd DOUBLE PRECISION NOT NULL,
+    supply DOUBLE PRECISION NOT NULL,
+    market_cap_usd DOUBLE PRECISION NOT NULL,
+    volume_usd_24h DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    sources TEXT NOT NULL DEFAULT ''
+);
+
+-- Keep the best ranked price of every symbol, as symbol lookups returned it
+INSERT INTO token_prices (
+    symbol, rank, price_usd, supply, market_cap_usd, volume_usd_24h, updated_at, sources
+)
+SELECT symbol, rank, price_usd, supply, market_cap_usd, volume_usd_24h, updated_at, sources
+FROM (
+    SELECT *, ROW_NUMBER() OVER (
+        PARTITION BY symbol
+        ORDER BY CASE WHEN rank > 0 THEN 0 ELSE 1 END, rank ASC, updated_at DESC
+    ) AS position
+    FROM token_prices_by_asset
+) ranked
+WHERE position = 1;
+
+DROP TABLE token_prices_by_asset;
+
+CREATE INDEX IF NOT EXISTS idx_token_prices_rank ON token_prices(rank);
+CREATE INDEX IF NOT EXISTS idx_token_prices_updated_at ON token_prices(updated_at DESC);
@@ -0,0 +1,45 @@
+-- Key token prices by provider asset ID instead of symbol, so that tokens sharing a
+-- symbol across chains or projects no longer collide. The symbol-keyed rows are carried
+-- over as symbol-only assets, which symbol lookups keep serving until the next poll.
+DROP INDEX IF EXISTS idx_token_prices_rank;
+DROP INDEX IF EXISTS idx_token_prices_updated_at;
+ALTER TABLE token_prices RENAME TO token_prices_by_symbol;
+
+CREATE TABLE IF NOT EXISTS token_prices (
+    asset_id TEXT PRIMARY KEY NOT NULL,
+    symbol TEXT NOT NULL,
+    chain_type TEXT NOT NULL DEFAULT '',
+    contract_address TEXT NOT NULL DEFAULT '',
+    rank INTEGER NOT NULL,
+    price_usd DOUBLE PRECISION NOT NULL,
+    supply DOUBLE PRECISION NOT NULL,
+    market_cap_usd DOUBLE PRECISION NOT NULL,
+    volume_usd_24h DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    sources TEXT NOT NULL DEFAULT ''
+);
+
+INSERT INTO token_prices (
+    asset_id, symbol, rank, price_usd, supply, market_cap_usd, volume_usd_24h, updated_at, sources
+)
+SELECT LOWER(symbol), symbol, rank, price_usd, supply, market_cap_usd, volume_usd_24h, updated_at, sources
+FROM token_prices_by_symbol;
+
+DROP TABLE token_prices_by_symbol;
+
+CREATE INDEX IF NOT EXISTS idx_token_prices_rank ON token_prices(rank);
+CREATE INDEX IF NOT EXISTS idx_token_prices_updated_at ON token_prices(updated_at DESC);
+CREATE INDEX IF NOT EXISTS idx_token_prices_symbol ON token_prices(symbol);
+CREATE INDEX IF NOT EXISTS idx_token_prices_contract ON token_prices(chain_type, contract_address);
+
+-- Map tokens to the price feed asset their value is derived from
+CREATE TABLE IF NOT EXISTS token_price_mappings (
+    chain_type TEXT NOT NULL,
+    token_address TEXT NOT NULL,
+    asset_id TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (chain_type, token_address)
+);
+
+CREATE INDEX IF NOT EXISTS idx_token_price_mappings_asset_id ON token_price_mappings(asset_id);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_fx_rates_as_of;
+DROP TABLE IF EXISTS fx_rates;
@@ -0,0 +1,11 @@
+-- Historical USD exchange rates of fiat currencies, one row per currency and publication time
+CREATE TABLE IF NOT EXISTS fx_rates (
+    currency TEXT NOT NULL,
+    rate NUMERIC(19, 8) NOT NULL,
+    as_of TIMESTAMPTZ NOT NULL,
+    source TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (currency, as_of)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fx_rates_as_of ON fx_rates(as_of DESC);
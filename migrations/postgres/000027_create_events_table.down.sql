@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_events_created_at;
+DROP TABLE IF EXISTS events;
@@ -0,0 +1 @@
+ALTER TABLE key_policies DROP COLUMN allow_unverified_destinations;
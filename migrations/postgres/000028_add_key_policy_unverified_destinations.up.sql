@@ -0,0 +1,3 @@
+-- Allow keys with a destination allowlist to sign data without a verifiable
+-- destination, such as generic digests and messages. Denied by default.
+ALTER TABLE key_policies ADD COLUMN allow_unverified_destinations BOOLEAN NOT NULL DEFAULT FALSE;